@@ -52,7 +52,7 @@ func (bs *boardsServiceAPI) LinkBoardToChannel(boardID string, channelID string,
 }
 
 func (bs *boardsServiceAPI) GetCards(boardID string) ([]*model.Card, error) {
-	return bs.app.GetCardsForBoard(boardID, 0, 0)
+	return bs.app.GetCardsForBoard(boardID, 0, 0, false)
 }
 
 func (bs *boardsServiceAPI) GetCard(cardID string) (*model.Card, error) {
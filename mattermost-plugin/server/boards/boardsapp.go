@@ -128,6 +128,12 @@ func NewBoardsApp(api model.ServicesAPI) (*BoardsApp, error) {
 	notifyBackends = append(notifyBackends, subscriptionsBackend)
 	mentionsBackend.AddListener(subscriptionsBackend)
 
+	remindersBackend, err3 := createRemindersNotifyBackend(backendParams)
+	if err3 != nil {
+		return nil, fmt.Errorf("error creating reminder notifications backend: %w", err3)
+	}
+	notifyBackends = append(notifyBackends, remindersBackend)
+
 	params := server.Params{
 		Cfg:                cfg,
 		SingleUserToken:    "",
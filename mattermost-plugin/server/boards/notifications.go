@@ -7,6 +7,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/notify/notifymentions"
+	"github.com/mattermost/focalboard/server/services/notify/notifyreminders"
 	"github.com/mattermost/focalboard/server/services/notify/notifysubscriptions"
 	"github.com/mattermost/focalboard/server/services/notify/plugindelivery"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -62,6 +63,22 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 	return backend, nil
 }
 
+func createRemindersNotifyBackend(params notifyBackendParams) (*notifyreminders.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyreminders.BackendParams{
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+	backend := notifyreminders.New(backendParams)
+
+	return backend, nil
+}
+
 func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*plugindelivery.PluginDelivery, error) {
 	bot := model.FocalboardBot
 
@@ -99,6 +116,10 @@ func (a *appAPI) GetBlockHistoryNewestChildren(parentID string, opts model.Query
 	return a.store.GetBlockHistoryNewestChildren(parentID, opts)
 }
 
+func (a *appAPI) GetBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error) {
+	return a.store.GetBlocks(opts)
+}
+
 func (a *appAPI) GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error) {
 	return a.store.GetBoardAndCardByID(blockID)
 }
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
@@ -13,6 +15,8 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+const adminJobsDefaultPerPage = 100
+
 type AdminSetPasswordData struct {
 	Password string `json:"password"`
 }
@@ -54,3 +58,381 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
+
+func (a *API) handleAdminCreateUser(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var request *model.AdminCreateUserRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminCreateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	user, err := a.app.AdminCreateUser(request)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("username", user.Username)
+
+	a.logger.Debug("AdminCreateUser", mlog.String("userID", user.ID))
+
+	data, err := json.Marshal(user)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetUsers(w http.ResponseWriter, r *http.Request) {
+	includeDeleted := false
+	if all := r.URL.Query().Get("all"); all != "" {
+		var err error
+		if includeDeleted, err = strconv.ParseBool(all); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest("invalid all parameter"))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminGetUsers", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	users, err := a.app.GetAllUsers(includeDeleted)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("userCount", len(users))
+
+	data, err := json.Marshal(users)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminDeactivateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("username", username)
+
+	if err := a.app.DeactivateUser(username); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetTeams(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminGetTeams", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	teams, err := a.app.GetAllTeams()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("teamCount", len(teams))
+
+	data, err := json.Marshal(teams)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	teamID := mux.Vars(r)["teamID"]
+
+	auditRec := a.makeAuditRecord(r, "adminRegenerateSignupToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	team, err := a.app.RegenerateSignupToken(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminTransferBoard(w http.ResponseWriter, r *http.Request) {
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var request model.AdminTransferBoardRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if request.UserID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("userId is required"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminTransferBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("userID", request.UserID)
+
+	board, err := a.app.TransferBoardOwnership(boardID, request.UserID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminTransferBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", request.UserID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminCleanUpSessions(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminCleanUpSessions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	if err := a.app.CleanUpSessions(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRunDataRetention(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var request *model.AdminDataRetentionRequest
+	if err = json.Unmarshal(requestBody, &request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminRunDataRetention", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("days", request.Days)
+
+	count, err := a.app.RunDataRetention(request.Days, time.Now())
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("count", count)
+
+	a.adminTaskResponse(w, r, count)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminReindex(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminReindex", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	count, err := a.app.RebuildBlockLinks()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("count", count)
+
+	a.adminTaskResponse(w, r, count)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminCheckIntegrity(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminCheckIntegrity", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	checks, err := a.app.CheckIntegrity()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(checks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) adminTaskResponse(w http.ResponseWriter, r *http.Request, count int64) {
+	data, err := json.Marshal(model.AdminTaskResult{Count: count})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleAdminGetJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := model.QueryJobsOptions{
+		Type:    query.Get("type"),
+		Status:  model.JobStatus(query.Get("status")),
+		PerPage: adminJobsDefaultPerPage,
+	}
+
+	var err error
+	if strPage := query.Get("page"); strPage != "" {
+		if opts.Page, err = strconv.Atoi(strPage); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest("invalid `page` parameter: "+err.Error()))
+			return
+		}
+	}
+	if strPerPage := query.Get("per_page"); strPerPage != "" {
+		if opts.PerPage, err = strconv.Atoi(strPerPage); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest("invalid `per_page` parameter: "+err.Error()))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminGetJobs", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	jobs, err := a.app.GetJobs(opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	auditRec.AddMeta("jobCount", len(jobs))
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["jobID"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetJob", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("jobID", jobID)
+
+	job, err := a.app.GetJob(jobID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminCancelJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["jobID"]
+
+	auditRec := a.makeAuditRecord(r, "adminCancelJob", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("jobID", jobID)
+
+	job, err := a.app.CancelJob(jobID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRetryJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["jobID"]
+
+	auditRec := a.makeAuditRecord(r, "adminRetryJob", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("jobID", jobID)
+
+	job, err := a.app.RetryJob(jobID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -101,13 +101,37 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// V3 routes
 	a.registerCardsRoutes(apiv2)
+	a.registerCardMirrorsRoutes(apiv2)
+	a.registerPortfolioRoutes(apiv2)
+	a.registerCommentsRoutes(apiv2)
+	a.registerCardContentRoutes(apiv2)
+	a.registerViewsRoutes(apiv2)
+	a.registerReadStateRoutes(apiv2)
+	a.registerCardRemindersRoutes(apiv2)
+	a.registerCardVotesRoutes(apiv2)
+	a.registerBacklinksRoutes(apiv2)
 
 	// System routes are outside the /api/v2 path
 	a.registerSystemRoutes(r)
+	a.registerHealthRoutes(r)
 }
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v2/admin/users", a.adminRequired(a.handleAdminGetUsers)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/users", a.adminRequired(a.handleAdminCreateUser)).Methods("POST")
 	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/users/{username}/deactivate", a.adminRequired(a.handleAdminDeactivateUser)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/teams", a.adminRequired(a.handleAdminGetTeams)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/teams/{teamID}/regenerate_signup_token", a.adminRequired(a.handleAdminRegenerateSignupToken)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/boards/{boardID}/owner", a.adminRequired(a.handleAdminTransferBoard)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/sessions/cleanup", a.adminRequired(a.handleAdminCleanUpSessions)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/data_retention", a.adminRequired(a.handleAdminRunDataRetention)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/reindex", a.adminRequired(a.handleAdminReindex)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/integrity", a.adminRequired(a.handleAdminCheckIntegrity)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/jobs", a.adminRequired(a.handleAdminGetJobs)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/jobs/{jobID}", a.adminRequired(a.handleAdminGetJob)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/jobs/{jobID}/cancel", a.adminRequired(a.handleAdminCancelJob)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/jobs/{jobID}/retry", a.adminRequired(a.handleAdminRetryJob)).Methods("POST")
 }
 
 func getUserID(r *http.Request) string {
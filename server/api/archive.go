@@ -80,6 +80,7 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	opts := model.ExportArchiveOptions{
 		TeamID:   board.TeamID,
 		BoardIDs: []string{board.ID},
+		UserID:   userID,
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
@@ -239,6 +240,7 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	opts := model.ExportArchiveOptions{
 		TeamID:   teamID,
 		BoardIDs: ids,
+		UserID:   userID,
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
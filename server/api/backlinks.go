@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) registerBacklinksRoutes(r *mux.Router) {
+	// Backlinks APIs
+	r.HandleFunc("/boards/{boardID}/backlinks", a.sessionRequired(a.handleGetBoardBacklinks)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/backlinks", a.sessionRequired(a.handleGetCardBacklinks)).Methods("GET")
+}
+
+func (a *API) handleGetBoardBacklinks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/backlinks getBoardBacklinks
+	//
+	// Returns the cards that link to a board in their content or
+	// comments. Cards of boards the current user can't view are left out.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Backlink"
+	//   '403':
+	//     description: access denied to board
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardBacklinks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	backlinks, err := a.app.GetBoardBacklinks(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(backlinks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("backlinkCount", len(backlinks))
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardBacklinks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/backlinks getCardBacklinks
+	//
+	// Returns the cards that link to a card in their content or
+	// comments. Cards of boards the current user can't view are left out.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Backlink"
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardBacklinks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	backlinks, err := a.app.GetCardBacklinks(cardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(backlinks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("backlinkCount", len(backlinks))
+	auditRec.Success()
+}
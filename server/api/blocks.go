@@ -49,6 +49,11 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   description: Type of blocks to return, omit to specify all types
 	//   required: false
 	//   type: string
+	// - name: include_archived
+	//   in: query
+	//   description: Whether to include archived cards and their content (default=false)
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -70,6 +75,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	blockType := query.Get("type")
 	all := query.Get("all")
 	blockID := query.Get("block_id")
+	includeArchived := query.Get("include_archived") == "true"
 	boardID := mux.Vars(r)["boardID"]
 
 	userID := getUserID(r)
@@ -120,6 +126,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("blockType", blockType)
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("includeArchived", includeArchived)
 
 	var blocks []*model.Block
 	var block *model.Block
@@ -136,7 +143,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r, err)
 			return
 		}
-		if block.BoardID != boardID {
+		if block.BoardID != boardID || !model.IsBlockVisibleTo(block, userID) {
 			message := fmt.Sprintf("block ID=%s on BoardID=%s", block.ID, boardID)
 			a.errorResponse(w, r, model.NewErrNotFound(message))
 			return
@@ -151,6 +158,11 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if blockID == "" && !includeArchived {
+		blocks = model.ExcludeArchivedCards(blocks)
+	}
+	blocks = model.FilterPrivateViews(blocks, userID)
+
 	a.logger.Debug("GetBlocks",
 		mlog.String("boardID", boardID),
 		mlog.String("parentID", parentID),
@@ -243,6 +255,7 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 
 	hasComments := false
 	hasContents := false
+	hasPrivateViews := false
 	for _, block := range blocks {
 		// Error checking
 		if len(block.Type) < 1 {
@@ -251,9 +264,19 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if block.Type == model.TypeComment {
+		ownerID := model.GetViewOwnerID(block)
+		if ownerID != "" && ownerID != userID {
+			message := fmt.Sprintf("invalid owner for private view id %s", block.ID)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+
+		switch {
+		case block.Type == model.TypeComment:
 			hasComments = true
-		} else {
+		case ownerID != "":
+			hasPrivateViews = true
+		default:
 			hasContents = true
 		}
 
@@ -288,6 +311,12 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if hasPrivateViews {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+			return
+		}
+	}
 
 	blocks = model.GenerateBlockIDs(blocks, a.logger)
 
@@ -376,22 +405,41 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
-		return
-	}
-
 	block, err := a.app.GetBlockByID(blockID)
 	if err != nil {
+		// missing blocks are only reported to users that can change the board
+		if model.IsErrNotFound(err) && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+			return
+		}
 		a.errorResponse(w, r, err)
 		return
 	}
-	if block.BoardID != boardID {
+	if block.BoardID != boardID || !model.IsBlockVisibleTo(block, userID) {
 		message := fmt.Sprintf("block ID=%s on BoardID=%s", block.ID, boardID)
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
 
+	// comments can be deleted by their authors, while deleting the
+	// comments of others requires a dedicated permission. Private views
+	// only need access to the board
+	permission := model.PermissionManageBoardCards
+	switch {
+	case block.Type == model.TypeComment:
+		permission = model.PermissionDeleteOthersComments
+		if block.CreatedBy == userID {
+			permission = model.PermissionCommentBoardCards
+		}
+	case model.GetViewOwnerID(block) != "":
+		permission = model.PermissionViewBoard
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, permission) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
@@ -462,13 +510,18 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if board.ID != block.BoardID {
+	if board.ID != block.BoardID || !model.IsBlockVisibleTo(block, userID) {
 		message := fmt.Sprintf("block ID=%s on BoardID=%s", block.ID, board.ID)
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+	permission := model.PermissionManageBoardCards
+	if model.GetViewOwnerID(block) != "" {
+		permission = model.PermissionViewBoard
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, permission) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to modify board members"))
 		return
 	}
@@ -545,22 +598,43 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get("disable_notify")
 	disableNotify := val == True
 
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
-		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
-		return
-	}
-
 	block, err := a.app.GetBlockByID(blockID)
 	if err != nil {
+		// missing blocks are only reported to users that can change the board
+		if model.IsErrNotFound(err) && !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+			return
+		}
 		a.errorResponse(w, r, err)
 		return
 	}
-	if block.BoardID != boardID {
+	if block.BoardID != boardID || !model.IsBlockVisibleTo(block, userID) {
 		message := fmt.Sprintf("block ID=%s on BoardID=%s", block.ID, boardID)
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
 
+	// authors can edit the text of their own comments, and owners their
+	// private views
+	permission := model.PermissionManageBoardCards
+	authorOnly := false
+	viewOwnerOnly := false
+	switch {
+	case block.Type == model.TypeComment && block.CreatedBy == userID:
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+			permission = model.PermissionCommentBoardCards
+			authorOnly = true
+		}
+	case model.GetViewOwnerID(block) != "":
+		permission = model.PermissionViewBoard
+		viewOwnerOnly = true
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, permission) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to make board changes"))
+		return
+	}
+
 	requestBody, err := io.ReadAll(r.Body)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -574,6 +648,16 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if authorOnly && !patch.ChangesOnlyTitle() {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to change more than the comment text"))
+		return
+	}
+
+	if viewOwnerOnly && patch.ChangesPrivateViewKind(block) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to change the type or parent of a private view"))
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
@@ -654,17 +738,36 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
 	}
 
-	for _, blockID := range patches.BlockIDs {
+	// the permissions are checked once per board
+	checked := map[string]bool{}
+	for i, blockID := range patches.BlockIDs {
 		var block *model.Block
 		block, err = a.app.GetBlockByID(blockID)
 		if err != nil {
 			a.errorResponse(w, r, model.NewErrForbidden("access denied to make board changes"))
 			return
 		}
-		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards) {
+		if !model.IsBlockVisibleTo(block, userID) {
+			a.errorResponse(w, r, model.NewErrNotFound("block ID="+blockID))
+			return
+		}
+		permission := model.PermissionManageBoardCards
+		if model.GetViewOwnerID(block) != "" {
+			permission = model.PermissionViewBoard
+			if i < len(patches.BlockPatches) && patches.BlockPatches[i].ChangesPrivateViewKind(block) {
+				a.errorResponse(w, r, model.NewErrPermission("access denied to change the type or parent of a private view"))
+				return
+			}
+		}
+		key := block.BoardID + "/" + permission.Id
+		if checked[key] {
+			continue
+		}
+		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, permission) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changesa"))
 			return
 		}
+		checked[key] = true
 	}
 
 	err = a.app.PatchBlocksAndNotify(teamID, patches, userID, disableNotify)
@@ -737,7 +840,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if board.ID != block.BoardID {
+	if board.ID != block.BoardID || !model.IsBlockVisibleTo(block, userID) {
 		message := fmt.Sprintf("block ID=%s on BoardID=%s", block.ID, board.ID)
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
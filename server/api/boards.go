@@ -19,8 +19,11 @@ func (a *API) registerBoardsRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handlePatchBoard)).Methods("PATCH")
 	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handleDeleteBoard)).Methods("DELETE")
 	r.HandleFunc("/boards/{boardID}/duplicate", a.sessionRequired(a.handleDuplicateBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/move", a.sessionRequired(a.handleMoveBoard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/undelete", a.sessionRequired(a.handleUndeleteBoard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/freeze", a.sessionRequired(a.handleFreezeBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/unfreeze", a.sessionRequired(a.handleUnfreezeBoard)).Methods("POST")
 }
 
 func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
@@ -359,6 +362,12 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if patch.IsFreezeChange() {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionFreezeBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to freezing board"))
+			return
+		}
+	}
 
 	auditRec := a.makeAuditRecord(r, "patchBoard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
@@ -551,6 +560,123 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleMoveBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/move moveBoard
+	//
+	// Moves a board to another team, keeping its blocks and history
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: toTeam
+	//   in: query
+	//   description: ID of the destination team
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	toTeam := r.URL.Query().Get("toTeam")
+
+	if toTeam == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("toTeam parameter is required"))
+		return
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to source team"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, toTeam, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to destination team"))
+		return
+	}
+
+	// moving a board removes it from its team, so it requires the
+	// same permission as deleting it
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move board"))
+		return
+	}
+
+	if board.Type == model.BoardTypeOpen {
+		if !a.permissions.HasPermissionToTeam(userID, toTeam, model.PermissionCreatePublicChannel) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to create public boards in destination team"))
+			return
+		}
+	} else {
+		if !a.permissions.HasPermissionToTeam(userID, toTeam, model.PermissionCreatePrivateChannel) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to create private boards in destination team"))
+			return
+		}
+	}
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if isGuest {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("fromTeam", board.TeamID)
+	auditRec.AddMeta("toTeam", toTeam)
+
+	movedBoard, err := a.app.MoveBoard(boardID, toTeam, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("fromTeam", board.TeamID),
+		mlog.String("toTeam", toTeam),
+	)
+
+	data, err := json.Marshal(movedBoard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/undelete undeleteBoard
 	//
@@ -636,7 +762,7 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, boardMetadata, err := a.app.GetBoardMetadata(boardID)
+	board, boardMetadata, err := a.app.GetBoardMetadata(boardID, userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -673,3 +799,164 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleFreezeBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/freeze freezeBoard
+	//
+	// Temporarily freezes a board, so only its admins can modify it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: freeze reason and optional automatic unfreeze time
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardFreeze"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if _, err := a.app.GetBoard(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var freeze *model.BoardFreeze
+	if err = json.Unmarshal(requestBody, &freeze); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	if freeze == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid board freeze"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionFreezeBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to freezing board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "freezeBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("reason", freeze.Reason)
+	auditRec.AddMeta("until", freeze.Until)
+
+	board, err := a.app.FreezeBoard(boardID, userID, freeze)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("FreezeBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int64("until", freeze.Until),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUnfreezeBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/unfreeze unfreezeBoard
+	//
+	// Lifts the freeze of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if _, err := a.app.GetBoard(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionFreezeBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to unfreezing board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "unfreezeBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	board, err := a.app.UnfreezeBoard(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UnfreezeBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/permissions"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -127,6 +128,12 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 			a.errorResponse(w, r, model.NewErrBadRequest(message))
 			return
 		}
+
+		if !model.IsBlockVisibleTo(block, userID) {
+			message := fmt.Sprintf("invalid owner for private view id %s", block.ID)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
 	}
 
 	// IDs of boards and blocks are used to confirm that they're
@@ -217,9 +224,8 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	}
 
 	teamID := ""
-	boardIDMap := map[string]bool{}
+	boards := map[string]*model.Board{}
 	for i, boardID := range pbab.BoardIDs {
-		boardIDMap[boardID] = true
 		patch := pbab.BoardPatches[i]
 
 		if err = patch.IsValid(); err != nil {
@@ -244,6 +250,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			a.errorResponse(w, r, err2)
 			return
 		}
+		boards[boardID] = board
 
 		if teamID == "" {
 			teamID = board.TeamID
@@ -261,12 +268,18 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
-		if _, ok := boardIDMap[block.BoardID]; !ok {
+		board, ok := boards[block.BoardID]
+		if !ok {
 			a.errorResponse(w, r, model.NewErrBadRequest("missing BoardID="+block.BoardID))
 			return
 		}
 
-		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards) {
+		if !model.IsBlockVisibleTo(block, userID) {
+			a.errorResponse(w, r, model.NewErrNotFound("block ID="+blockID))
+			return
+		}
+
+		if !permissions.HasPermissionToLoadedBoard(a.permissions, userID, board, model.PermissionManageBoardCards) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to modifying cards"))
 			return
 		}
@@ -342,15 +355,15 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	// user must have permission to delete all the boards, and that
 	// would include the permission to manage their blocks
 	teamID := ""
-	boardIDMap := map[string]bool{}
+	boards := map[string]*model.Board{}
 	for _, boardID := range dbab.Boards {
-		boardIDMap[boardID] = true
 		// all boards in the request should belong to the same team
 		board, err := a.app.GetBoard(boardID)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
 		}
+		boards[boardID] = board
 		if teamID == "" {
 			teamID = board.TeamID
 		}
@@ -373,12 +386,18 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 			return
 		}
 
-		if _, ok := boardIDMap[block.BoardID]; !ok {
+		board, ok := boards[block.BoardID]
+		if !ok {
 			a.errorResponse(w, r, model.NewErrBadRequest("missing BoardID="+block.BoardID))
 			return
 		}
 
-		if !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageBoardCards) {
+		if !model.IsBlockVisibleTo(block, userID) {
+			a.errorResponse(w, r, model.NewErrNotFound("block ID="+blockID))
+			return
+		}
+
+		if !permissions.HasPermissionToLoadedBoard(a.permissions, userID, board, model.PermissionManageBoardCards) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to modifying cards"))
 			return
 		}
@@ -0,0 +1,491 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerCardContentRoutes(r *mux.Router) {
+	// Card content APIs
+	r.HandleFunc("/cards/{cardID}/content", a.sessionRequired(a.handleGetCardContent)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/content", a.sessionRequired(a.handleInsertCardContent)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/content/{blockID}", a.sessionRequired(a.handlePatchCardContent)).Methods("PATCH")
+	r.HandleFunc("/cards/{cardID}/content/{blockID}", a.sessionRequired(a.handleDeleteCardContent)).Methods("DELETE")
+	r.HandleFunc("/cards/{cardID}/content/{blockID}/move", a.sessionRequired(a.handleMoveCardContent)).Methods("POST")
+}
+
+func (a *API) handleGetCardContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/content getCardContent
+	//
+	// Returns the content blocks of a card, in the content order of the
+	// card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card content"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	blocks, err := a.app.GetCardContent(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardContent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.Int("blockCount", len(blocks)),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleInsertCardContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/content insertCardContent
+	//
+	// Adds a content block to a card, at the given position in the
+	// content order of the card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: position
+	//   in: query
+	//   description: Position of the block in the content order, appends the block if missing or out of range
+	//   required: false
+	//   type: integer
+	// - name: Body
+	//   in: body
+	//   description: the content block to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Block"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	position, err := getPositionParam(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var block *model.Block
+	if err = json.Unmarshal(requestBody, &block); err != nil || block == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid content block"))
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify card content"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "insertCardContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("position", position)
+
+	block, err = a.app.InsertCardContent(cardID, block, position, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("InsertCardContent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("blockID", block.ID),
+	)
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blockID", block.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /cards/{cardID}/content/{blockID} patchCardContent
+	//
+	// Partially updates a content block of a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the content block to patch
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: block patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BlockPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: card or content block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	blockID := mux.Vars(r)["blockID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.BlockPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid block patch"))
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify card content"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	block, err := a.app.PatchCardContent(cardID, blockID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchCardContent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("blockID", blockID),
+	)
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/content/{blockID} deleteCardContent
+	//
+	// Deletes a content block of a card and removes it from the content
+	// order of the card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the content block to delete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: card or content block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	blockID := mux.Vars(r)["blockID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify card content"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	if err = a.app.DeleteCardContent(cardID, blockID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardContent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("blockID", blockID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleMoveCardContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/content/{blockID}/move moveCardContent
+	//
+	// Moves a content block of a card to the given position in the
+	// content order of the card, and returns the reordered content.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the content block to move
+	//   required: true
+	//   type: string
+	// - name: position
+	//   in: query
+	//   description: New position of the block in the content order, moves the block last if missing or out of range
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: card or content block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	blockID := mux.Vars(r)["blockID"]
+
+	position, err := getPositionParam(r)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify card content"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveCardContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("position", position)
+
+	blocks, err := a.app.MoveCardContent(cardID, blockID, position, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveCardContent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("blockID", blockID),
+		mlog.Int("position", position),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getCardBlock returns the block of the card with the given ID.
+func (a *API) getCardBlock(cardID string) (*model.Block, error) {
+	block, err := a.app.GetBlockByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != model.TypeCard {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	return block, nil
+}
+
+// getPositionParam returns the value of the position query parameter,
+// or -1 if it's missing.
+func getPositionParam(r *http.Request) (int, error) {
+	strPosition := r.URL.Query().Get("position")
+	if strPosition == "" {
+		return -1, nil
+	}
+
+	position, err := strconv.Atoi(strPosition)
+	if err != nil {
+		return 0, model.NewErrBadRequest(fmt.Sprintf("invalid `position` parameter: %s", err))
+	}
+
+	return position, nil
+}
@@ -0,0 +1,470 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerCardMirrorsRoutes(r *mux.Router) {
+	// Card mirrors APIs
+	r.HandleFunc("/boards/{boardID}/mirrors", a.sessionRequired(a.handleCreateCardMirror)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/mirrors", a.sessionRequired(a.handleGetCardMirrors)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/mirrors/{cardID}", a.sessionRequired(a.handleGetCardMirror)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/mirrors/{cardID}", a.sessionRequired(a.handlePatchCardMirror)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/mirrors/{cardID}", a.sessionRequired(a.handleDeleteCardMirror)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/mirrors/{cardID}/blocks", a.sessionRequired(a.handleGetCardMirrorBlocks)).Methods("GET")
+}
+
+func (a *API) handleCreateCardMirror(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/mirrors createCardMirror
+	//
+	// Mirrors a card from another board on the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card mirror to create, only the card ID is required
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardMirror"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardMirror'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newMirror *model.CardMirror
+	if err = json.Unmarshal(requestBody, &newMirror); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if newMirror == nil || newMirror.CardID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("missing card ID"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to mirror cards on board"))
+		return
+	}
+
+	card, err := a.app.GetCardByID(newMirror.CardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", newMirror.CardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardMirror", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("cardBoardID", card.BoardID)
+
+	mirror, err := a.app.CreateCardMirror(boardID, card.ID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardMirror",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(mirror)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardMirrors(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/mirrors getCardMirrors
+	//
+	// Fetches the cards mirrored on the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardMirror"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card mirrors"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardMirrors", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	mirrors, err := a.app.GetCardMirrorsForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardMirrors",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(mirrors)),
+	)
+
+	data, err := json.Marshal(mirrors)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardMirror(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/mirrors/{cardID} getCardMirror
+	//
+	// Fetches a card mirrored on the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardMirror'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card mirror"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardMirror", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	mirror, err := a.app.GetCardMirror(boardID, cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(mirror)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardMirrorBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/mirrors/{cardID}/blocks getCardMirrorBlocks
+	//
+	// Fetches the content blocks of a card mirrored on the specified
+	// board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card mirror"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardMirrorBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	blocks, err := a.app.GetCardMirrorBlocks(boardID, cardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardMirror(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/mirrors/{cardID} patchCardMirror
+	//
+	// Patches the board local property values of a card mirrored on
+	// the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card mirror patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardMirrorPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardMirror'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.CardMirrorPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("missing card mirror patch"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to patch card mirror"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardMirror", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	mirror, err := a.app.PatchCardMirror(boardID, cardID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchCardMirror",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(mirror)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardMirror(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/mirrors/{cardID} deleteCardMirror
+	//
+	// Removes a mirrored card from the specified board. The card is
+	// kept on its primary board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the mirroring board
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete card mirror"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardMirror", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	if err := a.app.DeleteCardMirror(boardID, cardID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardMirror",
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
@@ -0,0 +1,347 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerCardRemindersRoutes(r *mux.Router) {
+	// Card reminders APIs
+	r.HandleFunc("/cards/{cardID}/reminders", a.sessionRequired(a.handleCreateCardReminder)).Methods("POST")
+	r.HandleFunc("/users/me/reminders", a.sessionRequired(a.handleGetMyCardReminders)).Methods("GET")
+	r.HandleFunc("/users/me/reminders/{reminderID}", a.sessionRequired(a.handlePatchCardReminder)).Methods("PATCH")
+	r.HandleFunc("/users/me/reminders/{reminderID}", a.sessionRequired(a.handleDeleteCardReminder)).Methods("DELETE")
+	r.HandleFunc("/users/me/reminders/{reminderID}/snooze", a.sessionRequired(a.handleSnoozeCardReminder)).Methods("POST")
+}
+
+func (a *API) handleCreateCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/reminders createCardReminder
+	//
+	// Sets a private reminder of the current user on the specified card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the time of the reminder, and an optional note
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminderPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardReminder'
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.CardReminderPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid reminder"))
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	reminder, err := a.app.CreateCardReminder(cardID, userID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardReminder",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("reminderID", reminder.ID),
+	)
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("reminderID", reminder.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetMyCardReminders(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/reminders getMyCardReminders
+	//
+	// Returns the card reminders of the current user, the earliest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardReminder"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "getMyCardReminders", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	reminders, err := a.app.GetCardRemindersForUser(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(reminders)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("reminderCount", len(reminders))
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /users/me/reminders/{reminderID} patchCardReminder
+	//
+	// Edits the time or the note of a reminder of the current user.
+	// Changing the time of a delivered reminder schedules it again.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: reminderID
+	//   in: path
+	//   description: Reminder ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the reminder patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminderPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardReminder'
+	//   '404':
+	//     description: reminder not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	reminderID := mux.Vars(r)["reminderID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.CardReminderPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid reminder patch"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("reminderID", reminderID)
+
+	reminder, err := a.app.PatchCardReminder(reminderID, userID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleSnoozeCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/reminders/{reminderID}/snooze snoozeCardReminder
+	//
+	// Postpones a reminder of the current user by the given number of
+	// minutes from now.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: reminderID
+	//   in: path
+	//   description: Reminder ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the snooze time
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminderSnooze"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardReminder'
+	//   '404':
+	//     description: reminder not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	reminderID := mux.Vars(r)["reminderID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var snooze *model.CardReminderSnooze
+	if err = json.Unmarshal(requestBody, &snooze); err != nil || snooze == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid snooze"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "snoozeCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("reminderID", reminderID)
+	auditRec.AddMeta("minutes", snooze.Minutes)
+
+	reminder, err := a.app.SnoozeCardReminder(reminderID, userID, snooze)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/reminders/{reminderID} deleteCardReminder
+	//
+	// Deletes a reminder of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: reminderID
+	//   in: path
+	//   description: Reminder ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: reminder not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	reminderID := mux.Vars(r)["reminderID"]
+
+	auditRec := a.makeAuditRecord(r, "deleteCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("reminderID", reminderID)
+
+	if err := a.app.DeleteCardReminder(reminderID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
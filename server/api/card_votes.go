@@ -0,0 +1,307 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CardReactionRequest is the request to react to a card
+// swagger:model
+type CardReactionRequest struct {
+	// The emoji of the reaction
+	// required: true
+	Emoji string `json:"emoji"`
+}
+
+func (a *API) registerCardVotesRoutes(r *mux.Router) {
+	// Card votes and reactions APIs
+	r.HandleFunc("/cards/{cardID}/votes", a.sessionRequired(a.handleGetCardVotes)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/votes", a.sessionRequired(a.handleVoteForCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/votes", a.sessionRequired(a.handleRemoveCardVote)).Methods("DELETE")
+	r.HandleFunc("/cards/{cardID}/reactions", a.sessionRequired(a.handleAddCardReaction)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/reactions/{emoji}", a.sessionRequired(a.handleRemoveCardReaction)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardVotes(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/votes getCardVotes
+	//
+	// Returns the vote count and the reactions of a card, and whether the
+	// current user voted or reacted.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardVotes'
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardVotes", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	votes, err := a.app.GetCardVotes(cardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(votes)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleVoteForCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/votes voteForCard
+	//
+	// Adds the vote of the current user for a card. Fails if the user
+	// already voted for as many cards of the board as its vote limit.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardVotes'
+	//   '400':
+	//     description: vote limit reached
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCardVote(w, r, "voteForCard", "", func(cardID, userID string) (*model.CardVotes, error) {
+		return a.app.VoteForCard(cardID, userID)
+	})
+}
+
+func (a *API) handleRemoveCardVote(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/votes removeCardVote
+	//
+	// Removes the vote of the current user for a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardVotes'
+	//   '404':
+	//     description: card or vote not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCardVote(w, r, "removeCardVote", "", func(cardID, userID string) (*model.CardVotes, error) {
+		return a.app.RemoveCardVote(cardID, userID)
+	})
+}
+
+func (a *API) handleAddCardReaction(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/reactions addCardReaction
+	//
+	// Adds an emoji reaction of the current user to a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the reaction
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReactionRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardVotes'
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var reaction CardReactionRequest
+	if err = json.Unmarshal(requestBody, &reaction); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = model.ValidateReactionEmoji(reaction.Emoji); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.handleSetCardVote(w, r, "addCardReaction", reaction.Emoji, func(cardID, userID string) (*model.CardVotes, error) {
+		return a.app.AddCardReaction(cardID, userID, reaction.Emoji)
+	})
+}
+
+func (a *API) handleRemoveCardReaction(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/reactions/{emoji} removeCardReaction
+	//
+	// Removes an emoji reaction of the current user from a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: emoji
+	//   in: path
+	//   description: Emoji of the reaction
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardVotes'
+	//   '404':
+	//     description: card or reaction not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	emoji := mux.Vars(r)["emoji"]
+	a.handleSetCardVote(w, r, "removeCardReaction", emoji, func(cardID, userID string) (*model.CardVotes, error) {
+		return a.app.RemoveCardReaction(cardID, userID, emoji)
+	})
+}
+
+func (a *API) handleSetCardVote(w http.ResponseWriter, r *http.Request, action, emoji string, set func(cardID, userID string) (*model.CardVotes, error)) {
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to vote for cards"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	if emoji != "" {
+		auditRec.AddMeta("emoji", emoji)
+	}
+
+	votes, err := set(cardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardVote",
+		mlog.String("action", action),
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+	)
+
+	data, err := json.Marshal(votes)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -25,6 +26,12 @@ func (a *API) registerCardsRoutes(r *mux.Router) {
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/move", a.sessionRequired(a.handleMoveCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/copy", a.sessionRequired(a.handleCopyCard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/{cardID}/redirect", a.sessionRequired(a.handleGetCardRedirect)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards/search", a.sessionRequired(a.handleSearchCards)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards/archive", a.sessionRequired(a.handleArchiveCards)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards/unarchive", a.sessionRequired(a.handleUnarchiveCards)).Methods("POST")
 }
 
 func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
@@ -151,6 +158,16 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	//   description: Number of cards to return per page(default=100)
 	//   required: false
 	//   type: integer
+	// - name: include_archived
+	//   in: query
+	//   description: Whether to include archived cards (default=false)
+	//   required: false
+	//   type: boolean
+	// - name: view_id
+	//   in: query
+	//   description: The view to sort the cards by, if it sorts them by votes
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -170,6 +187,8 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	strPage := query.Get("page")
 	strPerPage := query.Get("per_page")
+	includeArchived := query.Get("include_archived") == "true"
+	viewID := query.Get("view_id")
 
 	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
@@ -200,8 +219,10 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("page", page)
 	auditRec.AddMeta("per_page", perPage)
+	auditRec.AddMeta("include_archived", includeArchived)
+	auditRec.AddMeta("viewID", viewID)
 
-	cards, err := a.app.GetCardsForBoard(boardID, page, perPage)
+	cards, err := a.app.GetCardsForView(boardID, viewID, userID, page, perPage, includeArchived)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
@@ -370,6 +391,11 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", card.BoardID)
 	auditRec.AddMeta("cardID", card.ID)
 
+	if err = a.app.SetCardsVotes([]*model.Card{card}, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	a.logger.Debug("GetCard",
 		mlog.String("boardID", card.BoardID),
 		mlog.String("cardID", card.ID),
@@ -387,3 +413,418 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleMoveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/move moveCard
+	//
+	// Moves the specified card, with its content, comments and
+	// attachments, to another board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the destination board and property mapping
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardMoveOptions"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleCardTransfer(w, r, "moveCard", model.PermissionManageBoardCards, a.app.MoveCard)
+}
+
+func (a *API) handleCopyCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/copy copyCard
+	//
+	// Copies the specified card, with its content, comments and
+	// attachments, to another board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the destination board and property mapping
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardMoveOptions"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleCardTransfer(w, r, "copyCard", model.PermissionViewBoard, a.app.CopyCard)
+}
+
+// handleCardTransfer checks the permissions on the source and the
+// destination boards and runs a card move or copy operation.
+func (a *API) handleCardTransfer(w http.ResponseWriter, r *http.Request, event string, sourcePermission *mmModel.Permission,
+	transfer func(cardID string, opts *model.CardMoveOptions, userID string) (*model.Card, error)) {
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var opts *model.CardMoveOptions
+	if err = json.Unmarshal(requestBody, &opts); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = opts.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, sourcePermission) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to source board"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, opts.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to destination board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, event, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("toBoardID", opts.BoardID)
+
+	result, err := transfer(cardID, opts, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(event,
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("toBoardID", result.BoardID),
+		mlog.String("resultCardID", result.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardRedirect(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/cards/{cardID}/redirect getCardRedirect
+	//
+	// Returns where a card that was moved out of the specified board
+	// can be found now.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: ID of the board the card was moved from
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardRedirect'
+	//   '404':
+	//     description: redirect not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	cardID := mux.Vars(r)["cardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	redirect, err := a.app.GetCardRedirect(boardID, cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(redirect)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSearchCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/cards/search searchCards
+	//
+	// Returns the cards of the specified board whose title matches the
+	// search term. Archived cards are included.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The search term. Must have at least one character
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	term := r.URL.Query().Get("q")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
+		return
+	}
+
+	if len(term) == 0 {
+		jsonStringResponse(w, http.StatusOK, "[]")
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	cards, err := a.app.SearchCardsForBoard(boardID, term)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SearchCards",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleArchiveCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/archive archiveCards
+	//
+	// Archives cards of the specified board. Archived cards are hidden
+	// from the board views but are not deleted.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the IDs of the cards to archive
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCardsArchived(w, r, "archiveCards", model.PermissionArchiveBoardCards, a.app.ArchiveCards)
+}
+
+func (a *API) handleUnarchiveCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/unarchive unarchiveCards
+	//
+	// Restores archived cards of the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the IDs of the cards to restore
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCardsArchived(w, r, "unarchiveCards", model.PermissionUnarchiveBoardCards, a.app.UnarchiveCards)
+}
+
+func (a *API) handleSetCardsArchived(w http.ResponseWriter, r *http.Request, event string, permission *mmModel.Permission,
+	setArchived func(boardID string, cardIDs []string, userID string) ([]*model.Card, error)) {
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var cardIDs []string
+	if err = json.Unmarshal(requestBody, &cardIDs); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = model.ValidateCardArchiveIDs(cardIDs); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, permission) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to "+permission.Id))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, event, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardCount", len(cardIDs))
+
+	cards, err := setArchived(boardID, cardIDs, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(event,
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
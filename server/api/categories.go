@@ -320,6 +320,11 @@ func (a *API) handleGetUserCategoryBoards(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err = a.app.SetCategoryBoardsUnreadCounts(userID, categoryBlocks); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	data, err := json.Marshal(categoryBlocks)
 	if err != nil {
 		a.errorResponse(w, r, err)
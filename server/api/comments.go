@@ -0,0 +1,1043 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CommentReplyRequest is the request to reply to a comment
+// swagger:model
+type CommentReplyRequest struct {
+	// The text of the reply
+	// required: true
+	Text string `json:"text"`
+}
+
+// CommentReactionRequest is the request to react to a comment
+// swagger:model
+type CommentReactionRequest struct {
+	// The emoji of the reaction
+	// required: true
+	Emoji string `json:"emoji"`
+}
+
+func (a *API) registerCommentsRoutes(r *mux.Router) {
+	// Comment threads APIs
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/thread", a.sessionRequired(a.handleGetCommentThread)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/replies", a.sessionRequired(a.handleReplyToComment)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/resolve", a.sessionRequired(a.handleResolveCommentThread)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/unresolve", a.sessionRequired(a.handleUnresolveCommentThread)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/history", a.sessionRequired(a.handleGetCommentHistory)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/reactions", a.sessionRequired(a.handleAddCommentReaction)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/comments/{commentID}/reactions/{emoji}", a.sessionRequired(a.handleRemoveCommentReaction)).Methods("DELETE")
+
+	// Card comments APIs
+	r.HandleFunc("/cards/{cardID}/comments", a.sessionRequired(a.handleGetCardComments)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/comments", a.sessionRequired(a.handleCreateCardComment)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/comments/{commentID}", a.sessionRequired(a.handleGetCardComment)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/comments/{commentID}", a.sessionRequired(a.handlePatchCardComment)).Methods("PATCH")
+	r.HandleFunc("/cards/{cardID}/comments/{commentID}", a.sessionRequired(a.handleDeleteCardComment)).Methods("DELETE")
+}
+
+func (a *API) handleGetCommentThread(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/comments/{commentID}/thread getCommentThread
+	//
+	// Returns the thread a comment belongs to, starting at its root
+	// comment, with the replies and the reactions to each comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	if _, err := a.getBoardComment(boardID, commentID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCommentThread", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	thread, err := a.app.GetCommentThread(commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCommentThread",
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+		mlog.Int("replyCount", len(thread.Replies)),
+	)
+
+	data, err := json.Marshal(thread)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleReplyToComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/comments/{commentID}/replies replyToComment
+	//
+	// Adds a reply to the thread of a comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of the comment to reply to
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the reply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CommentReplyRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var reply CommentReplyRequest
+	if err = json.Unmarshal(requestBody, &reply); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to post card comments"))
+		return
+	}
+
+	if _, err = a.getBoardComment(boardID, commentID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "replyToComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	block, err := a.app.ReplyToComment(commentID, reply.Text, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	comment, err := model.Block2Comment(block)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ReplyToComment",
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+		mlog.String("replyID", comment.ID),
+	)
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("replyID", comment.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleResolveCommentThread(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/comments/{commentID}/resolve resolveCommentThread
+	//
+	// Marks the thread of a root comment as resolved.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of the root comment of the thread
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCommentThreadResolved(w, r, true)
+}
+
+func (a *API) handleUnresolveCommentThread(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/comments/{commentID}/unresolve unresolveCommentThread
+	//
+	// Reopens the resolved thread of a root comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of the root comment of the thread
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.handleSetCommentThreadResolved(w, r, false)
+}
+
+func (a *API) handleSetCommentThreadResolved(w http.ResponseWriter, r *http.Request, resolved bool) {
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	action := "resolveCommentThread"
+	if !resolved {
+		action = "unresolveCommentThread"
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to resolve card comments"))
+		return
+	}
+
+	if _, err := a.getBoardComment(boardID, commentID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	var thread *model.Comment
+	var err error
+	if resolved {
+		thread, err = a.app.ResolveCommentThread(commentID, userID)
+	} else {
+		thread, err = a.app.UnresolveCommentThread(commentID, userID)
+	}
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCommentThreadResolved",
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+		mlog.Bool("resolved", resolved),
+	)
+
+	data, err := json.Marshal(thread)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCommentHistory(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/comments/{commentID}/history getCommentHistory
+	//
+	// Returns the edit history of a comment, as the versions of its
+	// text, oldest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CommentEdit"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+		return
+	}
+
+	if _, err := a.getBoardComment(boardID, commentID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCommentHistory", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	edits, err := a.app.GetCommentHistory(commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCommentHistory",
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+		mlog.Int("editCount", len(edits)),
+	)
+
+	data, err := json.Marshal(edits)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleAddCommentReaction(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/comments/{commentID}/reactions addCommentReaction
+	//
+	// Adds an emoji reaction of the current user to a comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the reaction
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CommentReactionRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CommentReactionSummary"
+	//   '404':
+	//     description: comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var reaction CommentReactionRequest
+	if err = json.Unmarshal(requestBody, &reaction); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = model.ValidateReactionEmoji(reaction.Emoji); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.handleSetCommentReaction(w, r, userID, boardID, commentID, reaction.Emoji, true)
+}
+
+func (a *API) handleRemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/comments/{commentID}/reactions/{emoji} removeCommentReaction
+	//
+	// Removes an emoji reaction of the current user from a comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// - name: emoji
+	//   in: path
+	//   description: Emoji of the reaction
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CommentReactionSummary"
+	//   '404':
+	//     description: comment or reaction not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+
+	a.handleSetCommentReaction(w, r, userID, vars["boardID"], vars["commentID"], vars["emoji"], false)
+}
+
+func (a *API) handleSetCommentReaction(w http.ResponseWriter, r *http.Request, userID, boardID, commentID, emoji string, add bool) {
+	action := "addCommentReaction"
+	if !add {
+		action = "removeCommentReaction"
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to react to card comments"))
+		return
+	}
+
+	if _, err := a.getBoardComment(boardID, commentID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("commentID", commentID)
+	auditRec.AddMeta("emoji", emoji)
+
+	var reactions []*model.CommentReactionSummary
+	var err error
+	if add {
+		reactions, err = a.app.AddCommentReaction(commentID, userID, emoji)
+	} else {
+		reactions, err = a.app.RemoveCommentReaction(commentID, userID, emoji)
+	}
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCommentReaction",
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+		mlog.Bool("add", add),
+	)
+
+	data, err := json.Marshal(reactions)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardComments(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/comments getCardComments
+	//
+	// Returns the comment threads of a card, oldest first, with the
+	// replies and the reactions to each comment.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card comments"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardComments", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	comments, err := a.app.GetCardComments(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardComments",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.Int("threadCount", len(comments)),
+	)
+
+	data, err := json.Marshal(comments)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateCardComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/comments createCardComment
+	//
+	// Adds a comment to a card. Comments with a thread ID are added as
+	// replies to the thread.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the comment to add, only the text and thread ID are used
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Comment"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: card or thread not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newComment *model.Comment
+	if err = json.Unmarshal(requestBody, &newComment); err != nil || newComment == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid comment"))
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to post card comments"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("threadID", newComment.ThreadID)
+
+	comment, err := a.app.CreateCardComment(cardID, newComment, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCardComment",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("commentID", comment.ID),
+	)
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("commentID", comment.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/comments/{commentID} getCardComment
+	//
+	// Returns a comment of a card, with the reactions to it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: card or comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card comments"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	comment, err := a.app.GetCardComment(cardID, commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardComment",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("commentID", commentID),
+	)
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /cards/{cardID}/comments/{commentID} patchCardComment
+	//
+	// Modifies the text of a comment of a card. Only the author of the
+	// comment can modify it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the comment patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CommentPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Comment"
+	//   '404':
+	//     description: card or comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.CommentPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid comment patch"))
+		return
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionCommentBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify card comments"))
+		return
+	}
+
+	comment, err := a.app.GetCardComment(cardID, commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if comment.CreatedBy != userID {
+		a.errorResponse(w, r, model.NewErrPermission("only the author of a comment can modify it"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	comment, err = a.app.PatchCardComment(cardID, commentID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchCardComment",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("commentID", commentID),
+	)
+
+	data, err := json.Marshal(comment)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/comments/{commentID} deleteCardComment
+	//
+	// Deletes a comment of a card. Deleting the root comment of a thread
+	// deletes its replies too.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: Comment ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: card or comment not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+	commentID := mux.Vars(r)["commentID"]
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	comment, err := a.app.GetCardComment(cardID, commentID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	permission := model.PermissionDeleteOthersComments
+	if comment.CreatedBy == userID {
+		permission = model.PermissionCommentBoardCards
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, permission) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete card comments"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	if err = a.app.DeleteCardComment(cardID, commentID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardComment",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("commentID", commentID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+// getBoardComment returns the comment with the given ID if it belongs
+// to the board.
+func (a *API) getBoardComment(boardID, commentID string) (*model.Block, error) {
+	block, err := a.app.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.BoardID != boardID {
+		return nil, model.NewErrNotFound(fmt.Sprintf("comment ID=%s on BoardID=%s", commentID, boardID))
+	}
+
+	return block, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *API) registerHealthRoutes(r *mux.Router) {
+	// Health APIs are used by probes, which don't send the CSRF header
+	r.HandleFunc("/api/v2/health/live", a.handleHealthLive).Methods("GET")
+	r.HandleFunc("/api/v2/health/ready", a.handleHealthReady).Methods("GET")
+}
+
+func (a *API) handleHealthLive(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /health/live healthLive
+	//
+	// Responds with the `ok` status if the web service is running, without
+	// checking the components it depends on.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Health"
+	a.healthResponse(w, r, &model.Health{Status: model.HealthStatusOK})
+}
+
+func (a *API) handleHealthReady(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /health/ready healthReady
+	//
+	// Checks the database, its migrations, the file store, the
+	// notification backends and the scheduled tasks, and responds with
+	// the status of each. The result of a check is reused for a few
+	// seconds.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: all the components are ok
+	//     schema:
+	//       "$ref": "#/definitions/Health"
+	//   '503':
+	//     description: some component is unavailable
+	//     schema:
+	//       "$ref": "#/definitions/Health"
+	a.healthResponse(w, r, a.app.CheckHealth())
+}
+
+func (a *API) healthResponse(w http.ResponseWriter, r *http.Request, health *model.Health) {
+	data, err := json.Marshal(health)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	code := http.StatusOK
+	if !health.IsOK() {
+		code = http.StatusServiceUnavailable
+	}
+	setResponseHeader(w, "Cache-Control", "no-store")
+	jsonBytesResponse(w, code, data)
+}
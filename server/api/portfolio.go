@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerPortfolioRoutes(r *mux.Router) {
+	// Portfolio APIs
+	r.HandleFunc("/portfolio", a.sessionRequired(a.handleQueryPortfolio)).Methods("POST")
+}
+
+func (a *API) handleQueryPortfolio(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /portfolio queryPortfolio
+	//
+	// Returns the cards of a set of boards as rows with a common set of
+	// columns. Boards are given explicitly or through a category of the
+	// user, and properties are matched to the columns by name, or by the
+	// names and aliases of the property library in the query.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the portfolio query
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PortfolioQuery"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/PortfolioResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var query *model.PortfolioQuery
+	if err = json.Unmarshal(requestBody, &query); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = query.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "queryPortfolio", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("categoryID", query.CategoryID)
+
+	boardIDs, err := a.app.GetPortfolioBoardIDs(userID, query)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// boards requested explicitly must all be accessible, while the
+	// boards of a category that the user can no longer access are
+	// left out of the portfolio
+	viewableBoardIDs := make([]string, 0, len(boardIDs))
+	for _, boardID := range boardIDs {
+		if a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			viewableBoardIDs = append(viewableBoardIDs, boardID)
+			continue
+		}
+
+		if query.CategoryID == "" {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board"))
+			return
+		}
+	}
+
+	result, err := a.app.QueryPortfolio(viewableBoardIDs, query)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("QueryPortfolio",
+		mlog.String("userID", userID),
+		mlog.Int("boardCount", len(result.BoardIDs)),
+		mlog.Int("total", result.Total),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardCount", len(result.BoardIDs))
+	auditRec.Success()
+}
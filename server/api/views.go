@@ -0,0 +1,630 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) registerViewsRoutes(r *mux.Router) {
+	// Views APIs
+	r.HandleFunc("/boards/{boardID}/views", a.sessionRequired(a.handleGetViews)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/views", a.sessionRequired(a.handleCreateView)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}", a.sessionRequired(a.handleGetView)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}", a.sessionRequired(a.handlePatchView)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}", a.sessionRequired(a.handleDeleteView)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}/duplicate", a.sessionRequired(a.handleDuplicateView)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}/share", a.sessionRequired(a.handleShareView)).Methods("POST")
+}
+
+func (a *API) handleGetViews(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/views getViews
+	//
+	// Returns the views of a board: the shared views and the private
+	// views of the user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/View"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch views"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getViews", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	views, err := a.app.GetViewsForBoard(boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetViews",
+		mlog.String("boardID", boardID),
+		mlog.Int("viewCount", len(views)),
+	)
+
+	data, err := json.Marshal(views)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleCreateView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/views createView
+	//
+	// Creates a new view for the specified board. Views with the ID of
+	// the user as owner are private to the user. References to
+	// properties that are not part of the board are removed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the view to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/View"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/View'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newView *model.View
+	if err = json.Unmarshal(requestBody, &newView); err != nil || newView == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid view"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, viewManagePermission(newView)) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	view, err := a.app.CreateView(newView, boardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateView",
+		mlog.String("boardID", view.BoardID),
+		mlog.String("viewID", view.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("viewID", view.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/views/{viewID} getView
+	//
+	// Returns a view of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/View'
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	view, err := a.getBoardView(boardID, viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handlePatchView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/views/{viewID} patchView
+	//
+	// Partially updates a view. References to properties that are not
+	// part of the board are removed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: view patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ViewPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/View'
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.ViewPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid view patch"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to patch view"))
+		return
+	}
+
+	view, err := a.getBoardView(boardID, viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, viewManagePermission(view)) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to patch view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	view, err = a.app.PatchView(viewID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/views/{viewID} deleteView
+	//
+	// Deletes a view of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete view"))
+		return
+	}
+
+	view, err := a.getBoardView(boardID, viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, viewManagePermission(view)) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	if err = a.app.DeleteView(viewID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+	)
+
+	// response
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleDuplicateView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/views/{viewID}/duplicate duplicateView
+	//
+	// Duplicates a view, either on its own board or on another board.
+	// Copies of private views are private too. Views duplicated to
+	// another board refer to the properties of that board with the same
+	// name and type.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: ID of the view to duplicate
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the destination of the duplicate
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/ViewDuplicateOptions"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/View'
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var opts model.ViewDuplicateOptions
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &opts); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+			return
+		}
+	}
+
+	destBoardID := opts.BoardID
+	if destBoardID == "" {
+		destBoardID = boardID
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch view"))
+		return
+	}
+
+	view, err := a.getBoardView(boardID, viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, destBoardID, viewManagePermission(view)) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create view"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "duplicateView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+	auditRec.AddMeta("destBoardID", destBoardID)
+
+	view, err = a.app.DuplicateView(viewID, destBoardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DuplicateView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.String("destBoardID", destBoardID),
+		mlog.String("newViewID", view.ID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("newViewID", view.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleShareView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/views/{viewID}/share shareView
+	//
+	// Shares a private view of the user with everyone on the board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: ID of the private view to share
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/View'
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to share view"))
+		return
+	}
+
+	if _, err := a.getBoardView(boardID, viewID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "shareView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	view, err := a.app.ShareView(viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ShareView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+// getBoardView returns the view with the given ID if it belongs to the
+// board and is visible to the user.
+func (a *API) getBoardView(boardID, viewID, userID string) (*model.View, error) {
+	view, err := a.app.GetViewByID(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.BoardID != boardID || (view.OwnerID != "" && view.OwnerID != userID) {
+		return nil, model.NewErrNotFound(fmt.Sprintf("view ID=%s on BoardID=%s", viewID, boardID))
+	}
+
+	return view, nil
+}
+
+// viewManagePermission returns the permission needed to change a view.
+// Private views only need access to the board, as they only affect
+// their owner.
+func viewManagePermission(view *model.View) *mmModel.Permission {
+	if view.OwnerID != "" {
+		return model.PermissionViewBoard
+	}
+	return model.PermissionManageBoardCards
+}
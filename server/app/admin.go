@@ -0,0 +1,157 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// MinSessionExpiryTime is the minimum age in seconds of the expired
+	// sessions that are cleaned up.
+	MinSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
+
+	adminBatchSize = 100
+)
+
+// AdminCreateUser creates a user, with the same checks as registering.
+func (a *App) AdminCreateUser(request *model.AdminCreateUserRequest) (*model.User, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.RegisterUser(request.Username, request.Email, request.Password); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.GetUserByUsername(request.Username)
+}
+
+// GetAllUsers returns the users, with the deactivated ones if
+// includeDeleted is true.
+func (a *App) GetAllUsers(includeDeleted bool) ([]*model.User, error) {
+	return a.store.GetAllUsers(includeDeleted)
+}
+
+// DeactivateUser prevents a user from logging in, and ends their
+// sessions. Their boards and content are kept.
+func (a *App) DeactivateUser(username string) error {
+	user, err := a.store.GetUserByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if err := a.store.DeactivateUser(user.ID); err != nil {
+		return err
+	}
+
+	a.logger.Info("Deactivated user", mlog.String("userID", user.ID), mlog.String("username", username))
+	return nil
+}
+
+// GetAllTeams returns all the teams.
+func (a *App) GetAllTeams() ([]*model.Team, error) {
+	return a.store.GetAllTeams()
+}
+
+// RegenerateSignupToken replaces the token required to register to a
+// team, invalidating the previous invite links.
+func (a *App) RegenerateSignupToken(teamID string) (*model.Team, error) {
+	team, err := a.store.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	team.SignupToken = utils.NewID(utils.IDTypeToken)
+	team.ModifiedBy = model.SystemUserID
+	if err := a.store.UpsertTeamSignupToken(*team); err != nil {
+		return nil, err
+	}
+
+	return a.store.GetTeam(teamID)
+}
+
+// TransferBoardOwnership makes a user the creator and an admin of a
+// board. The previous owner stays a member as an editor.
+func (a *App) TransferBoardOwnership(boardID, userID string) (*model.Board, error) {
+	if _, err := a.store.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.TransferBoardOwnership(boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
+		for _, member := range members {
+			a.wsAdapter.BroadcastMemberChange(board.TeamID, boardID, member)
+		}
+		return nil
+	})
+
+	return board, nil
+}
+
+// CleanUpSessions deletes the sessions that expired.
+func (a *App) CleanUpSessions() error {
+	cfg := a.GetConfig()
+	secondsAgo := MinSessionExpiryTime
+	if secondsAgo < cfg.SessionExpireTime {
+		secondsAgo = cfg.SessionExpireTime
+	}
+
+	return a.store.CleanUpSessions(secondsAgo)
+}
+
+// RunDataRetention deletes the boards and blocks not updated since the
+// start of the day a number of days ago, whether the data retention is
+// enabled or not, and returns the number of deleted rows.
+func (a *App) RunDataRetention(days int, now time.Time) (int64, error) {
+	if days <= 0 {
+		return 0, model.NewErrBadRequest("a positive number of days is required")
+	}
+
+	day := now.AddDate(0, 0, -days)
+	cutoff := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, now.Location())
+
+	a.logger.Info("Running the data retention", mlog.Int("days", days), mlog.String("cutoff", cutoff.String()))
+	return a.store.RunDataRetention(utils.GetMillisForTime(cutoff), adminBatchSize)
+}
+
+// RunScheduledDataRetention runs the data retention with the configured
+// number of days, if the data retention is enabled.
+func (a *App) RunScheduledDataRetention() error {
+	cfg := a.GetConfig()
+	if !cfg.EnableDataRetention {
+		return nil
+	}
+
+	count, err := a.RunDataRetention(cfg.DataRetentionDays, time.Now())
+	if err != nil {
+		return err
+	}
+	a.logger.Info("Data retention finished", mlog.Int64("deleted", count))
+	return nil
+}
+
+// RebuildBlockLinks saves again the links in the text of all the blocks,
+// which backlinks are looked up from, and returns the number of blocks
+// indexed.
+func (a *App) RebuildBlockLinks() (int64, error) {
+	return a.store.RebuildBlockLinks(adminBatchSize)
+}
+
+// CheckIntegrity runs the data integrity checks.
+func (a *App) CheckIntegrity() ([]*model.IntegrityCheck, error) {
+	return a.store.CheckIntegrity()
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestAdminRunDataRetention(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("deletes from the start of the day", func(t *testing.T) {
+		now := time.Date(2022, time.March, 10, 15, 30, 0, 0, time.UTC)
+		cutoff := utils.GetMillisForTime(time.Date(2022, time.March, 3, 0, 0, 0, 0, time.UTC))
+
+		th.Store.EXPECT().RunDataRetention(cutoff, int64(adminBatchSize)).Return(int64(4), nil)
+
+		count, err := th.App.RunDataRetention(7, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 4, count)
+	})
+
+	t.Run("requires a positive number of days", func(t *testing.T) {
+		_, err := th.App.RunDataRetention(0, time.Now())
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestAdminRunScheduledDataRetention(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.EnableDataRetention = false
+		require.NoError(t, th.App.RunScheduledDataRetention())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		th.App.config.EnableDataRetention = true
+		th.App.config.DataRetentionDays = 30
+		th.Store.EXPECT().RunDataRetention(gomock.Any(), int64(adminBatchSize)).Return(int64(2), nil)
+		require.NoError(t, th.App.RunScheduledDataRetention())
+	})
+}
+
+func TestAdminCleanUpSessions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("minimum expiry time", func(t *testing.T) {
+		th.App.config.SessionExpireTime = 60
+		th.Store.EXPECT().CleanUpSessions(MinSessionExpiryTime).Return(nil)
+		require.NoError(t, th.App.CleanUpSessions())
+	})
+
+	t.Run("configured expiry time", func(t *testing.T) {
+		th.App.config.SessionExpireTime = MinSessionExpiryTime * 2
+		th.Store.EXPECT().CleanUpSessions(MinSessionExpiryTime * 2).Return(nil)
+		require.NoError(t, th.App.CleanUpSessions())
+	})
+}
+
+func TestAdminTransferBoardOwnership(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("unknown user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByID("user-id").Return(nil, model.NewErrNotFound("user ID=user-id"))
+
+		_, err := th.App.TransferBoardOwnership("board-id", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("transfers the board", func(t *testing.T) {
+		board := &model.Board{ID: "board-id", TeamID: "team-id", CreatedBy: "user-id"}
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id"}, nil)
+		th.Store.EXPECT().TransferBoardOwnership("board-id", "user-id").Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		transferred, err := th.App.TransferBoardOwnership("board-id", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, board, transferred)
+	})
+}
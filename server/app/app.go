@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/scheduler"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/utils"
@@ -21,9 +23,8 @@ import (
 )
 
 const (
-	blockChangeNotifierQueueSize       = 1000
-	blockChangeNotifierPoolSize        = 10
-	blockChangeNotifierShutdownTimeout = time.Second * 10
+	blockChangeNotifierQueueSize = 1000
+	blockChangeNotifierPoolSize  = 10
 )
 
 type servicesAPI interface {
@@ -55,6 +56,7 @@ type Services struct {
 }
 
 type App struct {
+	configMux           sync.RWMutex
 	config              *config.Configuration
 	store               store.Store
 	auth                *auth.Auth
@@ -70,13 +72,36 @@ type App struct {
 
 	cardLimitMux sync.RWMutex
 	cardLimit    int
+
+	scheduledTasksMux sync.RWMutex
+	scheduledTasks    []*scheduler.ScheduledTask
+
+	healthMux       sync.Mutex
+	health          *model.Health
+	healthCheckedAt time.Time
+
+	unreadCountsMux     sync.Mutex
+	unreadCountsChanges map[string]*unreadCountsChange
 }
 
+// SetConfig replaces the configuration of the app and of the services
+// that read it.
 func (a *App) SetConfig(config *config.Configuration) {
+	a.configMux.Lock()
 	a.config = config
+	a.configMux.Unlock()
+
+	if a.auth != nil {
+		a.auth.SetConfig(config)
+	}
+	if a.webhook != nil {
+		a.webhook.SetConfig(config)
+	}
 }
 
 func (a *App) GetConfig() *config.Configuration {
+	a.configMux.RLock()
+	defer a.configMux.RUnlock()
 	return a.config
 }
 
@@ -94,6 +119,7 @@ func New(config *config.Configuration, wsAdapter ws.Adapter, services Services)
 		permissions:         services.Permissions,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
 		servicesAPI:         services.ServicesAPI,
+		unreadCountsChanges: map[string]*unreadCountsChange{},
 	}
 	app.initialize(services.SkipTemplateInit)
 	return app
@@ -69,7 +69,8 @@ func (a *App) GetUsersList(userIDs []string) ([]*model.User, error) {
 		return nil, errors.New("No User IDs")
 	}
 
-	users, err := a.store.GetUsersList(userIDs, a.config.ShowEmailAddress, a.config.ShowFullName)
+	cfg := a.GetConfig()
+	users, err := a.store.GetUsersList(userIDs, cfg.ShowEmailAddress, cfg.ShowFullName)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to find users")
 	}
@@ -184,7 +185,7 @@ func (a *App) RegisterUser(username, email, password string) error {
 		Email:       email,
 		Password:    auth.HashPassword(password),
 		MfaSecret:   "",
-		AuthService: a.config.AuthMode,
+		AuthService: a.GetConfig().AuthMode,
 		AuthData:    "",
 	})
 	if err != nil {
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetBoardBacklinks returns the cards that link to a board, leaving out
+// the cards of boards the user can't view.
+func (a *App) GetBoardBacklinks(boardID, userID string) ([]*model.Backlink, error) {
+	backlinks, err := a.store.GetBacklinks(boardID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return a.filterBacklinks(backlinks, userID), nil
+}
+
+// GetCardBacklinks returns the cards that link to a card, leaving out
+// the cards of boards the user can't view.
+func (a *App) GetCardBacklinks(cardID, userID string) ([]*model.Backlink, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	backlinks, err := a.store.GetBacklinks(card.BoardID, card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.filterBacklinks(backlinks, userID), nil
+}
+
+func (a *App) filterBacklinks(backlinks []*model.Backlink, userID string) []*model.Backlink {
+	canView := map[string]bool{}
+	filtered := make([]*model.Backlink, 0, len(backlinks))
+	for _, backlink := range backlinks {
+		allowed, ok := canView[backlink.BoardID]
+		if !ok {
+			allowed = a.permissions.HasPermissionToBoard(userID, backlink.BoardID, model.PermissionViewBoard)
+			canView[backlink.BoardID] = allowed
+		}
+		if allowed {
+			filtered = append(filtered, backlink)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGetCardBacklinks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id_1").Return(&model.Block{ID: "view_id_1", BoardID: "board_id_1", Type: model.TypeView}, nil)
+
+		backlinks, err := th.App.GetCardBacklinks("view_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, backlinks)
+	})
+
+	t.Run("no backlinks", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetBacklinks("board_id_1", "card_id_1").Return([]*model.Backlink{}, nil)
+
+		backlinks, err := th.App.GetCardBacklinks("card_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Empty(t, backlinks)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		th.Store.EXPECT().GetBacklinks("board_id_1", "").Return(nil, model.NewErrNotFound("board"))
+
+		backlinks, err := th.App.GetBoardBacklinks("board_id_1", "user_id_1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, backlinks)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -71,10 +72,24 @@ func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedB
 }
 
 func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch, modifiedByID string, disableNotify bool) (*model.Block, error) {
+	if blockPatch.ChangesArchiveState() {
+		return nil, model.ErrArchiveFieldsPatch
+	}
+	if blockPatch.ChangesCommentState() {
+		return nil, model.ErrCommentFieldsPatch
+	}
+	if blockPatch.ChangesViewOwner() {
+		return nil, model.ErrViewOwnerPatch
+	}
+
 	oldBlock, err := a.store.GetBlock(blockID)
 	if err != nil {
 		return nil, err
 	}
+	if blockPatch.ChangesPrivateViewKind(oldBlock) {
+		return nil, model.ErrPrivateViewKindPatch
+	}
+	blockPatch.StampCommentEdit(oldBlock, utils.GetMillis())
 
 	if a.IsCloudLimited() {
 		containsLimitedBlocks, lErr := a.ContainsLimitedBlocks([]*model.Block{oldBlock})
@@ -104,6 +119,8 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 	a.blockChangeNotifier.Enqueue(func() error {
 		// broadcast on websocket
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.broadcastCardMirrorsChange(block)
+		a.broadcastUnreadCountsChange(board.TeamID, block.BoardID, modifiedByID)
 
 		// broadcast on webhooks
 		a.webhook.NotifyUpdate(block)
@@ -122,11 +139,35 @@ func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, mo
 }
 
 func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string, disableNotify bool) error {
+	for i := range blockPatches.BlockPatches {
+		if blockPatches.BlockPatches[i].ChangesArchiveState() {
+			return model.ErrArchiveFieldsPatch
+		}
+		if blockPatches.BlockPatches[i].ChangesCommentState() {
+			return model.ErrCommentFieldsPatch
+		}
+		if blockPatches.BlockPatches[i].ChangesViewOwner() {
+			return model.ErrViewOwnerPatch
+		}
+	}
+
 	oldBlocks, err := a.store.GetBlocksByIDs(blockPatches.BlockIDs)
 	if err != nil {
 		return err
 	}
 
+	oldBlocksMap := map[string]*model.Block{}
+	for _, block := range oldBlocks {
+		oldBlocksMap[block.ID] = block
+	}
+	now := utils.GetMillis()
+	for i, blockID := range blockPatches.BlockIDs {
+		if blockPatches.BlockPatches[i].ChangesPrivateViewKind(oldBlocksMap[blockID]) {
+			return model.ErrPrivateViewKindPatch
+		}
+		blockPatches.BlockPatches[i].StampCommentEdit(oldBlocksMap[blockID], now)
+	}
+
 	if a.IsCloudLimited() {
 		containsLimitedBlocks, err := a.ContainsLimitedBlocks(oldBlocks)
 		if err != nil {
@@ -143,16 +184,22 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
+		changedBoardIDs := map[string]bool{}
 		for i, blockID := range blockPatches.BlockIDs {
 			newBlock, err := a.store.GetBlock(blockID)
 			if err != nil {
 				return err
 			}
 			a.wsAdapter.BroadcastBlockChange(teamID, newBlock)
+			a.broadcastCardMirrorsChange(newBlock)
 			a.webhook.NotifyUpdate(newBlock)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Update, newBlock, oldBlocks[i], modifiedByID)
 			}
+			changedBoardIDs[newBlock.BoardID] = true
+		}
+		for boardID := range changedBoardIDs {
+			a.broadcastUnreadCountsChange(teamID, boardID, modifiedByID)
 		}
 		return nil
 	})
@@ -173,6 +220,8 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 	if err == nil {
 		a.blockChangeNotifier.Enqueue(func() error {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+			a.broadcastCardMirrorsChange(block)
+			a.broadcastUnreadCountsChange(board.TeamID, block.BoardID, modifiedByID)
 			a.metrics.IncrementBlocksInserted(1)
 			a.webhook.NotifyUpdate(block)
 			if !disableNotify {
@@ -274,11 +323,15 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, b := range needsNotify {
 			block := b
+			a.broadcastCardMirrorsChange(block)
 			a.webhook.NotifyUpdate(block)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Add, block, nil, modifiedByID)
 			}
 		}
+		if len(needsNotify) > 0 {
+			a.broadcastUnreadCountsChange(board.TeamID, board.ID, modifiedByID)
+		}
 		return nil
 	})
 
@@ -318,13 +371,45 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		return nil
 	}
 
+	// replies keep the card as their parent, so they are deleted
+	// explicitly with the root comment of their thread
+	replies := []*model.Block{}
+	if block.Type == model.TypeComment && !model.IsCommentReply(block) {
+		replies, err = a.getCommentReplies(block)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = a.store.DeleteBlock(blockID, modifiedBy)
 	if err != nil {
 		return err
 	}
 
+	for _, reply := range replies {
+		if err = a.store.DeleteBlock(reply.ID, modifiedBy); err != nil {
+			return err
+		}
+	}
+
+	if block.Type == model.TypeCard {
+		if err = a.store.DeleteCardRemindersForCard(block.ID); err != nil {
+			return err
+		}
+		if err = a.store.DeleteCardVotesForCard(block.ID); err != nil {
+			return err
+		}
+	}
+
 	a.blockChangeNotifier.Enqueue(func() error {
+		for _, reply := range replies {
+			a.wsAdapter.BroadcastBlockDelete(board.TeamID, reply.ID, reply.BoardID)
+		}
 		a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, block.BoardID)
+		deletedBlock := *block
+		deletedBlock.DeleteAt = utils.GetMillis()
+		a.broadcastCardMirrorsChange(&deletedBlock)
+		a.broadcastUnreadCountsChange(board.TeamID, block.BoardID, modifiedBy)
 		a.metrics.IncrementBlocksDeleted(1)
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
@@ -387,6 +472,8 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.broadcastCardMirrorsChange(block)
+		a.broadcastUnreadCountsChange(board.TeamID, block.BoardID, modifiedBy)
 		a.metrics.IncrementBlocksInserted(1)
 		a.webhook.NotifyUpdate(block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
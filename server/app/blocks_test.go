@@ -32,7 +32,7 @@ func TestInsertBlock(t *testing.T) {
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
 		err := th.App.InsertBlock(block, "user-id-1")
 		require.NoError(t, err)
 	})
@@ -64,8 +64,8 @@ func TestPatchBlocks(t *testing.T) {
 		th.Store.EXPECT().GetBlocksByIDs([]string{"block1"}).Return([]*model.Block{block1}, nil)
 		th.Store.EXPECT().PatchBlocks(gomock.Eq(&blockPatches), gomock.Eq("user-id-1")).Return(nil)
 		th.Store.EXPECT().GetBlock("block1").Return(block1, nil)
-		// this call comes from the WS server notification
-		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Times(1)
+		// these calls come from the WS server and unread counts notifications
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Times(2)
 		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
 		require.NoError(t, err)
 	})
@@ -129,7 +129,7 @@ func TestDeleteBlock(t *testing.T) {
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(block, nil)
 		th.Store.EXPECT().DeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
 		th.Store.EXPECT().GetBoard(gomock.Eq(testBoardID)).Return(board, nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
 		err := th.App.DeleteBlock("block-id", "user-id-1")
 		require.NoError(t, err)
 	})
@@ -167,7 +167,7 @@ func TestUndeleteBlock(t *testing.T) {
 		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(block, nil)
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
 		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
 		require.NoError(t, err)
 	})
@@ -290,7 +290,7 @@ func TestInsertBlocks(t *testing.T) {
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
 		_, err := th.App.InsertBlocks([]*model.Block{block}, "user-id-1")
 		require.NoError(t, err)
 	})
@@ -317,7 +317,7 @@ func TestInsertBlocks(t *testing.T) {
 		board := &model.Board{ID: boardID}
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(block, "user-id-1").Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).Times(2)
 
 		// setting up mocks for limits
 		fakeLicense := &mmModel.License{
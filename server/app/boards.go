@@ -35,7 +35,7 @@ func (a *App) GetBoardCount() (int64, error) {
 	return a.store.GetBoardCount()
 }
 
-func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetadata, error) {
+func (a *App) GetBoardMetadata(boardID, userID string) (*model.Board, *model.BoardMetadata, error) {
 	license := a.store.GetLicense()
 	if license == nil || !(*license.Features.Compliance) {
 		return nil, nil, model.ErrInsufficientLicense
@@ -70,6 +70,14 @@ func (a *App) GetBoardMetadata(boardID string) (*model.Board, *model.BoardMetada
 		CreatedBy:               board.CreatedBy,
 		LastModifiedBy:          lastModifiedBy,
 	}
+
+	unreadCounts, err := a.GetBoardUnreadCounts(boardID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	boardMetadata.UnreadCount = unreadCounts.UnreadCount
+	boardMetadata.CardUnreadCounts = unreadCounts.Cards
+
 	return board, &boardMetadata, nil
 }
 
@@ -358,6 +366,13 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 		return nil, err
 	}
 
+	// views can't refer to properties that no longer exist
+	if len(patch.UpdatedCardProperties) != 0 || len(patch.DeletedCardProperties) != 0 {
+		if err = a.cleanViewsForBoard(updatedBoard, userID); err != nil {
+			a.logger.Error("Unable to clean the board views", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+
 	// Post message to channel if linked/unlinked
 	if patch.ChannelID != nil {
 		var username string
@@ -370,7 +385,7 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 			username = user.Username
 		}
 
-		boardLink := utils.MakeBoardLink(a.config.ServerRoot, updatedBoard.TeamID, updatedBoard.ID)
+		boardLink := utils.MakeBoardLink(a.GetConfig().ServerRoot, updatedBoard.TeamID, updatedBoard.ID)
 		title := updatedBoard.Title
 		if title == "" {
 			title = "Untitled board" // todo: localize this when server has i18n
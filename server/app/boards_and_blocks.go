@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -67,6 +68,18 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 }
 
 func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	for _, blockPatch := range pbab.BlockPatches {
+		if blockPatch.ChangesArchiveState() {
+			return nil, model.ErrArchiveFieldsPatch
+		}
+		if blockPatch.ChangesCommentState() {
+			return nil, model.ErrCommentFieldsPatch
+		}
+		if blockPatch.ChangesViewOwner() {
+			return nil, model.ErrViewOwnerPatch
+		}
+	}
+
 	oldBlocks, err := a.store.GetBlocksByIDs(pbab.BlockIDs)
 	if err != nil {
 		return nil, err
@@ -86,6 +99,13 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 	for _, block := range oldBlocks {
 		oldBlocksMap[block.ID] = block
 	}
+	now := utils.GetMillis()
+	for i, blockID := range pbab.BlockIDs {
+		if pbab.BlockPatches[i].ChangesPrivateViewKind(oldBlocksMap[blockID]) {
+			return nil, model.ErrPrivateViewKindPatch
+		}
+		pbab.BlockPatches[i].StampCommentEdit(oldBlocksMap[blockID], now)
+	}
 
 	bab, err := a.store.PatchBoardsAndBlocks(pbab, userID)
 	if err != nil {
@@ -105,12 +125,14 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 			b := block
 			a.metrics.IncrementBlocksPatched(1)
 			a.wsAdapter.BroadcastBlockChange(teamID, b)
+			a.broadcastCardMirrorsChange(b)
 			a.webhook.NotifyUpdate(b)
 			a.notifyBlockChanged(notify.Update, b, oldBlock, userID)
 		}
 
 		for _, board := range bab.Boards {
 			a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
+			a.broadcastUnreadCountsChange(board.TeamID, board.ID, userID)
 		}
 		return nil
 	})
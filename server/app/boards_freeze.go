@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// FreezeBoard temporarily prevents non admin members from modifying
+// the cards, properties and comments of a board. If `freeze.Until`
+// is set, the board is automatically unfrozen after that time.
+func (a *App) FreezeBoard(boardID, userID string, freeze *model.BoardFreeze) (*model.Board, error) {
+	if freeze.Until < 0 || (freeze.Until != 0 && freeze.Until <= utils.GetMillis()) {
+		return nil, model.NewErrBadRequest("freeze end time must be in the future")
+	}
+
+	frozen := true
+	patch := &model.BoardPatch{
+		Frozen:       &frozen,
+		FrozenReason: &freeze.Reason,
+		FrozenUntil:  &freeze.Until,
+	}
+
+	return a.PatchBoard(patch, boardID, userID)
+}
+
+// UnfreezeBoard lifts the freeze of a board.
+func (a *App) UnfreezeBoard(boardID, userID string) (*model.Board, error) {
+	frozen := false
+	reason := ""
+	var until int64
+	patch := &model.BoardPatch{
+		Frozen:       &frozen,
+		FrozenReason: &reason,
+		FrozenUntil:  &until,
+	}
+
+	return a.PatchBoard(patch, boardID, userID)
+}
+
+// UnfreezeExpiredBoards unfreezes all the boards whose automatic
+// unfreeze time has passed.
+func (a *App) UnfreezeExpiredBoards() error {
+	boards, err := a.store.GetBoardsWithExpiredFreeze(utils.GetMillis())
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		if _, err := a.UnfreezeBoard(board.ID, model.SystemUserID); err != nil {
+			a.logger.Error("Unable to unfreeze expired board",
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestFreezeBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const boardID = "board_id_1"
+	const userID = "user_id_1"
+	const teamID = "team_id_1"
+
+	t.Run("base case", func(t *testing.T) {
+		until := utils.GetMillis() + 60000
+		freeze := &model.BoardFreeze{Reason: "release", Until: until}
+
+		th.Store.EXPECT().PatchBoard(boardID, gomock.Any(), userID).DoAndReturn(
+			func(boardID string, patch *model.BoardPatch, userID string) (*model.Board, error) {
+				require.True(t, *patch.Frozen)
+				require.Equal(t, "release", *patch.FrozenReason)
+				require.Equal(t, until, *patch.FrozenUntil)
+				return patch.Patch(&model.Board{ID: boardID, TeamID: teamID}), nil
+			})
+
+		// for WS BroadcastBoardChange
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		board, err := th.App.FreezeBoard(boardID, userID, freeze)
+		require.NoError(t, err)
+		require.True(t, board.IsFrozen())
+		require.Equal(t, "release", board.FrozenReason)
+	})
+
+	t.Run("freeze end time in the past", func(t *testing.T) {
+		freeze := &model.BoardFreeze{Until: utils.GetMillis() - 1000}
+
+		board, err := th.App.FreezeBoard(boardID, userID, freeze)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, board)
+	})
+}
+
+func TestUnfreezeExpiredBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("base case", func(t *testing.T) {
+		boards := []*model.Board{
+			{ID: "board_id_1", TeamID: "team_id_1", Frozen: true, FrozenUntil: 1},
+			{ID: "board_id_2", TeamID: "team_id_1", Frozen: true, FrozenUntil: 2},
+		}
+
+		th.Store.EXPECT().GetBoardsWithExpiredFreeze(gomock.Any()).Return(boards, nil)
+		for _, board := range boards {
+			th.Store.EXPECT().PatchBoard(board.ID, gomock.Any(), model.SystemUserID).DoAndReturn(
+				func(boardID string, patch *model.BoardPatch, userID string) (*model.Board, error) {
+					require.False(t, *patch.Frozen)
+					require.Zero(t, *patch.FrozenUntil)
+					return &model.Board{ID: boardID, TeamID: "team_id_1"}, nil
+				})
+			th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		}
+
+		err := th.App.UnfreezeExpiredBoards()
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MoveBoard moves a board to another team, keeping its ID, blocks,
+// history and subscriptions. The board is detached from its linked
+// channel, its members are revalidated against the destination team,
+// the removed ones losing the board from their categories, and its files
+// are relocated to the destination team's folder.
+func (a *App) MoveBoard(boardID, toTeam, userID string) (*model.Board, error) {
+	if toTeam == "" || toTeam == model.GlobalTeamID {
+		return nil, model.NewErrBadRequest("invalid destination team")
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.TeamID == model.GlobalTeamID {
+		return nil, model.NewErrBadRequest("global templates cannot be moved")
+	}
+
+	if board.TeamID == toTeam {
+		return nil, model.NewErrBadRequest("board already belongs to team " + toTeam)
+	}
+
+	oldMembers, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	keptMembers := []*model.BoardMember{}
+	removedMembers := []*model.BoardMember{}
+	removedUserIDs := []string{}
+	for _, member := range oldMembers {
+		// synthetic memberships came from the detached channel, so
+		// they are gone together with the link
+		if member.Synthetic || !a.permissions.HasPermissionToTeam(member.UserID, toTeam, model.PermissionViewTeam) {
+			removedMembers = append(removedMembers, member)
+			removedUserIDs = append(removedUserIDs, member.UserID)
+			continue
+		}
+		keptMembers = append(keptMembers, member)
+	}
+
+	movedBoard, err := a.store.MoveBoardToTeam(boardID, toTeam, userID, removedUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.ChannelID != "" {
+		a.postBoardUnlinkMessage(movedBoard, board.ChannelID, userID)
+	}
+
+	a.moveBoardFiles(board, toTeam)
+
+	if !movedBoard.IsTemplate {
+		for _, member := range keptMembers {
+			if err := a.addBoardsToDefaultCategory(member.UserID, toTeam, []*model.Board{movedBoard}); err != nil {
+				a.logger.Error("Unable to add moved board to user's default category",
+					mlog.String("boardID", boardID),
+					mlog.String("userID", member.UserID),
+					mlog.Err(err),
+				)
+			}
+		}
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
+		a.wsAdapter.BroadcastBoardChange(toTeam, movedBoard)
+		for _, member := range removedMembers {
+			a.wsAdapter.BroadcastMemberDelete(board.TeamID, boardID, member.UserID)
+		}
+		for _, member := range keptMembers {
+			a.wsAdapter.BroadcastMemberChange(toTeam, boardID, member)
+		}
+		return nil
+	})
+
+	return movedBoard, nil
+}
+
+func (a *App) postBoardUnlinkMessage(board *model.Board, channelID, userID string) {
+	username := "unknown"
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		a.logger.Error("Unable to get the board updater", mlog.Err(err))
+	} else {
+		username = user.Username
+	}
+
+	boardLink := utils.MakeBoardLink(a.GetConfig().ServerRoot, board.TeamID, board.ID)
+	title := board.Title
+	if title == "" {
+		title = "Untitled board" // todo: localize this when server has i18n
+	}
+	a.postChannelMessage(fmt.Sprintf(unlinkBoardMessage, username, title, boardLink), channelID)
+}
+
+// moveBoardFiles relocates the files stored under the board's team
+// folder to the destination team. Files stored in the date based
+// folders don't depend on the team and are left untouched.
+func (a *App) moveBoardFiles(board *model.Board, toTeam string) {
+	blocks, err := a.store.GetBlocksForBoard(board.ID)
+	if err != nil {
+		a.logger.Error("Unable to get board blocks to move files", mlog.String("boardID", board.ID), mlog.Err(err))
+		return
+	}
+
+	a.moveBlockFiles(blocks, board.TeamID, board.ID, toTeam, board.ID)
+}
+
+// moveBlockFiles relocates the files of the image and attachment
+// blocks stored under the source team and board folder to the
+// destination team and board folder.
+func (a *App) moveBlockFiles(blocks []*model.Block, fromTeam, fromBoardID, toTeam, toBoardID string) {
+	for _, block := range blocks {
+		if !block.Type.HasFile() {
+			continue
+		}
+
+		fileID, ok := block.Fields["fileId"].(string)
+		if !ok {
+			fileID, ok = block.Fields["attachmentId"].(string)
+			if !ok {
+				continue
+			}
+		}
+
+		fileInfo, sourceFilePath, err := a.GetFilePath(fromTeam, fromBoardID, fileID)
+		if err != nil {
+			a.logger.Error("Unable to get file path to move file", mlog.String("fileID", fileID), mlog.Err(err))
+			continue
+		}
+
+		if sourceFilePath != filepath.Join(fromTeam, fromBoardID, fileID) {
+			continue
+		}
+
+		destinationFilePath := filepath.Join(toTeam, toBoardID, fileID)
+		if err := a.filesBackend.MoveFile(sourceFilePath, destinationFilePath); err != nil {
+			a.logger.Error("Unable to move file",
+				mlog.String("sourceFilePath", sourceFilePath),
+				mlog.String("destinationFilePath", destinationFilePath),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		if fileInfo != nil && fileInfo.Path != "" && fileInfo.Path != emptyString {
+			if err := a.store.UpdateFileInfoPath(fileInfo.Id, destinationFilePath); err != nil {
+				a.logger.Error("Unable to update moved file info", mlog.String("fileID", fileID), mlog.Err(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestMoveBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const boardID = "board_id_1"
+	const userID = "user_id_1"
+	const fromTeam = "team_id_1"
+	const toTeam = "team_id_2"
+
+	t.Run("invalid destination team", func(t *testing.T) {
+		board, err := th.App.MoveBoard(boardID, model.GlobalTeamID, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, board)
+	})
+
+	t.Run("board already in destination team", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{ID: boardID, TeamID: toTeam}, nil)
+
+		board, err := th.App.MoveBoard(boardID, toTeam, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, board)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		board := &model.Board{ID: boardID, TeamID: fromTeam, IsTemplate: true}
+		movedBoard := &model.Board{ID: boardID, TeamID: toTeam, IsTemplate: true}
+
+		members := []*model.BoardMember{
+			{BoardID: boardID, UserID: userID, SchemeAdmin: true},
+			{BoardID: boardID, UserID: "user_id_2", SchemeEditor: true},
+			{BoardID: boardID, UserID: "user_id_3", SchemeViewer: true, Synthetic: true},
+		}
+
+		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return(members, nil).Times(1)
+		th.API.EXPECT().HasPermissionToTeam(userID, toTeam, model.PermissionViewTeam).Return(true)
+		th.API.EXPECT().HasPermissionToTeam("user_id_2", toTeam, model.PermissionViewTeam).Return(false)
+		th.Store.EXPECT().MoveBoardToTeam(boardID, toTeam, userID, []string{"user_id_2", "user_id_3"}).Return(movedBoard, nil)
+		th.Store.EXPECT().GetBlocksForBoard(boardID).Return([]*model.Block{}, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		rBoard, err := th.App.MoveBoard(boardID, toTeam, userID)
+		require.NoError(t, err)
+		require.Equal(t, toTeam, rBoard.TeamID)
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetCardContent returns the content blocks of a card, in the content
+// order of the card.
+func (a *App) GetCardContent(cardID string) ([]*model.Block, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.getCardContent(card)
+}
+
+// InsertCardContent adds a content block to a card at the position in
+// its content order. Negative or out of range positions append the
+// block.
+func (a *App) InsertCardContent(cardID string, block *model.Block, position int, userID string) (*model.Block, error) {
+	if !model.IsContentBlockType(block.Type) {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("invalid content block type %s", block.Type))
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	block.ID = utils.NewID(model.BlockType2IDType(block.Type))
+	block.BoardID = card.BoardID
+	block.ParentID = card.ID
+	block.CreatedBy = userID
+	block.ModifiedBy = userID
+	block.CreateAt = now
+	block.UpdateAt = now
+	block.DeleteAt = 0
+
+	if err = block.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err = a.store.InsertCardContent(block, position, userID); err != nil {
+		return nil, err
+	}
+
+	card, err = a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, card)
+		a.broadcastCardMirrorsChange(card)
+		a.broadcastUnreadCountsChange(board.TeamID, card.BoardID, userID)
+		a.metrics.IncrementBlocksInserted(1)
+		a.webhook.NotifyUpdate(block)
+		a.notifyBlockChanged(notify.Add, block, nil, userID)
+		return nil
+	})
+
+	return block, nil
+}
+
+// PatchCardContent modifies a content block of a card. Content blocks
+// can't be moved to another card or changed to other kinds of blocks.
+func (a *App) PatchCardContent(cardID, blockID string, patch *model.BlockPatch, userID string) (*model.Block, error) {
+	if patch.ParentID != nil && *patch.ParentID != cardID {
+		return nil, model.NewErrBadRequest("content blocks can't be moved to another card")
+	}
+	if patch.Type != nil && !model.IsContentBlockType(*patch.Type) {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("invalid content block type %s", *patch.Type))
+	}
+
+	if _, err := a.getCardContentBlock(cardID, blockID); err != nil {
+		return nil, err
+	}
+
+	return a.PatchBlockAndNotify(blockID, patch, userID, false)
+}
+
+// MoveCardContent moves a content block of a card to the position in
+// its content order, and returns the reordered content of the card.
+func (a *App) MoveCardContent(cardID, blockID string, position int, userID string) ([]*model.Block, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.MoveCardContent(cardID, blockID, position, userID); err != nil {
+		return nil, err
+	}
+
+	card, err = a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, card)
+		a.broadcastCardMirrorsChange(card)
+		a.broadcastUnreadCountsChange(board.TeamID, card.BoardID, userID)
+		a.webhook.NotifyUpdate(card)
+		return nil
+	})
+
+	return a.getCardContent(card)
+}
+
+// DeleteCardContent deletes a content block of a card and removes it
+// from the content order of the card.
+func (a *App) DeleteCardContent(cardID, blockID, userID string) error {
+	block, err := a.getCardContentBlock(cardID, blockID)
+	if err != nil {
+		return err
+	}
+
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.store.DeleteCardContent(cardID, blockID, userID); err != nil {
+		return err
+	}
+
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, block.BoardID)
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, card)
+		a.broadcastCardMirrorsChange(card)
+		a.broadcastUnreadCountsChange(board.TeamID, card.BoardID, userID)
+		a.metrics.IncrementBlocksDeleted(1)
+		a.notifyBlockChanged(notify.Delete, block, block, userID)
+		return nil
+	})
+
+	return nil
+}
+
+// getCardBlock returns the block of the card with the given ID.
+func (a *App) getCardBlock(cardID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	return card, nil
+}
+
+// getCardContentBlock returns the content block with the given ID if it
+// belongs to the card.
+func (a *App) getCardContentBlock(cardID, blockID string) (*model.Block, error) {
+	block, err := a.store.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.ParentID != cardID || !model.IsContentBlockType(block.Type) {
+		return nil, model.NewErrNotFound(fmt.Sprintf("content block ID=%s on CardID=%s", blockID, cardID))
+	}
+
+	return block, nil
+}
+
+func (a *App) getCardContent(card *model.Block) ([]*model.Block, error) {
+	blocks, err := a.store.GetBlocksWithParent(card.BoardID, card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := []*model.Block{}
+	for _, block := range blocks {
+		if model.IsContentBlockType(block.Type) {
+			content = append(content, block)
+		}
+	}
+
+	return model.SortCardContent(card, content), nil
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestContentCard(contentOrder ...interface{}) *model.Block {
+	return &model.Block{
+		ID:       "card_id_1",
+		BoardID:  "board_id_1",
+		ParentID: "board_id_1",
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{model.CardFieldContentOrder: contentOrder},
+	}
+}
+
+func TestGetCardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := newTestContentCard("block_id_2", "block_id_1")
+	th.Store.EXPECT().GetBlock("card_id_1").Return(card, nil)
+	th.Store.EXPECT().GetBlocksWithParent("board_id_1", "card_id_1").Return([]*model.Block{
+		{ID: "block_id_1", ParentID: "card_id_1", Type: model.TypeText},
+		{ID: "comment_id_1", ParentID: "card_id_1", Type: model.TypeComment},
+		{ID: "block_id_3", ParentID: "card_id_1", Type: model.TypeDivider},
+		{ID: "block_id_2", ParentID: "card_id_1", Type: model.TypeCheckbox},
+	}, nil)
+
+	blocks, err := th.App.GetCardContent("card_id_1")
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	require.Equal(t, "block_id_2", blocks[0].ID)
+	require.Equal(t, "block_id_1", blocks[1].ID)
+	require.Equal(t, "block_id_3", blocks[2].ID)
+}
+
+func TestInsertCardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	t.Run("not a content block", func(t *testing.T) {
+		block, err := th.App.InsertCardContent("card_id_1", &model.Block{Type: model.TypeComment}, 0, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, block)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		card := newTestContentCard("block_id_1")
+		th.Store.EXPECT().GetBlock("card_id_1").Return(card, nil).Times(2)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertCardContent(gomock.Any(), 0, "user_id_1").DoAndReturn(
+			func(block *model.Block, position int, userID string) error {
+				require.NotEmpty(t, block.ID)
+				require.Equal(t, "board_id_1", block.BoardID)
+				require.Equal(t, "card_id_1", block.ParentID)
+				require.Equal(t, "user_id_1", block.CreatedBy)
+				return nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		block, err := th.App.InsertCardContent("card_id_1", &model.Block{Type: model.TypeText, Title: "text"}, 0, "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, "text", block.Title)
+		require.Equal(t, "card_id_1", block.ParentID)
+	})
+}
+
+func TestPatchCardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("move to another card", func(t *testing.T) {
+		parentID := "card_id_2"
+		block, err := th.App.PatchCardContent("card_id_1", "block_id_1", &model.BlockPatch{ParentID: &parentID}, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, block)
+	})
+
+	t.Run("change to another kind of block", func(t *testing.T) {
+		blockType := model.BlockType(model.TypeComment)
+		block, err := th.App.PatchCardContent("card_id_1", "block_id_1", &model.BlockPatch{Type: &blockType}, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, block)
+	})
+
+	t.Run("block of another card", func(t *testing.T) {
+		title := "text"
+		th.Store.EXPECT().GetBlock("block_id_1").Return(&model.Block{ID: "block_id_1", ParentID: "card_id_2", Type: model.TypeText}, nil)
+
+		block, err := th.App.PatchCardContent("card_id_1", "block_id_1", &model.BlockPatch{Title: &title}, "user_id_1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, block)
+	})
+}
+
+func TestMoveCardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	th.Store.EXPECT().GetBlock("card_id_1").Return(newTestContentCard("block_id_1", "block_id_2"), nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().MoveCardContent("card_id_1", "block_id_2", 0, "user_id_1").Return(nil)
+	th.Store.EXPECT().GetBlock("card_id_1").Return(newTestContentCard("block_id_2", "block_id_1"), nil)
+	th.Store.EXPECT().GetBlocksWithParent("board_id_1", "card_id_1").Return([]*model.Block{
+		{ID: "block_id_1", ParentID: "card_id_1", Type: model.TypeText},
+		{ID: "block_id_2", ParentID: "card_id_1", Type: model.TypeText},
+	}, nil)
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	blocks, err := th.App.MoveCardContent("card_id_1", "block_id_2", 0, "user_id_1")
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, "block_id_2", blocks[0].ID)
+	require.Equal(t, "block_id_1", blocks[1].ID)
+}
+
+func TestDeleteCardContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	block := &model.Block{ID: "block_id_1", BoardID: "board_id_1", ParentID: "card_id_1", Type: model.TypeText}
+
+	th.Store.EXPECT().GetBlock("block_id_1").Return(block, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().DeleteCardContent("card_id_1", "block_id_1", "user_id_1").Return(nil)
+	th.Store.EXPECT().GetBlock("card_id_1").Return(newTestContentCard(), nil)
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	require.NoError(t, th.App.DeleteCardContent("card_id_1", "block_id_1", "user_id_1"))
+}
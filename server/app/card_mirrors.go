@@ -0,0 +1,273 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CreateCardMirror makes a card appear on a board other than its
+// primary board. The mirror starts with the card property values
+// mapped by name and type to the mirroring board's properties.
+func (a *App) CreateCardMirror(boardID, cardID, userID string) (*model.CardMirror, error) {
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cardBlock.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	if cardBlock.BoardID == boardID {
+		return nil, model.NewErrBadRequest("card already belongs to board " + boardID)
+	}
+
+	primaryBoard, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, _ := cardBlock.Fields["properties"].(map[string]interface{})
+	mirroredProperties, err := model.MapCardProperties(properties, primaryBoard, board, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot map properties of card %s: %w", cardID, err)
+	}
+
+	mirror, err := a.store.SaveCardMirror(&model.CardMirror{
+		BoardID:    boardID,
+		CardID:     cardID,
+		Properties: mirroredProperties,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.populateCardMirror(mirror, cardBlock); err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastCardMirrorChange(board.TeamID, mirror, nil)
+		return nil
+	})
+
+	return mirror, nil
+}
+
+// GetCardMirror returns a card mirror with the mirrored card.
+func (a *App) GetCardMirror(boardID, cardID string) (*model.CardMirror, error) {
+	mirror, err := a.store.GetCardMirror(boardID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.populateCardMirror(mirror, cardBlock); err != nil {
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+// GetCardMirrorsForBoard returns the card mirrors of a board with the
+// mirrored cards. Mirrors whose card no longer exists are skipped.
+func (a *App) GetCardMirrorsForBoard(boardID string) ([]*model.CardMirror, error) {
+	mirrors, err := a.store.GetCardMirrorsForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mirrors) == 0 {
+		return []*model.CardMirror{}, nil
+	}
+
+	cardIDs := make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		cardIDs = append(cardIDs, mirror.CardID)
+	}
+
+	cardBlocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	cardBlocksMap := map[string]*model.Block{}
+	for _, cardBlock := range cardBlocks {
+		cardBlocksMap[cardBlock.ID] = cardBlock
+	}
+
+	result := []*model.CardMirror{}
+	for _, mirror := range mirrors {
+		cardBlock, ok := cardBlocksMap[mirror.CardID]
+		if !ok {
+			continue
+		}
+
+		if err := a.populateCardMirror(mirror, cardBlock); err != nil {
+			return nil, err
+		}
+		result = append(result, mirror)
+	}
+
+	return result, nil
+}
+
+// GetCardMirrorBlocks returns the content blocks of a mirrored card,
+// which are owned by the card's primary board, so the user needs to be
+// able to view that board too.
+func (a *App) GetCardMirrorBlocks(boardID, cardID, userID string) ([]*model.Block, error) {
+	if _, err := a.store.GetCardMirror(boardID, cardID); err != nil {
+		return nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, cardBlock.BoardID, model.PermissionViewBoard) {
+		return nil, model.NewErrPermission("access denied to the board of the mirrored card")
+	}
+
+	return a.store.GetBlocksWithParent(cardBlock.BoardID, cardID)
+}
+
+// PatchCardMirror updates the board local property values of a card
+// mirror.
+func (a *App) PatchCardMirror(boardID, cardID string, patch *model.CardMirrorPatch, userID string) (*model.CardMirror, error) {
+	mirror, err := a.store.GetCardMirror(boardID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror = patch.Patch(mirror)
+	mirror.ModifiedBy = userID
+
+	mirror, err = a.store.SaveCardMirror(mirror)
+	if err != nil {
+		return nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.populateCardMirror(mirror, cardBlock); err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastCardMirrorChange(board.TeamID, mirror, nil)
+		return nil
+	})
+
+	return mirror, nil
+}
+
+// DeleteCardMirror removes a card from a board that mirrors it. The
+// card is kept on its primary board.
+func (a *App) DeleteCardMirror(boardID, cardID, userID string) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.store.DeleteCardMirror(boardID, cardID, userID); err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	mirror := &model.CardMirror{
+		BoardID:    boardID,
+		CardID:     cardID,
+		ModifiedBy: userID,
+		UpdateAt:   now,
+		DeleteAt:   now,
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastCardMirrorChange(board.TeamID, mirror, nil)
+		return nil
+	})
+
+	return nil
+}
+
+func (a *App) populateCardMirror(mirror *model.CardMirror, cardBlock *model.Block) error {
+	card, err := model.Block2Card(cardBlock)
+	if err != nil {
+		return err
+	}
+
+	mirror.Card = mirror.MirroredCard(card)
+	return nil
+}
+
+// broadcastCardMirrorsChange propagates the change of a card, or of
+// one of its content blocks, to the boards that mirror the card. The
+// content blocks themselves aren't sent, as the members of the
+// mirroring boards may not see the card's board; they fetch them again
+// instead. It must be called from inside the block change notifier
+// queue.
+func (a *App) broadcastCardMirrorsChange(block *model.Block) {
+	cardID := block.ParentID
+	var changedBlock *model.Block
+	switch {
+	case block.Type == model.TypeCard:
+		cardID = block.ID
+		changedBlock = block
+	case block.Type != model.TypeComment && !model.IsContentBlockType(block.Type):
+		// blocks that are not cards or card children can't be mirrored
+		return
+	}
+
+	if cardID == "" || cardID == block.BoardID {
+		return
+	}
+
+	mirrors, err := a.store.GetCardMirrorsForCard(cardID)
+	if err != nil {
+		a.logger.Error("Unable to get card mirrors to broadcast block change",
+			mlog.String("cardID", cardID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	for _, mirror := range mirrors {
+		board, err := a.store.GetBoard(mirror.BoardID)
+		if err != nil {
+			a.logger.Error("Unable to get mirroring board to broadcast block change",
+				mlog.String("boardID", mirror.BoardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.wsAdapter.BroadcastCardMirrorChange(board.TeamID, mirror, changedBlock)
+	}
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCreateCardMirror(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	primaryBoard := &model.Board{
+		ID:     "board_id_1",
+		TeamID: "team_id_1",
+		CardProperties: []map[string]interface{}{
+			{"id": "primary_prop", "name": "Estimate", "type": "number"},
+		},
+	}
+	board := &model.Board{
+		ID:     "board_id_2",
+		TeamID: "team_id_1",
+		CardProperties: []map[string]interface{}{
+			{"id": "mirror_prop", "name": "Estimate", "type": "number"},
+		},
+	}
+	cardBlock := &model.Block{
+		ID:       "card_id_1",
+		BoardID:  primaryBoard.ID,
+		ParentID: primaryBoard.ID,
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"primary_prop": "3"}},
+	}
+
+	t.Run("card already on board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(cardBlock, nil)
+
+		mirror, err := th.App.CreateCardMirror(primaryBoard.ID, "card_id_1", userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, mirror)
+	})
+
+	t.Run("block is not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("text_id_1").Return(&model.Block{ID: "text_id_1", Type: model.TypeText}, nil)
+
+		mirror, err := th.App.CreateCardMirror(board.ID, "text_id_1", userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, mirror)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(primaryBoard.ID).Return(primaryBoard, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().SaveCardMirror(gomock.Any()).DoAndReturn(
+			func(mirror *model.CardMirror) (*model.CardMirror, error) {
+				require.Equal(t, board.ID, mirror.BoardID)
+				require.Equal(t, userID, mirror.CreatedBy)
+				require.Equal(t, map[string]interface{}{"mirror_prop": "3"}, mirror.Properties)
+				return mirror, nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		mirror, err := th.App.CreateCardMirror(board.ID, "card_id_1", userID)
+		require.NoError(t, err)
+		require.Equal(t, "card_id_1", mirror.Card.ID)
+		require.Equal(t, primaryBoard.ID, mirror.Card.BoardID)
+		require.Equal(t, map[string]interface{}{"mirror_prop": "3"}, mirror.Card.Properties)
+	})
+}
+
+func TestPatchCardMirror(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	board := &model.Board{ID: "board_id_2", TeamID: "team_id_1"}
+	cardBlock := &model.Block{
+		ID:       "card_id_1",
+		BoardID:  "board_id_1",
+		ParentID: "board_id_1",
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"primary_prop": "3"}},
+	}
+
+	t.Run("nonexistent mirror", func(t *testing.T) {
+		th.Store.EXPECT().GetCardMirror(board.ID, "card_id_2").Return(nil, model.NewErrNotFound("card mirror"))
+
+		mirror, err := th.App.PatchCardMirror(board.ID, "card_id_2", &model.CardMirrorPatch{}, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, mirror)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		th.Store.EXPECT().GetCardMirror(board.ID, "card_id_1").Return(&model.CardMirror{
+			BoardID:    board.ID,
+			CardID:     "card_id_1",
+			Properties: map[string]interface{}{"prop_1": "a", "prop_2": "b"},
+			CreatedBy:  "user_id_2",
+		}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().SaveCardMirror(gomock.Any()).DoAndReturn(
+			func(mirror *model.CardMirror) (*model.CardMirror, error) {
+				return mirror, nil
+			})
+		th.Store.EXPECT().GetBlock("card_id_1").Return(cardBlock, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		patch := &model.CardMirrorPatch{
+			UpdatedProperties: map[string]interface{}{"prop_1": "c"},
+			DeletedProperties: []string{"prop_2"},
+		}
+		mirror, err := th.App.PatchCardMirror(board.ID, "card_id_1", patch, userID)
+		require.NoError(t, err)
+		require.Equal(t, userID, mirror.ModifiedBy)
+		require.Equal(t, "user_id_2", mirror.CreatedBy)
+		require.Equal(t, map[string]interface{}{"prop_1": "c"}, mirror.Properties)
+		require.Equal(t, map[string]interface{}{"prop_1": "c"}, mirror.Card.Properties)
+	})
+}
+
+func TestGetCardMirrorsForBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("mirrors of deleted cards are skipped", func(t *testing.T) {
+		th.Store.EXPECT().GetCardMirrorsForBoard("board_id_2").Return([]*model.CardMirror{
+			{BoardID: "board_id_2", CardID: "card_id_1"},
+			{BoardID: "board_id_2", CardID: "card_id_2"},
+		}, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_1", "card_id_2"}).Return([]*model.Block{
+			{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard},
+		}, nil)
+
+		mirrors, err := th.App.GetCardMirrorsForBoard("board_id_2")
+		require.NoError(t, err)
+		require.Len(t, mirrors, 1)
+		require.Equal(t, "card_id_1", mirrors[0].Card.ID)
+	})
+}
+
+func TestGetCardMirrorBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("user without access to the card's board", func(t *testing.T) {
+		th.Store.EXPECT().GetCardMirror("board_id_2", "card_id_1").Return(&model.CardMirror{BoardID: "board_id_2", CardID: "card_id_1"}, nil)
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.PermissionsStore.EXPECT().GetBoard("board_id_1").Return(&model.Board{ID: "board_id_1", TeamID: "team_id_1"}, nil)
+		th.API.EXPECT().HasPermissionToTeam("user_id_1", "team_id_1", model.PermissionViewTeam).Return(false)
+
+		blocks, err := th.App.GetCardMirrorBlocks("board_id_2", "card_id_1", "user_id_1")
+		require.True(t, model.IsErrForbidden(err))
+		require.Nil(t, blocks)
+	})
+}
+
+func TestBroadcastCardMirrorsChange(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("blocks that are not part of a card", func(t *testing.T) {
+		// no store calls are expected
+		th.App.broadcastCardMirrorsChange(&model.Block{ID: "view_id_1", BoardID: "board_id_1", ParentID: "board_id_1", Type: model.TypeView})
+		th.App.broadcastCardMirrorsChange(&model.Block{ID: "view_id_2", BoardID: "board_id_1", ParentID: "card_id_1", Type: model.TypeView})
+	})
+
+	t.Run("card content changes reach the mirroring boards", func(t *testing.T) {
+		th.Store.EXPECT().GetCardMirrorsForCard("card_id_1").Return([]*model.CardMirror{
+			{BoardID: "board_id_2", CardID: "card_id_1"},
+			{BoardID: "board_id_3", CardID: "card_id_1"},
+		}, nil)
+		th.Store.EXPECT().GetBoard("board_id_2").Return(&model.Board{ID: "board_id_2", TeamID: "team_id_1"}, nil)
+		th.Store.EXPECT().GetBoard("board_id_3").Return(&model.Board{ID: "board_id_3", TeamID: "team_id_1"}, nil)
+		th.Store.EXPECT().GetMembersForBoard("board_id_2").Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard("board_id_3").Return([]*model.BoardMember{}, nil)
+
+		th.App.broadcastCardMirrorsChange(&model.Block{ID: "text_id_1", BoardID: "board_id_1", ParentID: "card_id_1", Type: model.TypeText})
+	})
+}
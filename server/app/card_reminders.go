@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CreateCardReminder sets a private reminder of the user on a card.
+func (a *App) CreateCardReminder(cardID, userID string, patch *model.CardReminderPatch) (*model.CardReminder, error) {
+	if !patch.ChangesTime() {
+		return nil, model.NewErrBadRequest("missing reminder time")
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder := &model.CardReminder{
+		ID:      utils.NewID(utils.IDTypeNone),
+		UserID:  userID,
+		BoardID: card.BoardID,
+		CardID:  card.ID,
+	}
+
+	return a.saveCardReminder(reminder, patch)
+}
+
+// GetCardReminder returns a reminder of the user. Reminders are
+// private, so the reminders of other users are not found.
+func (a *App) GetCardReminder(reminderID, userID string) (*model.CardReminder, error) {
+	reminder, err := a.store.GetCardReminder(reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.UserID != userID {
+		return nil, model.NewErrNotFound("card reminder ID=" + reminderID)
+	}
+
+	return reminder, nil
+}
+
+// GetCardRemindersForUser returns the reminders of the user, the
+// earliest first.
+func (a *App) GetCardRemindersForUser(userID string) ([]*model.CardReminder, error) {
+	return a.store.GetCardRemindersForUser(userID)
+}
+
+// PatchCardReminder edits the time or the note of a reminder of the
+// user.
+func (a *App) PatchCardReminder(reminderID, userID string, patch *model.CardReminderPatch) (*model.CardReminder, error) {
+	reminder, err := a.GetCardReminder(reminderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.saveCardReminder(reminder, patch)
+}
+
+// SnoozeCardReminder postpones a reminder of the user, whether it was
+// already delivered or not.
+func (a *App) SnoozeCardReminder(reminderID, userID string, snooze *model.CardReminderSnooze) (*model.CardReminder, error) {
+	if snooze.Minutes <= 0 {
+		return nil, model.NewErrBadRequest("snooze time must be positive")
+	}
+
+	remindAt := utils.GetMillis() + (time.Duration(snooze.Minutes) * time.Minute).Milliseconds()
+	return a.PatchCardReminder(reminderID, userID, &model.CardReminderPatch{RemindAt: &remindAt})
+}
+
+// DeleteCardReminder removes a reminder of the user.
+func (a *App) DeleteCardReminder(reminderID, userID string) error {
+	if _, err := a.GetCardReminder(reminderID, userID); err != nil {
+		return err
+	}
+
+	return a.store.DeleteCardReminder(reminderID)
+}
+
+func (a *App) saveCardReminder(reminder *model.CardReminder, patch *model.CardReminderPatch) (*model.CardReminder, error) {
+	reminder, err := patch.Patch(reminder, a.getUserLocation(reminder.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.ChangesTime() && reminder.RemindAt <= utils.GetMillis() {
+		return nil, model.NewErrBadRequest("reminder time must be in the future")
+	}
+
+	return a.store.SaveCardReminder(reminder)
+}
+
+// getUserLocation returns the location of the user's timezone, or UTC
+// if the timezone is not available.
+func (a *App) getUserLocation(userID string) *time.Location {
+	timezone, err := a.store.GetUserTimezone(userID)
+	if err != nil {
+		a.logger.Debug("Unable to get user timezone, using UTC",
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		a.logger.Debug("Unable to load user timezone, using UTC",
+			mlog.String("userID", userID),
+			mlog.String("timezone", timezone),
+			mlog.Err(err),
+		)
+		return time.UTC
+	}
+
+	return location
+}
+
+// SendDueCardReminders delivers the reminders whose time has come
+// through the notification backends, and cleans up the reminders of
+// deleted cards.
+func (a *App) SendDueCardReminders() error {
+	now := utils.GetMillis()
+	reminders, err := a.store.GetDueCardReminders(now)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		card, err := a.store.GetBlock(reminder.CardID)
+		if model.IsErrNotFound(err) {
+			if err = a.store.DeleteCardReminder(reminder.ID); err != nil && !model.IsErrNotFound(err) {
+				a.logger.Error("Unable to delete reminder of deleted card",
+					mlog.String("reminderID", reminder.ID),
+					mlog.Err(err),
+				)
+			}
+			continue
+		}
+		if err != nil {
+			a.logger.Error("Unable to get card of reminder",
+				mlog.String("reminderID", reminder.ID),
+				mlog.String("cardID", reminder.CardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		board, err := a.store.GetBoard(card.BoardID)
+		if err != nil {
+			a.logger.Error("Unable to get board of reminder",
+				mlog.String("reminderID", reminder.ID),
+				mlog.String("boardID", card.BoardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		// another node may have delivered the reminder already
+		delivered, err := a.store.MarkCardReminderDelivered(reminder.ID, now)
+		if err != nil {
+			a.logger.Error("Unable to mark reminder as delivered",
+				mlog.String("reminderID", reminder.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		if !delivered || a.notifications == nil {
+			continue
+		}
+
+		reminder.DeliveredAt = now
+		a.notifications.CardReminderDue(notify.CardReminderEvent{
+			TeamID:   board.TeamID,
+			Board:    board,
+			Card:     card,
+			Reminder: reminder,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestCreateCardReminder(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("missing time", func(t *testing.T) {
+		note := "note"
+		reminder, err := th.App.CreateCardReminder("card_id_1", "user_id_1", &model.CardReminderPatch{Note: &note})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, reminder)
+	})
+
+	t.Run("time in the past", func(t *testing.T) {
+		remindAt := utils.GetMillis() - 1000
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetUserTimezone("user_id_1").Return("", errors.New("no timezone"))
+
+		reminder, err := th.App.CreateCardReminder("card_id_1", "user_id_1", &model.CardReminderPatch{RemindAt: &remindAt})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, reminder)
+	})
+
+	t.Run("local time in the user timezone", func(t *testing.T) {
+		location, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+		tomorrow := time.Now().In(location).Add(24 * time.Hour)
+		localTime := tomorrow.Format(model.CardReminderLocalTimeLayout)
+		expected := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), tomorrow.Hour(), tomorrow.Minute(), 0, 0, location)
+
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetUserTimezone("user_id_1").Return("Europe/Berlin", nil)
+		th.Store.EXPECT().SaveCardReminder(gomock.Any()).DoAndReturn(func(reminder *model.CardReminder) (*model.CardReminder, error) {
+			return reminder, nil
+		})
+
+		reminder, err := th.App.CreateCardReminder("card_id_1", "user_id_1", &model.CardReminderPatch{LocalTime: &localTime})
+		require.NoError(t, err)
+		require.NotEmpty(t, reminder.ID)
+		require.Equal(t, "user_id_1", reminder.UserID)
+		require.Equal(t, "board_id_1", reminder.BoardID)
+		require.Equal(t, "card_id_1", reminder.CardID)
+		require.Equal(t, expected.UnixMilli(), reminder.RemindAt)
+	})
+}
+
+func TestCardRemindersArePrivate(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	reminder := &model.CardReminder{ID: "reminder_id_1", UserID: "user_id_1", BoardID: "board_id_1", CardID: "card_id_1", RemindAt: 1000}
+	th.Store.EXPECT().GetCardReminder("reminder_id_1").Return(reminder, nil).AnyTimes()
+
+	t.Run("get a reminder of another user", func(t *testing.T) {
+		result, err := th.App.GetCardReminder("reminder_id_1", "user_id_2")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, result)
+	})
+
+	t.Run("delete a reminder of another user", func(t *testing.T) {
+		err := th.App.DeleteCardReminder("reminder_id_1", "user_id_2")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete an own reminder", func(t *testing.T) {
+		th.Store.EXPECT().DeleteCardReminder("reminder_id_1").Return(nil)
+
+		require.NoError(t, th.App.DeleteCardReminder("reminder_id_1", "user_id_1"))
+	})
+}
+
+func TestSnoozeCardReminder(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("invalid snooze", func(t *testing.T) {
+		reminder, err := th.App.SnoozeCardReminder("reminder_id_1", "user_id_1", &model.CardReminderSnooze{Minutes: 0})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, reminder)
+	})
+
+	t.Run("snooze a delivered reminder", func(t *testing.T) {
+		th.Store.EXPECT().GetCardReminder("reminder_id_1").Return(&model.CardReminder{
+			ID:          "reminder_id_1",
+			UserID:      "user_id_1",
+			BoardID:     "board_id_1",
+			CardID:      "card_id_1",
+			RemindAt:    1000,
+			DeliveredAt: 1000,
+		}, nil)
+		th.Store.EXPECT().GetUserTimezone("user_id_1").Return("UTC", nil)
+		th.Store.EXPECT().SaveCardReminder(gomock.Any()).DoAndReturn(func(reminder *model.CardReminder) (*model.CardReminder, error) {
+			return reminder, nil
+		})
+
+		before := utils.GetMillis()
+		reminder, err := th.App.SnoozeCardReminder("reminder_id_1", "user_id_1", &model.CardReminderSnooze{Minutes: 10})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, reminder.RemindAt, before+(10*time.Minute).Milliseconds())
+		require.Zero(t, reminder.DeliveredAt)
+	})
+}
+
+func TestSendDueCardReminders(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	deletedCardReminder := &model.CardReminder{ID: "reminder_id_1", UserID: "user_id_1", BoardID: "board_id_1", CardID: "deleted_card_id", RemindAt: 1000}
+	claimedReminder := &model.CardReminder{ID: "reminder_id_2", UserID: "user_id_1", BoardID: "board_id_1", CardID: "card_id_1", RemindAt: 1000}
+	dueReminder := &model.CardReminder{ID: "reminder_id_3", UserID: "user_id_1", BoardID: "board_id_1", CardID: "card_id_1", RemindAt: 1000}
+
+	th.Store.EXPECT().GetDueCardReminders(gomock.Any()).Return([]*model.CardReminder{deletedCardReminder, claimedReminder, dueReminder}, nil)
+	th.Store.EXPECT().GetBlock("deleted_card_id").Return(nil, model.NewErrNotFound("deleted_card_id"))
+	th.Store.EXPECT().DeleteCardReminder("reminder_id_1").Return(nil)
+	th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil).Times(2)
+	th.Store.EXPECT().GetBoard("board_id_1").Return(&model.Board{ID: "board_id_1", TeamID: "team_id_1"}, nil).Times(2)
+	// the first reminder was delivered by another node
+	th.Store.EXPECT().MarkCardReminderDelivered("reminder_id_2", gomock.Any()).Return(false, nil)
+	th.Store.EXPECT().MarkCardReminderDelivered("reminder_id_3", gomock.Any()).Return(true, nil)
+
+	require.NoError(t, th.App.SendDueCardReminders())
+	require.Zero(t, claimedReminder.DeliveredAt)
+}
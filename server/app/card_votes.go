@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetCardVotes returns the votes and reactions of a card as seen by the
+// user.
+func (a *App) GetCardVotes(cardID, userID string) (*model.CardVotes, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := a.store.GetCardVotes([]string{card.ID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return votes[card.ID], nil
+}
+
+// VoteForCard adds the vote of a user for a card, within the vote limit
+// of the board, and returns the updated votes of the card.
+func (a *App) VoteForCard(cardID, userID string) (*model.CardVotes, error) {
+	card, board, err := a.getCardBlockAndBoard(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	vote := &model.CardVote{
+		CardID:  card.ID,
+		BoardID: card.BoardID,
+		UserID:  userID,
+	}
+	if err = a.store.SaveCardVote(vote, board.GetVoteLimit()); err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCardVotesChange(board, card.ID, userID)
+}
+
+// RemoveCardVote removes the vote of a user for a card and returns the
+// updated votes of the card.
+func (a *App) RemoveCardVote(cardID, userID string) (*model.CardVotes, error) {
+	card, board, err := a.getCardBlockAndBoard(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.DeleteCardVote(card.ID, userID); err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCardVotesChange(board, card.ID, userID)
+}
+
+// AddCardReaction adds an emoji reaction of a user to a card and
+// returns the updated votes of the card.
+func (a *App) AddCardReaction(cardID, userID, emoji string) (*model.CardVotes, error) {
+	card, board, err := a.getCardBlockAndBoard(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.store.SaveCardReaction(&model.CardReaction{
+		CardID:  card.ID,
+		BoardID: card.BoardID,
+		UserID:  userID,
+		Emoji:   emoji,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCardVotesChange(board, card.ID, userID)
+}
+
+// RemoveCardReaction removes an emoji reaction of a user from a card and
+// returns the updated votes of the card.
+func (a *App) RemoveCardReaction(cardID, userID, emoji string) (*model.CardVotes, error) {
+	card, board, err := a.getCardBlockAndBoard(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.DeleteCardReaction(card.ID, userID, emoji); err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCardVotesChange(board, card.ID, userID)
+}
+
+// SetCardsVotes fills the votes of the cards as seen by the user.
+func (a *App) SetCardsVotes(cards []*model.Card, userID string) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	votes, err := a.store.GetCardVotes(cardIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		card.Votes = votes[card.ID]
+	}
+
+	return nil
+}
+
+// GetCardsForView returns a page of the cards of the board with their
+// votes as seen by the user. If the view sorts the cards by votes, the
+// cards are sorted before paginating.
+func (a *App) GetCardsForView(boardID, viewID, userID string, page, perPage int, includeArchived bool) ([]*model.Card, error) {
+	var sortOptions []model.ViewSortOption
+	if viewID != "" {
+		view, err := a.GetViewByID(viewID)
+		if err != nil {
+			return nil, err
+		}
+		if view.BoardID != boardID {
+			return nil, model.NewErrBadRequest("view does not belong to the board")
+		}
+		sortOptions = view.SortOptions
+	}
+
+	if len(sortOptions) == 0 || sortOptions[0].PropertyID != model.ViewSortPropertyVotes {
+		cards, err := a.GetCardsForBoard(boardID, page, perPage, includeArchived)
+		if err != nil {
+			return nil, err
+		}
+		return cards, a.SetCardsVotes(cards, userID)
+	}
+
+	cards, err := a.GetCardsForBoard(boardID, 0, 0, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.SetCardsVotes(cards, userID); err != nil {
+		return nil, err
+	}
+
+	model.SortCardsForView(cards, sortOptions)
+
+	if perPage <= 0 {
+		return cards, nil
+	}
+
+	start := page * perPage
+	if start >= len(cards) {
+		return []*model.Card{}, nil
+	}
+	end := start + perPage
+	if end > len(cards) {
+		end = len(cards)
+	}
+	return cards[start:end], nil
+}
+
+func (a *App) getCardBlockAndBoard(cardID string) (*model.Block, *model.Board, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return card, board, nil
+}
+
+func (a *App) broadcastCardVotesChange(board *model.Board, cardID, userID string) (*model.CardVotes, error) {
+	votes, err := a.store.GetCardVotes([]string{cardID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cardVotes := votes[cardID]
+	anonymous := cardVotes.Anonymous()
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastCardVotesChange(board.TeamID, board.ID, userID, anonymous)
+		return nil
+	})
+
+	return cardVotes, nil
+}
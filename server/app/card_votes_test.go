@@ -0,0 +1,123 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestVoteForCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id_1").Return(&model.Block{ID: "view_id_1", BoardID: "board_id_1", Type: model.TypeView}, nil)
+
+		votes, err := th.App.VoteForCard("view_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, votes)
+	})
+
+	t.Run("vote within the limit of the board", func(t *testing.T) {
+		board := &model.Board{
+			ID:         "board_id_1",
+			TeamID:     "team_id_1",
+			Properties: map[string]interface{}{model.BoardPropertyVoteLimit: float64(3)},
+		}
+		cardVotes := &model.CardVotes{CardID: "card_id_1", VoteCount: 2, Voted: true, Reactions: []*model.CardReactionCount{}}
+
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetBoard("board_id_1").Return(board, nil)
+		th.Store.EXPECT().SaveCardVote(&model.CardVote{CardID: "card_id_1", BoardID: "board_id_1", UserID: "user_id_1"}, 3).Return(nil)
+		th.Store.EXPECT().GetCardVotes([]string{"card_id_1"}, "user_id_1").Return(map[string]*model.CardVotes{"card_id_1": cardVotes}, nil)
+
+		votes, err := th.App.VoteForCard("card_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, cardVotes, votes)
+	})
+
+	t.Run("vote limit reached", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_2").Return(&model.Block{ID: "card_id_2", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().GetBoard("board_id_1").Return(&model.Board{ID: "board_id_1"}, nil)
+		th.Store.EXPECT().SaveCardVote(gomock.Any(), 0).Return(model.NewErrBadRequest("vote limit reached"))
+
+		votes, err := th.App.VoteForCard("card_id_2", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, votes)
+	})
+}
+
+func TestGetCardsForView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	cardBlocks := []*model.Block{
+		{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard},
+		{ID: "card_id_2", BoardID: "board_id_1", Type: model.TypeCard},
+		{ID: "card_id_3", BoardID: "board_id_1", Type: model.TypeCard},
+	}
+	cardVotes := map[string]*model.CardVotes{
+		"card_id_1": {CardID: "card_id_1", VoteCount: 1},
+		"card_id_2": {CardID: "card_id_2", VoteCount: 4},
+		"card_id_3": {CardID: "card_id_3", VoteCount: 2},
+	}
+
+	t.Run("view sorted by votes", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id_1").Return(&model.Block{
+			ID:      "view_id_1",
+			BoardID: "board_id_1",
+			Type:    model.TypeView,
+			Fields: map[string]interface{}{
+				"viewType":    "board",
+				"sortOptions": []interface{}{map[string]interface{}{"propertyId": model.ViewSortPropertyVotes, "reversed": true}},
+			},
+		}, nil)
+		th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{
+			BoardID:         "board_id_1",
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
+		}).Return(cardBlocks, nil)
+		th.Store.EXPECT().GetCardVotes([]string{"card_id_1", "card_id_2", "card_id_3"}, "user_id_1").Return(cardVotes, nil)
+
+		cards, err := th.App.GetCardsForView("board_id_1", "view_id_1", "user_id_1", 0, 2, false)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		require.Equal(t, "card_id_2", cards[0].ID)
+		require.Equal(t, "card_id_3", cards[1].ID)
+		require.Equal(t, 4, cards[0].Votes.VoteCount)
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id_2").Return(&model.Block{
+			ID:      "view_id_2",
+			BoardID: "board_id_2",
+			Type:    model.TypeView,
+			Fields:  map[string]interface{}{"viewType": "board"},
+		}, nil)
+
+		cards, err := th.App.GetCardsForView("board_id_1", "view_id_2", "user_id_1", 0, 2, false)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, cards)
+	})
+
+	t.Run("without a view", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{
+			BoardID:         "board_id_1",
+			BlockType:       model.TypeCard,
+			PerPage:         2,
+			ExcludeArchived: true,
+		}).Return(cardBlocks[:2], nil)
+		th.Store.EXPECT().GetCardVotes([]string{"card_id_1", "card_id_2"}, "user_id_1").Return(cardVotes, nil)
+
+		cards, err := th.App.GetCardsForView("board_id_1", "", "user_id_1", 0, 2, false)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		require.Equal(t, "card_id_1", cards[0].ID)
+		require.Equal(t, 1, cards[0].Votes.VoteCount)
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// ArchiveCards archives cards of a board, which hides them from the
+// board views without deleting them. Cards that are already archived
+// are left unchanged.
+func (a *App) ArchiveCards(boardID string, cardIDs []string, userID string) ([]*model.Card, error) {
+	now := utils.GetMillis()
+	return a.setCardsArchived(boardID, cardIDs, userID, true, func() model.BlockPatch {
+		return model.NewArchiveCardPatch(userID, now)
+	})
+}
+
+// UnarchiveCards restores archived cards of a board. Cards that are not
+// archived are left unchanged.
+func (a *App) UnarchiveCards(boardID string, cardIDs []string, userID string) ([]*model.Card, error) {
+	return a.setCardsArchived(boardID, cardIDs, userID, false, model.NewUnarchiveCardPatch)
+}
+
+// SearchCardsForBoard returns the cards of a board whose title matches
+// the term. Archived cards are included.
+func (a *App) SearchCardsForBoard(boardID, term string) ([]*model.Card, error) {
+	blocks, err := a.store.SearchCardsForBoard(boardID, term)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks2Cards(blocks)
+}
+
+func (a *App) setCardsArchived(boardID string, cardIDs []string, userID string, archived bool, newPatch func() model.BlockPatch) ([]*model.Card, error) {
+	if err := model.ValidateCardArchiveIDs(cardIDs); err != nil {
+		return nil, err
+	}
+	cardIDs = uniqueStrings(cardIDs)
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	patches := &model.BlockPatchBatch{}
+	for _, block := range blocks {
+		if block.Type != model.TypeCard || block.BoardID != boardID {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card of board %s", block.ID, boardID))
+		}
+
+		if model.IsArchivedCard(block) == archived {
+			continue
+		}
+
+		patches.BlockIDs = append(patches.BlockIDs, block.ID)
+		patches.BlockPatches = append(patches.BlockPatches, newPatch())
+	}
+
+	if len(patches.BlockIDs) != 0 {
+		// patching the cards stores their previous state in the
+		// blocks history
+		if err = a.store.PatchBlocks(patches, userID); err != nil {
+			return nil, err
+		}
+
+		blocks, err = a.store.GetBlocksByIDs(cardIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changed := map[string]bool{}
+	for _, blockID := range patches.BlockIDs {
+		changed[blockID] = true
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range blocks {
+			if !changed[block.ID] {
+				continue
+			}
+
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+			a.broadcastCardMirrorsChange(block)
+			a.webhook.NotifyUpdate(block)
+		}
+		return nil
+	})
+
+	return blocks2Cards(blocks)
+}
+
+func blocks2Cards(blocks []*model.Block) ([]*model.Card, error) {
+	cards := make([]*model.Card, 0, len(blocks))
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
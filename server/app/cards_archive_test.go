@@ -0,0 +1,144 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestArchiveCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	t.Run("missing card IDs", func(t *testing.T) {
+		cards, err := th.App.ArchiveCards(board.ID, []string{}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, cards)
+	})
+
+	t.Run("card of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_1"}).Return([]*model.Block{
+			{ID: "card_id_1", BoardID: "board_id_2", Type: model.TypeCard},
+		}, nil)
+
+		cards, err := th.App.ArchiveCards(board.ID, []string{"card_id_1"}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, cards)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		cardBlock := &model.Block{ID: "card_id_1", BoardID: board.ID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+		archivedCardBlock := &model.Block{
+			ID:      "card_id_2",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{model.CardFieldArchivedAt: float64(1), model.CardFieldArchivedBy: userID},
+		}
+		patchedCardBlock := &model.Block{
+			ID:      "card_id_1",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{model.CardFieldArchivedAt: float64(2), model.CardFieldArchivedBy: userID},
+		}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		gomock.InOrder(
+			th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_1", "card_id_2"}).Return([]*model.Block{cardBlock, archivedCardBlock}, nil),
+			th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_1", "card_id_2"}).Return([]*model.Block{patchedCardBlock, archivedCardBlock}, nil),
+		)
+		// only the card that wasn't archived yet is patched
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), userID).DoAndReturn(
+			func(patches *model.BlockPatchBatch, modifiedByID string) error {
+				require.Equal(t, []string{"card_id_1"}, patches.BlockIDs)
+				require.Equal(t, userID, patches.BlockPatches[0].UpdatedFields[model.CardFieldArchivedBy])
+				return nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard("card_id_1").Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		cards, err := th.App.ArchiveCards(board.ID, []string{"card_id_1", "card_id_2", "card_id_1"}, userID)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		require.Equal(t, int64(2), cards[0].ArchivedAt)
+		require.Equal(t, int64(1), cards[1].ArchivedAt)
+	})
+}
+
+func TestUnarchiveCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	t.Run("nothing to restore", func(t *testing.T) {
+		cardBlock := &model.Block{ID: "card_id_1", BoardID: board.ID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_1"}).Return([]*model.Block{cardBlock}, nil)
+
+		cards, err := th.App.UnarchiveCards(board.ID, []string{"card_id_1"}, userID)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Zero(t, cards[0].ArchivedAt)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		archivedCardBlock := &model.Block{
+			ID:      "card_id_2",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{model.CardFieldArchivedAt: float64(1), model.CardFieldArchivedBy: userID},
+		}
+		restoredCardBlock := &model.Block{ID: "card_id_2", BoardID: board.ID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		gomock.InOrder(
+			th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_2"}).Return([]*model.Block{archivedCardBlock}, nil),
+			th.Store.EXPECT().GetBlocksByIDs([]string{"card_id_2"}).Return([]*model.Block{restoredCardBlock}, nil),
+		)
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), userID).DoAndReturn(
+			func(patches *model.BlockPatchBatch, modifiedByID string) error {
+				require.Equal(t, []string{"card_id_2"}, patches.BlockIDs)
+				require.ElementsMatch(t, []string{model.CardFieldArchivedAt, model.CardFieldArchivedBy}, patches.BlockPatches[0].DeletedFields)
+				return nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard("card_id_2").Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		cards, err := th.App.UnarchiveCards(board.ID, []string{"card_id_2"}, userID)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Zero(t, cards[0].ArchivedAt)
+	})
+}
+
+func TestPatchArchiveFields(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	patch := model.NewArchiveCardPatch("user_id_1", 1)
+
+	block, err := th.App.PatchBlockAndNotify("card_id_1", &patch, "user_id_1", false)
+	require.True(t, model.IsErrBadRequest(err))
+	require.Nil(t, block)
+
+	err = th.App.PatchBlocksAndNotify("team_id_1", &model.BlockPatchBatch{
+		BlockIDs:     []string{"card_id_1"},
+		BlockPatches: []model.BlockPatch{patch},
+	}, "user_id_1", false)
+	require.True(t, model.IsErrBadRequest(err))
+}
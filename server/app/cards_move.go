@@ -0,0 +1,187 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MoveCard moves a card with its content, comments and attachments to
+// another board, keeping their IDs and history. The card properties
+// are mapped to the destination board schema and a redirect is left
+// on the source board so old card links keep working.
+func (a *App) MoveCard(cardID string, opts *model.CardMoveOptions, userID string) (*model.Card, error) {
+	cardBlock, fromBoard, toBoard, err := a.getCardMoveBoards(cardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromBoard.ID == toBoard.ID {
+		return nil, model.NewErrBadRequest("card already belongs to board " + toBoard.ID)
+	}
+
+	if err := a.checkCardNotLimited(cardBlock); err != nil {
+		return nil, err
+	}
+
+	if err := a.mapCardBlockProperties(cardBlock, fromBoard, toBoard, opts.PropertyMapping); err != nil {
+		return nil, err
+	}
+
+	if _, err := a.store.MoveCardToBoard(cardBlock, toBoard.ID, userID); err != nil {
+		return nil, err
+	}
+
+	movedBlocks, err := a.store.GetSubTree2(toBoard.ID, cardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	a.moveBlockFiles(movedBlocks, fromBoard.TeamID, fromBoard.ID, toBoard.TeamID, toBoard.ID)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range movedBlocks {
+			a.wsAdapter.BroadcastBlockDelete(fromBoard.TeamID, block.ID, fromBoard.ID)
+			a.wsAdapter.BroadcastBlockChange(toBoard.TeamID, block)
+			a.webhook.NotifyUpdate(block)
+		}
+		return nil
+	})
+
+	for _, block := range movedBlocks {
+		if block.ID == cardID {
+			return model.Block2Card(block)
+		}
+	}
+
+	return nil, model.NewErrNotFound("card ID=" + cardID)
+}
+
+// CopyCard creates a copy of a card with its content, comments and
+// attachments in another board, mapping the card properties to the
+// destination board schema. The copy goes through the same checks and
+// notifications as the blocks inserted on the destination board.
+func (a *App) CopyCard(cardID string, opts *model.CardMoveOptions, userID string) (*model.Card, error) {
+	cardBlock, fromBoard, toBoard, err := a.getCardMoveBoards(cardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.checkCardNotLimited(cardBlock); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetSubTree2(fromBoard.ID, cardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, block := range blocks {
+		if block.ID == cardID {
+			if err := a.mapCardBlockProperties(block, fromBoard, toBoard, opts.PropertyMapping); err != nil {
+				return nil, err
+			}
+			// the card goes first so it's inserted before its children
+			blocks[0], blocks[i] = blocks[i], blocks[0]
+		}
+		block.BoardID = toBoard.ID
+	}
+	if len(blocks) == 0 || blocks[0].ID != cardID {
+		return nil, model.NewErrNotFound("card ID=" + cardID)
+	}
+
+	blocks = model.GenerateBlockIDs(blocks, a.logger)
+
+	// the files are copied first so that the blocks are inserted with
+	// their new file IDs
+	newFileNames, err := a.CopyCardFiles(fromBoard.ID, blocks, false)
+	if err != nil {
+		a.logger.Error("Could not copy files while copying card", mlog.String("cardID", cardID), mlog.Err(err))
+		return nil, err
+	}
+	for _, block := range blocks {
+		if !block.Type.HasFile() {
+			continue
+		}
+		if fileID, ok := block.Fields["fileId"].(string); ok {
+			block.Fields["fileId"] = newFileNames[fileID]
+			delete(block.Fields, "attachmentId")
+		}
+	}
+
+	copiedBlocks, err := a.InsertBlocksAndNotify(blocks, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.Block2Card(copiedBlocks[0])
+}
+
+// GetCardRedirect returns the redirect left on a board when one of
+// its cards was moved to another board.
+func (a *App) GetCardRedirect(boardID, cardID string) (*model.CardRedirect, error) {
+	return a.store.GetCardRedirect(boardID, cardID)
+}
+
+func (a *App) getCardMoveBoards(cardID string, opts *model.CardMoveOptions) (*model.Block, *model.Board, *model.Board, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if cardBlock.Type != model.TypeCard {
+		return nil, nil, nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	fromBoard, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	toBoard, err := a.store.GetBoard(opts.BoardID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return cardBlock, fromBoard, toBoard, nil
+}
+
+// checkCardNotLimited returns an error if the card is hidden by the
+// cloud card limit, as such cards can't be moved or copied.
+func (a *App) checkCardNotLimited(cardBlock *model.Block) error {
+	if !a.IsCloudLimited() {
+		return nil
+	}
+
+	containsLimitedBlocks, err := a.ContainsLimitedBlocks([]*model.Block{cardBlock})
+	if err != nil {
+		return err
+	}
+	if containsLimitedBlocks {
+		return model.ErrPatchUpdatesLimitedCards
+	}
+	return nil
+}
+
+func (a *App) mapCardBlockProperties(cardBlock *model.Block, fromBoard, toBoard *model.Board, mapping map[string]string) error {
+	properties, _ := cardBlock.Fields["properties"].(map[string]interface{})
+	mapped, err := model.MapCardProperties(properties, fromBoard, toBoard, mapping)
+	if err != nil {
+		return fmt.Errorf("cannot map properties of card %s: %w", cardBlock.ID, err)
+	}
+
+	if cardBlock.Fields == nil {
+		cardBlock.Fields = map[string]interface{}{}
+	}
+	cardBlock.Fields["properties"] = mapped
+	return nil
+}
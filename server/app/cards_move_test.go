@@ -0,0 +1,163 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestMoveCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	fromBoard := &model.Board{
+		ID:     "board_id_1",
+		TeamID: "team_id_1",
+		CardProperties: []map[string]interface{}{
+			{"id": "from_prop", "name": "Estimate", "type": "number"},
+		},
+	}
+	toBoard := &model.Board{
+		ID:     "board_id_2",
+		TeamID: "team_id_1",
+		CardProperties: []map[string]interface{}{
+			{"id": "to_prop", "name": "Estimate", "type": "number"},
+		},
+	}
+
+	t.Run("missing destination board", func(t *testing.T) {
+		card, err := th.App.MoveCard("card_id_1", &model.CardMoveOptions{}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, card)
+	})
+
+	t.Run("block is not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("text_id_1").Return(&model.Block{ID: "text_id_1", Type: model.TypeText}, nil)
+
+		card, err := th.App.MoveCard("text_id_1", &model.CardMoveOptions{BoardID: toBoard.ID}, userID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, card)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:       "card_id_1",
+			BoardID:  fromBoard.ID,
+			ParentID: fromBoard.ID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"from_prop": "3"}},
+		}
+		movedCard := &model.Block{
+			ID:       "card_id_1",
+			BoardID:  toBoard.ID,
+			ParentID: fromBoard.ID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": map[string]interface{}{"to_prop": "3"}},
+		}
+
+		th.Store.EXPECT().GetBlock("card_id_1").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(fromBoard.ID).Return(fromBoard, nil)
+		th.Store.EXPECT().GetBoard(toBoard.ID).Return(toBoard, nil)
+		th.Store.EXPECT().MoveCardToBoard(gomock.Any(), toBoard.ID, userID).DoAndReturn(
+			func(card *model.Block, toBoardID, userID string) (*model.CardRedirect, error) {
+				require.Equal(t, map[string]interface{}{"to_prop": "3"}, card.Fields["properties"])
+				return &model.CardRedirect{CardID: card.ID, SourceBoardID: fromBoard.ID, TargetBoardID: toBoardID}, nil
+			})
+		th.Store.EXPECT().GetSubTree2(toBoard.ID, "card_id_1", gomock.Any()).Return([]*model.Block{movedCard}, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		card, err := th.App.MoveCard("card_id_1", &model.CardMoveOptions{BoardID: toBoard.ID}, userID)
+		require.NoError(t, err)
+		require.Equal(t, toBoard.ID, card.BoardID)
+		require.Equal(t, "3", card.Properties["to_prop"])
+	})
+}
+
+func TestCopyCardToBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	fromBoard := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	toBoard := &model.Board{ID: "board_id_2", TeamID: "team_id_1"}
+
+	t.Run("base case", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:       "card_id_1",
+			BoardID:  fromBoard.ID,
+			ParentID: fromBoard.ID,
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"contentOrder": []interface{}{"text_id_1"}},
+		}
+		textBlock := &model.Block{
+			ID:       "text_id_1",
+			BoardID:  fromBoard.ID,
+			ParentID: "card_id_1",
+			Type:     model.TypeText,
+		}
+
+		var insertedBlocks []*model.Block
+		th.Store.EXPECT().GetBlock("card_id_1").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(fromBoard.ID).Return(fromBoard, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(toBoard.ID).Return(toBoard, nil).AnyTimes()
+		th.Store.EXPECT().GetSubTree2(fromBoard.ID, "card_id_1", gomock.Any()).Return([]*model.Block{textBlock, cardBlock}, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), userID).DoAndReturn(
+			func(block *model.Block, userID string) error {
+				insertedBlocks = append(insertedBlocks, block)
+				return nil
+			}).Times(2)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		card, err := th.App.CopyCard("card_id_1", &model.CardMoveOptions{BoardID: toBoard.ID}, userID)
+		require.NoError(t, err)
+		require.NotEqual(t, "card_id_1", card.ID)
+		require.Equal(t, toBoard.ID, card.BoardID)
+
+		require.Len(t, insertedBlocks, 2)
+		require.Equal(t, card.ID, insertedBlocks[0].ID)
+		require.Equal(t, card.ID, insertedBlocks[1].ParentID)
+		require.Equal(t, toBoard.ID, insertedBlocks[1].BoardID)
+		require.Equal(t, []interface{}{insertedBlocks[1].ID}, insertedBlocks[0].Fields["contentOrder"])
+	})
+
+	t.Run("files that can't be copied", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:       "card_id_2",
+			BoardID:  fromBoard.ID,
+			ParentID: fromBoard.ID,
+			Type:     model.TypeCard,
+		}
+		imageBlock := &model.Block{
+			ID:       "image_id_1",
+			BoardID:  fromBoard.ID,
+			ParentID: "card_id_2",
+			Type:     model.TypeImage,
+			Fields:   map[string]interface{}{"fileId": "7file_id_1.png"},
+		}
+
+		th.Store.EXPECT().GetBlock("card_id_2").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(fromBoard.ID).Return(fromBoard, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(toBoard.ID).Return(toBoard, nil).AnyTimes()
+		th.Store.EXPECT().GetSubTree2(fromBoard.ID, "card_id_2", gomock.Any()).Return([]*model.Block{cardBlock, imageBlock}, nil)
+		th.Store.EXPECT().GetFileInfo("file_id_1").Return(nil, errors.New("connection refused"))
+
+		// the card isn't copied with blocks that lost their files
+		th.Store.EXPECT().InsertBlock(gomock.Any(), gomock.Any()).Times(0)
+
+		card, err := th.App.CopyCard("card_id_2", &model.CardMoveOptions{BoardID: toBoard.ID}, userID)
+		require.Error(t, err)
+		require.Nil(t, card)
+	})
+}
@@ -36,7 +36,9 @@ func TestCreateCard(t *testing.T) {
 	t.Run("success scenario", func(t *testing.T) {
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().InsertBlock(gomock.AssignableToTypeOf(reflect.TypeOf(block)), userID).Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).Times(2)
+		// for WS broadcasts to mirroring boards
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
 
 		newCard, err := th.App.CreateCard(card, board.ID, userID, false)
 
@@ -84,26 +86,28 @@ func TestGetCards(t *testing.T) {
 
 	t.Run("success scenario", func(t *testing.T) {
 		opts := model.QueryBlocksOptions{
-			BoardID:   board.ID,
-			BlockType: model.TypeCard,
+			BoardID:         board.ID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
 		}
 
 		th.Store.EXPECT().GetBlocks(opts).Return(blocks, nil)
 
-		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0)
+		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0, false)
 		require.NoError(t, err)
 		assert.Len(t, cards, cardCount)
 	})
 
 	t.Run("error scenario", func(t *testing.T) {
 		opts := model.QueryBlocksOptions{
-			BoardID:   board.ID,
-			BlockType: model.TypeCard,
+			BoardID:         board.ID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
 		}
 
 		th.Store.EXPECT().GetBlocks(opts).Return(nil, blockError{"error"})
 
-		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0)
+		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0, false)
 		require.Error(t, err)
 		require.Nil(t, cards)
 	})
@@ -147,8 +151,10 @@ func TestPatchCard(t *testing.T) {
 		var blockPatch *model.BlockPatch
 		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().PatchBlock(card.ID, gomock.AssignableToTypeOf(reflect.TypeOf(blockPatch)), userID).Return(nil)
-		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).Times(2)
 		th.Store.EXPECT().GetBlock(card.ID).Return(expectedPatchedBlock, nil).AnyTimes()
+		// for WS broadcasts to mirroring boards
+		th.Store.EXPECT().GetCardMirrorsForCard(card.ID).Return([]*model.CardMirror{}, nil).AnyTimes()
 
 		patchedCard, err := th.App.PatchCard(cardPatch, card.ID, userID, false)
 
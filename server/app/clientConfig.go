@@ -5,12 +5,13 @@ import (
 )
 
 func (a *App) GetClientConfig() *model.ClientConfig {
+	cfg := a.GetConfig()
 	return &model.ClientConfig{
-		Telemetry:                a.config.Telemetry,
-		TelemetryID:              a.config.TelemetryID,
-		EnablePublicSharedBoards: a.config.EnablePublicSharedBoards,
-		TeammateNameDisplay:      a.config.TeammateNameDisplay,
-		FeatureFlags:             a.config.FeatureFlags,
-		MaxFileSize:              a.config.MaxFileSize,
+		Telemetry:                cfg.Telemetry,
+		TelemetryID:              cfg.TelemetryID,
+		EnablePublicSharedBoards: cfg.EnablePublicSharedBoards,
+		TeammateNameDisplay:      cfg.TeammateNameDisplay,
+		FeatureFlags:             cfg.FeatureFlags,
+		MaxFileSize:              cfg.MaxFileSize,
 	}
 }
@@ -0,0 +1,382 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetComment returns the comment block with the given ID.
+func (a *App) GetComment(commentID string) (*model.Block, error) {
+	block, err := a.store.GetBlock(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != model.TypeComment {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a comment", commentID))
+	}
+
+	return block, nil
+}
+
+// GetCommentThread returns the thread a comment belongs to, starting
+// at its root comment, with the replies and reactions.
+func (a *App) GetCommentThread(commentID string) (*model.Comment, error) {
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.getCommentThread(block)
+}
+
+// ReplyToComment adds a reply to the thread of a comment. Replying to a
+// reply adds to the same thread, as threads are a single level deep.
+func (a *App) ReplyToComment(commentID, text, userID string) (*model.Block, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, model.NewErrBadRequest("missing reply text")
+	}
+
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	threadID := model.CommentThreadID(block)
+	now := utils.GetMillis()
+	reply := &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		BoardID:    block.BoardID,
+		ParentID:   block.ParentID,
+		Type:       model.TypeComment,
+		Title:      text,
+		Fields:     map[string]interface{}{model.CommentFieldThreadID: threadID},
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+
+	if err := reply.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err := a.InsertBlockAndNotify(reply, userID, false); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// ResolveCommentThread marks the thread of a root comment as resolved.
+// Resolving a resolved thread leaves it unchanged.
+func (a *App) ResolveCommentThread(commentID, userID string) (*model.Comment, error) {
+	return a.setCommentThreadResolved(commentID, userID, true, func() model.BlockPatch {
+		return model.NewResolveCommentPatch(userID, utils.GetMillis())
+	})
+}
+
+// UnresolveCommentThread reopens the resolved thread of a root comment.
+func (a *App) UnresolveCommentThread(commentID, userID string) (*model.Comment, error) {
+	return a.setCommentThreadResolved(commentID, userID, false, model.NewUnresolveCommentPatch)
+}
+
+// GetCommentHistory returns the versions of the text of a comment,
+// oldest first.
+func (a *App) GetCommentHistory(commentID string) ([]*model.CommentEdit, error) {
+	if _, err := a.GetComment(commentID); err != nil {
+		return nil, err
+	}
+
+	history, err := a.store.GetBlockHistory(commentID, model.QueryBlockHistoryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCommentEdits(history), nil
+}
+
+// AddCommentReaction adds an emoji reaction of a user to a comment and
+// returns the updated reactions to the comment.
+func (a *App) AddCommentReaction(commentID, userID, emoji string) ([]*model.CommentReactionSummary, error) {
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.store.SaveCommentReaction(&model.CommentReaction{
+		CommentID: commentID,
+		BoardID:   block.BoardID,
+		UserID:    userID,
+		Emoji:     emoji,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCommentReactionsChange(block)
+}
+
+// RemoveCommentReaction removes an emoji reaction of a user from a
+// comment and returns the updated reactions to the comment.
+func (a *App) RemoveCommentReaction(commentID, userID, emoji string) ([]*model.CommentReactionSummary, error) {
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.DeleteCommentReaction(commentID, userID, emoji); err != nil {
+		return nil, err
+	}
+
+	return a.broadcastCommentReactionsChange(block)
+}
+
+// GetCardComments returns the comment threads of a card, oldest first.
+func (a *App) GetCardComments(cardID string) ([]*model.Comment, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := a.store.GetBlocksWithParentAndType(card.BoardID, card.ID, string(model.TypeComment))
+	if err != nil {
+		return nil, err
+	}
+
+	commentIDs := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+
+	reactions, err := a.store.GetCommentReactions(commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCommentThreads(comments, reactions)
+}
+
+// CreateCardComment adds a comment to a card. Comments with a thread ID
+// are added as replies to the thread.
+func (a *App) CreateCardComment(cardID string, comment *model.Comment, userID string) (*model.Comment, error) {
+	if comment.ThreadID != "" {
+		if _, err := a.GetCardComment(cardID, comment.ThreadID); err != nil {
+			return nil, err
+		}
+
+		reply, err := a.ReplyToComment(comment.ThreadID, comment.Text, userID)
+		if err != nil {
+			return nil, err
+		}
+		return model.Block2Comment(reply)
+	}
+
+	if strings.TrimSpace(comment.Text) == "" {
+		return nil, model.NewErrBadRequest("missing comment text")
+	}
+
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	block := &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		BoardID:    card.BoardID,
+		ParentID:   card.ID,
+		Type:       model.TypeComment,
+		Title:      comment.Text,
+		Fields:     map[string]interface{}{},
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+
+	if err = a.InsertBlockAndNotify(block, userID, false); err != nil {
+		return nil, err
+	}
+
+	return model.Block2Comment(block)
+}
+
+// GetCardComment returns the comment with the given ID if it belongs to
+// the card, with the reactions to it.
+func (a *App) GetCardComment(cardID, commentID string) (*model.Comment, error) {
+	block, err := a.getCardCommentBlock(cardID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.getCommentWithReactions(block)
+}
+
+// PatchCardComment modifies the text of a comment of a card.
+func (a *App) PatchCardComment(cardID, commentID string, patch *model.CommentPatch, userID string) (*model.Comment, error) {
+	if patch.Text == nil || strings.TrimSpace(*patch.Text) == "" {
+		return nil, model.NewErrBadRequest("missing comment text")
+	}
+
+	if _, err := a.getCardCommentBlock(cardID, commentID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.PatchBlockAndNotify(commentID, &model.BlockPatch{Title: patch.Text}, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.getCommentWithReactions(block)
+}
+
+// DeleteCardComment deletes a comment of a card. Deleting the root
+// comment of a thread deletes its replies too.
+func (a *App) DeleteCardComment(cardID, commentID, userID string) error {
+	if _, err := a.getCardCommentBlock(cardID, commentID); err != nil {
+		return err
+	}
+
+	return a.DeleteBlockAndNotify(commentID, userID, false)
+}
+
+// getCardCommentBlock returns the comment with the given ID if it
+// belongs to the card.
+func (a *App) getCardCommentBlock(cardID, commentID string) (*model.Block, error) {
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.ParentID != cardID {
+		return nil, model.NewErrNotFound(fmt.Sprintf("comment ID=%s on CardID=%s", commentID, cardID))
+	}
+
+	return block, nil
+}
+
+func (a *App) getCommentWithReactions(block *model.Block) (*model.Comment, error) {
+	reactions, err := a.store.GetCommentReactions([]string{block.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := model.Block2Comment(block)
+	if err != nil {
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		comment.AddReaction(reaction)
+	}
+
+	return comment, nil
+}
+
+func (a *App) getCommentThread(block *model.Block) (*model.Comment, error) {
+	comments, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   block.BoardID,
+		ParentID:  block.ParentID,
+		BlockType: model.TypeComment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	threadID := model.CommentThreadID(block)
+	commentIDs := []string{}
+	for _, comment := range comments {
+		if model.CommentThreadID(comment) == threadID {
+			commentIDs = append(commentIDs, comment.ID)
+		}
+	}
+
+	reactions, err := a.store.GetCommentReactions(commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCommentThread(threadID, comments, reactions)
+}
+
+// getCommentReplies returns the replies of the thread of a root
+// comment.
+func (a *App) getCommentReplies(block *model.Block) ([]*model.Block, error) {
+	comments, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   block.BoardID,
+		ParentID:  block.ParentID,
+		BlockType: model.TypeComment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replies := []*model.Block{}
+	for _, comment := range comments {
+		if comment.ID != block.ID && model.CommentThreadID(comment) == block.ID {
+			replies = append(replies, comment)
+		}
+	}
+	return replies, nil
+}
+
+func (a *App) setCommentThreadResolved(commentID, userID string, resolved bool, newPatch func() model.BlockPatch) (*model.Comment, error) {
+	block, err := a.GetComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.IsCommentReply(block) {
+		return nil, model.NewErrBadRequest("only the root comment of a thread can be resolved")
+	}
+
+	if model.IsResolvedComment(block) != resolved {
+		board, err := a.store.GetBoard(block.BoardID)
+		if err != nil {
+			return nil, err
+		}
+
+		patch := newPatch()
+		if err = a.store.PatchBlock(commentID, &patch, userID); err != nil {
+			return nil, err
+		}
+
+		block, err = a.store.GetBlock(commentID)
+		if err != nil {
+			return nil, err
+		}
+
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+			a.webhook.NotifyUpdate(block)
+			return nil
+		})
+	}
+
+	return a.getCommentThread(block)
+}
+
+func (a *App) broadcastCommentReactionsChange(block *model.Block) ([]*model.CommentReactionSummary, error) {
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := a.getCommentWithReactions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastCommentReactionsChange(board.TeamID, block.BoardID, block.ID, comment.Reactions)
+		return nil
+	})
+
+	return comment.Reactions, nil
+}
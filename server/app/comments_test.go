@@ -0,0 +1,417 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestCommentBlock(id, threadID, createdBy string, createAt int64) *model.Block {
+	fields := map[string]interface{}{}
+	if threadID != "" {
+		fields[model.CommentFieldThreadID] = threadID
+	}
+
+	return &model.Block{
+		ID:        id,
+		BoardID:   "board_id_1",
+		ParentID:  "card_id_1",
+		Type:      model.TypeComment,
+		Title:     "comment " + id,
+		Fields:    fields,
+		CreatedBy: createdBy,
+		CreateAt:  createAt,
+	}
+}
+
+func TestGetCommentThread(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("not a comment", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", Type: model.TypeCard}, nil)
+
+		thread, err := th.App.GetCommentThread("card_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, thread)
+	})
+
+	t.Run("thread of a reply", func(t *testing.T) {
+		root := newTestCommentBlock("comment_id_1", "", "user_id_1", 100)
+		reply1 := newTestCommentBlock("comment_id_2", "comment_id_1", "user_id_2", 300)
+		reply2 := newTestCommentBlock("comment_id_3", "comment_id_1", "user_id_1", 200)
+		other := newTestCommentBlock("comment_id_4", "", "user_id_2", 150)
+
+		th.Store.EXPECT().GetBlock("comment_id_2").Return(reply1, nil)
+		th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{
+			BoardID:   "board_id_1",
+			ParentID:  "card_id_1",
+			BlockType: model.TypeComment,
+		}).Return([]*model.Block{root, other, reply1, reply2}, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1", "comment_id_2", "comment_id_3"}).Return([]*model.CommentReaction{
+			{CommentID: "comment_id_1", UserID: "user_id_2", Emoji: "+1", CreateAt: 2},
+			{CommentID: "comment_id_1", UserID: "user_id_1", Emoji: "+1", CreateAt: 1},
+		}, nil)
+
+		thread, err := th.App.GetCommentThread("comment_id_2")
+		require.NoError(t, err)
+		require.Equal(t, "comment_id_1", thread.ID)
+		require.Len(t, thread.Replies, 2)
+		require.Equal(t, "comment_id_3", thread.Replies[0].ID)
+		require.Equal(t, "comment_id_1", thread.Replies[0].ThreadID)
+		require.Equal(t, []*model.CommentReactionSummary{
+			{Emoji: "+1", UserIDs: []string{"user_id_1", "user_id_2"}},
+		}, thread.Reactions)
+	})
+}
+
+func TestReplyToComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	t.Run("empty reply", func(t *testing.T) {
+		reply, err := th.App.ReplyToComment("comment_id_1", " ", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, reply)
+	})
+
+	t.Run("reply to a reply", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("comment_id_2").Return(newTestCommentBlock("comment_id_2", "comment_id_1", "user_id_2", 100), nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user_id_1").DoAndReturn(
+			func(block *model.Block, userID string) error {
+				require.EqualValues(t, model.TypeComment, block.Type)
+				require.Equal(t, "card_id_1", block.ParentID)
+				require.Equal(t, "comment_id_1", block.Fields[model.CommentFieldThreadID])
+				return nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		reply, err := th.App.ReplyToComment("comment_id_2", "a reply", "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, "a reply", reply.Title)
+		require.Equal(t, "user_id_1", reply.CreatedBy)
+		require.True(t, model.IsCommentReply(reply))
+	})
+}
+
+func TestResolveCommentThread(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	commentsQuery := model.QueryBlocksOptions{
+		BoardID:   "board_id_1",
+		ParentID:  "card_id_1",
+		BlockType: model.TypeComment,
+	}
+
+	t.Run("reply", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("comment_id_2").Return(newTestCommentBlock("comment_id_2", "comment_id_1", "user_id_2", 100), nil)
+
+		thread, err := th.App.ResolveCommentThread("comment_id_2", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, thread)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		root := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+		resolvedRoot := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+		resolvedRoot.Fields[model.CommentFieldResolvedAt] = float64(200)
+		resolvedRoot.Fields[model.CommentFieldResolvedBy] = "user_id_1"
+
+		gomock.InOrder(
+			th.Store.EXPECT().GetBlock("comment_id_1").Return(root, nil),
+			th.Store.EXPECT().GetBlock("comment_id_1").Return(resolvedRoot, nil),
+		)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().PatchBlock("comment_id_1", gomock.Any(), "user_id_1").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, "user_id_1", patch.UpdatedFields[model.CommentFieldResolvedBy])
+				return nil
+			})
+		th.Store.EXPECT().GetBlocks(commentsQuery).Return([]*model.Block{resolvedRoot}, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1"}).Return([]*model.CommentReaction{}, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		thread, err := th.App.ResolveCommentThread("comment_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, int64(200), thread.ResolvedAt)
+		require.Equal(t, "user_id_1", thread.ResolvedBy)
+	})
+
+	t.Run("unresolve a thread that is not resolved", func(t *testing.T) {
+		root := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(root, nil)
+		th.Store.EXPECT().GetBlocks(commentsQuery).Return([]*model.Block{root}, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1"}).Return([]*model.CommentReaction{}, nil)
+
+		thread, err := th.App.UnresolveCommentThread("comment_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Zero(t, thread.ResolvedAt)
+	})
+}
+
+func TestAddCommentReaction(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	comment := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+
+	th.Store.EXPECT().GetBlock("comment_id_1").Return(comment, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("add a reaction", func(t *testing.T) {
+		th.Store.EXPECT().SaveCommentReaction(&model.CommentReaction{
+			CommentID: "comment_id_1",
+			BoardID:   "board_id_1",
+			UserID:    "user_id_1",
+			Emoji:     "tada",
+		}).Return(&model.CommentReaction{}, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1"}).Return([]*model.CommentReaction{
+			{CommentID: "comment_id_1", UserID: "user_id_2", Emoji: "+1"},
+			{CommentID: "comment_id_1", UserID: "user_id_1", Emoji: "tada"},
+		}, nil)
+
+		reactions, err := th.App.AddCommentReaction("comment_id_1", "user_id_1", "tada")
+		require.NoError(t, err)
+		require.Equal(t, []*model.CommentReactionSummary{
+			{Emoji: "+1", UserIDs: []string{"user_id_2"}},
+			{Emoji: "tada", UserIDs: []string{"user_id_1"}},
+		}, reactions)
+	})
+
+	t.Run("remove a reaction that doesn't exist", func(t *testing.T) {
+		th.Store.EXPECT().DeleteCommentReaction("comment_id_1", "user_id_1", "+1").Return(model.NewErrNotFound("comment reaction"))
+
+		reactions, err := th.App.RemoveCommentReaction("comment_id_1", "user_id_1", "+1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, reactions)
+	})
+}
+
+func TestGetCommentHistory(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetBlock("comment_id_1").Return(newTestCommentBlock("comment_id_1", "", "user_id_1", 100), nil)
+	th.Store.EXPECT().GetBlockHistory("comment_id_1", model.QueryBlockHistoryOptions{}).Return([]*model.Block{
+		{ID: "comment_id_1", Title: "first", ModifiedBy: "user_id_1", UpdateAt: 100},
+		{ID: "comment_id_1", Title: "first", ModifiedBy: "user_id_1", UpdateAt: 150},
+		{ID: "comment_id_1", Title: "second", ModifiedBy: "user_id_1", UpdateAt: 200},
+	}, nil)
+
+	edits, err := th.App.GetCommentHistory("comment_id_1")
+	require.NoError(t, err)
+	require.Equal(t, []*model.CommentEdit{
+		{Text: "first", ModifiedBy: "user_id_1", UpdateAt: 100},
+		{Text: "second", ModifiedBy: "user_id_1", UpdateAt: 200},
+	}, edits)
+}
+
+func TestDeleteRootComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	root := newTestCommentBlock("comment_id_1", "", "user_id_1", 100)
+	reply := newTestCommentBlock("comment_id_2", "comment_id_1", "user_id_2", 200)
+	other := newTestCommentBlock("comment_id_3", "", "user_id_2", 300)
+
+	th.Store.EXPECT().GetBlock("comment_id_1").Return(root, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{
+		BoardID:   "board_id_1",
+		ParentID:  "card_id_1",
+		BlockType: model.TypeComment,
+	}).Return([]*model.Block{root, reply, other}, nil)
+	th.Store.EXPECT().DeleteBlock("comment_id_1", "user_id_1").Return(nil)
+	th.Store.EXPECT().DeleteBlock("comment_id_2", "user_id_1").Return(nil)
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	err := th.App.DeleteBlock("comment_id_1", "user_id_1")
+	require.NoError(t, err)
+}
+
+func TestPatchCommentFields(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("comment state", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.CommentFieldResolvedAt: 1}}
+
+		block, err := th.App.PatchBlock("comment_id_1", patch, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, block)
+	})
+
+	t.Run("edited text", func(t *testing.T) {
+		comment := newTestCommentBlock("comment_id_1", "", "user_id_1", 100)
+		board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+		text := "new text"
+
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(comment, nil).Times(2)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().PatchBlock("comment_id_1", gomock.Any(), "user_id_1").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.NotZero(t, patch.UpdatedFields[model.CommentFieldEditedAt])
+				return nil
+			})
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		_, err := th.App.PatchBlock("comment_id_1", &model.BlockPatch{Title: &text}, "user_id_1")
+		require.NoError(t, err)
+	})
+}
+
+func TestGetCardComments(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := &model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(newTestCommentBlock("comment_id_1", "", "user_id_1", 100), nil)
+
+		comments, err := th.App.GetCardComments("comment_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, comments)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		root1 := newTestCommentBlock("comment_id_1", "", "user_id_1", 200)
+		root2 := newTestCommentBlock("comment_id_2", "", "user_id_2", 100)
+		reply := newTestCommentBlock("comment_id_3", "comment_id_1", "user_id_2", 300)
+
+		th.Store.EXPECT().GetBlock("card_id_1").Return(card, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType("board_id_1", "card_id_1", string(model.TypeComment)).
+			Return([]*model.Block{root1, root2, reply}, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1", "comment_id_2", "comment_id_3"}).Return([]*model.CommentReaction{}, nil)
+
+		comments, err := th.App.GetCardComments("card_id_1")
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		require.Equal(t, "comment_id_2", comments[0].ID)
+		require.Empty(t, comments[0].Replies)
+		require.Equal(t, "comment_id_1", comments[1].ID)
+		require.Len(t, comments[1].Replies, 1)
+		require.Equal(t, "comment_id_3", comments[1].Replies[0].ID)
+	})
+}
+
+func TestCreateCardComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+	card := &model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	t.Run("empty comment", func(t *testing.T) {
+		comment, err := th.App.CreateCardComment("card_id_1", &model.Comment{Text: " "}, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("root comment", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user_id_1").DoAndReturn(
+			func(block *model.Block, userID string) error {
+				require.EqualValues(t, model.TypeComment, block.Type)
+				require.Equal(t, "card_id_1", block.ParentID)
+				require.False(t, model.IsCommentReply(block))
+				return nil
+			})
+
+		comment, err := th.App.CreateCardComment("card_id_1", &model.Comment{Text: "a comment"}, "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, "a comment", comment.Text)
+		require.Equal(t, "card_id_1", comment.CardID)
+		require.Empty(t, comment.ThreadID)
+	})
+
+	t.Run("thread of another card", func(t *testing.T) {
+		other := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+		other.ParentID = "card_id_2"
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(other, nil)
+
+		comment, err := th.App.CreateCardComment("card_id_1", &model.Comment{Text: "a reply", ThreadID: "comment_id_1"}, "user_id_1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		root := newTestCommentBlock("comment_id_1", "", "user_id_2", 100)
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(root, nil).Times(2)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1"}).Return([]*model.CommentReaction{}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user_id_1").Return(nil)
+
+		comment, err := th.App.CreateCardComment("card_id_1", &model.Comment{Text: "a reply", ThreadID: "comment_id_1"}, "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, "a reply", comment.Text)
+		require.Equal(t, "comment_id_1", comment.ThreadID)
+	})
+}
+
+func TestPatchCardComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id_1", TeamID: "team_id_1"}
+
+	t.Run("missing text", func(t *testing.T) {
+		comment, err := th.App.PatchCardComment("card_id_1", "comment_id_1", &model.CommentPatch{}, "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, comment)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		text := "edited comment"
+		block := newTestCommentBlock("comment_id_1", "", "user_id_1", 100)
+		edited := newTestCommentBlock("comment_id_1", "", "user_id_1", 100)
+		edited.Title = text
+
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(block, nil).Times(2)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().PatchBlock("comment_id_1", gomock.Any(), "user_id_1").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, text, *patch.Title)
+				require.Contains(t, patch.UpdatedFields, model.CommentFieldEditedAt)
+				return nil
+			})
+		th.Store.EXPECT().GetBlock("comment_id_1").Return(edited, nil)
+		th.Store.EXPECT().GetCommentReactions([]string{"comment_id_1"}).Return([]*model.CommentReaction{}, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		comment, err := th.App.PatchCardComment("card_id_1", "comment_id_1", &model.CommentPatch{Text: &text}, "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, text, comment.Text)
+	})
+}
@@ -55,6 +55,9 @@ func TestMoveContentBlock(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
 
+	// the unread counts of the board are sent once, on shutdown
+	th.Store.EXPECT().GetMembersForBoard("test-board").Return([]*model.BoardMember{}, nil)
+
 	ttCases := []struct {
 		name                 string
 		srcBlock             model.Block
@@ -86,12 +86,13 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 	if err != nil {
 		return err
 	}
+	blocks = model.FilterPrivateViews(blocks, opt.UserID)
 
 	for _, block := range blocks {
 		if err = a.writeArchiveBlockLine(w, block); err != nil {
 			return err
 		}
-		if block.Type == model.TypeImage || block.Type == model.TypeAttachment {
+		if block.Type.HasFile() {
 			filename, err2 := extractFilename(block)
 			if err2 != nil {
 				return err2
@@ -239,17 +240,17 @@ func (a *App) getBoardsForArchive(boardIDs []string) ([]model.Board, error) {
 }
 
 func extractFilename(block *model.Block) (string, error) {
-	f, ok := block.Fields["fileId"]
-	if !ok {
-		f, ok = block.Fields["attachmentId"]
+	for _, field := range model.BlockTypeFileFields(block.Type) {
+		f, ok := block.Fields[field]
 		if !ok {
-			return "", model.ErrInvalidImageBlock
+			continue
 		}
-	}
 
-	filename, ok := f.(string)
-	if !ok {
-		return "", model.ErrInvalidImageBlock
+		filename, ok := f.(string)
+		if !ok {
+			return "", model.ErrInvalidImageBlock
+		}
+		return filename, nil
 	}
-	return filename, nil
+	return "", model.ErrInvalidImageBlock
 }
@@ -192,7 +192,7 @@ func (a *App) CopyAndUpdateCardFiles(boardID, userID string, blocks []*model.Blo
 	blockIDs := make([]string, 0)
 	blockPatches := make([]model.BlockPatch, 0)
 	for _, block := range blocks {
-		if block.Type == model.TypeImage || block.Type == model.TypeAttachment {
+		if block.Type.HasFile() {
 			if fileID, ok := block.Fields["fileId"].(string); ok {
 				blockIDs = append(blockIDs, block.ID)
 				blockPatches = append(blockPatches, model.BlockPatch{
@@ -235,7 +235,7 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []*model.Block, a
 	var destBoard *model.Board
 	newFileNames := make(map[string]string)
 	for _, block := range copiedBlocks {
-		if block.Type != model.TypeImage && block.Type != model.TypeAttachment {
+		if !block.Type.HasFile() {
 			continue
 		}
 
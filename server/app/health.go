@@ -0,0 +1,181 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// healthCheckTimeout is the time after which a component that hasn't
+// answered its check is reported as unavailable.
+const healthCheckTimeout = 5 * time.Second
+
+// healthCheckFilePrefix starts the name of the files written to check
+// the file backend.
+const healthCheckFilePrefix = "health_check_"
+
+// healthCacheTTL is the time during which the result of a health check
+// is reused, so frequent probes don't load the components.
+var healthCacheTTL = 5 * time.Second
+
+var errHealthCheckTimeout = fmt.Errorf("check timed out after %s", healthCheckTimeout)
+
+// SetScheduledTasks sets the recurring tasks whose liveness is part of
+// the health of the server.
+func (a *App) SetScheduledTasks(tasks []*scheduler.ScheduledTask) {
+	a.scheduledTasksMux.Lock()
+	a.scheduledTasks = tasks
+	a.scheduledTasksMux.Unlock()
+
+	a.healthMux.Lock()
+	a.health = nil
+	a.healthMux.Unlock()
+}
+
+// CheckHealth returns the health of the components the server depends
+// on. The components are checked again once the last result is older
+// than healthCacheTTL.
+func (a *App) CheckHealth() *model.Health {
+	a.healthMux.Lock()
+	defer a.healthMux.Unlock()
+
+	if a.health != nil && time.Since(a.healthCheckedAt) < healthCacheTTL {
+		return a.health
+	}
+
+	a.health = a.checkHealth()
+	a.healthCheckedAt = time.Now()
+	return a.health
+}
+
+// checkHealth checks the components concurrently, and reports the ones
+// that fail or don't answer in time as unavailable. The errors are only
+// logged, as the health is public.
+func (a *App) checkHealth() *model.Health {
+	checks := map[string]func() error{
+		model.HealthComponentDatabase:   a.checkDatabaseHealth,
+		model.HealthComponentMigrations: a.checkMigrationsHealth,
+		model.HealthComponentFiles:      a.checkFilesHealth,
+		model.HealthComponentScheduler:  a.checkSchedulerHealth,
+	}
+	if a.notifications != nil {
+		checks[model.HealthComponentNotifications] = a.notifications.CheckHealth
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks))
+	for name, check := range checks {
+		go func(name string, check func() error) {
+			results <- result{name: name, err: check()}
+		}(name, check)
+	}
+
+	health := &model.Health{
+		Status:     model.HealthStatusOK,
+		Components: make(map[string]string, len(checks)),
+	}
+	setStatus := func(name string, err error) {
+		if err != nil {
+			a.logger.Warn("Health check failed", mlog.String("component", name), mlog.Err(err))
+			health.Components[name] = model.HealthStatusUnavailable
+			health.Status = model.HealthStatusUnavailable
+			return
+		}
+		health.Components[name] = model.HealthStatusOK
+	}
+
+	timeout := time.NewTimer(healthCheckTimeout)
+	defer timeout.Stop()
+
+	for len(health.Components) < len(checks) {
+		select {
+		case r := <-results:
+			setStatus(r.name, r.err)
+		case <-timeout.C:
+			for name := range checks {
+				if _, ok := health.Components[name]; !ok {
+					setStatus(name, errHealthCheckTimeout)
+				}
+			}
+		}
+	}
+
+	return health
+}
+
+func (a *App) checkDatabaseHealth() error {
+	_, err := a.store.GetMigrationStatus()
+	return err
+}
+
+func (a *App) checkMigrationsHealth() error {
+	status, err := a.store.GetMigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	if status.AppliedVersion < status.LatestVersion {
+		return fmt.Errorf("database schema is at version %d, the server needs version %d", status.AppliedVersion, status.LatestVersion)
+	}
+	return nil
+}
+
+// checkFilesHealth writes a small file in the file backend, reads it
+// back and removes it.
+func (a *App) checkFilesHealth() error {
+	path := healthCheckFilePrefix + utils.NewID(utils.IDTypeNone)
+	content := []byte("focalboard health check")
+
+	if _, err := a.filesBackend.WriteFile(bytes.NewReader(content), path); err != nil {
+		return fmt.Errorf("cannot write: %w", err)
+	}
+	defer func() {
+		if err := a.filesBackend.RemoveFile(path); err != nil {
+			a.logger.Warn("Cannot remove the health check file", mlog.String("path", path), mlog.Err(err))
+		}
+	}()
+
+	reader, err := a.filesBackend.Reader(path)
+	if err != nil {
+		return fmt.Errorf("cannot read: %w", err)
+	}
+	defer reader.Close()
+
+	read, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("cannot read: %w", err)
+	}
+	if !bytes.Equal(read, content) {
+		return errors.New("read content differs from the written one")
+	}
+	return nil
+}
+
+func (a *App) checkSchedulerHealth() error {
+	a.scheduledTasksMux.RLock()
+	defer a.scheduledTasksMux.RUnlock()
+
+	var stuck []string
+	for _, task := range a.scheduledTasks {
+		if !task.IsAlive() {
+			stuck = append(stuck, task.Name)
+		}
+	}
+
+	if len(stuck) > 0 {
+		return fmt.Errorf("tasks not running: %s", strings.Join(stuck, ", "))
+	}
+	return nil
+}
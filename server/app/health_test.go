@@ -0,0 +1,76 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+)
+
+func TestCheckHealth(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.FilesBackend.On("WriteFile", mock.Anything, mock.Anything).Return(int64(0), errors.New("read-only file system"))
+
+	// every check runs again, unless the cache is tested
+	ttl := healthCacheTTL
+	healthCacheTTL = 0
+	defer func() { healthCacheTTL = ttl }()
+
+	t.Run("unavailable database", func(t *testing.T) {
+		th.Store.EXPECT().GetMigrationStatus().Return(nil, errors.New("connection refused")).Times(2)
+
+		health := th.App.CheckHealth()
+		require.False(t, health.IsOK())
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentDatabase])
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentMigrations])
+	})
+
+	t.Run("pending migrations", func(t *testing.T) {
+		status := &model.MigrationStatus{AppliedVersion: 47, LatestVersion: 48}
+		th.Store.EXPECT().GetMigrationStatus().Return(status, nil).Times(2)
+
+		health := th.App.CheckHealth()
+		require.Equal(t, model.HealthStatusOK, health.Components[model.HealthComponentDatabase])
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentMigrations])
+	})
+
+	t.Run("unwritable files and stuck tasks", func(t *testing.T) {
+		status := &model.MigrationStatus{AppliedVersion: 48, LatestVersion: 48}
+		th.Store.EXPECT().GetMigrationStatus().Return(status, nil).Times(2)
+
+		task := scheduler.CreateRecurringTask("stuckTask", func() {}, time.Minute)
+		task.Cancel()
+		th.App.SetScheduledTasks([]*scheduler.ScheduledTask{task})
+		defer th.App.SetScheduledTasks(nil)
+
+		health := th.App.CheckHealth()
+		require.False(t, health.IsOK())
+		require.Equal(t, model.HealthStatusOK, health.Components[model.HealthComponentMigrations])
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentFiles])
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentScheduler])
+		require.NotContains(t, health.Components, model.HealthComponentNotifications)
+	})
+
+	t.Run("the result is reused until it expires", func(t *testing.T) {
+		healthCacheTTL = time.Minute
+		defer func() { healthCacheTTL = 0 }()
+
+		status := &model.MigrationStatus{AppliedVersion: 48, LatestVersion: 48}
+		th.Store.EXPECT().GetMigrationStatus().Return(status, nil).Times(2)
+
+		health := th.App.CheckHealth()
+		require.Same(t, health, th.App.CheckHealth())
+
+		// changing the scheduled tasks discards the last result
+		th.Store.EXPECT().GetMigrationStatus().Return(status, nil).Times(2)
+		th.App.SetScheduledTasks(nil)
+		require.NotSame(t, health, th.App.CheckHealth())
+	})
+}
@@ -3,6 +3,7 @@
 package app
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -27,6 +28,8 @@ type TestHelper struct {
 	FilesBackend *mocks.FileBackend
 	logger       mlog.LoggerIFace
 	API          *mmpermissionsMocks.MockAPI
+
+	PermissionsStore *permissionsMocks.MockStore
 }
 
 func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
@@ -58,7 +61,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	app2 := New(&cfg, wsserver, appServices)
 
 	tearDown := func() {
-		app2.Shutdown()
+		app2.Shutdown(context.Background())
 		if logger != nil {
 			_ = logger.Shutdown()
 		}
@@ -70,5 +73,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 		FilesBackend: filesBackend,
 		logger:       logger,
 		API:          mockAPI,
+
+		PermissionsStore: mockStore,
 	}, tearDown
 }
@@ -15,12 +15,18 @@ func (a *App) initialize(skipTemplateInit bool) {
 	}
 }
 
-func (a *App) Shutdown() {
+// Shutdown drains the queued block change notifications, for as long as
+// the context allows, then sends the pending unread counts.
+func (a *App) Shutdown(ctx context.Context) {
 	if a.blockChangeNotifier != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), blockChangeNotifierShutdownTimeout)
-		defer cancel()
 		if !a.blockChangeNotifier.Shutdown(ctx) {
-			a.logger.Warn("blockChangeNotifier shutdown timed out")
+			a.logger.Warn("blockChangeNotifier shutdown timed out",
+				mlog.Int("dropped", a.blockChangeNotifier.Len()),
+			)
+			return
 		}
+		a.logger.Debug("blockChangeNotifier drained")
 	}
+
+	a.flushUnreadCountsChanges()
 }
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetJobs returns the background jobs, the most recently scheduled
+// first.
+func (a *App) GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error) {
+	if opts.Status != "" && !opts.Status.IsValid() {
+		return nil, model.NewErrBadRequest("invalid job status " + string(opts.Status))
+	}
+	return a.store.GetJobs(opts)
+}
+
+func (a *App) GetJob(jobID string) (*model.Job, error) {
+	return a.store.GetJob(jobID)
+}
+
+// CancelJob cancels a pending or running job. A running job stops once
+// its worker notices, on its next heartbeat.
+func (a *App) CancelJob(jobID string) (*model.Job, error) {
+	ok, err := a.store.CancelJob(jobID, utils.GetMillis())
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := a.store.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, model.NewErrBadRequest("job " + jobID + " is already " + string(job.Status))
+	}
+
+	a.logger.Info("Cancelled job", mlog.String("jobID", jobID), mlog.String("type", job.Type))
+	return job, nil
+}
+
+// RetryJob schedules again a failed or cancelled job, with all its
+// attempts.
+func (a *App) RetryJob(jobID string) (*model.Job, error) {
+	ok, err := a.store.RetryJob(jobID, utils.GetMillis())
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := a.store.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, model.NewErrBadRequest("job " + jobID + " is " + string(job.Status) + ", only failed or cancelled jobs can be retried")
+	}
+
+	a.logger.Info("Retrying job", mlog.String("jobID", jobID), mlog.String("type", job.Type))
+	return job, nil
+}
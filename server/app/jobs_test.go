@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestCancelJob(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("pending job", func(t *testing.T) {
+		th.Store.EXPECT().CancelJob("job-id", gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetJob("job-id").Return(&model.Job{ID: "job-id", Status: model.JobStatusCanceled}, nil)
+
+		job, err := th.App.CancelJob("job-id")
+		require.NoError(t, err)
+		require.Equal(t, model.JobStatusCanceled, job.Status)
+	})
+
+	t.Run("finished job", func(t *testing.T) {
+		th.Store.EXPECT().CancelJob("job-id", gomock.Any()).Return(false, nil)
+		th.Store.EXPECT().GetJob("job-id").Return(&model.Job{ID: "job-id", Status: model.JobStatusSuccess}, nil)
+
+		_, err := th.App.CancelJob("job-id")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Contains(t, err.Error(), "already success")
+	})
+
+	t.Run("nonexistent job", func(t *testing.T) {
+		th.Store.EXPECT().CancelJob("job-id", gomock.Any()).Return(false, nil)
+		th.Store.EXPECT().GetJob("job-id").Return(nil, model.NewErrNotFound("job ID=job-id"))
+
+		_, err := th.App.CancelJob("job-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestRetryJob(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("failed job", func(t *testing.T) {
+		th.Store.EXPECT().RetryJob("job-id", gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetJob("job-id").Return(&model.Job{ID: "job-id", Status: model.JobStatusPending}, nil)
+
+		job, err := th.App.RetryJob("job-id")
+		require.NoError(t, err)
+		require.Equal(t, model.JobStatusPending, job.Status)
+	})
+
+	t.Run("running job", func(t *testing.T) {
+		th.Store.EXPECT().RetryJob("job-id", gomock.Any()).Return(false, nil)
+		th.Store.EXPECT().GetJob("job-id").Return(&model.Job{ID: "job-id", Status: model.JobStatusRunning}, nil)
+
+		_, err := th.App.RetryJob("job-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestGetJobs(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	_, err := th.App.GetJobs(model.QueryJobsOptions{Status: "stuck"})
+	require.True(t, model.IsErrBadRequest(err))
+
+	opts := model.QueryJobsOptions{Type: model.JobTypeUnfreezeBoards, Status: model.JobStatusError}
+	th.Store.EXPECT().GetJobs(opts).Return([]*model.Job{{ID: "job-id"}}, nil)
+	jobs, err := th.App.GetJobs(opts)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetPortfolioBoardIDs returns the IDs of the boards that a portfolio
+// query refers to, either explicitly or through a category of the user.
+func (a *App) GetPortfolioBoardIDs(userID string, query *model.PortfolioQuery) ([]string, error) {
+	if query.CategoryID == "" {
+		return uniqueStrings(query.BoardIDs), nil
+	}
+
+	category, err := a.store.GetCategory(query.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if category.UserID != userID {
+		return nil, model.NewErrPermission("access denied to category")
+	}
+
+	categoryBoards, err := a.store.GetUserCategoryBoards(userID, category.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cb := range categoryBoards {
+		if cb.ID != category.ID {
+			continue
+		}
+
+		boardIDs := make([]string, 0, len(cb.BoardMetadata))
+		for _, metadata := range cb.BoardMetadata {
+			boardIDs = append(boardIDs, metadata.BoardID)
+		}
+
+		if len(boardIDs) > model.PortfolioMaxBoards {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("a portfolio can't include more than %d boards", model.PortfolioMaxBoards))
+		}
+		return boardIDs, nil
+	}
+
+	return []string{}, nil
+}
+
+// QueryPortfolio returns the cards of the boards as rows with the
+// columns of the query, filtered, sorted, grouped and paginated.
+// Permissions to the boards must be checked by the caller.
+func (a *App) QueryPortfolio(boardIDs []string, query *model.PortfolioQuery) (*model.PortfolioResult, error) {
+	boards := make([]*model.Board, 0, len(boardIDs))
+	for _, boardID := range boardIDs {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+		if board.IsTemplate {
+			continue
+		}
+		boards = append(boards, board)
+	}
+
+	columns := query.Columns
+	if len(columns) == 0 {
+		columns = model.PortfolioColumnsForBoards(boards)
+	}
+
+	rows := []*model.PortfolioRow{}
+	includedBoardIDs := make([]string, 0, len(boards))
+	for _, board := range boards {
+		cards, err := a.GetCardsForBoard(board.ID, 0, 0, false)
+		if err != nil {
+			return nil, err
+		}
+
+		boardCards := make([]*model.Card, 0, len(cards))
+		for _, card := range cards {
+			if !card.IsTemplate {
+				boardCards = append(boardCards, card)
+			}
+		}
+
+		boardRows, err := model.NewPortfolioRows(board, boardCards, columns)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build portfolio rows for board %s: %w", board.ID, err)
+		}
+
+		rows = append(rows, boardRows...)
+		includedBoardIDs = append(includedBoardIDs, board.ID)
+	}
+
+	pageRows, groups, total := model.ApplyPortfolioQuery(rows, query)
+
+	perPage := query.PerPage
+	if perPage == 0 {
+		perPage = model.PortfolioDefaultPerPage
+	}
+
+	return &model.PortfolioResult{
+		BoardIDs: includedBoardIDs,
+		Columns:  columns,
+		Rows:     pageRows,
+		Groups:   groups,
+		Total:    total,
+		Page:     query.Page,
+		PerPage:  perPage,
+		HasNext:  (query.Page+1)*perPage < total,
+	}, nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
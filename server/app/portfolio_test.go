@@ -0,0 +1,136 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestGetPortfolioBoardIDs(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "user_id_1"
+
+	t.Run("explicit boards", func(t *testing.T) {
+		query := &model.PortfolioQuery{BoardIDs: []string{"board_id_1", "board_id_2", "board_id_1"}}
+
+		boardIDs, err := th.App.GetPortfolioBoardIDs(userID, query)
+		require.NoError(t, err)
+		require.Equal(t, []string{"board_id_1", "board_id_2"}, boardIDs)
+	})
+
+	t.Run("category of another user", func(t *testing.T) {
+		th.Store.EXPECT().GetCategory("category_id_1").Return(&model.Category{
+			ID:     "category_id_1",
+			UserID: "user_id_2",
+			TeamID: "team_id_1",
+		}, nil)
+
+		boardIDs, err := th.App.GetPortfolioBoardIDs(userID, &model.PortfolioQuery{CategoryID: "category_id_1"})
+		require.True(t, model.IsErrForbidden(err))
+		require.Nil(t, boardIDs)
+	})
+
+	t.Run("category boards", func(t *testing.T) {
+		category := model.Category{ID: "category_id_2", UserID: userID, TeamID: "team_id_1"}
+		th.Store.EXPECT().GetCategory("category_id_2").Return(&category, nil)
+		th.Store.EXPECT().GetUserCategoryBoards(userID, "team_id_1").Return([]model.CategoryBoards{
+			{
+				Category:      model.Category{ID: "category_id_3"},
+				BoardMetadata: []model.CategoryBoardMetadata{{BoardID: "board_id_3"}},
+			},
+			{
+				Category: category,
+				BoardMetadata: []model.CategoryBoardMetadata{
+					{BoardID: "board_id_2"},
+					{BoardID: "board_id_1", Hidden: true},
+				},
+			},
+		}, nil)
+
+		boardIDs, err := th.App.GetPortfolioBoardIDs(userID, &model.PortfolioQuery{CategoryID: "category_id_2"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"board_id_2", "board_id_1"}, boardIDs)
+	})
+}
+
+func TestQueryPortfolio(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board1 := &model.Board{
+		ID:    "board_id_1",
+		Title: "Team A",
+		CardProperties: []map[string]interface{}{
+			{"id": "status_1", "name": "Status", "type": "text"},
+		},
+	}
+	board2 := &model.Board{
+		ID:    "board_id_2",
+		Title: "Team B",
+		CardProperties: []map[string]interface{}{
+			{"id": "status_2", "name": "status", "type": "text"},
+			{"id": "estimate_2", "name": "Estimate", "type": "number"},
+		},
+	}
+	templateBoard := &model.Board{ID: "board_id_3", IsTemplate: true}
+
+	newCardBlock := func(id, boardID, title string, properties map[string]interface{}) *model.Block {
+		return &model.Block{
+			ID:       id,
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields:   map[string]interface{}{"properties": properties},
+		}
+	}
+
+	th.Store.EXPECT().GetBoard("board_id_1").Return(board1, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard("board_id_2").Return(board2, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard("board_id_3").Return(templateBoard, nil).AnyTimes()
+	th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{BoardID: "board_id_1", BlockType: model.TypeCard, ExcludeArchived: true}).Return([]*model.Block{
+		newCardBlock("card_id_1", "board_id_1", "Epic 1", map[string]interface{}{"status_1": "Done"}),
+		newCardBlock("card_id_2", "board_id_1", "Epic 2", map[string]interface{}{"status_1": "In progress"}),
+	}, nil).AnyTimes()
+	th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{BoardID: "board_id_2", BlockType: model.TypeCard, ExcludeArchived: true}).Return([]*model.Block{
+		newCardBlock("card_id_3", "board_id_2", "Epic 3", map[string]interface{}{"status_2": "Done", "estimate_2": "5"}),
+	}, nil).AnyTimes()
+
+	t.Run("common columns", func(t *testing.T) {
+		query := &model.PortfolioQuery{BoardIDs: []string{"board_id_1", "board_id_2", "board_id_3"}}
+
+		result, err := th.App.QueryPortfolio(query.BoardIDs, query)
+		require.NoError(t, err)
+		require.Equal(t, []string{"board_id_1", "board_id_2"}, result.BoardIDs)
+		require.Equal(t, []model.PortfolioColumn{{Name: "Status", Type: "text"}}, result.Columns)
+		require.Equal(t, 3, result.Total)
+		require.Len(t, result.Rows, 3)
+		require.Equal(t, "Done", result.Rows[2].Values["Status"])
+		require.Equal(t, "Team B", result.Rows[2].BoardTitle)
+		require.False(t, result.HasNext)
+	})
+
+	t.Run("filter, sort and paginate", func(t *testing.T) {
+		query := &model.PortfolioQuery{
+			BoardIDs: []string{"board_id_1", "board_id_2"},
+			Columns:  []model.PortfolioColumn{{Name: "Status"}, {Name: "Estimate"}},
+			Filters: []model.PortfolioFilter{
+				{Column: "Status", Condition: model.PortfolioFilterIncludes, Values: []string{"done"}},
+			},
+			Sort:    []model.PortfolioSort{{Column: model.PortfolioColumnTitle, Reversed: true}},
+			PerPage: 1,
+		}
+
+		result, err := th.App.QueryPortfolio(query.BoardIDs, query)
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Total)
+		require.True(t, result.HasNext)
+		require.Len(t, result.Rows, 1)
+		require.Equal(t, "card_id_3", result.Rows[0].CardID)
+		require.Equal(t, "5", result.Rows[0].Values["Estimate"])
+	})
+}
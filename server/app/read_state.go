@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// MarkBoardViewed records that the user viewed the board now, and
+// returns the unread counts of the board for the user.
+func (a *App) MarkBoardViewed(boardID, userID string) (*model.BoardUnreadCounts, error) {
+	readState := &model.ReadState{
+		UserID:   userID,
+		BoardID:  boardID,
+		ViewedAt: utils.GetMillis(),
+	}
+
+	if err := a.store.SaveReadState(readState); err != nil {
+		return nil, err
+	}
+
+	return a.GetBoardUnreadCounts(boardID, userID)
+}
+
+// MarkCardViewed records that the user viewed the card now, and returns
+// the unread counts of the card's board for the user.
+func (a *App) MarkCardViewed(cardID, userID string) (*model.BoardUnreadCounts, error) {
+	card, err := a.getCardBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	readState := &model.ReadState{
+		UserID:   userID,
+		BoardID:  card.BoardID,
+		CardID:   card.ID,
+		ViewedAt: utils.GetMillis(),
+	}
+
+	if err := a.store.SaveReadState(readState); err != nil {
+		return nil, err
+	}
+
+	return a.GetBoardUnreadCounts(card.BoardID, userID)
+}
+
+// GetBoardUnreadCounts returns the number of changes made by other
+// users to the board and to its cards since the user last viewed them.
+func (a *App) GetBoardUnreadCounts(boardID, userID string) (*model.BoardUnreadCounts, error) {
+	boardCounts, err := a.store.GetBoardUnreadCounts(userID, []string{boardID})
+	if err != nil {
+		return nil, err
+	}
+
+	cardCounts, err := a.store.GetCardUnreadCounts(userID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BoardUnreadCounts{
+		BoardID:     boardID,
+		UnreadCount: boardCounts[boardID],
+		Cards:       cardCounts,
+	}, nil
+}
+
+// SetCategoryBoardsUnreadCounts fills the unread count of every board
+// in the user's categories.
+func (a *App) SetCategoryBoardsUnreadCounts(userID string, categoryBoards []model.CategoryBoards) error {
+	boardIDs := []string{}
+	for _, categoryBoard := range categoryBoards {
+		for _, boardMetadata := range categoryBoard.BoardMetadata {
+			boardIDs = append(boardIDs, boardMetadata.BoardID)
+		}
+	}
+
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	unreadCounts, err := a.store.GetBoardUnreadCounts(userID, boardIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range categoryBoards {
+		for j := range categoryBoards[i].BoardMetadata {
+			boardMetadata := &categoryBoards[i].BoardMetadata[j]
+			boardMetadata.UnreadCount = unreadCounts[boardMetadata.BoardID]
+		}
+	}
+
+	return nil
+}
+
+// unreadCountsBroadcastDelay is how long the changes to a board are
+// collected before its unread counts are sent to the members, so a
+// burst of changes costs a single round of counts.
+var unreadCountsBroadcastDelay = 2 * time.Second
+
+// unreadCountsChange holds the changes to a board whose unread counts
+// are waiting to be broadcast.
+type unreadCountsChange struct {
+	teamID        string
+	modifiedByIDs map[string]bool
+	timer         *time.Timer
+}
+
+// broadcastUnreadCountsChange schedules the broadcast of the unread
+// counts of a board to its members. The changes made to the board
+// within unreadCountsBroadcastDelay are sent together.
+func (a *App) broadcastUnreadCountsChange(teamID, boardID, modifiedByID string) {
+	a.unreadCountsMux.Lock()
+	defer a.unreadCountsMux.Unlock()
+
+	if change, ok := a.unreadCountsChanges[boardID]; ok {
+		change.modifiedByIDs[modifiedByID] = true
+		return
+	}
+
+	change := &unreadCountsChange{
+		teamID:        teamID,
+		modifiedByIDs: map[string]bool{modifiedByID: true},
+	}
+	change.timer = time.AfterFunc(unreadCountsBroadcastDelay, func() {
+		a.unreadCountsMux.Lock()
+		delete(a.unreadCountsChanges, boardID)
+		a.unreadCountsMux.Unlock()
+
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.sendUnreadCounts(boardID, change)
+			return nil
+		})
+	})
+	a.unreadCountsChanges[boardID] = change
+}
+
+// flushUnreadCountsChanges sends the pending unread counts without
+// waiting for their delay. It is called on shutdown, once the block
+// change notifier queue is drained.
+func (a *App) flushUnreadCountsChanges() {
+	a.unreadCountsMux.Lock()
+	changes := a.unreadCountsChanges
+	a.unreadCountsChanges = map[string]*unreadCountsChange{}
+	a.unreadCountsMux.Unlock()
+
+	for boardID, change := range changes {
+		if change.timer.Stop() {
+			a.sendUnreadCounts(boardID, change)
+		}
+	}
+}
+
+// sendUnreadCounts sends the unread counts of a board to its members,
+// except for the user that made all the changes.
+func (a *App) sendUnreadCounts(boardID string, change *unreadCountsChange) {
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		a.logger.Error("Unable to get board members to broadcast unread counts",
+			mlog.String("boardID", boardID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	for _, member := range members {
+		if len(change.modifiedByIDs) == 1 && change.modifiedByIDs[member.UserID] {
+			continue
+		}
+
+		unreadCounts, err := a.GetBoardUnreadCounts(boardID, member.UserID)
+		if err != nil {
+			a.logger.Error("Unable to get unread counts to broadcast",
+				mlog.String("boardID", boardID),
+				mlog.String("userID", member.UserID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.wsAdapter.BroadcastUnreadCountsChange(change.teamID, member.UserID, unreadCounts)
+	}
+}
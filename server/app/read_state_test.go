@@ -0,0 +1,120 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestMarkBoardViewed(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().SaveReadState(gomock.Any()).DoAndReturn(func(readState *model.ReadState) error {
+		require.Equal(t, "user_id_1", readState.UserID)
+		require.Equal(t, "board_id_1", readState.BoardID)
+		require.Empty(t, readState.CardID)
+		require.Greater(t, readState.ViewedAt, int64(0))
+		return nil
+	})
+	th.Store.EXPECT().GetBoardUnreadCounts("user_id_1", []string{"board_id_1"}).Return(map[string]int{}, nil)
+	th.Store.EXPECT().GetCardUnreadCounts("user_id_1", "board_id_1").Return(map[string]int{}, nil)
+
+	unreadCounts, err := th.App.MarkBoardViewed("board_id_1", "user_id_1")
+	require.NoError(t, err)
+	require.Equal(t, "board_id_1", unreadCounts.BoardID)
+	require.Zero(t, unreadCounts.UnreadCount)
+	require.Empty(t, unreadCounts.Cards)
+}
+
+func TestMarkCardViewed(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id_1").Return(&model.Block{ID: "view_id_1", BoardID: "board_id_1", Type: model.TypeView}, nil)
+
+		unreadCounts, err := th.App.MarkCardViewed("view_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, unreadCounts)
+	})
+
+	t.Run("mark a card viewed", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", BoardID: "board_id_1", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().SaveReadState(gomock.Any()).DoAndReturn(func(readState *model.ReadState) error {
+			require.Equal(t, "board_id_1", readState.BoardID)
+			require.Equal(t, "card_id_1", readState.CardID)
+			return nil
+		})
+		th.Store.EXPECT().GetBoardUnreadCounts("user_id_1", []string{"board_id_1"}).Return(map[string]int{"board_id_1": 3}, nil)
+		th.Store.EXPECT().GetCardUnreadCounts("user_id_1", "board_id_1").Return(map[string]int{"card_id_2": 1}, nil)
+
+		unreadCounts, err := th.App.MarkCardViewed("card_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, 3, unreadCounts.UnreadCount)
+		require.Equal(t, map[string]int{"card_id_2": 1}, unreadCounts.Cards)
+	})
+}
+
+func TestSetCategoryBoardsUnreadCounts(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("no boards", func(t *testing.T) {
+		err := th.App.SetCategoryBoardsUnreadCounts("user_id_1", []model.CategoryBoards{{}})
+		require.NoError(t, err)
+	})
+
+	t.Run("boards in several categories", func(t *testing.T) {
+		categoryBoards := []model.CategoryBoards{
+			{BoardMetadata: []model.CategoryBoardMetadata{{BoardID: "board_id_1"}, {BoardID: "board_id_2"}}},
+			{BoardMetadata: []model.CategoryBoardMetadata{{BoardID: "board_id_3"}}},
+		}
+
+		th.Store.EXPECT().GetBoardUnreadCounts("user_id_1", []string{"board_id_1", "board_id_2", "board_id_3"}).
+			Return(map[string]int{"board_id_1": 2, "board_id_3": 5}, nil)
+
+		err := th.App.SetCategoryBoardsUnreadCounts("user_id_1", categoryBoards)
+		require.NoError(t, err)
+		require.Equal(t, 2, categoryBoards[0].BoardMetadata[0].UnreadCount)
+		require.Zero(t, categoryBoards[0].BoardMetadata[1].UnreadCount)
+		require.Equal(t, 5, categoryBoards[1].BoardMetadata[0].UnreadCount)
+	})
+}
+
+func TestBroadcastUnreadCountsChange(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("changes to a board are sent together", func(t *testing.T) {
+		th.App.broadcastUnreadCountsChange("team_id_1", "board_id_1", "user_id_1")
+		th.App.broadcastUnreadCountsChange("team_id_1", "board_id_1", "user_id_2")
+		th.App.broadcastUnreadCountsChange("team_id_1", "board_id_2", "user_id_1")
+
+		th.App.unreadCountsMux.Lock()
+		defer th.App.unreadCountsMux.Unlock()
+		require.Len(t, th.App.unreadCountsChanges, 2)
+		require.Equal(t, map[string]bool{"user_id_1": true, "user_id_2": true}, th.App.unreadCountsChanges["board_id_1"].modifiedByIDs)
+		for _, change := range th.App.unreadCountsChanges {
+			change.timer.Stop()
+		}
+		th.App.unreadCountsChanges = map[string]*unreadCountsChange{}
+	})
+
+	t.Run("the user that made all the changes isn't sent the counts", func(t *testing.T) {
+		th.Store.EXPECT().GetMembersForBoard("board_id_1").Return([]*model.BoardMember{
+			{BoardID: "board_id_1", UserID: "user_id_1"},
+			{BoardID: "board_id_1", UserID: "user_id_2"},
+		}, nil)
+		th.Store.EXPECT().GetBoardUnreadCounts("user_id_2", []string{"board_id_1"}).Return(map[string]int{"board_id_1": 1}, nil)
+		th.Store.EXPECT().GetCardUnreadCounts("user_id_2", "board_id_1").Return(map[string]int{"card_id_1": 1}, nil)
+
+		th.App.sendUnreadCounts("board_id_1", &unreadCountsChange{
+			teamID:        "team_id_1",
+			modifiedByIDs: map[string]bool{"user_id_1": true},
+		})
+	})
+}
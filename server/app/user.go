@@ -6,11 +6,13 @@ import (
 )
 
 func (a *App) GetTeamUsers(teamID string, asGuestID string) ([]*model.User, error) {
-	return a.store.GetUsersByTeam(teamID, asGuestID, a.config.ShowEmailAddress, a.config.ShowFullName)
+	cfg := a.GetConfig()
+	return a.store.GetUsersByTeam(teamID, asGuestID, cfg.ShowEmailAddress, cfg.ShowFullName)
 }
 
 func (a *App) SearchTeamUsers(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
-	users, err := a.store.SearchUsersByTeam(teamID, searchQuery, asGuestID, excludeBots, a.config.ShowEmailAddress, a.config.ShowFullName)
+	cfg := a.GetConfig()
+	users, err := a.store.SearchUsersByTeam(teamID, searchQuery, asGuestID, excludeBots, cfg.ShowEmailAddress, cfg.ShowFullName)
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +89,9 @@ func (a *App) SanitizeProfile(user *model.User, isAdmin bool) {
 		options["fullname"] = true
 		options["email"] = true
 	} else {
-		options["fullname"] = a.config.ShowFullName
-		options["email"] = a.config.ShowEmailAddress
+		cfg := a.GetConfig()
+		options["fullname"] = cfg.ShowFullName
+		options["email"] = cfg.ShowEmailAddress
 	}
 	user.Sanitize(options)
 }
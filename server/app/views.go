@@ -0,0 +1,276 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetViewsForBoard returns the views of a board that are visible to the
+// user, that is, the shared views and the private views of the user.
+func (a *App) GetViewsForBoard(boardID, userID string) ([]*model.View, error) {
+	blocks, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   boardID,
+		BlockType: model.TypeView,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks2Views(model.FilterPrivateViews(blocks, userID))
+}
+
+func blocks2Views(blocks []*model.Block) ([]*model.View, error) {
+	views := make([]*model.View, 0, len(blocks))
+	for _, block := range blocks {
+		view, err := model.Block2View(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2View fail: %w", err)
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// GetViewByID returns the view with the given ID.
+func (a *App) GetViewByID(viewID string) (*model.View, error) {
+	block, err := a.store.GetBlock(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != model.TypeView {
+		return nil, model.NewErrNotFound("view ID=" + viewID)
+	}
+
+	return model.Block2View(block)
+}
+
+// CreateView adds a view to a board. Views with an owner are private to
+// that user. References to properties that are not part of the property
+// schema of the board are removed.
+func (a *App) CreateView(view *model.View, boardID string, userID string) (*model.View, error) {
+	if view.OwnerID != "" && view.OwnerID != userID {
+		return nil, model.NewErrBadRequest("private views can only be owned by their creator")
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	view.ID = ""
+	return a.insertView(view, board, userID)
+}
+
+// PatchView modifies a view. References to properties that are not
+// part of the property schema of the board are removed.
+func (a *App) PatchView(viewID string, patch *model.ViewPatch, userID string) (*model.View, error) {
+	view, err := a.GetViewByID(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(view.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	view = patch.Patch(view)
+	if _, err = view.CleanForBoard(board); err != nil {
+		return nil, err
+	}
+
+	if err = view.IsValid(); err != nil {
+		return nil, err
+	}
+
+	blockPatch, err := model.View2BlockPatch(view)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := a.PatchBlockAndNotify(viewID, blockPatch, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch view %s: %w", viewID, err)
+	}
+
+	return model.Block2View(block)
+}
+
+// DeleteView deletes a view.
+func (a *App) DeleteView(viewID string, userID string) error {
+	if _, err := a.GetViewByID(viewID); err != nil {
+		return err
+	}
+
+	return a.DeleteBlockAndNotify(viewID, userID, false)
+}
+
+// ShareView turns a private view of the user into a view shared with
+// everyone on the board.
+func (a *App) ShareView(viewID string, userID string) (*model.View, error) {
+	view, err := a.GetViewByID(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.OwnerID == "" {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("view %s is already shared", viewID))
+	}
+	if view.OwnerID != userID {
+		return nil, model.NewErrPermission("only the owner of a private view can share it")
+	}
+
+	board, err := a.store.GetBoard(view.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := model.NewShareViewPatch()
+	if err = a.store.PatchBlock(viewID, &patch, userID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.store.GetBlock(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.webhook.NotifyUpdate(block)
+		return nil
+	})
+
+	return model.Block2View(block)
+}
+
+// DuplicateView copies a view to a board, which can be its own board.
+// Copies of private views are private too. When copied to another
+// board, the properties and options the view refers to are matched by
+// name in the property schema of the other board.
+func (a *App) DuplicateView(viewID string, boardID string, userID string) (*model.View, error) {
+	view, err := a.GetViewByID(viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(view.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if boardID != "" && boardID != board.ID {
+		sourceBoard := board
+		board, err = a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = model.MapViewToBoard(view, sourceBoard, board); err != nil {
+			return nil, err
+		}
+	}
+
+	view.ID = ""
+	return a.insertView(view, board, userID)
+}
+
+func (a *App) insertView(view *model.View, board *model.Board, userID string) (*model.View, error) {
+	now := utils.GetMillis()
+	view.ID = utils.NewID(utils.IDTypeView)
+	view.BoardID = board.ID
+	view.CreatedBy = userID
+	view.ModifiedBy = userID
+	view.CreateAt = now
+	view.UpdateAt = now
+	view.DeleteAt = 0
+	view.Populate()
+
+	if _, err := view.CleanForBoard(board); err != nil {
+		return nil, err
+	}
+
+	if err := view.IsValid(); err != nil {
+		return nil, err
+	}
+
+	block, err := model.View2Block(view)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.InsertBlockAndNotify(block, userID, false); err != nil {
+		return nil, fmt.Errorf("cannot create view: %w", err)
+	}
+
+	return model.Block2View(block)
+}
+
+// cleanViewsForBoard removes the references to deleted properties and
+// options from the views of a board, after its property schema changed.
+func (a *App) cleanViewsForBoard(board *model.Board, userID string) error {
+	blocks, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   board.ID,
+		BlockType: model.TypeView,
+	})
+	if err != nil {
+		return err
+	}
+
+	views, err := blocks2Views(blocks)
+	if err != nil {
+		return err
+	}
+
+	updatedBlocks := []*model.Block{}
+	for _, view := range views {
+		changed, err := view.CleanForBoard(board)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		blockPatch, err := model.View2BlockPatch(view)
+		if err != nil {
+			return err
+		}
+
+		if err = a.store.PatchBlock(view.ID, blockPatch, userID); err != nil {
+			return err
+		}
+
+		block, err := a.store.GetBlock(view.ID)
+		if err != nil {
+			return err
+		}
+		updatedBlocks = append(updatedBlocks, block)
+	}
+
+	if len(updatedBlocks) == 0 {
+		return nil
+	}
+
+	a.logger.Debug("Cleaned views after a property schema change",
+		mlog.String("boardID", board.ID),
+		mlog.Int("viewCount", len(updatedBlocks)),
+	)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range updatedBlocks {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		}
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestViewBoard(id string, propertyIDs ...string) *model.Board {
+	board := &model.Board{ID: id, TeamID: "team_id_1", CardProperties: []map[string]interface{}{}}
+	for _, propertyID := range propertyIDs {
+		board.CardProperties = append(board.CardProperties, map[string]interface{}{
+			"id":   propertyID,
+			"name": "name " + propertyID,
+			"type": "text",
+		})
+	}
+	return board
+}
+
+func newTestViewBlock(id, boardID string, fields map[string]interface{}) *model.Block {
+	return &model.Block{
+		ID:       id,
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeView,
+		Title:    "view " + id,
+		Fields:   fields,
+	}
+}
+
+func TestCreateView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := newTestViewBoard("board_id_1", "prop_id_1")
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	t.Run("invalid view", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board_id_1").Return(board, nil)
+
+		view := &model.View{ViewFields: model.ViewFields{ViewType: "list"}}
+		newView, err := th.App.CreateView(view, "board_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, newView)
+	})
+
+	t.Run("private view of another user", func(t *testing.T) {
+		view := &model.View{OwnerID: "user_id_2", ViewFields: model.ViewFields{ViewType: model.ViewTypeTable}}
+		newView, err := th.App.CreateView(view, "board_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, newView)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board_id_1").Return(board, nil).Times(2)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user_id_1").DoAndReturn(
+			func(block *model.Block, userID string) error {
+				require.EqualValues(t, model.TypeView, block.Type)
+				require.Equal(t, "board_id_1", block.ParentID)
+				require.Equal(t, []interface{}{"prop_id_1"}, block.Fields["visiblePropertyIds"])
+				return nil
+			})
+
+		view := &model.View{
+			ID:    "view_id_1",
+			Title: "my view",
+			ViewFields: model.ViewFields{
+				ViewType:           model.ViewTypeTable,
+				VisiblePropertyIDs: []string{"prop_id_1", "deleted_prop_id"},
+			},
+		}
+		newView, err := th.App.CreateView(view, "board_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.NotEqual(t, "view_id_1", newView.ID)
+		require.Equal(t, "board_id_1", newView.BoardID)
+		require.Equal(t, "user_id_1", newView.CreatedBy)
+		require.Equal(t, []string{"prop_id_1"}, newView.VisiblePropertyIDs)
+	})
+}
+
+func TestGetViewsForBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	blocks := []*model.Block{
+		newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{"viewType": "board"}),
+		newTestViewBlock("view_id_2", "board_id_1", map[string]interface{}{"viewType": "table", "ownerId": "user_id_1"}),
+		newTestViewBlock("view_id_3", "board_id_1", map[string]interface{}{"viewType": "table", "ownerId": "user_id_2"}),
+	}
+	opts := model.QueryBlocksOptions{BoardID: "board_id_1", BlockType: model.TypeView}
+	th.Store.EXPECT().GetBlocks(opts).Return(blocks, nil)
+
+	views, err := th.App.GetViewsForBoard("board_id_1", "user_id_1")
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	require.Equal(t, "view_id_1", views[0].ID)
+	require.Empty(t, views[0].OwnerID)
+	require.Equal(t, "view_id_2", views[1].ID)
+	require.Equal(t, "user_id_1", views[1].OwnerID)
+}
+
+func TestShareView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := newTestViewBoard("board_id_1")
+
+	t.Run("shared view", func(t *testing.T) {
+		block := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{"viewType": "board"})
+		th.Store.EXPECT().GetBlock("view_id_1").Return(block, nil)
+
+		view, err := th.App.ShareView("view_id_1", "user_id_1")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, view)
+	})
+
+	t.Run("private view of another user", func(t *testing.T) {
+		block := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{"viewType": "board", "ownerId": "user_id_2"})
+		th.Store.EXPECT().GetBlock("view_id_1").Return(block, nil)
+
+		view, err := th.App.ShareView("view_id_1", "user_id_1")
+		var errPermission *model.ErrPermission
+		require.ErrorAs(t, err, &errPermission)
+		require.Nil(t, view)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		block := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{"viewType": "board", "ownerId": "user_id_1"})
+		shared := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{"viewType": "board"})
+
+		th.Store.EXPECT().GetBlock("view_id_1").Return(block, nil)
+		th.Store.EXPECT().GetBoard("board_id_1").Return(board, nil)
+		th.Store.EXPECT().PatchBlock("view_id_1", gomock.Any(), "user_id_1").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, []string{model.ViewFieldOwnerID}, patch.DeletedFields)
+				return nil
+			})
+		th.Store.EXPECT().GetBlock("view_id_1").Return(shared, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		view, err := th.App.ShareView("view_id_1", "user_id_1")
+		require.NoError(t, err)
+		require.Empty(t, view.OwnerID)
+	})
+}
+
+func TestPatchView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := newTestViewBoard("board_id_1", "prop_id_1")
+
+	t.Run("not a view", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card_id_1").Return(&model.Block{ID: "card_id_1", Type: model.TypeCard}, nil)
+
+		view, err := th.App.PatchView("card_id_1", &model.ViewPatch{}, "user_id_1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, view)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		block := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{
+			"viewType":    "board",
+			"hiddenField": "kept",
+		})
+		groupByID := "prop_id_1"
+		patched := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{
+			"viewType":  "board",
+			"groupById": groupByID,
+		})
+
+		th.Store.EXPECT().GetBlock("view_id_1").Return(block, nil).Times(2)
+		th.Store.EXPECT().GetBoard("board_id_1").Return(board, nil).Times(2)
+		th.Store.EXPECT().PatchBlock("view_id_1", gomock.Any(), "user_id_1").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, groupByID, patch.UpdatedFields["groupById"])
+				require.NotContains(t, patch.UpdatedFields, "hiddenField")
+				require.Empty(t, patch.DeletedFields)
+				return nil
+			})
+		th.Store.EXPECT().GetBlock("view_id_1").Return(patched, nil)
+
+		// for WS broadcasts
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+		view, err := th.App.PatchView("view_id_1", &model.ViewPatch{GroupByID: &groupByID}, "user_id_1")
+		require.NoError(t, err)
+		require.Equal(t, groupByID, view.GroupByID)
+	})
+}
+
+func TestDuplicateView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	source := newTestViewBoard("board_id_1", "prop_id_1")
+	dest := newTestViewBoard("board_id_2", "prop_id_2")
+	dest.CardProperties[0]["name"] = "name prop_id_1"
+
+	block := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{
+		"viewType":           "table",
+		"visiblePropertyIds": []interface{}{"prop_id_1"},
+		"cardOrder":          []interface{}{"card_id_1"},
+	})
+
+	th.Store.EXPECT().GetBlock("view_id_1").Return(block, nil)
+	th.Store.EXPECT().GetBoard("board_id_1").Return(source, nil)
+	th.Store.EXPECT().GetBoard("board_id_2").Return(dest, nil).Times(2)
+	th.Store.EXPECT().InsertBlock(gomock.Any(), "user_id_1").Return(nil)
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardMirrorsForCard(gomock.Any()).Return([]*model.CardMirror{}, nil).AnyTimes()
+
+	view, err := th.App.DuplicateView("view_id_1", "board_id_2", "user_id_1")
+	require.NoError(t, err)
+	require.NotEqual(t, "view_id_1", view.ID)
+	require.Equal(t, "board_id_2", view.BoardID)
+	require.Equal(t, []string{"prop_id_2"}, view.VisiblePropertyIDs)
+	require.Empty(t, view.CardOrder)
+}
+
+func TestCleanViewsForBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := newTestViewBoard("board_id_1", "prop_id_1")
+	clean := newTestViewBlock("view_id_1", "board_id_1", map[string]interface{}{
+		"visiblePropertyIds": []interface{}{"prop_id_1"},
+	})
+	outdated := newTestViewBlock("view_id_2", "board_id_1", map[string]interface{}{
+		"visiblePropertyIds": []interface{}{"prop_id_1", "prop_id_2"},
+	})
+
+	th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{
+		BoardID:   "board_id_1",
+		BlockType: model.TypeView,
+	}).Return([]*model.Block{clean, outdated}, nil)
+	th.Store.EXPECT().PatchBlock("view_id_2", gomock.Any(), "user_id_1").DoAndReturn(
+		func(blockID string, patch *model.BlockPatch, userID string) error {
+			require.Equal(t, []interface{}{"prop_id_1"}, patch.UpdatedFields["visiblePropertyIds"])
+			return nil
+		})
+	th.Store.EXPECT().GetBlock("view_id_2").Return(outdated, nil)
+
+	// for WS broadcasts
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	require.NoError(t, th.App.cleanViewsForBoard(board, "user_id_1"))
+}
@@ -2,6 +2,8 @@
 package auth
 
 import (
+	"sync"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -18,6 +20,7 @@ type AuthInterface interface {
 
 // Auth authenticates sessions.
 type Auth struct {
+	configMux   sync.RWMutex
 	config      *config.Configuration
 	store       store.Store
 	permissions permissions.PermissionsService
@@ -28,17 +31,31 @@ func New(config *config.Configuration, store store.Store, permissions permission
 	return &Auth{config: config, store: store, permissions: permissions}
 }
 
+// SetConfig replaces the configuration used to authenticate sessions.
+func (a *Auth) SetConfig(config *config.Configuration) {
+	a.configMux.Lock()
+	defer a.configMux.Unlock()
+	a.config = config
+}
+
+func (a *Auth) getConfig() *config.Configuration {
+	a.configMux.RLock()
+	defer a.configMux.RUnlock()
+	return a.config
+}
+
 // GetSession Get a user active session and refresh the session if needed.
 func (a *Auth) GetSession(token string) (*model.Session, error) {
 	if len(token) < 1 {
 		return nil, errors.New("no session token")
 	}
 
-	session, err := a.store.GetSession(token, a.config.SessionExpireTime)
+	cfg := a.getConfig()
+	session, err := a.store.GetSession(token, cfg.SessionExpireTime)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get the session for the token")
 	}
-	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
+	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(cfg.SessionRefreshTime)) {
 		_ = a.store.RefreshSession(session)
 	}
 	return session, nil
@@ -54,7 +71,7 @@ func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error)
 		return false, err
 	}
 
-	if !a.config.EnablePublicSharedBoards {
+	if !a.getConfig().EnablePublicSharedBoards {
 		return false, errors.New("public shared boards disabled")
 	}
 
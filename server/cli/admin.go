@@ -0,0 +1,342 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	serverconfig "github.com/mattermost/focalboard/server/services/config"
+)
+
+const defaultSocketPath = "/var/tmp/focalboard_local.socket"
+
+var errIntegrity = errors.New("the data integrity checks found problems")
+
+// adminFlagSet returns the flag set of an admin command, which talks to
+// the local mode socket instead of a server URL.
+func (a *app) adminFlagSet(name string) *flag.FlagSet {
+	fs := a.newFlagSet(name)
+	fs.StringVar(&a.socketPath, "socket", "", "the local mode socket of the server")
+	fs.BoolVar(&a.json, "json", false, "print JSON")
+	return fs
+}
+
+// localClient returns a client for the local mode socket of the flags,
+// which can be overridden by the environment.
+func (a *app) localClient() *client.Client {
+	socketPath := firstNonEmpty(a.socketPath, os.Getenv("FOCALBOARD_SOCKET"), defaultSocketPath)
+	return client.NewLocalClient(socketPath)
+}
+
+func (a *app) adminCreateUser(args []string) error {
+	fs := a.adminFlagSet("admin users create")
+	username := fs.String("username", "", "the username")
+	email := fs.String("email", "", "the email")
+	password := fs.String("password", "", "the password, defaults to $FOCALBOARD_PASSWORD")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"username": *username, "email": *email}); err != nil {
+		return err
+	}
+
+	pass, err := a.password(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	user, resp := a.localClient().AdminCreateUser(&model.AdminCreateUserRequest{
+		Username: *username,
+		Email:    *email,
+		Password: pass,
+	})
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(user, "Created user %s with ID %s", user.Username, user.ID)
+}
+
+func (a *app) adminListUsers(args []string) error {
+	fs := a.adminFlagSet("admin users list")
+	all := fs.Bool("all", false, "list the deactivated users too")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	users, resp := a.localClient().AdminGetUsers(*all)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(users, []string{"ID", "USERNAME", "CREATED", "DEACTIVATED"}, func(add func(...interface{})) {
+		for _, user := range users {
+			add(user.ID, user.Username, formatTime(user.CreateAt), formatTime(user.DeleteAt))
+		}
+	})
+}
+
+func (a *app) adminDeactivateUser(args []string) error {
+	fs := a.adminFlagSet("admin users deactivate")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	username := positional[0]
+
+	if _, resp := a.localClient().AdminDeactivateUser(username); resp.Error != nil {
+		return check(resp)
+	}
+
+	return a.printMessage(map[string]string{"username": username}, "Deactivated user %s", username)
+}
+
+func (a *app) adminSetPassword(args []string) error {
+	fs := a.adminFlagSet("admin users set-password")
+	password := fs.String("password", "", "the password, defaults to $FOCALBOARD_PASSWORD")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	username := positional[0]
+
+	pass, err := a.password(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	if _, resp := a.localClient().AdminSetPassword(username, pass); resp.Error != nil {
+		return check(resp)
+	}
+
+	return a.printMessage(map[string]string{"username": username}, "Set the password of user %s", username)
+}
+
+func (a *app) adminListTeams(args []string) error {
+	fs := a.adminFlagSet("admin teams list")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	teams, resp := a.localClient().AdminGetTeams()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(teams, []string{"ID", "TITLE", "SIGNUP TOKEN"}, func(add func(...interface{})) {
+		for _, team := range teams {
+			add(team.ID, team.Title, team.SignupToken)
+		}
+	})
+}
+
+func (a *app) adminRegenerateSignupToken(args []string) error {
+	fs := a.adminFlagSet("admin teams regenerate-token")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	team, resp := a.localClient().AdminRegenerateSignupToken(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(team, "The signup token of team %s is now %s", team.ID, team.SignupToken)
+}
+
+func (a *app) adminTransferBoard(args []string) error {
+	fs := a.adminFlagSet("admin boards transfer")
+	userID := fs.String("user", "", "the ID of the new owner")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(map[string]string{"user": *userID}); err != nil {
+		return err
+	}
+
+	board, resp := a.localClient().AdminTransferBoard(positional[0], *userID)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(board, "Board %s is now owned by %s", board.ID, board.CreatedBy)
+}
+
+func (a *app) adminCleanUpSessions(args []string) error {
+	fs := a.adminFlagSet("admin sessions cleanup")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if _, resp := a.localClient().AdminCleanUpSessions(); resp.Error != nil {
+		return check(resp)
+	}
+
+	return a.printMessage(map[string]string{}, "Deleted the expired sessions")
+}
+
+func (a *app) adminRunDataRetention(args []string) error {
+	fs := a.adminFlagSet("admin retention run")
+	days := fs.Int("days", 0, "the number of days after which boards and cards not updated are deleted")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *days <= 0 {
+		return fmt.Errorf("missing --days: %w", errUsage)
+	}
+
+	result, resp := a.localClient().AdminRunDataRetention(*days)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(result, "Deleted %d rows not updated for %d days", result.Count, *days)
+}
+
+func (a *app) adminReindex(args []string) error {
+	fs := a.adminFlagSet("admin reindex")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	result, resp := a.localClient().AdminReindex()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(result, "Indexed the links of %d blocks", result.Count)
+}
+
+func (a *app) adminListJobs(args []string) error {
+	fs := a.adminFlagSet("admin jobs list")
+	jobType := fs.String("type", "", "list only the jobs of a type")
+	status := fs.String("status", "", "list only the jobs with a status: pending, running, success, error or canceled")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	jobs, resp := a.localClient().AdminGetJobs(model.QueryJobsOptions{
+		Type:   *jobType,
+		Status: model.JobStatus(*status),
+	})
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(jobs, []string{"ID", "TYPE", "STATUS", "PROGRESS", "ATTEMPTS", "SCHEDULED", "LAST ERROR"}, func(add func(...interface{})) {
+		for _, job := range jobs {
+			add(job.ID, job.Type, job.Status, fmt.Sprintf("%d%%", job.Progress),
+				fmt.Sprintf("%d/%d", job.Attempts, job.MaxAttempts), formatTime(job.ScheduledAt), job.LastError)
+		}
+	})
+}
+
+func (a *app) adminCancelJob(args []string) error {
+	fs := a.adminFlagSet("admin jobs cancel")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	job, resp := a.localClient().AdminCancelJob(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(job, "Cancelled job %s", job.ID)
+}
+
+func (a *app) adminRetryJob(args []string) error {
+	fs := a.adminFlagSet("admin jobs retry")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	job, resp := a.localClient().AdminRetryJob(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(job, "Scheduled job %s again", job.ID)
+}
+
+// adminValidateConfig checks a configuration file locally, without the
+// server.
+func (a *app) adminValidateConfig(args []string) error {
+	fs := a.adminFlagSet("admin config validate")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	// reading the configuration logs it, secrets included
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	cfg, err := serverconfig.ReadConfigFile(positional[0])
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", positional[0], err)
+	}
+
+	var problems []string
+	var invalidErr *serverconfig.InvalidConfigError
+	if err := cfg.IsValid(); errors.As(err, &invalidErr) {
+		problems = invalidErr.Problems
+	} else if err != nil {
+		return err
+	}
+
+	if a.json {
+		if err := a.printMessage(map[string]interface{}{"valid": len(problems) == 0, "problems": problems}, ""); err != nil {
+			return err
+		}
+	} else if len(problems) == 0 {
+		fmt.Fprintf(a.stdout, "%s is valid\n", positional[0])
+	} else {
+		fmt.Fprintf(a.stdout, "%s is invalid:\n    %s\n", positional[0], strings.Join(problems, "\n    "))
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration")
+	}
+	return nil
+}
+
+func (a *app) adminCheck(args []string) error {
+	fs := a.adminFlagSet("admin check")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	checks, resp := a.localClient().AdminCheckIntegrity()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	err := a.print(checks, []string{"CHECK", "COUNT", "DESCRIPTION", "SAMPLE"}, func(add func(...interface{})) {
+		for _, check := range checks {
+			add(check.Name, check.Count, check.Description, strings.Join(check.SampleIDs, ", "))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, check := range checks {
+		if check.Count > 0 {
+			return errIntegrity
+		}
+	}
+	return nil
+}
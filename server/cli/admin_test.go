@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// newTestSocketServer serves a fake admin API on a unix socket, and
+// returns the path of the socket.
+func newTestSocketServer(t *testing.T, checks []*model.IntegrityCheck) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/admin/integrity", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		writeJSON(t, w, checks)
+	})
+
+	mux.HandleFunc("/api/v2/admin/jobs", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "error", r.URL.Query().Get("status"))
+		writeJSON(t, w, []*model.Job{
+			{ID: "job-id", Type: model.JobTypeUnfreezeBoards, Status: model.JobStatusError, Attempts: 3, MaxAttempts: 3, LastError: "boom"},
+		})
+	})
+
+	socketPath := filepath.Join(t.TempDir(), "focalboard.socket")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(mux)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+	return socketPath
+}
+
+func TestAdminCheck(t *testing.T) {
+	t.Run("no problems", func(t *testing.T) {
+		socketPath := newTestSocketServer(t, []*model.IntegrityCheck{
+			{Name: "blocks_without_board", Description: "blocks of boards that don't exist"},
+		})
+
+		a, stdout, stderr := newTestApp(t)
+		code := a.run([]string{"admin", "check", "--socket", socketPath})
+		require.Equal(t, 0, code, stderr.String())
+		require.Contains(t, stdout.String(), "blocks_without_board")
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		socketPath := newTestSocketServer(t, []*model.IntegrityCheck{
+			{Name: "blocks_without_board", Description: "blocks of boards that don't exist"},
+			{Name: "boards_without_admin", Description: "boards without any admin member", Count: 2, SampleIDs: []string{"b1", "b2"}},
+		})
+
+		a, stdout, stderr := newTestApp(t)
+		t.Setenv("FOCALBOARD_SOCKET", socketPath)
+		code := a.run([]string{"admin", "check"})
+		require.Equal(t, 1, code)
+		require.Contains(t, stderr.String(), errIntegrity.Error())
+		require.Contains(t, stdout.String(), "b1, b2")
+	})
+}
+
+func TestAdminListJobs(t *testing.T) {
+	socketPath := newTestSocketServer(t, nil)
+
+	a, stdout, stderr := newTestApp(t)
+	code := a.run([]string{"admin", "jobs", "list", "--status", "error", "--socket", socketPath})
+	require.Equal(t, 0, code, stderr.String())
+	require.Contains(t, stdout.String(), "unfreeze_boards")
+	require.Contains(t, stdout.String(), "3/3")
+	require.Contains(t, stdout.String(), "boom")
+}
+
+func TestAdminValidateConfig(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		path := writeConfig(t, `{
+			"serverRoot": "http://localhost:8000",
+			"port": 8000,
+			"dbconfig": "./focalboard.db",
+			"session_expire_time": 2592000
+		}`)
+
+		a, stdout, stderr := newTestApp(t)
+		code := a.run([]string{"admin", "config", "validate", path})
+		require.Equal(t, 0, code, stderr.String())
+		require.Contains(t, stdout.String(), "is valid")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		path := writeConfig(t, `{"serverRoot": "localhost", "dbtype": "oracle"}`)
+
+		a, stdout, _ := newTestApp(t)
+		code := a.run([]string{"admin", "config", "validate", "--json", path})
+		require.Equal(t, 1, code)
+		require.Contains(t, stdout.String(), `"valid": false`)
+		require.Contains(t, stdout.String(), `dbtype \"oracle\"`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		code := a.run([]string{"admin", "config", "validate", filepath.Join(t.TempDir(), "missing.json")})
+		require.Equal(t, 1, code)
+		require.Contains(t, stderr.String(), "cannot read")
+	})
+}
+
+func TestAdminUsage(t *testing.T) {
+	a, _, stderr := newTestApp(t)
+	require.Equal(t, 2, a.run([]string{"admin", "users"}))
+	require.Contains(t, stderr.String(), "admin users create --username NAME")
+
+	a, _, stderr = newTestApp(t)
+	require.Equal(t, 2, a.run([]string{"admin", "retention", "run"}))
+	require.Contains(t, stderr.String(), "missing --days")
+}
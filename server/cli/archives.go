@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io"
+	"os"
+)
+
+func (a *app) exportArchive(args []string) error {
+	fs := a.flagSet("export")
+	boardID := fs.String("board", "", "the ID of the board")
+	out := fs.String("out", "", "the archive file, defaults to BOARD.boardarchive")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	archive, resp := c.ExportBoardArchive(*boardID)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	if *out == "-" {
+		return writeAll(a.stdout, archive)
+	}
+
+	path := firstNonEmpty(*out, *boardID+".boardarchive")
+	if err := os.WriteFile(path, archive, 0600); err != nil {
+		return err
+	}
+
+	result := map[string]string{"boardId": *boardID, "file": path}
+	return a.printMessage(result, "Exported board %s to %s", *boardID, path)
+}
+
+func (a *app) importArchive(args []string) error {
+	fs := a.flagSet("import")
+	teamID := fs.String("team", "", "the ID of the team")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(map[string]string{"team": *teamID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	path := positional[0]
+	var archive io.Reader = a.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		archive = f
+	}
+
+	if err := check(c.ImportArchive(*teamID, archive)); err != nil {
+		return err
+	}
+
+	result := map[string]string{"teamId": *teamID, "file": path}
+	return a.printMessage(result, "Imported %s to team %s", path, *teamID)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *app) login(args []string) error {
+	fs := a.flagSet("login")
+	username := fs.String("username", "", "the username")
+	password := fs.String("password", "", "the password, defaults to $FOCALBOARD_PASSWORD")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	mfaToken := fs.String("mfa-token", "", "the multi-factor authentication token")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	// without a username, the token is checked and saved as is, which
+	// is how personal access tokens are used
+	switch {
+	case *username == "" && c.Token == "":
+		return fmt.Errorf("missing --username or --token: %w", errUsage)
+	case *username != "":
+		pass, err := a.password(*password, *passwordStdin)
+		if err != nil {
+			return err
+		}
+
+		loginResponse, resp := c.Login(&model.LoginRequest{
+			Type:     "normal",
+			Username: *username,
+			Password: pass,
+			MfaToken: *mfaToken,
+		})
+		if err := check(resp); err != nil {
+			return err
+		}
+		if loginResponse == nil || loginResponse.Token == "" {
+			return errors.New("the server didn't return a session")
+		}
+	}
+
+	me, resp := c.GetMe()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	if err := a.saveConfig(&config{Server: c.URL, Token: c.Token}); err != nil {
+		return err
+	}
+
+	return a.printMessage(me, "Logged in to %s as %s", c.URL, me.Username)
+}
+
+func (a *app) logout(args []string) error {
+	fs := a.flagSet("logout")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	cfg.Token = ""
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+
+	return a.printMessage(cfg, "Logged out")
+}
+
+func (a *app) whoami(args []string) error {
+	fs := a.flagSet("whoami")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	me, resp := c.GetMe()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(me, []string{"ID", "USERNAME", "NICKNAME"}, func(add func(...interface{})) {
+		add(me.ID, me.Username, me.Nickname)
+	})
+}
+
+// password returns the password of the flags, read from stdin with
+// --password-stdin, or else from $FOCALBOARD_PASSWORD.
+func (a *app) password(flagValue string, fromStdin bool) (string, error) {
+	pass := firstNonEmpty(flagValue, os.Getenv("FOCALBOARD_PASSWORD"))
+	if fromStdin {
+		line, err := bufio.NewReader(a.stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("cannot read the password: %w", err)
+		}
+		pass = strings.TrimRight(line, "\r\n")
+	}
+	if pass == "" {
+		return "", fmt.Errorf("missing --password or --password-stdin: %w", errUsage)
+	}
+	return pass, nil
+}
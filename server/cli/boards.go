@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *app) listTeams(args []string) error {
+	fs := a.flagSet("teams")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	teams, resp := c.GetTeams()
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(teams, []string{"ID", "TITLE"}, func(add func(...interface{})) {
+		for _, team := range teams {
+			add(team.ID, team.Title)
+		}
+	})
+}
+
+func (a *app) listBoards(args []string) error {
+	fs := a.flagSet("boards list")
+	teamID := fs.String("team", "", "the ID of the team")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"team": *teamID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	boards, resp := c.GetBoardsForTeam(*teamID)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printBoards(boards)
+}
+
+func (a *app) searchBoards(args []string) error {
+	fs := a.flagSet("boards search")
+	teamID := fs.String("team", "", "the ID of the team")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(map[string]string{"team": *teamID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	boards, resp := c.SearchBoardsForTeam(*teamID, positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printBoards(boards)
+}
+
+func (a *app) listTemplates(args []string) error {
+	fs := a.flagSet("boards templates")
+	teamID := fs.String("team", "", "the ID of the team")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"team": *teamID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	templates, err := getTemplates(c, *teamID)
+	if err != nil {
+		return err
+	}
+
+	return a.printBoards(templates)
+}
+
+func (a *app) createBoard(args []string) error {
+	fs := a.flagSet("boards create")
+	teamID := fs.String("team", "", "the ID of the team")
+	title := fs.String("title", "", "the title of the board")
+	template := fs.String("template", "", "the ID or title of the template to create the board from")
+	private := fs.Bool("private", false, "create a private board")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"team": *teamID, "title": *title}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	boardType := model.BoardTypeOpen
+	if *private {
+		boardType = model.BoardTypePrivate
+	}
+
+	var board *model.Board
+	if *template == "" {
+		var resp *client.Response
+		board, resp = c.CreateBoard(&model.Board{
+			TeamID: *teamID,
+			Type:   boardType,
+			Title:  *title,
+		})
+		if err := check(resp); err != nil {
+			return err
+		}
+	} else {
+		if board, err = createBoardFromTemplate(c, *teamID, *template, *title, boardType); err != nil {
+			return err
+		}
+	}
+
+	return a.printMessage(board, "Created board %s", board.ID)
+}
+
+// createBoardFromTemplate creates a board from a template given by ID
+// or title, as the webapp does.
+func createBoardFromTemplate(c *client.Client, teamID, template, title string, boardType model.BoardType) (*model.Board, error) {
+	templates, err := getTemplates(c, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var templateID string
+	for _, t := range templates {
+		if t.ID == template || strings.EqualFold(strings.TrimSpace(t.Title), strings.TrimSpace(template)) {
+			templateID = t.ID
+			break
+		}
+	}
+	if templateID == "" {
+		return nil, fmt.Errorf("template %q not found", template)
+	}
+
+	bab, resp := c.DuplicateBoard(templateID, false, teamID)
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	if len(bab.Boards) == 0 {
+		return nil, fmt.Errorf("template %q was not duplicated", template)
+	}
+
+	board, resp := c.PatchBoard(bab.Boards[0].ID, &model.BoardPatch{
+		Title: &title,
+		Type:  &boardType,
+	})
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// getTemplates returns the templates of a team followed by the
+// built-in ones.
+func getTemplates(c *client.Client, teamID string) ([]*model.Board, error) {
+	templates, resp := c.GetTemplatesForTeam(teamID)
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	if teamID == model.GlobalTeamID {
+		return templates, nil
+	}
+
+	globalTemplates, resp := c.GetTemplatesForTeam(model.GlobalTeamID)
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	return append(templates, globalTemplates...), nil
+}
+
+func (a *app) printBoards(boards []*model.Board) error {
+	return a.print(boards, []string{"ID", "TEAM", "TYPE", "TITLE"}, func(add func(...interface{})) {
+		for _, board := range boards {
+			add(board.ID, board.TeamID, board.Type, board.Title)
+		}
+	})
+}
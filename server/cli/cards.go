@@ -0,0 +1,351 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const defaultCardsPerPage = 100
+
+func (a *app) listCards(args []string) error {
+	fs := a.flagSet("cards list")
+	boardID := fs.String("board", "", "the ID of the board")
+	page := fs.Int("page", 0, "the page of cards")
+	perPage := fs.Int("per-page", defaultCardsPerPage, "the number of cards per page")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	cards, resp := c.GetCards(*boardID, *page, *perPage)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printCards(c, *boardID, cards)
+}
+
+func (a *app) searchCards(args []string) error {
+	fs := a.flagSet("cards search")
+	boardID := fs.String("board", "", "the ID of the board")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	cards, resp := c.SearchCards(*boardID, positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printCards(c, *boardID, cards)
+}
+
+func (a *app) getCard(args []string) error {
+	fs := a.flagSet("cards get")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	card, resp := c.GetCard(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printCards(c, card.BoardID, []*model.Card{card})
+}
+
+func (a *app) createCard(args []string) error {
+	fs := a.flagSet("cards create")
+	boardID := fs.String("board", "", "the ID of the board")
+	title := fs.String("title", "", "the title of the card")
+	icon := fs.String("icon", "", "the icon of the card")
+	var props stringsFlag
+	fs.Var(&props, "prop", "a property value as NAME=VALUE, can be repeated")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID, "title": *title}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	board, resp := c.GetBoard(*boardID, "")
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	properties, err := cardProperties(board, props)
+	if err != nil {
+		return err
+	}
+
+	card, resp := c.CreateCard(*boardID, &model.Card{
+		Title:        *title,
+		Icon:         *icon,
+		Properties:   properties,
+		ContentOrder: []string{},
+	}, false)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(card, "Created card %s", card.ID)
+}
+
+func (a *app) updateCard(args []string) error {
+	fs := a.flagSet("cards update")
+	title := fs.String("title", "", "the title of the card")
+	icon := fs.String("icon", "", "the icon of the card")
+	var props stringsFlag
+	fs.Var(&props, "prop", "a property value as NAME=VALUE, can be repeated")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	card, resp := c.GetCard(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	patch := &model.CardPatch{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			patch.Title = title
+		case "icon":
+			patch.Icon = icon
+		}
+	})
+
+	if len(props) > 0 {
+		board, resp := c.GetBoard(card.BoardID, "")
+		if err := check(resp); err != nil {
+			return err
+		}
+		if patch.UpdatedProperties, err = cardProperties(board, props); err != nil {
+			return err
+		}
+	}
+
+	card, resp = c.PatchCard(card.ID, patch, false)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(card, "Updated card %s", card.ID)
+}
+
+func (a *app) moveCard(args []string) error {
+	fs := a.flagSet("cards move")
+	boardID := fs.String("board", "", "the ID of the destination board")
+	var mappings stringsFlag
+	fs.Var(&mappings, "map", "a property of the card and the one of the destination board as NAME=NAME, can be repeated. Properties are otherwise mapped by name and type")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	opts := &model.CardMoveOptions{BoardID: *boardID}
+	if len(mappings) > 0 {
+		if opts.PropertyMapping, err = propertyMapping(c, positional[0], *boardID, mappings); err != nil {
+			return err
+		}
+	}
+
+	card, resp := c.MoveCard(positional[0], opts)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(card, "Moved card %s to board %s", card.ID, card.BoardID)
+}
+
+// propertyMapping returns the IDs of the properties of a card mapped to
+// the IDs of the properties of the destination board, from SOURCE=DEST
+// arguments with property names. An empty destination drops the
+// property.
+func propertyMapping(c *client.Client, cardID, boardID string, mappings []string) (map[string]string, error) {
+	card, resp := c.GetCard(cardID)
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+
+	srcBoard, resp := c.GetBoard(card.BoardID, "")
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	srcSchema, err := model.ParsePropertySchema(srcBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	dstBoard, resp := c.GetBoard(boardID, "")
+	if err := check(resp); err != nil {
+		return nil, err
+	}
+	dstSchema, err := model.ParsePropertySchema(dstBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := map[string]string{}
+	for _, m := range mappings {
+		src, dst, ok := strings.Cut(m, "=")
+		if !ok {
+			return nil, fmt.Errorf("mapping %q is not given as NAME=NAME: %w", m, errUsage)
+		}
+
+		srcProp, err := findProperty(srcSchema, strings.TrimSpace(src))
+		if err != nil {
+			return nil, err
+		}
+
+		if dst = strings.TrimSpace(dst); dst == "" {
+			mapping[srcProp.ID] = ""
+			continue
+		}
+		dstProp, err := findProperty(dstSchema, dst)
+		if err != nil {
+			return nil, err
+		}
+		mapping[srcProp.ID] = dstProp.ID
+	}
+
+	return mapping, nil
+}
+
+// printCards prints cards with their property values by name.
+func (a *app) printCards(c *client.Client, boardID string, cards []*model.Card) error {
+	if a.json {
+		return a.print(cards, nil, nil)
+	}
+
+	board, resp := c.GetBoard(boardID, "")
+	if err := check(resp); err != nil {
+		return err
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	return a.print(cards, []string{"ID", "TITLE", "PROPERTIES"}, func(add func(...interface{})) {
+		for _, card := range cards {
+			add(card.ID, card.Title, formatProperties(schema, card.Properties))
+		}
+	})
+}
+
+// formatProperties returns the property values of a card as
+// NAME=VALUE, in the order of the board properties.
+func formatProperties(schema model.PropSchema, properties map[string]any) string {
+	values := make([]string, len(schema))
+	for id, v := range properties {
+		prop, ok := schema[id]
+		if !ok {
+			continue
+		}
+
+		value, err := prop.GetValue(v, nil)
+		if err != nil || value == "" {
+			continue
+		}
+		values[prop.Index] = prop.Name + "=" + value
+	}
+
+	nonEmpty := values[:0]
+	for _, v := range values {
+		if v != "" {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+func (a *app) listComments(args []string) error {
+	fs := a.flagSet("comments list")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	comments, resp := c.GetCardComments(positional[0])
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(comments, []string{"ID", "CREATED BY", "UPDATED", "TEXT"}, func(add func(...interface{})) {
+		for _, comment := range comments {
+			add(comment.ID, comment.CreatedBy, formatTime(comment.UpdateAt), strings.ReplaceAll(comment.Text, "\n", " "))
+		}
+	})
+}
+
+func (a *app) addComment(args []string) error {
+	fs := a.flagSet("comments add")
+	positional, err := parse(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	comment, resp := c.CreateCardComment(positional[0], &model.Comment{Text: positional[1]})
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(comment, "Created comment %s", comment.ID)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const configFilename = "cli.json"
+
+// config is the configuration saved by the login command.
+type config struct {
+	Server string `json:"server"`
+	Token  string `json:"token,omitempty"`
+}
+
+// getConfigPath returns the path of the configuration file.
+func (a *app) getConfigPath() (string, error) {
+	if path := firstNonEmpty(a.configPath, os.Getenv("FOCALBOARD_CONFIG")); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find the configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "focalboard", configFilename), nil
+}
+
+// loadConfig returns the saved configuration, or an empty one if none
+// was saved yet.
+func (a *app) loadConfig() (*config, error) {
+	if a.config != nil {
+		return a.config, nil
+	}
+
+	path, err := a.getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config{}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("cannot read the configuration: %w", err)
+	default:
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("invalid configuration %s: %w", path, err)
+		}
+	}
+
+	a.config = cfg
+	return cfg, nil
+}
+
+// saveConfig saves the configuration, readable by the user only as it
+// holds the session token.
+func (a *app) saveConfig(cfg *config) error {
+	path, err := a.getConfigPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("cannot save the configuration: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("cannot save the configuration: %w", err)
+	}
+
+	a.config = cfg
+	return nil
+}
@@ -0,0 +1,535 @@
+// focalboard is a command-line tool to script boards through the
+// Focalboard API.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/client"
+)
+
+const usageText = `Usage:
+    focalboard <command> [<subcommand>] [flags] [arguments]
+
+Commands:
+`
+
+const usageFooter = `
+Every command accepts the flags:
+    --server URL      the URL of the server, defaults to $FOCALBOARD_SERVER or the saved one
+    --token TOKEN     a session or personal access token, defaults to $FOCALBOARD_TOKEN or the saved one
+    --config PATH     the configuration file, defaults to $FOCALBOARD_CONFIG or the user configuration directory
+    --json            print JSON, for scripting
+
+The admin commands accept --socket PATH instead of --server, --token and
+--config: the local mode socket of the server, defaults to $FOCALBOARD_SOCKET
+or /var/tmp/focalboard_local.socket.
+
+Run "focalboard <command> [<subcommand>] --help" for the flags of a command.
+`
+
+var errUsage = errors.New("invalid usage")
+
+// command is a command, or a group of subcommands.
+type command struct {
+	usage       string
+	description string
+	run         func(a *app, args []string) error
+	subcommands map[string]*command
+}
+
+var commands = map[string]*command{
+	"login": {
+		usage:       "login [--username NAME] [--password PASSWORD | --password-stdin]",
+		description: "log in with a username and password, or with --token, and save the session",
+		run:         (*app).login,
+	},
+	"logout": {
+		usage:       "logout",
+		description: "forget the saved session",
+		run:         (*app).logout,
+	},
+	"whoami": {
+		usage:       "whoami",
+		description: "show the logged in user",
+		run:         (*app).whoami,
+	},
+	"teams": {
+		usage:       "teams",
+		description: "list the teams",
+		run:         (*app).listTeams,
+	},
+	"boards": {
+		description: "list, search and create boards",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "boards list --team TEAM",
+				description: "list the boards of a team",
+				run:         (*app).listBoards,
+			},
+			"search": {
+				usage:       "boards search --team TEAM TERM",
+				description: "search the boards of a team by title",
+				run:         (*app).searchBoards,
+			},
+			"templates": {
+				usage:       "boards templates --team TEAM",
+				description: "list the templates of a team and the built-in ones",
+				run:         (*app).listTemplates,
+			},
+			"create": {
+				usage:       "boards create --team TEAM --title TITLE [--template TEMPLATE] [--private]",
+				description: "create a board, empty or from a template given by ID or title",
+				run:         (*app).createBoard,
+			},
+		},
+	},
+	"cards": {
+		description: "list, search, create, update and move cards",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "cards list --board BOARD [--page N] [--per-page N]",
+				description: "list the cards of a board",
+				run:         (*app).listCards,
+			},
+			"search": {
+				usage:       "cards search --board BOARD TERM",
+				description: "search the cards of a board by title",
+				run:         (*app).searchCards,
+			},
+			"get": {
+				usage:       "cards get CARD",
+				description: "show a card",
+				run:         (*app).getCard,
+			},
+			"create": {
+				usage:       "cards create --board BOARD --title TITLE [--icon ICON] [--prop NAME=VALUE]...",
+				description: "create a card, with property values given by name",
+				run:         (*app).createCard,
+			},
+			"update": {
+				usage:       "cards update CARD [--title TITLE] [--icon ICON] [--prop NAME=VALUE]...",
+				description: "update a card, with property values given by name",
+				run:         (*app).updateCard,
+			},
+			"move": {
+				usage:       "cards move CARD --board BOARD [--map NAME=NAME]...",
+				description: "move a card to another board, mapping properties by name",
+				run:         (*app).moveCard,
+			},
+		},
+	},
+	"comments": {
+		description: "list and add comments",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "comments list CARD",
+				description: "list the comments of a card",
+				run:         (*app).listComments,
+			},
+			"add": {
+				usage:       "comments add CARD TEXT",
+				description: "add a comment to a card",
+				run:         (*app).addComment,
+			},
+		},
+	},
+	"members": {
+		description: "manage the members of a board",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "members list --board BOARD",
+				description: "list the members of a board",
+				run:         (*app).listMembers,
+			},
+			"add": {
+				usage:       "members add --board BOARD --user USER [--role ROLE]",
+				description: "add a member to a board, as viewer, commenter, editor or admin",
+				run:         (*app).addMember,
+			},
+			"update": {
+				usage:       "members update --board BOARD --user USER --role ROLE",
+				description: "change the role of a member",
+				run:         (*app).updateMember,
+			},
+			"remove": {
+				usage:       "members remove --board BOARD --user USER",
+				description: "remove a member from a board",
+				run:         (*app).removeMember,
+			},
+		},
+	},
+	"export": {
+		usage:       "export --board BOARD [--out FILE]",
+		description: "export a board to an archive, written to stdout if FILE is -",
+		run:         (*app).exportArchive,
+	},
+	"import": {
+		usage:       "import --team TEAM FILE",
+		description: "import the boards of an archive, read from stdin if FILE is -",
+		run:         (*app).importArchive,
+	},
+	"plan": {
+		usage:       "plan [--prune] [--fail-on-drift] FILE",
+		description: "show the changes that make the boards match a YAML or JSON spec, read from stdin if FILE is -",
+		run:         (*app).planSpec,
+	},
+	"apply": {
+		usage:       "apply [--prune] FILE",
+		description: "make the boards match a YAML or JSON spec, read from stdin if FILE is -",
+		run:         (*app).applySpec,
+	},
+	"admin": {
+		description: "administer a server in local mode, on the same host",
+		subcommands: adminCommands,
+	},
+}
+
+var adminCommands = map[string]*command{
+	"users": {
+		description: "create, list and deactivate users",
+		subcommands: map[string]*command{
+			"create": {
+				usage:       "admin users create --username NAME --email EMAIL [--password PASSWORD | --password-stdin]",
+				description: "create a user",
+				run:         (*app).adminCreateUser,
+			},
+			"list": {
+				usage:       "admin users list [--all]",
+				description: "list the users, with the deactivated ones with --all",
+				run:         (*app).adminListUsers,
+			},
+			"deactivate": {
+				usage:       "admin users deactivate USERNAME",
+				description: "deactivate a user and end their sessions",
+				run:         (*app).adminDeactivateUser,
+			},
+			"set-password": {
+				usage:       "admin users set-password USERNAME [--password PASSWORD | --password-stdin]",
+				description: "set the password of a user",
+				run:         (*app).adminSetPassword,
+			},
+		},
+	},
+	"teams": {
+		description: "list teams and regenerate their signup tokens",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "admin teams list",
+				description: "list all the teams",
+				run:         (*app).adminListTeams,
+			},
+			"regenerate-token": {
+				usage:       "admin teams regenerate-token TEAM",
+				description: "regenerate the signup token of a team, invalidating its invite links",
+				run:         (*app).adminRegenerateSignupToken,
+			},
+		},
+	},
+	"boards": {
+		description: "manage any board",
+		subcommands: map[string]*command{
+			"transfer": {
+				usage:       "admin boards transfer BOARD --user USER",
+				description: "make a user the owner and an admin of a board",
+				run:         (*app).adminTransferBoard,
+			},
+		},
+	},
+	"sessions": {
+		description: "manage the sessions",
+		subcommands: map[string]*command{
+			"cleanup": {
+				usage:       "admin sessions cleanup",
+				description: "delete the expired sessions",
+				run:         (*app).adminCleanUpSessions,
+			},
+		},
+	},
+	"retention": {
+		description: "run the data retention",
+		subcommands: map[string]*command{
+			"run": {
+				usage:       "admin retention run --days N",
+				description: "delete the boards and cards not updated for N days, even if the data retention is disabled",
+				run:         (*app).adminRunDataRetention,
+			},
+		},
+	},
+	"jobs": {
+		description: "manage the background jobs",
+		subcommands: map[string]*command{
+			"list": {
+				usage:       "admin jobs list [--type TYPE] [--status STATUS]",
+				description: "list the background jobs, the most recently scheduled first",
+				run:         (*app).adminListJobs,
+			},
+			"cancel": {
+				usage:       "admin jobs cancel JOB",
+				description: "cancel a pending or running job",
+				run:         (*app).adminCancelJob,
+			},
+			"retry": {
+				usage:       "admin jobs retry JOB",
+				description: "schedule again a failed or cancelled job",
+				run:         (*app).adminRetryJob,
+			},
+		},
+	},
+	"reindex": {
+		usage:       "admin reindex",
+		description: "rebuild the index of the links between blocks",
+		run:         (*app).adminReindex,
+	},
+	"config": {
+		description: "check configuration files",
+		subcommands: map[string]*command{
+			"validate": {
+				usage:       "admin config validate FILE",
+				description: "check a server configuration file, with the environment overrides",
+				run:         (*app).adminValidateConfig,
+			},
+		},
+	},
+	"check": {
+		usage:       "admin check",
+		description: "run the data integrity checks, and fail if any finds problems",
+		run:         (*app).adminCheck,
+	},
+}
+
+func main() {
+	a := &app{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	os.Exit(a.run(os.Args[1:]))
+}
+
+// app runs the commands, with the state shared by all of them.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	serverURL  string
+	token      string
+	configPath string
+	socketPath string
+	json       bool
+
+	config *config
+	cmd    *command
+}
+
+// run runs the command of the arguments and returns the exit code.
+func (a *app) run(args []string) int {
+	if len(args) == 1 && (args[0] == "help" || args[0] == "-h" || args[0] == "--help") {
+		a.usage(nil, "")
+		return 0
+	}
+
+	cmd, args, name := findCommand(args)
+	if cmd == nil || cmd.run == nil {
+		a.usage(cmd, name)
+		return 2
+	}
+
+	a.cmd = cmd
+	err := cmd.run(a, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "Error: %s\nUsage:\n    focalboard %s\n", err, cmd.usage)
+		return 2
+	default:
+		fmt.Fprintf(a.stderr, "Error: %s\n", err)
+		return 1
+	}
+}
+
+// findCommand returns the command of the arguments, its arguments and
+// its name.
+func findCommand(args []string) (*command, []string, string) {
+	if len(args) == 0 {
+		return nil, nil, ""
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return nil, nil, ""
+	}
+	name := args[0]
+	args = args[1:]
+
+	for cmd.subcommands != nil {
+		if len(args) == 0 {
+			return cmd, nil, name
+		}
+
+		sub, ok := cmd.subcommands[args[0]]
+		if !ok {
+			return cmd, nil, name
+		}
+		cmd = sub
+		name += " " + args[0]
+		args = args[1:]
+	}
+	return cmd, args, name
+}
+
+func (a *app) usage(cmd *command, name string) {
+	out := a.stderr
+	fmt.Fprint(out, usageText)
+
+	list := commands
+	if cmd != nil {
+		list = cmd.subcommands
+		fmt.Fprintf(out, "    %s: %s\n\n", name, cmd.description)
+	}
+
+	names := make([]string, 0, len(list))
+	for n := range list {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		c := list[n]
+		if c.subcommands != nil {
+			fmt.Fprintf(out, "    %-10s %s\n", n, c.description)
+			continue
+		}
+		fmt.Fprintf(out, "    %s\n        %s\n", c.usage, c.description)
+	}
+	fmt.Fprint(out, usageFooter)
+}
+
+// flagSet returns the flag set of a command, with the flags common to
+// all the commands.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := a.newFlagSet(name)
+	fs.StringVar(&a.serverURL, "server", "", "the URL of the server")
+	fs.StringVar(&a.token, "token", "", "a session or personal access token")
+	fs.StringVar(&a.configPath, "config", "", "the configuration file")
+	fs.BoolVar(&a.json, "json", false, "print JSON")
+	return fs
+}
+
+// newFlagSet returns an empty flag set, whose usage is the one of the
+// command.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		if a.cmd != nil {
+			fmt.Fprintf(a.stderr, "Usage:\n    focalboard %s\n\n%s\n\nFlags:\n", a.cmd.usage, a.cmd.description)
+		}
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses the flags of a command, given before or after its
+// positional arguments, and checks the number of positional arguments.
+func parse(fs *flag.FlagSet, args []string, nArgs int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != nArgs {
+		return nil, fmt.Errorf("expected %d arguments, got %d: %w", nArgs, len(positional), errUsage)
+	}
+	return positional, nil
+}
+
+// required checks that the given flags are set.
+func required(flags map[string]string) error {
+	var missing []string
+	for name, value := range flags {
+		if value == "" {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	return fmt.Errorf("missing %s: %w", strings.Join(missing, ", "), errUsage)
+}
+
+// stringsFlag is a flag that can be given several times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// client returns a client for the server of the configuration, which
+// can be overridden by the flags and the environment.
+func (a *app) client() (*client.Client, error) {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL := firstNonEmpty(a.serverURL, os.Getenv("FOCALBOARD_SERVER"), cfg.Server)
+	if serverURL == "" {
+		return nil, errors.New("no server, log in or use --server")
+	}
+
+	token := firstNonEmpty(a.token, os.Getenv("FOCALBOARD_TOKEN"))
+	if token == "" && strings.TrimRight(serverURL, "/") == strings.TrimRight(cfg.Server, "/") {
+		token = cfg.Token
+	}
+
+	return client.NewClient(serverURL, token), nil
+}
+
+// check returns the error of a response, if any, with the message of
+// the server for the errors it returned.
+func check(resp *client.Response) error {
+	var apiErr *client.APIError
+	if !errors.As(resp.Error, &apiErr) {
+		return resp.Error
+	}
+
+	if kind := apiErr.Unwrap(); kind != nil {
+		return fmt.Errorf("%w: %s", kind, apiErr.Message)
+	}
+	return fmt.Errorf("%s: %s", http.StatusText(apiErr.StatusCode), apiErr.Message)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
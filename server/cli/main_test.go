@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// testServer is a fake API that records the cards it receives.
+type testServer struct {
+	*httptest.Server
+	cards []*model.Card
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/login", func(w http.ResponseWriter, r *http.Request) {
+		var request model.LoginRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"incorrect login","errorCode":401}`))
+			return
+		}
+		writeJSON(t, w, model.LoginResponse{Token: "session-token"})
+	})
+	mux.HandleFunc("/api/v2/users/me", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(t, w, model.User{ID: "user-id", Username: "jane"})
+	})
+	mux.HandleFunc("/api/v2/boards/board-id", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, newTestBoard())
+	})
+	mux.HandleFunc("/api/v2/boards/board-id/views", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []*model.View{})
+	})
+	mux.HandleFunc("/api/v2/boards/board-id/members", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []*model.BoardMember{})
+	})
+	mux.HandleFunc("/api/v2/boards/board-id/cards", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var card model.Card
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&card))
+		card.ID = "card-id"
+		card.BoardID = "board-id"
+		s.cards = append(s.cards, &card)
+		writeJSON(t, w, card)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func newTestApp(t *testing.T) (*app, *bytes.Buffer, *bytes.Buffer) {
+	t.Setenv("FOCALBOARD_SERVER", "")
+	t.Setenv("FOCALBOARD_TOKEN", "")
+	t.Setenv("FOCALBOARD_PASSWORD", "")
+	t.Setenv("FOCALBOARD_CONFIG", filepath.Join(t.TempDir(), "cli.json"))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	return &app{stdin: &bytes.Buffer{}, stdout: stdout, stderr: stderr}, stdout, stderr
+}
+
+func TestLogin(t *testing.T) {
+	server := newTestServer(t)
+
+	t.Run("with a password", func(t *testing.T) {
+		a, stdout, stderr := newTestApp(t)
+		a.stdin = bytes.NewBufferString("password\n")
+
+		code := a.run([]string{"login", "--server", server.URL, "--username", "jane", "--password-stdin"})
+		require.Equal(t, 0, code, stderr.String())
+		require.Contains(t, stdout.String(), "as jane")
+
+		data, err := os.ReadFile(os.Getenv("FOCALBOARD_CONFIG"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"server":"`+server.URL+`","token":"session-token"}`, string(data))
+
+		// the saved session is used by the next commands
+		stdout.Reset()
+		a = &app{stdout: stdout, stderr: stderr}
+		require.Equal(t, 0, a.run([]string{"whoami", "--json"}), stderr.String())
+		require.JSONEq(t, toJSONString(t, model.User{ID: "user-id", Username: "jane"}), stdout.String())
+	})
+
+	t.Run("with an invalid password", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+
+		code := a.run([]string{"login", "--server", server.URL, "--username", "jane", "--password", "wrong"})
+		require.Equal(t, 1, code)
+		require.Contains(t, stderr.String(), "incorrect login")
+	})
+
+	t.Run("with a personal access token", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+
+		code := a.run([]string{"login", "--server", server.URL, "--token", "personal-token"})
+		require.Equal(t, 0, code, stderr.String())
+
+		data, err := os.ReadFile(os.Getenv("FOCALBOARD_CONFIG"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"server":"`+server.URL+`","token":"personal-token"}`, string(data))
+	})
+}
+
+func toJSONString(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestCreateCard(t *testing.T) {
+	server := newTestServer(t)
+
+	a, stdout, stderr := newTestApp(t)
+	t.Setenv("FOCALBOARD_SERVER", server.URL)
+	t.Setenv("FOCALBOARD_TOKEN", "token")
+
+	code := a.run([]string{
+		"cards", "create", "--json",
+		"--board", "board-id",
+		"--title", "New card",
+		"--prop", "Status=Done",
+		"--prop", "Tags=bug",
+	})
+	require.Equal(t, 0, code, stderr.String())
+
+	require.Len(t, server.cards, 1)
+	require.Equal(t, "New card", server.cards[0].Title)
+	require.Equal(t, map[string]any{
+		"status-id": "done-id",
+		"tags-id":   []any{"bug-id"},
+	}, server.cards[0].Properties)
+
+	var card model.Card
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &card))
+	require.Equal(t, "card-id", card.ID)
+}
+
+func TestPlan(t *testing.T) {
+	server := newTestServer(t)
+
+	a, stdout, stderr := newTestApp(t)
+	t.Setenv("FOCALBOARD_SERVER", server.URL)
+	t.Setenv("FOCALBOARD_TOKEN", "token")
+	a.stdin = bytes.NewBufferString(`
+boards:
+  - id: board-id
+    team: team-id
+    title: Roadmap
+    properties:
+      - name: Status
+        type: select
+        options:
+          - value: To Do
+          - value: Done 🙌
+          - value: Doing
+`)
+
+	code := a.run([]string{"plan", "-", "--fail-on-drift"})
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr.String(), errDrift.Error())
+	require.Contains(t, stdout.String(), `~ board "Roadmap": title "" → "Roadmap"`)
+	require.Contains(t, stdout.String(), `- property "Tags"`)
+	require.Contains(t, stdout.String(), "1 of 1 boards to change.")
+	require.NotContains(t, stdout.String(), `property "Status"`)
+}
+
+func TestUsage(t *testing.T) {
+	t.Run("unknown command", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		require.Equal(t, 2, a.run([]string{"unknown"}))
+		require.Contains(t, stderr.String(), "Commands:")
+	})
+
+	t.Run("command group", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		require.Equal(t, 2, a.run([]string{"cards"}))
+		require.Contains(t, stderr.String(), "cards create --board BOARD")
+	})
+
+	t.Run("missing flags", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		require.Equal(t, 2, a.run([]string{"cards", "create", "--board", "board-id"}))
+		require.Contains(t, stderr.String(), "missing --title")
+	})
+
+	t.Run("wrong number of arguments", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		require.Equal(t, 2, a.run([]string{"comments", "add", "card-id", "--server", "http://localhost:1"}))
+		require.Contains(t, stderr.String(), "expected 2 arguments, got 1")
+	})
+
+	t.Run("no server", func(t *testing.T) {
+		a, _, stderr := newTestApp(t)
+		require.Equal(t, 1, a.run([]string{"teams"}))
+		require.Contains(t, stderr.String(), "no server")
+	})
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *app) listMembers(args []string) error {
+	fs := a.flagSet("members list")
+	boardID := fs.String("board", "", "the ID of the board")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	members, resp := c.GetMembersForBoard(*boardID)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.print(members, []string{"USER", "ROLE"}, func(add func(...interface{})) {
+		for _, member := range members {
+			add(member.UserID, member.Role())
+		}
+	})
+}
+
+func (a *app) addMember(args []string) error {
+	fs := a.flagSet("members add")
+	boardID := fs.String("board", "", "the ID of the board")
+	userID := fs.String("user", "", "the ID of the user")
+	role := fs.String("role", string(model.BoardRoleEditor), "the role of the member: viewer, commenter, editor or admin")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID, "user": *userID}); err != nil {
+		return err
+	}
+
+	wanted, err := newMember(*boardID, *userID, *role)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	member, resp := c.AddMemberToBoard(wanted)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	// members that already exist are returned as they are
+	if string(member.Role()) != *role {
+		if member, resp = c.UpdateBoardMember(wanted); resp.Error != nil {
+			return check(resp)
+		}
+	}
+
+	return a.printMessage(member, "Added %s to board %s as %s", member.UserID, member.BoardID, member.Role())
+}
+
+func (a *app) updateMember(args []string) error {
+	fs := a.flagSet("members update")
+	boardID := fs.String("board", "", "the ID of the board")
+	userID := fs.String("user", "", "the ID of the user")
+	role := fs.String("role", "", "the role of the member: viewer, commenter, editor or admin")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID, "user": *userID, "role": *role}); err != nil {
+		return err
+	}
+
+	member, err := newMember(*boardID, *userID, *role)
+	if err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	member, resp := c.UpdateBoardMember(member)
+	if err := check(resp); err != nil {
+		return err
+	}
+
+	return a.printMessage(member, "Changed the role of %s on board %s to %s", member.UserID, member.BoardID, member.Role())
+}
+
+func (a *app) removeMember(args []string) error {
+	fs := a.flagSet("members remove")
+	boardID := fs.String("board", "", "the ID of the board")
+	userID := fs.String("user", "", "the ID of the user")
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"board": *boardID, "user": *userID}); err != nil {
+		return err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	member := &model.BoardMember{BoardID: *boardID, UserID: *userID}
+	if _, resp := c.DeleteBoardMember(member); resp.Error != nil {
+		return check(resp)
+	}
+
+	return a.printMessage(member, "Removed %s from board %s", member.UserID, member.BoardID)
+}
+
+// newMember returns a member with the scheme roles of a role.
+func newMember(boardID, userID, role string) (*model.BoardMember, error) {
+	member := &model.BoardMember{BoardID: boardID, UserID: userID}
+	if err := member.SetRole(model.BoardRole(role)); err != nil {
+		return nil, fmt.Errorf("invalid role %q, the roles are viewer, commenter, editor and admin: %w", role, errUsage)
+	}
+	return member, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// print prints a value as JSON with --json, or as a table otherwise.
+func (a *app) print(v interface{}, header []string, rows func(add func(values ...interface{}))) error {
+	if a.json {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	rows(func(values ...interface{}) {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			cells[i] = fmt.Sprint(value)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	})
+	return w.Flush()
+}
+
+// printMessage prints a message, or a value as JSON with --json.
+func (a *app) printMessage(v interface{}, format string, args ...interface{}) error {
+	if a.json {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	_, err := fmt.Fprintf(a.stdout, format+"\n", args...)
+	return err
+}
+
+func formatTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return utils.GetTimeForMillis(millis).Format(time.RFC3339)
+}
+
+func writeAll(w io.Writer, data []byte) error {
+	_, err := w.Write(data)
+	return err
+}
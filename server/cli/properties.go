@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const dateLayout = "2006-01-02"
+
+var errInvalidPropertyValue = errors.New("invalid property value")
+
+// cardProperties returns the values of the card properties of a board,
+// keyed by property ID, from NAME=VALUE arguments. Properties are
+// looked up by name, case-insensitively, or by ID, and values are
+// converted to what the property type stores:
+//   - select and multiSelect options are given by value, or by the
+//     start of their value, multiple values being separated by commas;
+//   - multiPerson user IDs are separated by commas;
+//   - dates are given as YYYY-MM-DD, or as a FROM..TO range;
+//   - checkboxes are given as true or false.
+//
+// An empty value clears the property.
+func cardProperties(board *model.Board, values []string) (map[string]any, error) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]any{}
+	for _, nameValue := range values {
+		name, value, ok := strings.Cut(nameValue, "=")
+		if !ok {
+			return nil, fmt.Errorf("property %q is not given as NAME=VALUE: %w", nameValue, errUsage)
+		}
+
+		prop, err := findProperty(schema, name)
+		if err != nil {
+			return nil, err
+		}
+
+		propValue, err := propertyValue(prop, value)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", prop.Name, err)
+		}
+		properties[prop.ID] = propValue
+	}
+
+	return properties, nil
+}
+
+// findProperty returns the property of a schema with the given name or
+// ID.
+func findProperty(schema model.PropSchema, name string) (model.PropDef, error) {
+	if prop, ok := schema[name]; ok {
+		return prop, nil
+	}
+
+	for _, prop := range schema {
+		if strings.EqualFold(prop.Name, name) {
+			return prop, nil
+		}
+	}
+
+	names := make([]string, 0, len(schema))
+	for _, prop := range schema {
+		names = append(names, prop.Name)
+	}
+	sort.Strings(names)
+
+	return model.PropDef{}, fmt.Errorf("unknown property %q, the properties of the board are: %s", name, strings.Join(names, ", "))
+}
+
+func propertyValue(prop model.PropDef, value string) (any, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	switch prop.Type {
+	case "createdTime", "createdBy", "updatedTime", "updatedBy":
+		return nil, fmt.Errorf("%s properties are read-only: %w", prop.Type, errInvalidPropertyValue)
+
+	case "select":
+		return findOption(prop, value)
+
+	case "multiSelect":
+		ids := []string{}
+		for _, v := range splitList(value) {
+			id, err := findOption(prop, v)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+
+	case "multiPerson":
+		return splitList(value), nil
+
+	case "date":
+		return dateValue(value)
+
+	case "checkbox":
+		checked, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false: %w", value, errInvalidPropertyValue)
+		}
+		if !checked {
+			return "", nil
+		}
+		return "true", nil
+
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a number: %w", value, errInvalidPropertyValue)
+		}
+	}
+
+	return value, nil
+}
+
+// findOption returns the ID of the option of a property with the given
+// value or ID. As values often end with an emoji, an option can also be
+// given by the start of its value if no other option starts the same.
+func findOption(prop model.PropDef, value string) (string, error) {
+	if _, ok := prop.Options[value]; ok {
+		return value, nil
+	}
+
+	var prefixMatches []string
+	values := make([]string, 0, len(prop.Options))
+	for _, option := range prop.Options {
+		if strings.EqualFold(strings.TrimSpace(option.Value), value) {
+			return option.ID, nil
+		}
+		if strings.HasPrefix(strings.ToLower(option.Value), strings.ToLower(value)) {
+			prefixMatches = append(prefixMatches, option.ID)
+		}
+		values = append(values, option.Value)
+	}
+	if len(prefixMatches) == 1 {
+		return prefixMatches[0], nil
+	}
+	sort.Strings(values)
+
+	return "", fmt.Errorf("unknown option %q, the options are: %s: %w", value, strings.Join(values, ", "), errInvalidPropertyValue)
+}
+
+// dateValue returns the value of a date property, stored as JSON with
+// the dates at noon UTC as the webapp does.
+func dateValue(value string) (string, error) {
+	from, to, isRange := strings.Cut(value, "..")
+
+	date := map[string]int64{}
+	for key, s := range map[string]string{"from": from, "to": to} {
+		if key == "to" && !isRange {
+			continue
+		}
+
+		t, err := time.Parse(dateLayout, strings.TrimSpace(s))
+		if err != nil {
+			return "", fmt.Errorf("%q is not a date as YYYY-MM-DD: %w", s, errInvalidPropertyValue)
+		}
+		date[key] = t.Add(12*time.Hour).UnixNano() / int64(time.Millisecond)
+	}
+
+	data, err := json.Marshal(date)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
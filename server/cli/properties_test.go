@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestBoard() *model.Board {
+	return &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-id",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo-id", "value": "To Do"},
+					map[string]interface{}{"id": "done-id", "value": "Done 🙌"},
+					map[string]interface{}{"id": "doing-id", "value": "Doing"},
+				},
+			},
+			{
+				"id":   "tags-id",
+				"name": "Tags",
+				"type": "multiSelect",
+				"options": []interface{}{
+					map[string]interface{}{"id": "bug-id", "value": "Bug"},
+					map[string]interface{}{"id": "ui-id", "value": "UI"},
+				},
+			},
+			{"id": "due-id", "name": "Due date", "type": "date"},
+			{"id": "checked-id", "name": "Checked", "type": "checkbox"},
+			{"id": "estimate-id", "name": "Estimate", "type": "number"},
+			{"id": "owners-id", "name": "Owners", "type": "multiPerson"},
+			{"id": "created-id", "name": "Created", "type": "createdTime"},
+			{"id": "notes-id", "name": "Notes", "type": "text"},
+		},
+	}
+}
+
+func TestCardProperties(t *testing.T) {
+	board := newTestBoard()
+
+	t.Run("values by name", func(t *testing.T) {
+		properties, err := cardProperties(board, []string{
+			"status=done",
+			"Tags=Bug, ui",
+			"Due date=2023-03-15",
+			"Checked=true",
+			"Estimate=3.5",
+			"Owners=user-1,user-2",
+			"notes-id=a = b",
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{
+			"status-id":   "done-id",
+			"tags-id":     []string{"bug-id", "ui-id"},
+			"due-id":      `{"from":1678881600000}`,
+			"checked-id":  "true",
+			"estimate-id": "3.5",
+			"owners-id":   []string{"user-1", "user-2"},
+			"notes-id":    "a = b",
+		}, properties)
+	})
+
+	t.Run("options by the start of their value", func(t *testing.T) {
+		properties, err := cardProperties(board, []string{"Status=to"})
+		require.NoError(t, err)
+		require.Equal(t, "todo-id", properties["status-id"])
+
+		_, err = cardProperties(board, []string{"Status=do"})
+		require.ErrorIs(t, err, errInvalidPropertyValue)
+	})
+
+	t.Run("empty values clear properties", func(t *testing.T) {
+		properties, err := cardProperties(board, []string{"Status=", "Checked=false"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"status-id": "", "checked-id": ""}, properties)
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		properties, err := cardProperties(board, []string{"Due date=2023-03-15..2023-03-16"})
+		require.NoError(t, err)
+		require.Equal(t, `{"from":1678881600000,"to":1678968000000}`, properties["due-id"])
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		testCases := []string{
+			"Status=Blocked",
+			"Tags=Bug,Feature",
+			"Due date=tomorrow",
+			"Checked=maybe",
+			"Estimate=a lot",
+			"Created=2023-03-15",
+		}
+		for _, value := range testCases {
+			_, err := cardProperties(board, []string{value})
+			require.ErrorIs(t, err, errInvalidPropertyValue, value)
+		}
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		_, err := cardProperties(board, []string{"Priority=High"})
+		require.ErrorContains(t, err, `unknown property "Priority"`)
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		_, err := cardProperties(board, []string{"Status"})
+		require.ErrorIs(t, err, errUsage)
+	})
+}
+
+func TestFormatProperties(t *testing.T) {
+	schema, err := model.ParsePropertySchema(newTestBoard())
+	require.NoError(t, err)
+
+	formatted := formatProperties(schema, map[string]any{
+		"notes-id":   "some notes",
+		"status-id":  "done-id",
+		"unknown-id": "value",
+		"checked-id": "",
+	})
+	require.Equal(t, "Status=DONE 🙌, Notes=some notes", formatted)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/client/boardspec"
+)
+
+var errDrift = errors.New("the boards don't match the spec")
+
+func (a *app) planSpec(args []string) error {
+	fs := a.flagSet("plan")
+	prune := fs.Bool("prune", false, "plan to delete what the spec doesn't describe")
+	failOnDrift := fs.Bool("fail-on-drift", false, "exit with an error if the boards don't match the spec")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	_, plan, err := a.newPlan(positional[0], *prune)
+	if err != nil {
+		return err
+	}
+
+	if err := a.printPlan(plan); err != nil {
+		return err
+	}
+	if *failOnDrift && (plan.HasChanges() || len(plan.Drift) > 0) {
+		return errDrift
+	}
+	return nil
+}
+
+func (a *app) applySpec(args []string) error {
+	fs := a.flagSet("apply")
+	prune := fs.Bool("prune", false, "delete what the spec doesn't describe")
+	positional, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, plan, err := a.newPlan(positional[0], *prune)
+	if err != nil {
+		return err
+	}
+
+	// the plan is printed first, so that the changes are known if
+	// applying them fails midway
+	if err := a.printPlan(plan); err != nil {
+		return err
+	}
+	if !plan.HasChanges() {
+		return nil
+	}
+
+	if err := plan.Apply(c); err != nil {
+		return err
+	}
+	if a.json {
+		return nil
+	}
+	return a.printMessage(nil, "Applied the changes of %d boards", changedBoards(plan))
+}
+
+// newPlan loads a spec, read from stdin if the path is -, and plans its
+// changes.
+func (a *app) newPlan(path string, prune bool) (*client.Client, *boardspec.Plan, error) {
+	var spec *boardspec.Spec
+	var err error
+	if path == "-" {
+		spec, err = boardspec.Load(a.stdin)
+	} else {
+		spec, err = boardspec.LoadFile(path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c, err := a.client()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := boardspec.NewPlan(c, spec, boardspec.Options{Prune: prune})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, plan, nil
+}
+
+func (a *app) printPlan(plan *boardspec.Plan) error {
+	if a.json {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+	return plan.WriteReport(a.stdout)
+}
+
+func changedBoards(plan *boardspec.Plan) int {
+	n := 0
+	for _, board := range plan.Boards {
+		if len(board.Changes) > 0 {
+			n++
+		}
+	}
+	return n
+}
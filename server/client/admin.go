@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// localURL is the URL of the requests to the local mode socket, whose
+// host is ignored.
+const localURL = "http://_"
+
+// NewLocalClient returns a client of the admin API of a server running
+// in local mode, sending its requests to the unix socket at socketPath.
+// The admin API requires no session token.
+func NewLocalClient(socketPath string) *Client {
+	c := NewClient(localURL, "")
+
+	transport := c.HTTPClient.Transport.(*http.Transport)
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+
+	return c
+}
+
+func (c *Client) GetAdminRoute() string {
+	return "/admin"
+}
+
+func (c *Client) GetAdminUsersRoute() string {
+	return c.GetAdminRoute() + "/users"
+}
+
+func (c *Client) GetAdminUserRoute(username string) string {
+	return fmt.Sprintf("%s/%s", c.GetAdminUsersRoute(), url.PathEscape(username))
+}
+
+func (c *Client) AdminCreateUser(request *model.AdminCreateUserRequest) (*model.User, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminUsersRoute(), toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	user, err := model.UserFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return user, BuildResponse(r)
+}
+
+func (c *Client) AdminGetUsers(includeDeleted bool) ([]*model.User, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s?all=%t", c.GetAdminUsersRoute(), includeDeleted), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var users []*model.User
+	if jsonErr := json.NewDecoder(r.Body).Decode(&users); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return users, BuildResponse(r)
+}
+
+func (c *Client) AdminSetPassword(username, password string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/password", toJSON(&api.AdminSetPasswordData{Password: password}))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) AdminDeactivateUser(username string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/deactivate", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) AdminGetTeams() ([]*model.Team, *Response) {
+	r, err := c.DoAPIGet(c.GetAdminRoute()+"/teams", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AdminRegenerateSignupToken(teamID string) (*model.Team, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/teams/%s/regenerate_signup_token", c.GetAdminRoute(), teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AdminTransferBoard(boardID, userID string) (*model.Board, *Response) {
+	request := &model.AdminTransferBoardRequest{UserID: userID}
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/boards/%s/owner", c.GetAdminRoute(), boardID), toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AdminCleanUpSessions() (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminRoute()+"/sessions/cleanup", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) AdminRunDataRetention(days int) (*model.AdminTaskResult, *Response) {
+	request := &model.AdminDataRetentionRequest{Days: days}
+	r, err := c.DoAPIPost(c.GetAdminRoute()+"/data_retention", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return adminTaskResultFromResponse(r)
+}
+
+func (c *Client) AdminReindex() (*model.AdminTaskResult, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminRoute()+"/reindex", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return adminTaskResultFromResponse(r)
+}
+
+func (c *Client) AdminCheckIntegrity() ([]*model.IntegrityCheck, *Response) {
+	r, err := c.DoAPIGet(c.GetAdminRoute()+"/integrity", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var checks []*model.IntegrityCheck
+	if jsonErr := json.NewDecoder(r.Body).Decode(&checks); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return checks, BuildResponse(r)
+}
+
+func (c *Client) GetAdminJobRoute(jobID string) string {
+	return fmt.Sprintf("%s/jobs/%s", c.GetAdminRoute(), jobID)
+}
+
+func (c *Client) AdminGetJobs(opts model.QueryJobsOptions) ([]*model.Job, *Response) {
+	query := url.Values{}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.Status != "" {
+		query.Set("status", string(opts.Status))
+	}
+	if opts.Page != 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage != 0 {
+		query.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	r, err := c.DoAPIGet(c.GetAdminRoute()+"/jobs?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var jobs []*model.Job
+	if jsonErr := json.NewDecoder(r.Body).Decode(&jobs); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return jobs, BuildResponse(r)
+}
+
+func (c *Client) AdminGetJob(jobID string) (*model.Job, *Response) {
+	r, err := c.DoAPIGet(c.GetAdminJobRoute(jobID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return jobFromResponse(r)
+}
+
+func (c *Client) AdminCancelJob(jobID string) (*model.Job, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminJobRoute(jobID)+"/cancel", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return jobFromResponse(r)
+}
+
+func (c *Client) AdminRetryJob(jobID string) (*model.Job, *Response) {
+	r, err := c.DoAPIPost(c.GetAdminJobRoute(jobID)+"/retry", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return jobFromResponse(r)
+}
+
+func jobFromResponse(r *http.Response) (*model.Job, *Response) {
+	var job *model.Job
+	if jsonErr := json.NewDecoder(r.Body).Decode(&job); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return job, BuildResponse(r)
+}
+
+func adminTaskResultFromResponse(r *http.Response) (*model.AdminTaskResult, *Response) {
+	var result *model.AdminTaskResult
+	if jsonErr := json.NewDecoder(r.Body).Decode(&result); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return result, BuildResponse(r)
+}
@@ -0,0 +1,106 @@
+package boardspec
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// Apply makes the live boards match the spec of the plan, board by
+// board. Applying a plan again once it succeeded doesn't change
+// anything.
+func (p *Plan) Apply(c *client.Client) error {
+	for _, board := range p.Boards {
+		if len(board.Changes) == 0 {
+			continue
+		}
+		if err := board.apply(c); err != nil {
+			return fmt.Errorf("board %q: %w", board.Title, err)
+		}
+	}
+	return nil
+}
+
+func (bp *BoardPlan) apply(c *client.Client) error {
+	if bp.create != nil {
+		board, resp := c.CreateBoard(bp.create)
+		if resp.Error != nil {
+			return fmt.Errorf("cannot create the board: %w", resp.Error)
+		}
+		bp.BoardID = board.ID
+		bp.create = nil
+	} else if bp.patch != nil {
+		if _, resp := c.PatchBoard(bp.BoardID, bp.patch); resp.Error != nil {
+			return fmt.Errorf("cannot patch the board: %w", resp.Error)
+		}
+		bp.patch = nil
+	}
+
+	for _, view := range bp.createViews {
+		view.BoardID = bp.BoardID
+		if _, resp := c.CreateView(bp.BoardID, view); resp.Error != nil {
+			return fmt.Errorf("cannot create view %q: %w", view.Title, resp.Error)
+		}
+	}
+	bp.createViews = nil
+
+	for viewID, patch := range bp.patchViews {
+		if _, resp := c.PatchView(bp.BoardID, viewID, patch); resp.Error != nil {
+			return fmt.Errorf("cannot patch view %s: %w", viewID, resp.Error)
+		}
+	}
+	bp.patchViews = map[string]*model.ViewPatch{}
+
+	for _, viewID := range bp.deleteViews {
+		if _, resp := c.DeleteView(bp.BoardID, viewID); resp.Error != nil {
+			return fmt.Errorf("cannot delete view %s: %w", viewID, resp.Error)
+		}
+	}
+	bp.deleteViews = nil
+
+	for _, member := range bp.addMembers {
+		member.BoardID = bp.BoardID
+		if _, resp := c.AddMemberToBoard(member); resp.Error != nil {
+			return fmt.Errorf("cannot add member %s: %w", member.UserID, resp.Error)
+		}
+	}
+	bp.addMembers = nil
+
+	for _, member := range bp.updateMembers {
+		member.BoardID = bp.BoardID
+		if _, resp := c.UpdateBoardMember(member); resp.Error != nil {
+			return fmt.Errorf("cannot update member %s: %w", member.UserID, resp.Error)
+		}
+	}
+	bp.updateMembers = nil
+
+	for _, member := range bp.removeMembers {
+		if _, resp := c.DeleteBoardMember(member); resp.Error != nil {
+			return fmt.Errorf("cannot remove member %s: %w", member.UserID, resp.Error)
+		}
+	}
+	bp.removeMembers = nil
+
+	if bp.category != nil {
+		categoryID := bp.category.id
+		if categoryID == "" {
+			category, resp := c.CreateCategory(model.Category{
+				Name:   bp.category.name,
+				UserID: bp.userID,
+				TeamID: bp.TeamID,
+				Type:   model.CategoryTypeCustom,
+			})
+			if resp.Error != nil {
+				return fmt.Errorf("cannot create category %q: %w", bp.category.name, resp.Error)
+			}
+			categoryID = category.ID
+		}
+		if resp := c.UpdateCategoryBoard(bp.TeamID, categoryID, bp.BoardID); resp.Error != nil {
+			return fmt.Errorf("cannot add the board to category %q: %w", bp.category.name, resp.Error)
+		}
+		bp.category = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,759 @@
+package boardspec
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	propTypeSelect      = "select"
+	propTypeMultiSelect = "multiSelect"
+
+	// titlePropertyID is the ID views use for the title of the cards.
+	titlePropertyID = "title"
+
+	defaultOptionColor = "propColorDefault"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type Kind string
+
+const (
+	KindBoard    Kind = "board"
+	KindProperty Kind = "property"
+	KindView     Kind = "view"
+	KindMember   Kind = "member"
+	KindCategory Kind = "category"
+)
+
+var actionSymbols = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// Options are the options of a plan.
+type Options struct {
+	// Prune deletes the properties, options, views and members of the
+	// live boards that the spec doesn't describe. They are reported as
+	// drift otherwise.
+	Prune bool
+}
+
+// Change is a difference between the spec and a live board.
+type Change struct {
+	Action Action `json:"action"`
+	Kind   Kind   `json:"kind"`
+	Name   string `json:"name"`
+
+	// Details are the differences of the fields of updated objects.
+	Details []string `json:"details,omitempty"`
+}
+
+func (c *Change) String() string {
+	s := fmt.Sprintf("%s %s %q", actionSymbols[c.Action], c.Kind, c.Name)
+	if len(c.Details) > 0 {
+		s += ": " + strings.Join(c.Details, ", ")
+	}
+	return s
+}
+
+// Plan is the changes that make the live boards match a spec.
+type Plan struct {
+	Boards []*BoardPlan `json:"boards"`
+
+	// Drift are the objects of the live boards that the spec doesn't
+	// describe, and that are kept as they are without the prune option.
+	Drift []*BoardDrift `json:"drift,omitempty"`
+}
+
+// BoardPlan is the changes of a board.
+type BoardPlan struct {
+	TeamID string `json:"teamId"`
+	Title  string `json:"title"`
+
+	// BoardID is the ID of the live board, empty for new boards until
+	// the plan is applied.
+	BoardID string `json:"boardId,omitempty"`
+
+	Changes []*Change `json:"changes"`
+
+	userID        string
+	create        *model.Board
+	patch         *model.BoardPatch
+	createViews   []*model.View
+	patchViews    map[string]*model.ViewPatch
+	deleteViews   []string
+	addMembers    []*model.BoardMember
+	updateMembers []*model.BoardMember
+	removeMembers []*model.BoardMember
+	category      *categoryChange
+}
+
+// BoardDrift is the objects of a live board that the spec doesn't
+// describe.
+type BoardDrift struct {
+	TeamID  string    `json:"teamId"`
+	Title   string    `json:"title"`
+	BoardID string    `json:"boardId"`
+	Changes []*Change `json:"changes"`
+}
+
+type categoryChange struct {
+	id   string
+	name string
+}
+
+// HasChanges returns true if applying the plan changes any board.
+func (p *Plan) HasChanges() bool {
+	for _, board := range p.Boards {
+		if len(board.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteReport writes the changes of the plan, and the drift of the live
+// boards.
+func (p *Plan) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+
+	changed := 0
+	for _, board := range p.Boards {
+		if len(board.Changes) == 0 {
+			continue
+		}
+		changed++
+		fmt.Fprintf(&sb, "Board %q (team %s%s):\n", board.Title, board.TeamID, boardIDSuffix(board.BoardID))
+		for _, change := range board.Changes {
+			fmt.Fprintf(&sb, "  %s\n", change)
+		}
+	}
+
+	for _, drift := range p.Drift {
+		fmt.Fprintf(&sb, "Drift of board %q (team %s%s), kept without prune:\n", drift.Title, drift.TeamID, boardIDSuffix(drift.BoardID))
+		for _, change := range drift.Changes {
+			fmt.Fprintf(&sb, "  %s\n", change)
+		}
+	}
+
+	if changed == 0 {
+		fmt.Fprintf(&sb, "No changes, the %d boards match the spec.\n", len(p.Boards))
+	} else {
+		fmt.Fprintf(&sb, "%d of %d boards to change.\n", changed, len(p.Boards))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func boardIDSuffix(boardID string) string {
+	if boardID == "" {
+		return ""
+	}
+	return ", " + boardID
+}
+
+// NewPlan compares a spec with the live boards, as seen by the user of
+// the client.
+func NewPlan(c *client.Client, spec *Spec, opts Options) (*Plan, error) {
+	me, resp := c.GetMe()
+	if resp.Error != nil {
+		return nil, fmt.Errorf("cannot get the current user: %w", resp.Error)
+	}
+
+	p := &planner{
+		c:          c,
+		userID:     me.ID,
+		opts:       opts,
+		boards:     map[string][]*model.Board{},
+		categories: map[string][]model.CategoryBoards{},
+	}
+
+	plan := &Plan{}
+	for _, boardSpec := range spec.Boards {
+		bp, drift, err := p.planBoard(boardSpec, spec.boardSchema(boardSpec))
+		if err != nil {
+			return nil, fmt.Errorf("board %q: %w", boardSpec.Title, err)
+		}
+		plan.Boards = append(plan.Boards, bp)
+		if drift != nil {
+			plan.Drift = append(plan.Drift, drift)
+		}
+	}
+
+	return plan, nil
+}
+
+// planner fetches the live boards of a plan, caching the ones of each
+// team.
+type planner struct {
+	c          *client.Client
+	userID     string
+	opts       Options
+	boards     map[string][]*model.Board
+	categories map[string][]model.CategoryBoards
+}
+
+func (p *planner) planBoard(spec *BoardSpec, schema *Schema) (*BoardPlan, *BoardDrift, error) {
+	board, err := p.findBoard(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bp := &BoardPlan{
+		TeamID:     spec.TeamID,
+		Title:      spec.Title,
+		userID:     p.userID,
+		patchViews: map[string]*model.ViewPatch{},
+	}
+
+	var views []*model.View
+	var members []*model.BoardMember
+	if board == nil {
+		board = &model.Board{
+			TeamID: spec.TeamID,
+			Type:   model.BoardTypeOpen,
+		}
+		bp.create = board
+		bp.Changes = append(bp.Changes, &Change{Action: ActionCreate, Kind: KindBoard, Name: spec.Title})
+	} else {
+		bp.BoardID = board.ID
+
+		var resp *client.Response
+		if views, resp = p.c.GetViews(board.ID); resp.Error != nil {
+			return nil, nil, resp.Error
+		}
+		if members, resp = p.c.GetMembersForBoard(board.ID); resp.Error != nil {
+			return nil, nil, resp.Error
+		}
+	}
+
+	var drift []*Change
+	propertyIDs, boardDrift := bp.diffBoard(spec, schema, board, p.opts.Prune)
+	drift = append(drift, boardDrift...)
+	drift = append(drift, bp.diffViews(schema.Views, propertyIDs, views, p.opts.Prune)...)
+	drift = append(drift, bp.diffMembers(spec.Members, members, p.opts.Prune)...)
+
+	if spec.Category != "" {
+		categories, err := p.getCategories(spec.TeamID)
+		if err != nil {
+			return nil, nil, err
+		}
+		bp.diffCategory(spec.Category, categories)
+	}
+
+	if len(drift) == 0 {
+		return bp, nil, nil
+	}
+	return bp, &BoardDrift{TeamID: spec.TeamID, Title: spec.Title, BoardID: bp.BoardID, Changes: drift}, nil
+}
+
+// findBoard returns the live board of a spec, or nil if it doesn't
+// exist yet.
+func (p *planner) findBoard(spec *BoardSpec) (*model.Board, error) {
+	if spec.ID != "" {
+		board, resp := p.c.GetBoard(spec.ID, "")
+		if resp.Error != nil {
+			return nil, fmt.Errorf("cannot get board %s: %w", spec.ID, resp.Error)
+		}
+		return board, nil
+	}
+
+	boards, ok := p.boards[spec.TeamID]
+	if !ok {
+		var resp *client.Response
+		if boards, resp = p.c.GetBoardsForTeam(spec.TeamID); resp.Error != nil {
+			return nil, fmt.Errorf("cannot get the boards of team %s: %w", spec.TeamID, resp.Error)
+		}
+		p.boards[spec.TeamID] = boards
+	}
+
+	var found *model.Board
+	for _, board := range boards {
+		if board.IsTemplate || board.Title != spec.Title {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("several boards of team %s are titled %q, give the board ID", spec.TeamID, spec.Title)
+		}
+		found = board
+	}
+	return found, nil
+}
+
+func (p *planner) getCategories(teamID string) ([]model.CategoryBoards, error) {
+	if categories, ok := p.categories[teamID]; ok {
+		return categories, nil
+	}
+
+	categories, resp := p.c.GetUserCategoryBoards(teamID)
+	if resp.Error != nil {
+		return nil, fmt.Errorf("cannot get the categories of team %s: %w", teamID, resp.Error)
+	}
+	p.categories[teamID] = categories
+	return categories, nil
+}
+
+// diffBoard compares the fields and properties of a board, and returns
+// the IDs of the properties keyed by lowercase name, and the drift.
+func (bp *BoardPlan) diffBoard(spec *BoardSpec, schema *Schema, board *model.Board, prune bool) (map[string]string, []*Change) {
+	patch := &model.BoardPatch{}
+	var details []string
+
+	if board.Title != spec.Title {
+		if bp.create == nil {
+			details = append(details, fmt.Sprintf("title %q → %q", board.Title, spec.Title))
+		}
+		patch.Title = &spec.Title
+	}
+	if spec.Description != nil && board.Description != *spec.Description {
+		details = append(details, "description")
+		patch.Description = spec.Description
+	}
+	if spec.Icon != nil && board.Icon != *spec.Icon {
+		details = append(details, fmt.Sprintf("icon %q → %q", board.Icon, *spec.Icon))
+		patch.Icon = spec.Icon
+	}
+	if spec.Type != "" && board.Type != spec.Type {
+		if bp.create == nil {
+			details = append(details, fmt.Sprintf("type %s → %s", board.Type, spec.Type))
+		}
+		patch.Type = &spec.Type
+	}
+
+	if bp.create == nil && len(details) > 0 {
+		bp.Changes = append(bp.Changes, &Change{Action: ActionUpdate, Kind: KindBoard, Name: spec.Title, Details: details})
+	}
+
+	propertyIDs, drift := bp.diffProperties(schema.Properties, board.CardProperties, patch, prune)
+
+	if bp.create != nil {
+		bp.create = patch.Patch(bp.create)
+	} else if !reflect.DeepEqual(patch, &model.BoardPatch{}) {
+		bp.patch = patch
+	}
+
+	return propertyIDs, drift
+}
+
+// diffProperties adds the changes of the card properties of a board to
+// a patch, and returns the IDs of the properties keyed by lowercase
+// name, and the drift. The order of existing properties and options is
+// kept.
+func (bp *BoardPlan) diffProperties(specs []*PropertySpec, liveProps []map[string]interface{}, patch *model.BoardPatch, prune bool) (map[string]string, []*Change) {
+	propertyIDs := map[string]string{}
+	live := map[string]map[string]interface{}{}
+	for _, prop := range liveProps {
+		name, _ := prop["name"].(string)
+		if _, ok := live[strings.ToLower(name)]; !ok {
+			live[strings.ToLower(name)] = prop
+		}
+	}
+
+	for _, spec := range specs {
+		key := strings.ToLower(spec.Name)
+		prop, ok := live[key]
+		if !ok {
+			prop = map[string]interface{}{
+				"id":      utils.NewID(utils.IDTypeNone),
+				"name":    spec.Name,
+				"type":    spec.Type,
+				"options": newOptions(spec.Options),
+			}
+			propertyIDs[key] = prop["id"].(string)
+			patch.UpdatedCardProperties = append(patch.UpdatedCardProperties, prop)
+			bp.Changes = append(bp.Changes, &Change{Action: ActionCreate, Kind: KindProperty, Name: spec.Name})
+			continue
+		}
+
+		propertyIDs[key], _ = prop["id"].(string)
+		updated, details := diffProperty(spec, prop, prune)
+		if len(details) > 0 {
+			patch.UpdatedCardProperties = append(patch.UpdatedCardProperties, updated)
+			bp.Changes = append(bp.Changes, &Change{Action: ActionUpdate, Kind: KindProperty, Name: spec.Name, Details: details})
+		}
+	}
+
+	// properties and options the spec doesn't describe
+	var drift []*Change
+	specNames := map[string]*PropertySpec{}
+	for _, spec := range specs {
+		specNames[strings.ToLower(spec.Name)] = spec
+	}
+	for _, prop := range liveProps {
+		name, _ := prop["name"].(string)
+		id, _ := prop["id"].(string)
+		spec, ok := specNames[strings.ToLower(name)]
+		if !ok {
+			change := &Change{Action: ActionDelete, Kind: KindProperty, Name: name}
+			if prune {
+				patch.DeletedCardProperties = append(patch.DeletedCardProperties, id)
+				bp.Changes = append(bp.Changes, change)
+			} else {
+				drift = append(drift, change)
+			}
+			continue
+		}
+
+		if !prune {
+			if extra := extraOptions(spec, prop); len(extra) > 0 {
+				drift = append(drift, &Change{Action: ActionUpdate, Kind: KindProperty, Name: name, Details: extra})
+			}
+		}
+	}
+
+	return propertyIDs, drift
+}
+
+// diffProperty returns a live property updated to match its spec, and
+// the differences.
+func diffProperty(spec *PropertySpec, live map[string]interface{}, prune bool) (map[string]interface{}, []string) {
+	updated := map[string]interface{}{}
+	for k, v := range live {
+		updated[k] = v
+	}
+
+	var details []string
+	if liveType, _ := live["type"].(string); liveType != spec.Type {
+		details = append(details, fmt.Sprintf("type %s → %s", liveType, spec.Type))
+		updated["type"] = spec.Type
+	}
+
+	// options are kept in their live order, new ones last
+	specOptions := map[string]*OptionSpec{}
+	for _, option := range spec.Options {
+		specOptions[option.Value] = option
+	}
+
+	options := []interface{}{}
+	liveValues := map[string]bool{}
+	for _, o := range liveOptions(live) {
+		value, _ := o["value"].(string)
+		liveValues[value] = true
+
+		optionSpec, ok := specOptions[value]
+		if !ok && hasOptions(spec.Type) && !prune {
+			options = append(options, o)
+			continue
+		}
+		if !ok {
+			details = append(details, fmt.Sprintf("option %q deleted", value))
+			continue
+		}
+
+		if color, _ := o["color"].(string); optionSpec.Color != "" && color != optionSpec.Color {
+			details = append(details, fmt.Sprintf("option %q color %s → %s", value, color, optionSpec.Color))
+			option := map[string]interface{}{}
+			for k, v := range o {
+				option[k] = v
+			}
+			option["color"] = optionSpec.Color
+			o = option
+		}
+		options = append(options, o)
+	}
+
+	for _, option := range spec.Options {
+		if !liveValues[option.Value] {
+			details = append(details, fmt.Sprintf("option %q added", option.Value))
+			options = append(options, newOption(option))
+		}
+	}
+
+	if hasOptions(spec.Type) || len(liveOptions(live)) > 0 {
+		updated["options"] = options
+	}
+
+	return updated, details
+}
+
+// extraOptions returns the options of a live property that its spec
+// doesn't describe.
+func extraOptions(spec *PropertySpec, live map[string]interface{}) []string {
+	if !hasOptions(spec.Type) {
+		return nil
+	}
+
+	specValues := map[string]bool{}
+	for _, option := range spec.Options {
+		specValues[option.Value] = true
+	}
+
+	var extra []string
+	for _, o := range liveOptions(live) {
+		if value, _ := o["value"].(string); !specValues[value] {
+			extra = append(extra, fmt.Sprintf("option %q not in spec", value))
+		}
+	}
+	return extra
+}
+
+func liveOptions(prop map[string]interface{}) []map[string]interface{} {
+	list, _ := prop["options"].([]interface{})
+	options := make([]map[string]interface{}, 0, len(list))
+	for _, o := range list {
+		if option, ok := o.(map[string]interface{}); ok {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+func newOptions(specs []*OptionSpec) []interface{} {
+	options := []interface{}{}
+	for _, spec := range specs {
+		options = append(options, newOption(spec))
+	}
+	return options
+}
+
+func newOption(spec *OptionSpec) map[string]interface{} {
+	color := spec.Color
+	if color == "" {
+		color = defaultOptionColor
+	}
+	return map[string]interface{}{
+		"id":    utils.NewID(utils.IDTypeNone),
+		"value": spec.Value,
+		"color": color,
+	}
+}
+
+// diffViews compares the views of a board, ignoring private views, and
+// returns the drift.
+func (bp *BoardPlan) diffViews(specs []*ViewSpec, propertyIDs map[string]string, views []*model.View, prune bool) []*Change {
+	live := map[string]*model.View{}
+	for _, view := range views {
+		if view.OwnerID == "" {
+			if _, ok := live[view.Title]; !ok {
+				live[view.Title] = view
+			}
+		}
+	}
+
+	specTitles := map[string]bool{}
+	for _, spec := range specs {
+		specTitles[spec.Title] = true
+		fields := viewFields(spec, propertyIDs)
+
+		view, ok := live[spec.Title]
+		if !ok {
+			bp.createViews = append(bp.createViews, &model.View{Title: spec.Title, ViewFields: fields})
+			bp.Changes = append(bp.Changes, &Change{Action: ActionCreate, Kind: KindView, Name: spec.Title})
+			continue
+		}
+
+		patch, details := diffView(spec, fields, view)
+		if len(details) > 0 {
+			bp.patchViews[view.ID] = patch
+			bp.Changes = append(bp.Changes, &Change{Action: ActionUpdate, Kind: KindView, Name: spec.Title, Details: details})
+		}
+	}
+
+	var drift []*Change
+	for _, view := range views {
+		if view.OwnerID != "" || specTitles[view.Title] {
+			continue
+		}
+		change := &Change{Action: ActionDelete, Kind: KindView, Name: view.Title}
+		if prune {
+			bp.deleteViews = append(bp.deleteViews, view.ID)
+			bp.Changes = append(bp.Changes, change)
+		} else {
+			drift = append(drift, change)
+		}
+	}
+	return drift
+}
+
+// viewFields returns the fields a view spec sets, with property IDs.
+func viewFields(spec *ViewSpec, propertyIDs map[string]string) model.ViewFields {
+	propertyID := func(name string) string {
+		if name == titlePropertyID {
+			return titlePropertyID
+		}
+		return propertyIDs[strings.ToLower(name)]
+	}
+
+	fields := model.ViewFields{ViewType: spec.Type}
+	if spec.GroupBy != nil && *spec.GroupBy != "" {
+		fields.GroupByID = propertyID(*spec.GroupBy)
+	}
+	if spec.DateDisplayProperty != nil && *spec.DateDisplayProperty != "" {
+		fields.DateDisplayPropertyID = propertyID(*spec.DateDisplayProperty)
+	}
+	if spec.VisibleProperties != nil {
+		fields.VisiblePropertyIDs = []string{}
+		for _, name := range spec.VisibleProperties {
+			fields.VisiblePropertyIDs = append(fields.VisiblePropertyIDs, propertyID(name))
+		}
+	}
+	if spec.Sort != nil {
+		fields.SortOptions = []model.ViewSortOption{}
+		for _, sort := range spec.Sort {
+			fields.SortOptions = append(fields.SortOptions, model.ViewSortOption{
+				PropertyID: propertyID(sort.Property),
+				Reversed:   sort.Reversed,
+			})
+		}
+	}
+	return fields
+}
+
+// diffView returns the patch of a live view for the fields its spec
+// sets, and the differences.
+func diffView(spec *ViewSpec, fields model.ViewFields, view *model.View) (*model.ViewPatch, []string) {
+	patch := &model.ViewPatch{}
+	var details []string
+
+	if view.ViewType != fields.ViewType {
+		details = append(details, fmt.Sprintf("type %s → %s", view.ViewType, fields.ViewType))
+		patch.ViewType = &fields.ViewType
+	}
+	if spec.GroupBy != nil && view.GroupByID != fields.GroupByID {
+		details = append(details, "group by")
+		patch.GroupByID = &fields.GroupByID
+	}
+	if spec.DateDisplayProperty != nil && view.DateDisplayPropertyID != fields.DateDisplayPropertyID {
+		details = append(details, "date display property")
+		patch.DateDisplayPropertyID = &fields.DateDisplayPropertyID
+	}
+	if spec.VisibleProperties != nil && !equalStrings(view.VisiblePropertyIDs, fields.VisiblePropertyIDs) {
+		details = append(details, "visible properties")
+		patch.VisiblePropertyIDs = &fields.VisiblePropertyIDs
+	}
+	if spec.Sort != nil && !equalSortOptions(view.SortOptions, fields.SortOptions) {
+		details = append(details, "sort")
+		patch.SortOptions = &fields.SortOptions
+	}
+
+	return patch, details
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSortOptions(a, b []model.ViewSortOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diffMembers compares the members of a board, and returns the drift.
+// The user applying the spec is never removed.
+func (bp *BoardPlan) diffMembers(specs []*MemberSpec, members []*model.BoardMember, prune bool) []*Change {
+	live := map[string]*model.BoardMember{}
+	for _, member := range members {
+		live[member.UserID] = member
+	}
+
+	specUsers := map[string]bool{}
+	for _, spec := range specs {
+		specUsers[spec.UserID] = true
+
+		member := &model.BoardMember{BoardID: bp.BoardID, UserID: spec.UserID}
+		_ = member.SetRole(spec.Role)
+
+		liveMember, ok := live[spec.UserID]
+		// the creator of a new board is an admin of it
+		if !ok && bp.create != nil && spec.UserID == bp.userID {
+			liveMember = &model.BoardMember{}
+			_ = liveMember.SetRole(model.BoardRoleAdmin)
+			ok = true
+		}
+
+		switch {
+		case !ok:
+			bp.addMembers = append(bp.addMembers, member)
+			bp.Changes = append(bp.Changes, &Change{
+				Action:  ActionCreate,
+				Kind:    KindMember,
+				Name:    spec.UserID,
+				Details: []string{string(spec.Role)},
+			})
+		case liveMember.Role() != spec.Role:
+			bp.updateMembers = append(bp.updateMembers, member)
+			bp.Changes = append(bp.Changes, &Change{
+				Action:  ActionUpdate,
+				Kind:    KindMember,
+				Name:    spec.UserID,
+				Details: []string{fmt.Sprintf("%s → %s", liveMember.Role(), spec.Role)},
+			})
+		}
+	}
+
+	var drift []*Change
+	for _, member := range members {
+		if specUsers[member.UserID] || member.UserID == bp.userID {
+			continue
+		}
+		change := &Change{
+			Action:  ActionDelete,
+			Kind:    KindMember,
+			Name:    member.UserID,
+			Details: []string{string(member.Role())},
+		}
+		if prune {
+			bp.removeMembers = append(bp.removeMembers, member)
+			bp.Changes = append(bp.Changes, change)
+		} else {
+			drift = append(drift, change)
+		}
+	}
+	return drift
+}
+
+// diffCategory checks that the board is in the category of the spec,
+// for the user applying the spec.
+func (bp *BoardPlan) diffCategory(name string, categories []model.CategoryBoards) {
+	var categoryID string
+	for _, category := range categories {
+		if category.Name != name {
+			continue
+		}
+		categoryID = category.ID
+
+		for _, metadata := range category.BoardMetadata {
+			if bp.BoardID != "" && metadata.BoardID == bp.BoardID {
+				return
+			}
+		}
+	}
+
+	action := ActionUpdate
+	if categoryID == "" {
+		action = ActionCreate
+	}
+	bp.category = &categoryChange{id: categoryID, name: name}
+	bp.Changes = append(bp.Changes, &Change{Action: action, Kind: KindCategory, Name: name, Details: []string{"add board"}})
+}
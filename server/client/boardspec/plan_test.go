@@ -0,0 +1,268 @@
+package boardspec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestCardProperties() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"id":   "status-id",
+			"name": "Status",
+			"type": "select",
+			"options": []interface{}{
+				map[string]interface{}{"id": "todo-id", "value": "To Do", "color": "propColorRed"},
+				map[string]interface{}{"id": "done-id", "value": "Done", "color": "propColorGreen"},
+			},
+		},
+		{"id": "notes-id", "name": "Notes", "type": "text"},
+	}
+}
+
+func TestDiffProperties(t *testing.T) {
+	statusSpec := &PropertySpec{
+		Name:    "status",
+		Type:    "select",
+		Options: []*OptionSpec{{Value: "To Do"}, {Value: "Done", Color: "propColorGreen"}},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		bp := &BoardPlan{}
+		patch := &model.BoardPatch{}
+		ids, drift := bp.diffProperties([]*PropertySpec{statusSpec, {Name: "Notes", Type: "text"}}, newTestCardProperties(), patch, false)
+		require.Empty(t, bp.Changes)
+		require.Empty(t, drift)
+		require.Equal(t, &model.BoardPatch{}, patch)
+		require.Equal(t, map[string]string{"status": "status-id", "notes": "notes-id"}, ids)
+	})
+
+	t.Run("new property and option", func(t *testing.T) {
+		bp := &BoardPlan{}
+		patch := &model.BoardPatch{}
+		specs := []*PropertySpec{
+			{Name: "Status", Type: "select", Options: []*OptionSpec{{Value: "To Do"}, {Value: "Done"}, {Value: "Blocked"}}},
+			{Name: "Notes", Type: "text"},
+			{Name: "Due", Type: "date"},
+		}
+		ids, drift := bp.diffProperties(specs, newTestCardProperties(), patch, false)
+		require.Empty(t, drift)
+		require.Len(t, bp.Changes, 2)
+		require.Equal(t, &Change{Action: ActionUpdate, Kind: KindProperty, Name: "Status", Details: []string{`option "Blocked" added`}}, bp.Changes[0])
+		require.Equal(t, &Change{Action: ActionCreate, Kind: KindProperty, Name: "Due"}, bp.Changes[1])
+
+		require.Len(t, patch.UpdatedCardProperties, 2)
+		status := patch.UpdatedCardProperties[0]
+		require.Equal(t, "status-id", status["id"])
+		options := status["options"].([]interface{})
+		require.Len(t, options, 3)
+		require.Equal(t, "todo-id", options[0].(map[string]interface{})["id"])
+		require.Equal(t, "Blocked", options[2].(map[string]interface{})["value"])
+		require.Equal(t, defaultOptionColor, options[2].(map[string]interface{})["color"])
+
+		due := patch.UpdatedCardProperties[1]
+		require.Equal(t, ids["due"], due["id"])
+		require.NotEmpty(t, ids["due"])
+		require.Equal(t, "date", due["type"])
+	})
+
+	t.Run("type and color", func(t *testing.T) {
+		bp := &BoardPlan{}
+		patch := &model.BoardPatch{}
+		specs := []*PropertySpec{
+			{Name: "Status", Type: "select", Options: []*OptionSpec{{Value: "To Do", Color: "propColorBlue"}, {Value: "Done"}}},
+			{Name: "Notes", Type: "url"},
+		}
+		_, drift := bp.diffProperties(specs, newTestCardProperties(), patch, false)
+		require.Empty(t, drift)
+		require.Equal(t, []*Change{
+			{Action: ActionUpdate, Kind: KindProperty, Name: "Status", Details: []string{`option "To Do" color propColorRed → propColorBlue`}},
+			{Action: ActionUpdate, Kind: KindProperty, Name: "Notes", Details: []string{"type text → url"}},
+		}, bp.Changes)
+
+		options := patch.UpdatedCardProperties[0]["options"].([]interface{})
+		require.Equal(t, "propColorBlue", options[0].(map[string]interface{})["color"])
+		require.Equal(t, "url", patch.UpdatedCardProperties[1]["type"])
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		bp := &BoardPlan{}
+		patch := &model.BoardPatch{}
+		specs := []*PropertySpec{{Name: "Status", Type: "select", Options: []*OptionSpec{{Value: "To Do"}}}}
+		_, drift := bp.diffProperties(specs, newTestCardProperties(), patch, false)
+		require.Empty(t, bp.Changes)
+		require.Equal(t, &model.BoardPatch{}, patch)
+		require.Equal(t, []*Change{
+			{Action: ActionUpdate, Kind: KindProperty, Name: "Status", Details: []string{`option "Done" not in spec`}},
+			{Action: ActionDelete, Kind: KindProperty, Name: "Notes"},
+		}, drift)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		bp := &BoardPlan{}
+		patch := &model.BoardPatch{}
+		specs := []*PropertySpec{{Name: "Status", Type: "select", Options: []*OptionSpec{{Value: "To Do"}}}}
+		_, drift := bp.diffProperties(specs, newTestCardProperties(), patch, true)
+		require.Empty(t, drift)
+		require.Equal(t, []*Change{
+			{Action: ActionUpdate, Kind: KindProperty, Name: "Status", Details: []string{`option "Done" deleted`}},
+			{Action: ActionDelete, Kind: KindProperty, Name: "Notes"},
+		}, bp.Changes)
+		require.Equal(t, []string{"notes-id"}, patch.DeletedCardProperties)
+		require.Len(t, patch.UpdatedCardProperties[0]["options"], 1)
+	})
+}
+
+func TestDiffViews(t *testing.T) {
+	propertyIDs := map[string]string{"status": "status-id", "notes": "notes-id"}
+	groupBy := "Status"
+	views := []*model.View{
+		{
+			ID:    "board-view-id",
+			Title: "By status",
+			ViewFields: model.ViewFields{
+				ViewType:           model.ViewTypeBoard,
+				GroupByID:          "status-id",
+				VisiblePropertyIDs: []string{"notes-id"},
+			},
+		},
+		{ID: "table-view-id", Title: "All", ViewFields: model.ViewFields{ViewType: model.ViewTypeTable}},
+		{ID: "private-view-id", Title: "Mine", OwnerID: "user-id", ViewFields: model.ViewFields{ViewType: model.ViewTypeTable}},
+	}
+
+	t.Run("unset fields are kept", func(t *testing.T) {
+		bp := &BoardPlan{patchViews: map[string]*model.ViewPatch{}}
+		specs := []*ViewSpec{
+			{Title: "By status", Type: model.ViewTypeBoard, GroupBy: &groupBy},
+			{Title: "All", Type: model.ViewTypeTable},
+		}
+		drift := bp.diffViews(specs, propertyIDs, views, false)
+		require.Empty(t, drift)
+		require.Empty(t, bp.Changes)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		bp := &BoardPlan{patchViews: map[string]*model.ViewPatch{}}
+		specs := []*ViewSpec{
+			{Title: "By status", Type: model.ViewTypeBoard, VisibleProperties: []string{"Notes", "Status"}},
+			{Title: "All", Type: model.ViewTypeGallery, Sort: []*SortSpec{{Property: "title", Reversed: true}}},
+			{Title: "Calendar", Type: model.ViewTypeCalendar},
+		}
+		drift := bp.diffViews(specs, propertyIDs, views, false)
+		require.Empty(t, drift)
+		require.Equal(t, []*Change{
+			{Action: ActionUpdate, Kind: KindView, Name: "By status", Details: []string{"visible properties"}},
+			{Action: ActionUpdate, Kind: KindView, Name: "All", Details: []string{"type table → gallery", "sort"}},
+			{Action: ActionCreate, Kind: KindView, Name: "Calendar"},
+		}, bp.Changes)
+
+		require.Equal(t, []string{"notes-id", "status-id"}, *bp.patchViews["board-view-id"].VisiblePropertyIDs)
+		require.Nil(t, bp.patchViews["board-view-id"].GroupByID)
+		require.Equal(t, []model.ViewSortOption{{PropertyID: "title", Reversed: true}}, *bp.patchViews["table-view-id"].SortOptions)
+		require.Len(t, bp.createViews, 1)
+		require.Equal(t, model.ViewTypeCalendar, bp.createViews[0].ViewType)
+	})
+
+	t.Run("drift and prune", func(t *testing.T) {
+		specs := []*ViewSpec{{Title: "All", Type: model.ViewTypeTable}}
+		expected := []*Change{{Action: ActionDelete, Kind: KindView, Name: "By status"}}
+
+		bp := &BoardPlan{patchViews: map[string]*model.ViewPatch{}}
+		require.Equal(t, expected, bp.diffViews(specs, propertyIDs, views, false))
+		require.Empty(t, bp.Changes)
+
+		bp = &BoardPlan{patchViews: map[string]*model.ViewPatch{}}
+		require.Empty(t, bp.diffViews(specs, propertyIDs, views, true))
+		require.Equal(t, expected, bp.Changes)
+		require.Equal(t, []string{"board-view-id"}, bp.deleteViews)
+	})
+}
+
+func TestDiffMembers(t *testing.T) {
+	newMember := func(userID string, role model.BoardRole) *model.BoardMember {
+		member := &model.BoardMember{BoardID: "board-id", UserID: userID}
+		require.NoError(t, member.SetRole(role))
+		return member
+	}
+	members := []*model.BoardMember{
+		newMember("me", model.BoardRoleAdmin),
+		newMember("user-1", model.BoardRoleViewer),
+		newMember("user-2", model.BoardRoleEditor),
+	}
+	specs := []*MemberSpec{
+		{UserID: "user-1", Role: model.BoardRoleCommenter},
+		{UserID: "user-3", Role: model.BoardRoleEditor},
+	}
+
+	bp := &BoardPlan{BoardID: "board-id", userID: "me"}
+	drift := bp.diffMembers(specs, members, false)
+	require.Equal(t, []*Change{
+		{Action: ActionUpdate, Kind: KindMember, Name: "user-1", Details: []string{"viewer → commenter"}},
+		{Action: ActionCreate, Kind: KindMember, Name: "user-3", Details: []string{"editor"}},
+	}, bp.Changes)
+	require.Equal(t, []*Change{{Action: ActionDelete, Kind: KindMember, Name: "user-2", Details: []string{"editor"}}}, drift)
+	require.Equal(t, []*model.BoardMember{newMember("user-3", model.BoardRoleEditor)}, bp.addMembers)
+	require.Equal(t, []*model.BoardMember{newMember("user-1", model.BoardRoleCommenter)}, bp.updateMembers)
+
+	bp = &BoardPlan{BoardID: "board-id", userID: "me"}
+	require.Empty(t, bp.diffMembers(specs, members, true))
+	require.Equal(t, []*model.BoardMember{members[2]}, bp.removeMembers)
+
+	t.Run("creator of a new board", func(t *testing.T) {
+		bp := &BoardPlan{userID: "me", create: &model.Board{}}
+		bp.diffMembers([]*MemberSpec{{UserID: "me", Role: model.BoardRoleAdmin}}, nil, true)
+		require.Empty(t, bp.Changes)
+	})
+}
+
+func TestDiffCategory(t *testing.T) {
+	categories := []model.CategoryBoards{
+		{
+			Category:      model.Category{ID: "category-id", Name: "Planning"},
+			BoardMetadata: []model.CategoryBoardMetadata{{BoardID: "board-id"}},
+		},
+	}
+
+	bp := &BoardPlan{BoardID: "board-id"}
+	bp.diffCategory("Planning", categories)
+	require.Empty(t, bp.Changes)
+
+	bp = &BoardPlan{BoardID: "other-board-id"}
+	bp.diffCategory("Planning", categories)
+	require.Equal(t, &categoryChange{id: "category-id", name: "Planning"}, bp.category)
+	require.Equal(t, ActionUpdate, bp.Changes[0].Action)
+
+	bp = &BoardPlan{}
+	bp.diffCategory("Archive", categories)
+	require.Equal(t, &categoryChange{name: "Archive"}, bp.category)
+	require.Equal(t, ActionCreate, bp.Changes[0].Action)
+}
+
+func TestWriteReport(t *testing.T) {
+	plan := &Plan{
+		Boards: []*BoardPlan{
+			{TeamID: "team-id", Title: "Roadmap", Changes: []*Change{{Action: ActionCreate, Kind: KindBoard, Name: "Roadmap"}}},
+			{TeamID: "team-id", Title: "Bugs", BoardID: "board-id"},
+		},
+		Drift: []*BoardDrift{
+			{TeamID: "team-id", Title: "Bugs", BoardID: "board-id", Changes: []*Change{{Action: ActionDelete, Kind: KindView, Name: "Old"}}},
+		},
+	}
+	require.True(t, plan.HasChanges())
+
+	var buf bytes.Buffer
+	require.NoError(t, plan.WriteReport(&buf))
+	require.Equal(t, `Board "Roadmap" (team team-id):
+  + board "Roadmap"
+Drift of board "Bugs" (team team-id, board-id), kept without prune:
+  - view "Old"
+1 of 2 boards to change.
+`, buf.String())
+
+	plan.Boards[0].Changes = nil
+	require.False(t, plan.HasChanges())
+}
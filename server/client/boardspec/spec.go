@@ -0,0 +1,271 @@
+// Package boardspec provisions boards from a declarative spec, in YAML
+// or JSON, describing their properties, views, members and categories.
+// A plan compares the spec with the live boards through the API client,
+// and applying it makes the live boards match the spec.
+package boardspec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+var ErrInvalidSpec = errors.New("invalid spec")
+
+// Spec describes boards, with schemas that several boards can share.
+type Spec struct {
+	// Schemas are the properties and views shared by boards, keyed by
+	// name.
+	Schemas map[string]*Schema `yaml:"schemas,omitempty"`
+
+	Boards []*BoardSpec `yaml:"boards"`
+}
+
+// Schema are the card properties and views of boards.
+type Schema struct {
+	Properties []*PropertySpec `yaml:"properties,omitempty"`
+	Views      []*ViewSpec     `yaml:"views,omitempty"`
+}
+
+// BoardSpec describes a board. Boards are matched by ID if given, or by
+// team and title otherwise. Fields that are not set are left as they
+// are on the live board.
+type BoardSpec struct {
+	ID          string          `yaml:"id,omitempty"`
+	TeamID      string          `yaml:"team"`
+	Title       string          `yaml:"title"`
+	Description *string         `yaml:"description,omitempty"`
+	Icon        *string         `yaml:"icon,omitempty"`
+	Type        model.BoardType `yaml:"type,omitempty"`
+
+	// Schema is the name of the schema of the board, whose properties
+	// and views come before the ones of the board.
+	Schema string `yaml:"schema,omitempty"`
+
+	Properties []*PropertySpec `yaml:"properties,omitempty"`
+	Views      []*ViewSpec     `yaml:"views,omitempty"`
+	Members    []*MemberSpec   `yaml:"members,omitempty"`
+
+	// Category is the name of the sidebar category of the board, for
+	// the user applying the spec.
+	Category string `yaml:"category,omitempty"`
+}
+
+// PropertySpec describes a card property, matched by name.
+type PropertySpec struct {
+	Name    string        `yaml:"name"`
+	Type    string        `yaml:"type"`
+	Options []*OptionSpec `yaml:"options,omitempty"`
+}
+
+// OptionSpec describes an option of a select or multiSelect property,
+// matched by value. The color is left as it is if not set.
+type OptionSpec struct {
+	Value string `yaml:"value"`
+	Color string `yaml:"color,omitempty"`
+}
+
+// ViewSpec describes a view, matched by title. Properties are given by
+// name, and fields that are not set are left as they are.
+type ViewSpec struct {
+	Title               string         `yaml:"title"`
+	Type                model.ViewType `yaml:"type"`
+	GroupBy             *string        `yaml:"groupBy,omitempty"`
+	DateDisplayProperty *string        `yaml:"dateDisplayProperty,omitempty"`
+	VisibleProperties   []string       `yaml:"visibleProperties,omitempty"`
+	Sort                []*SortSpec    `yaml:"sort,omitempty"`
+}
+
+// SortSpec is a sort criteria of a view. The property "title" sorts by
+// card title.
+type SortSpec struct {
+	Property string `yaml:"property"`
+	Reversed bool   `yaml:"reversed,omitempty"`
+}
+
+// MemberSpec describes a member of a board.
+type MemberSpec struct {
+	UserID string          `yaml:"user"`
+	Role   model.BoardRole `yaml:"role"`
+}
+
+// LoadFile reads a spec from a file.
+func LoadFile(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Load reads a spec in YAML or JSON, and checks that it is valid.
+func Load(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var spec Spec
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSpec, err)
+	}
+
+	if err := spec.IsValid(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// IsValid checks that the spec is valid.
+func (s *Spec) IsValid() error {
+	if len(s.Boards) == 0 {
+		return fmt.Errorf("%w: no boards", ErrInvalidSpec)
+	}
+
+	boards := map[string]bool{}
+	for i, board := range s.Boards {
+		name := fmt.Sprintf("board %d (%s)", i+1, board.Title)
+
+		if board.TeamID == "" {
+			return fmt.Errorf("%w: %s: missing team", ErrInvalidSpec, name)
+		}
+		if board.Title == "" {
+			return fmt.Errorf("%w: %s: missing title", ErrInvalidSpec, name)
+		}
+		if board.Type != "" && !model.IsBoardTypeValid(board.Type) {
+			return fmt.Errorf("%w: %s: invalid type %q", ErrInvalidSpec, name, board.Type)
+		}
+
+		key := board.ID
+		if key == "" {
+			key = board.TeamID + "/" + board.Title
+		}
+		if boards[key] {
+			return fmt.Errorf("%w: %s: described twice", ErrInvalidSpec, name)
+		}
+		boards[key] = true
+
+		if board.Schema != "" && s.Schemas[board.Schema] == nil {
+			return fmt.Errorf("%w: %s: unknown schema %q", ErrInvalidSpec, name, board.Schema)
+		}
+
+		if err := isBoardValid(s.boardSchema(board)); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidSpec, name, err)
+		}
+
+		members := map[string]bool{}
+		for _, member := range board.Members {
+			if member.UserID == "" {
+				return fmt.Errorf("%w: %s: member without user", ErrInvalidSpec, name)
+			}
+			if members[member.UserID] {
+				return fmt.Errorf("%w: %s: member %s given twice", ErrInvalidSpec, name, member.UserID)
+			}
+			members[member.UserID] = true
+
+			if err := (&model.BoardMember{}).SetRole(member.Role); err != nil {
+				return fmt.Errorf("%w: %s: member %s: %s", ErrInvalidSpec, name, member.UserID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// boardSchema returns the properties and views of a board, with the
+// ones of its schema.
+func (s *Spec) boardSchema(board *BoardSpec) *Schema {
+	schema := &Schema{}
+	if shared := s.Schemas[board.Schema]; board.Schema != "" && shared != nil {
+		schema.Properties = append(schema.Properties, shared.Properties...)
+		schema.Views = append(schema.Views, shared.Views...)
+	}
+	schema.Properties = append(schema.Properties, board.Properties...)
+	schema.Views = append(schema.Views, board.Views...)
+	return schema
+}
+
+func isBoardValid(schema *Schema) error {
+	properties := map[string]*PropertySpec{}
+	for _, prop := range schema.Properties {
+		if prop.Name == "" {
+			return errors.New("property without name")
+		}
+		key := strings.ToLower(prop.Name)
+		if properties[key] != nil {
+			return fmt.Errorf("property %q given twice", prop.Name)
+		}
+		properties[key] = prop
+
+		if prop.Type == "" {
+			return fmt.Errorf("property %q without type", prop.Name)
+		}
+		if len(prop.Options) > 0 && !hasOptions(prop.Type) {
+			return fmt.Errorf("property %q of type %s can't have options", prop.Name, prop.Type)
+		}
+
+		options := map[string]bool{}
+		for _, option := range prop.Options {
+			if option.Value == "" {
+				return fmt.Errorf("property %q: option without value", prop.Name)
+			}
+			if options[option.Value] {
+				return fmt.Errorf("property %q: option %q given twice", prop.Name, option.Value)
+			}
+			options[option.Value] = true
+		}
+	}
+
+	views := map[string]bool{}
+	for _, view := range schema.Views {
+		if view.Title == "" {
+			return errors.New("view without title")
+		}
+		if views[view.Title] {
+			return fmt.Errorf("view %q given twice", view.Title)
+		}
+		views[view.Title] = true
+
+		switch view.Type {
+		case model.ViewTypeBoard, model.ViewTypeTable, model.ViewTypeGallery, model.ViewTypeCalendar:
+		default:
+			return fmt.Errorf("view %q: invalid type %q", view.Title, view.Type)
+		}
+
+		names := append([]string{}, view.VisibleProperties...)
+		if view.GroupBy != nil && *view.GroupBy != "" {
+			names = append(names, *view.GroupBy)
+		}
+		if view.DateDisplayProperty != nil && *view.DateDisplayProperty != "" {
+			names = append(names, *view.DateDisplayProperty)
+		}
+		for _, sort := range view.Sort {
+			if sort.Property != titlePropertyID {
+				names = append(names, sort.Property)
+			}
+		}
+		for _, name := range names {
+			if properties[strings.ToLower(name)] == nil {
+				return fmt.Errorf("view %q: unknown property %q", view.Title, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasOptions(propType string) bool {
+	return propType == propTypeSelect || propType == propTypeMultiSelect
+}
@@ -0,0 +1,145 @@
+package boardspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const testSpec = `
+schemas:
+  tasks:
+    properties:
+      - name: Status
+        type: select
+        options:
+          - value: To Do
+            color: propColorRed
+          - value: Done
+    views:
+      - title: By status
+        type: board
+        groupBy: Status
+boards:
+  - team: team-id
+    title: Roadmap
+    schema: tasks
+    type: P
+    icon: "🗺️"
+    properties:
+      - name: Due
+        type: date
+    views:
+      - title: Calendar
+        type: calendar
+        dateDisplayProperty: Due
+        sort:
+          - property: title
+            reversed: true
+    members:
+      - user: user-id
+        role: editor
+    category: Planning
+`
+
+func TestLoad(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		spec, err := Load(strings.NewReader(testSpec))
+		require.NoError(t, err)
+		require.Len(t, spec.Boards, 1)
+
+		board := spec.Boards[0]
+		require.Equal(t, "team-id", board.TeamID)
+		require.Equal(t, model.BoardTypePrivate, board.Type)
+		require.Equal(t, "🗺️", *board.Icon)
+		require.Nil(t, board.Description)
+		require.Equal(t, []*MemberSpec{{UserID: "user-id", Role: model.BoardRoleEditor}}, board.Members)
+		require.Equal(t, "Planning", board.Category)
+
+		schema := spec.boardSchema(board)
+		require.Equal(t, []string{"Status", "Due"}, []string{schema.Properties[0].Name, schema.Properties[1].Name})
+		require.Equal(t, []*OptionSpec{{Value: "To Do", Color: "propColorRed"}, {Value: "Done"}}, schema.Properties[0].Options)
+		require.Equal(t, []string{"By status", "Calendar"}, []string{schema.Views[0].Title, schema.Views[1].Title})
+		require.Equal(t, []*SortSpec{{Property: "title", Reversed: true}}, schema.Views[1].Sort)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		spec, err := Load(strings.NewReader(`{"boards": [{"team": "team-id", "title": "Roadmap", "properties": [{"name": "Notes", "type": "text"}]}]}`))
+		require.NoError(t, err)
+		require.Equal(t, "Notes", spec.Boards[0].Properties[0].Name)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := Load(strings.NewReader("boards:\n  - team: team-id\n    title: Roadmap\n    color: red\n"))
+		require.ErrorIs(t, err, ErrInvalidSpec)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Load(strings.NewReader(""))
+		require.ErrorIs(t, err, ErrInvalidSpec)
+	})
+}
+
+func TestSpecIsValid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		board string
+		err   string
+	}{
+		{"missing team", "title: Roadmap", "missing team"},
+		{"missing title", "team: team-id", "missing title"},
+		{"invalid type", "team: team-id\n    title: Roadmap\n    type: X", `invalid type "X"`},
+		{"unknown schema", "team: team-id\n    title: Roadmap\n    schema: bugs", `unknown schema "bugs"`},
+		{
+			"duplicated property",
+			"team: team-id\n    title: Roadmap\n    properties:\n      - {name: Notes, type: text}\n      - {name: notes, type: text}",
+			`property "notes" given twice`,
+		},
+		{
+			"property without type",
+			"team: team-id\n    title: Roadmap\n    properties:\n      - {name: Notes}",
+			`property "Notes" without type`,
+		},
+		{
+			"options of a text property",
+			"team: team-id\n    title: Roadmap\n    properties:\n      - {name: Notes, type: text, options: [{value: A}]}",
+			"can't have options",
+		},
+		{
+			"duplicated option",
+			"team: team-id\n    title: Roadmap\n    properties:\n      - {name: Status, type: select, options: [{value: A}, {value: A}]}",
+			`option "A" given twice`,
+		},
+		{
+			"invalid view type",
+			"team: team-id\n    title: Roadmap\n    views:\n      - {title: All, type: list}",
+			`invalid type "list"`,
+		},
+		{
+			"unknown view property",
+			"team: team-id\n    title: Roadmap\n    views:\n      - {title: All, type: table, visibleProperties: [Status]}",
+			`unknown property "Status"`,
+		},
+		{
+			"invalid member role",
+			"team: team-id\n    title: Roadmap\n    members:\n      - {user: user-id, role: owner}",
+			"member user-id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader("boards:\n  - " + tc.board + "\n"))
+			require.ErrorIs(t, err, ErrInvalidSpec)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	t.Run("board described twice", func(t *testing.T) {
+		_, err := Load(strings.NewReader("boards:\n  - {team: team-id, title: Roadmap}\n  - {team: team-id, title: Roadmap}\n"))
+		require.ErrorContains(t, err, "described twice")
+	})
+}
@@ -2,12 +2,18 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/mattermost/focalboard/server/api"
 	"github.com/mattermost/focalboard/server/model"
@@ -17,8 +23,53 @@ import (
 
 const (
 	APIURLSuffix = "/api/v2"
+
+	// DefaultResponseHeaderTimeout is the time the HTTP client of
+	// NewClient waits for the headers of a response, so requests on
+	// dead connections fail instead of hanging.
+	DefaultResponseHeaderTimeout = time.Minute
 )
 
+// DefaultRetryPolicy is the retry policy of NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// RetryPolicy configures how failed requests are retried. Idempotent
+// requests (GET, HEAD, PUT, DELETE and OPTIONS) are retried on network
+// errors and on 502, 503 and 504 responses. Any request is retried when
+// rate limited with a 429 response. The delay between retries doubles
+// from MinBackoff up to MaxBackoff, unless the server asks for a delay
+// with a Retry-After header.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a request. Zero
+	// disables retries.
+	MaxRetries int
+
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// backoff returns the delay before a retry, with some jitter so that
+// clients don't retry in lockstep.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.MinBackoff
+	for i := 0; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	//nolint:gosec
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
 type RequestReaderError struct {
 	buf []byte
 }
@@ -74,6 +125,10 @@ type Client struct {
 	HTTPHeader map[string]string
 	// Token if token is empty indicate client is not login yet
 	Token string
+	// Retry is the retry policy of the requests
+	Retry RetryPolicy
+
+	ctx context.Context
 }
 
 func NewClient(url, sessionToken string) *Client {
@@ -83,7 +138,33 @@ func NewClient(url, sessionToken string) *Client {
 		"X-Requested-With": "XMLHttpRequest",
 	}
 
-	return &Client{url, url + APIURLSuffix, &http.Client{}, headers, sessionToken}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = DefaultResponseHeaderTimeout
+
+	return &Client{
+		URL:        url,
+		APIURL:     url + APIURLSuffix,
+		HTTPClient: &http.Client{Transport: transport},
+		HTTPHeader: headers,
+		Token:      sessionToken,
+		Retry:      DefaultRetryPolicy,
+	}
+}
+
+// WithContext returns a copy of the client that makes its requests
+// with the given context, so they can be canceled or time out. The copy
+// shares the HTTP client and headers of the original client.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
 }
 
 func (c *Client) DoAPIGet(url, etag string) (*http.Response, error) {
@@ -113,7 +194,82 @@ func (c *Client) DoAPIRequest(method, url, data, etag string) (*http.Response, e
 type requestOption func(r *http.Request)
 
 func (c *Client) doAPIRequestReader(method, url string, data io.Reader, _ /* etag */ string, opts ...requestOption) (*http.Response, error) {
-	rq, err := http.NewRequest(method, url, data)
+	ctx := c.context()
+
+	// the body is kept to send it again on retries
+	var body []byte
+	if c.Retry.MaxRetries > 0 && data != nil {
+		var err error
+		if body, err = io.ReadAll(data); err != nil {
+			return nil, err
+		}
+	}
+
+	for retry := 0; ; retry++ {
+		if body != nil {
+			data = bytes.NewReader(body)
+		}
+
+		rp, err := c.doAPIRequestOnce(ctx, method, url, data, opts...)
+
+		delay, ok := c.retryDelay(ctx, method, retry, err)
+		if !ok {
+			return rp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return rp, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns the delay before retrying a failed request, and
+// false if the request shouldn't be retried.
+func (c *Client) retryDelay(ctx context.Context, method string, retry int, err error) (time.Duration, bool) {
+	if err == nil || retry >= c.Retry.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// network errors
+		var netErr net.Error
+		if isIdempotent(method) && (errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+			return c.Retry.backoff(retry), true
+		}
+		return 0, false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, true
+	}
+	return c.Retry.backoff(retry), true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func (c *Client) doAPIRequestOnce(ctx context.Context, method, url string, data io.Reader, opts ...requestOption) (*http.Response, error) {
+	rq, err := http.NewRequestWithContext(ctx, method, url, data)
 	if err != nil {
 		return nil, err
 	}
@@ -141,289 +297,952 @@ func (c *Client) doAPIRequestReader(method, url string, data io.Reader, _ /* eta
 		return rp, nil
 	}
 
-	if rp.StatusCode >= http.StatusMultipleChoices {
-		defer closeBody(rp)
-		b, err := io.ReadAll(rp.Body)
-		if err != nil {
-			return rp, fmt.Errorf("error when parsing response with code %d: %w", rp.StatusCode, err)
-		}
-		return rp, RequestReaderError{b}
+	if rp.StatusCode >= http.StatusMultipleChoices {
+		defer closeBody(rp)
+		b, err := io.ReadAll(rp.Body)
+		if err != nil {
+			return rp, fmt.Errorf("error when parsing response with code %d: %w", rp.StatusCode, err)
+		}
+		return rp, newAPIError(rp, b)
+	}
+
+	return rp, nil
+}
+
+func (c *Client) GetTeamRoute(teamID string) string {
+	return fmt.Sprintf("%s/%s", c.GetTeamsRoute(), teamID)
+}
+
+func (c *Client) GetTeamsRoute() string {
+	return "/teams"
+}
+
+func (c *Client) GetBlockRoute(boardID, blockID string) string {
+	return fmt.Sprintf("%s/%s", c.GetBlocksRoute(boardID), blockID)
+}
+
+func (c *Client) GetBoardsRoute() string {
+	return "/boards"
+}
+
+func (c *Client) GetBoardRoute(boardID string) string {
+	return fmt.Sprintf("%s/%s", c.GetBoardsRoute(), boardID)
+}
+
+func (c *Client) GetBoardMetadataRoute(boardID string) string {
+	return fmt.Sprintf("%s/%s/metadata", c.GetBoardsRoute(), boardID)
+}
+
+func (c *Client) GetJoinBoardRoute(boardID string) string {
+	return fmt.Sprintf("%s/%s/join", c.GetBoardsRoute(), boardID)
+}
+
+func (c *Client) GetLeaveBoardRoute(boardID string) string {
+	return fmt.Sprintf("%s/%s/join", c.GetBoardsRoute(), boardID)
+}
+
+func (c *Client) GetBlocksRoute(boardID string) string {
+	return fmt.Sprintf("%s/blocks", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetAllBlocksRoute(boardID string) string {
+	return fmt.Sprintf("%s/blocks?all=true", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetBoardsAndBlocksRoute() string {
+	return "/boards-and-blocks"
+}
+
+func (c *Client) GetCardsRoute() string {
+	return "/cards"
+}
+
+func (c *Client) GetCardRoute(cardID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardsRoute(), cardID)
+}
+
+func (c *Client) GetTeam(teamID string) (*model.Team, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetTeams() ([]*model.Team, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetTeamBoardsInsights(teamID string, userID string, timeRange string, page int, perPage int) (*model.BoardInsightsList, *Response) {
+	query := fmt.Sprintf("?time_range=%v&page=%v&per_page=%v", timeRange, page, perPage)
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/insights"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boardInsightsList *model.BoardInsightsList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&boardInsightsList); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return boardInsightsList, BuildResponse(r)
+}
+
+func (c *Client) GetUserBoardsInsights(teamID string, userID string, timeRange string, page int, perPage int) (*model.BoardInsightsList, *Response) {
+	query := fmt.Sprintf("?time_range=%v&page=%v&per_page=%v&team_id=%v", timeRange, page, perPage, teamID)
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/boards/insights"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boardInsightsList *model.BoardInsightsList
+	if jsonErr := json.NewDecoder(r.Body).Decode(&boardInsightsList); jsonErr != nil {
+		return nil, BuildErrorResponse(r, jsonErr)
+	}
+	return boardInsightsList, BuildResponse(r)
+}
+
+func (c *Client) GetBlocksForBoard(boardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetAllBlocksForBoard(boardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetAllBlocksRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+const disableNotifyQueryParam = "disable_notify=true"
+
+func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch, disableNotify bool) (bool, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPatch(c.GetBlockRoute(boardID, blockID)+queryParams, toJSON(blockPatch))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) DuplicateBoard(boardID string, asTemplate bool, teamID string) (*model.BoardsAndBlocks, *Response) {
+	queryParams := "?asTemplate=false&"
+	if asTemplate {
+		queryParams = "?asTemplate=true"
+	}
+	if len(teamID) > 0 {
+		queryParams = queryParams + "&toTeam=" + teamID
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/duplicate"+queryParams, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool, *Response) {
+	queryParams := "?asTemplate=false"
+	if asTemplate {
+		queryParams = "?asTemplate=true"
+	}
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/duplicate"+queryParams, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/undelete", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) InsertBlocks(boardID string, blocks []*model.Block, disableNotify bool) ([]*model.Block, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+queryParams, toJSON(blocks))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteBlock(boardID, blockID string, disableNotify bool) (bool, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID)+queryParams, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+//
+// Cards
+//
+
+func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool) (*model.Card, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards"+queryParams, toJSON(card))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cardNew *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cardNew, BuildResponse(r)
+}
+
+func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
+	return c.getCards(fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage))
+}
+
+func (c *Client) GetCardsIncludingArchived(boardID string, page int, perPage int) ([]*model.Card, *Response) {
+	return c.getCards(fmt.Sprintf("%s/cards?page=%d&per_page=%d&include_archived=true", c.GetBoardRoute(boardID), page, perPage))
+}
+
+func (c *Client) GetCardsForView(boardID, viewID string, page int, perPage int) ([]*model.Card, *Response) {
+	return c.getCards(fmt.Sprintf("%s/cards?page=%d&per_page=%d&view_id=%s", c.GetBoardRoute(boardID), page, perPage, url.QueryEscape(viewID)))
+}
+
+func (c *Client) SearchCards(boardID, term string) ([]*model.Card, *Response) {
+	return c.getCards(c.GetBoardRoute(boardID) + "/cards/search?q=" + url.QueryEscape(term))
+}
+
+func (c *Client) ArchiveCards(boardID string, cardIDs []string) ([]*model.Card, *Response) {
+	return c.postCards(c.GetBoardRoute(boardID)+"/cards/archive", toJSON(cardIDs))
+}
+
+func (c *Client) UnarchiveCards(boardID string, cardIDs []string) ([]*model.Card, *Response) {
+	return c.postCards(c.GetBoardRoute(boardID)+"/cards/unarchive", toJSON(cardIDs))
+}
+
+func (c *Client) postCards(route, data string) ([]*model.Card, *Response) {
+	r, err := c.DoAPIPost(route, data)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) getCards(route string) ([]*model.Card, *Response) {
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPatch(c.GetCardRoute(cardID)+queryParams, toJSON(cardPatch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cardNew *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cardNew, BuildResponse(r)
+}
+
+func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) MoveCard(cardID string, opts *model.CardMoveOptions) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/move", toJSON(opts))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) CopyCard(cardID string, opts *model.CardMoveOptions) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/copy", toJSON(opts))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetCardRedirect(boardID, cardID string) (*model.CardRedirect, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/cards/"+cardID+"/redirect", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var redirect *model.CardRedirect
+	if err := json.NewDecoder(r.Body).Decode(&redirect); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return redirect, BuildResponse(r)
+}
+
+func (c *Client) GetCardMirrorsRoute(boardID string) string {
+	return fmt.Sprintf("%s/mirrors", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetCardMirrorRoute(boardID, cardID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardMirrorsRoute(boardID), cardID)
+}
+
+func (c *Client) CreateCardMirror(boardID, cardID string) (*model.CardMirror, *Response) {
+	r, err := c.DoAPIPost(c.GetCardMirrorsRoute(boardID), toJSON(&model.CardMirror{CardID: cardID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CardMirrorFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardMirrors(boardID string) ([]*model.CardMirror, *Response) {
+	r, err := c.DoAPIGet(c.GetCardMirrorsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CardMirrorsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardMirror(boardID, cardID string) (*model.CardMirror, *Response) {
+	r, err := c.DoAPIGet(c.GetCardMirrorRoute(boardID, cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CardMirrorFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardMirrorBlocks(boardID, cardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetCardMirrorRoute(boardID, cardID)+"/blocks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchCardMirror(boardID, cardID string, patch *model.CardMirrorPatch) (*model.CardMirror, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardMirrorRoute(boardID, cardID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CardMirrorFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteCardMirror(boardID, cardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardMirrorRoute(boardID, cardID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) QueryPortfolio(query *model.PortfolioQuery) (*model.PortfolioResult, *Response) {
+	r, err := c.DoAPIPost("/portfolio", toJSON(query))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.PortfolioResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) GetCommentRoute(boardID, commentID string) string {
+	return fmt.Sprintf("%s/comments/%s", c.GetBoardRoute(boardID), commentID)
+}
+
+func (c *Client) GetCommentThread(boardID, commentID string) (*model.Comment, *Response) {
+	r, err := c.DoAPIGet(c.GetCommentRoute(boardID, commentID)+"/thread", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ReplyToComment(boardID, commentID, text string) (*model.Comment, *Response) {
+	r, err := c.DoAPIPost(c.GetCommentRoute(boardID, commentID)+"/replies", toJSON(map[string]string{"text": text}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ResolveCommentThread(boardID, commentID string) (*model.Comment, *Response) {
+	return c.postCommentThread(c.GetCommentRoute(boardID, commentID) + "/resolve")
+}
+
+func (c *Client) UnresolveCommentThread(boardID, commentID string) (*model.Comment, *Response) {
+	return c.postCommentThread(c.GetCommentRoute(boardID, commentID) + "/unresolve")
+}
+
+func (c *Client) postCommentThread(route string) (*model.Comment, *Response) {
+	r, err := c.DoAPIPost(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCommentHistory(boardID, commentID string) ([]*model.CommentEdit, *Response) {
+	r, err := c.DoAPIGet(c.GetCommentRoute(boardID, commentID)+"/history", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentEditsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) AddCommentReaction(boardID, commentID, emoji string) ([]*model.CommentReactionSummary, *Response) {
+	r, err := c.DoAPIPost(c.GetCommentRoute(boardID, commentID)+"/reactions", toJSON(map[string]string{"emoji": emoji}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentReactionSummariesFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) RemoveCommentReaction(boardID, commentID, emoji string) ([]*model.CommentReactionSummary, *Response) {
+	r, err := c.DoAPIDelete(c.GetCommentRoute(boardID, commentID)+"/reactions/"+url.PathEscape(emoji), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentReactionSummariesFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardCommentsRoute(cardID string) string {
+	return fmt.Sprintf("%s/comments", c.GetCardRoute(cardID))
+}
+
+func (c *Client) GetCardCommentRoute(cardID, commentID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardCommentsRoute(cardID), commentID)
+}
+
+func (c *Client) GetCardComments(cardID string) ([]*model.Comment, *Response) {
+	r, err := c.DoAPIGet(c.GetCardCommentsRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateCardComment(cardID string, comment *model.Comment) (*model.Comment, *Response) {
+	r, err := c.DoAPIPost(c.GetCardCommentsRoute(cardID), toJSON(comment))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardComment(cardID, commentID string) (*model.Comment, *Response) {
+	r, err := c.DoAPIGet(c.GetCardCommentRoute(cardID, commentID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchCardComment(cardID, commentID string, patch *model.CommentPatch) (*model.Comment, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardCommentRoute(cardID, commentID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CommentFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteCardComment(cardID, commentID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardCommentRoute(cardID, commentID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+//
+// Card content.
+//
+
+func (c *Client) GetCardContentRoute(cardID string) string {
+	return fmt.Sprintf("%s/content", c.GetCardRoute(cardID))
+}
+
+func (c *Client) GetCardContentBlockRoute(cardID, blockID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardContentRoute(cardID), blockID)
+}
+
+func (c *Client) GetCardContent(cardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetCardContentRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+// InsertCardContent adds a content block to a card at the position in
+// its content order. Negative positions append the block.
+func (c *Client) InsertCardContent(cardID string, block *model.Block, position int) (*model.Block, *Response) {
+	route := c.GetCardContentRoute(cardID)
+	if position >= 0 {
+		route += fmt.Sprintf("?position=%d", position)
+	}
+
+	r, err := c.DoAPIPost(route, toJSON(block))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newBlock *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&newBlock); err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
 
-	return rp, nil
+	return newBlock, BuildResponse(r)
 }
 
-func (c *Client) GetTeamRoute(teamID string) string {
-	return fmt.Sprintf("%s/%s", c.GetTeamsRoute(), teamID)
-}
+func (c *Client) PatchCardContent(cardID, blockID string, patch *model.BlockPatch) (*model.Block, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardContentBlockRoute(cardID, blockID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-func (c *Client) GetTeamsRoute() string {
-	return "/teams"
-}
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
 
-func (c *Client) GetBlockRoute(boardID, blockID string) string {
-	return fmt.Sprintf("%s/%s", c.GetBlocksRoute(boardID), blockID)
+	return block, BuildResponse(r)
 }
 
-func (c *Client) GetBoardsRoute() string {
-	return "/boards"
-}
+// MoveCardContent moves a content block of a card to the position in
+// its content order and returns the reordered content. Negative
+// positions move the block last.
+func (c *Client) MoveCardContent(cardID, blockID string, position int) ([]*model.Block, *Response) {
+	route := c.GetCardContentBlockRoute(cardID, blockID) + "/move"
+	if position >= 0 {
+		route += fmt.Sprintf("?position=%d", position)
+	}
 
-func (c *Client) GetBoardRoute(boardID string) string {
-	return fmt.Sprintf("%s/%s", c.GetBoardsRoute(), boardID)
-}
+	r, err := c.DoAPIPost(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-func (c *Client) GetBoardMetadataRoute(boardID string) string {
-	return fmt.Sprintf("%s/%s/metadata", c.GetBoardsRoute(), boardID)
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetJoinBoardRoute(boardID string) string {
-	return fmt.Sprintf("%s/%s/join", c.GetBoardsRoute(), boardID)
-}
+func (c *Client) DeleteCardContent(cardID, blockID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardContentBlockRoute(cardID, blockID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-func (c *Client) GetLeaveBoardRoute(boardID string) string {
-	return fmt.Sprintf("%s/%s/join", c.GetBoardsRoute(), boardID)
+	return true, BuildResponse(r)
 }
 
-func (c *Client) GetBlocksRoute(boardID string) string {
-	return fmt.Sprintf("%s/blocks", c.GetBoardRoute(boardID))
-}
+//
+// Views.
+//
 
-func (c *Client) GetAllBlocksRoute(boardID string) string {
-	return fmt.Sprintf("%s/blocks?all=true", c.GetBoardRoute(boardID))
+func (c *Client) GetViewsRoute(boardID string) string {
+	return fmt.Sprintf("%s/views", c.GetBoardRoute(boardID))
 }
 
-func (c *Client) GetBoardsAndBlocksRoute() string {
-	return "/boards-and-blocks"
+func (c *Client) GetViewRoute(boardID, viewID string) string {
+	return fmt.Sprintf("%s/%s", c.GetViewsRoute(boardID), viewID)
 }
 
-func (c *Client) GetCardsRoute() string {
-	return "/cards"
-}
+func (c *Client) GetViews(boardID string) ([]*model.View, *Response) {
+	r, err := c.DoAPIGet(c.GetViewsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
 
-func (c *Client) GetCardRoute(cardID string) string {
-	return fmt.Sprintf("%s/%s", c.GetCardsRoute(), cardID)
+	return model.ViewsFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetTeam(teamID string) (*model.Team, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID), "")
+func (c *Client) CreateView(boardID string, view *model.View) (*model.View, *Response) {
+	r, err := c.DoAPIPost(c.GetViewsRoute(boardID), toJSON(view))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.TeamFromJSON(r.Body), BuildResponse(r)
+	return model.ViewFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetTeamBoardsInsights(teamID string, userID string, timeRange string, page int, perPage int) (*model.BoardInsightsList, *Response) {
-	query := fmt.Sprintf("?time_range=%v&page=%v&per_page=%v", timeRange, page, perPage)
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/insights"+query, "")
+func (c *Client) GetView(boardID, viewID string) (*model.View, *Response) {
+	r, err := c.DoAPIGet(c.GetViewRoute(boardID, viewID), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var boardInsightsList *model.BoardInsightsList
-	if jsonErr := json.NewDecoder(r.Body).Decode(&boardInsightsList); jsonErr != nil {
-		return nil, BuildErrorResponse(r, jsonErr)
-	}
-	return boardInsightsList, BuildResponse(r)
+	return model.ViewFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetUserBoardsInsights(teamID string, userID string, timeRange string, page int, perPage int) (*model.BoardInsightsList, *Response) {
-	query := fmt.Sprintf("?time_range=%v&page=%v&per_page=%v&team_id=%v", timeRange, page, perPage, teamID)
-	r, err := c.DoAPIGet(c.GetMeRoute()+"/boards/insights"+query, "")
+func (c *Client) PatchView(boardID, viewID string, patch *model.ViewPatch) (*model.View, *Response) {
+	r, err := c.DoAPIPatch(c.GetViewRoute(boardID, viewID), toJSON(patch))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var boardInsightsList *model.BoardInsightsList
-	if jsonErr := json.NewDecoder(r.Body).Decode(&boardInsightsList); jsonErr != nil {
-		return nil, BuildErrorResponse(r, jsonErr)
+	return model.ViewFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteView(boardID, viewID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetViewRoute(boardID, viewID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
 	}
-	return boardInsightsList, BuildResponse(r)
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
 }
 
-func (c *Client) GetBlocksForBoard(boardID string) ([]*model.Block, *Response) {
-	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID), "")
+// DuplicateView copies a view to the destination board, or to its own
+// board if the destination is empty.
+func (c *Client) DuplicateView(boardID, viewID, destBoardID string) (*model.View, *Response) {
+	opts := model.ViewDuplicateOptions{BoardID: destBoardID}
+	r, err := c.DoAPIPost(c.GetViewRoute(boardID, viewID)+"/duplicate", toJSON(opts))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+	return model.ViewFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetAllBlocksForBoard(boardID string) ([]*model.Block, *Response) {
-	r, err := c.DoAPIGet(c.GetAllBlocksRoute(boardID), "")
+// ShareView shares a private view of the user with everyone on the
+// board.
+func (c *Client) ShareView(boardID, viewID string) (*model.View, *Response) {
+	r, err := c.DoAPIPost(c.GetViewRoute(boardID, viewID)+"/share", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+	return model.ViewFromJSON(r.Body), BuildResponse(r)
 }
 
-const disableNotifyQueryParam = "disable_notify=true"
+//
+// Read state.
+//
 
-func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch, disableNotify bool) (bool, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPatch(c.GetBlockRoute(boardID, blockID)+queryParams, toJSON(blockPatch))
+// MarkBoardViewed marks a board as viewed by the user and returns its
+// unread counts.
+func (c *Client) MarkBoardViewed(boardID string) (*model.BoardUnreadCounts, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/viewed", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return model.BoardUnreadCountsFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) DuplicateBoard(boardID string, asTemplate bool, teamID string) (*model.BoardsAndBlocks, *Response) {
-	queryParams := "?asTemplate=false&"
-	if asTemplate {
-		queryParams = "?asTemplate=true"
-	}
-	if len(teamID) > 0 {
-		queryParams = queryParams + "&toTeam=" + teamID
-	}
-	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/duplicate"+queryParams, "")
+// MarkCardViewed marks a card as viewed by the user and returns the
+// unread counts of its board.
+func (c *Client) MarkCardViewed(cardID string) (*model.BoardUnreadCounts, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/viewed", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+	return model.BoardUnreadCountsFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool, *Response) {
-	queryParams := "?asTemplate=false"
-	if asTemplate {
-		queryParams = "?asTemplate=true"
-	}
-	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/duplicate"+queryParams, "")
+func (c *Client) GetBoardUnreadCounts(boardID string) (*model.BoardUnreadCounts, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/unread", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return model.BoardUnreadCountsFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/undelete", "")
+//
+// Card reminders.
+//
+
+func (c *Client) GetMyCardRemindersRoute() string {
+	return "/users/me/reminders"
+}
+
+func (c *Client) GetMyCardReminderRoute(reminderID string) string {
+	return fmt.Sprintf("%s/%s", c.GetMyCardRemindersRoute(), reminderID)
+}
+
+func (c *Client) CreateCardReminder(cardID string, patch *model.CardReminderPatch) (*model.CardReminder, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/reminders", toJSON(patch))
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return model.CardReminderFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) InsertBlocks(boardID string, blocks []*model.Block, disableNotify bool) ([]*model.Block, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
+func (c *Client) GetMyCardReminders() ([]*model.CardReminder, *Response) {
+	r, err := c.DoAPIGet(c.GetMyCardRemindersRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
-	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+queryParams, toJSON(blocks))
+	defer closeBody(r)
+
+	return model.CardRemindersFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchCardReminder(reminderID string, patch *model.CardReminderPatch) (*model.CardReminder, *Response) {
+	r, err := c.DoAPIPatch(c.GetMyCardReminderRoute(reminderID), toJSON(patch))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+	return model.CardReminderFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) DeleteBlock(boardID, blockID string, disableNotify bool) (bool, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
+func (c *Client) SnoozeCardReminder(reminderID string, minutes int) (*model.CardReminder, *Response) {
+	snooze := &model.CardReminderSnooze{Minutes: minutes}
+	r, err := c.DoAPIPost(c.GetMyCardReminderRoute(reminderID)+"/snooze", toJSON(snooze))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
 	}
-	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID)+queryParams, "")
+	defer closeBody(r)
+
+	return model.CardReminderFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteCardReminder(reminderID string) *Response {
+	r, err := c.DoAPIDelete(c.GetMyCardReminderRoute(reminderID), "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return BuildResponse(r)
 }
 
 //
-// Cards
+// Card votes and reactions.
 //
 
-func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool) (*model.Card, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards"+queryParams, toJSON(card))
+func (c *Client) GetCardVotes(cardID string) (*model.CardVotes, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/votes", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var cardNew *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
-		return nil, BuildErrorResponse(r, err)
-	}
-
-	return cardNew, BuildResponse(r)
+	return model.CardVotesFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
-	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage)
-	r, err := c.DoAPIGet(url, "")
+func (c *Client) VoteForCard(cardID string) (*model.CardVotes, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/votes", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var cards []*model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+	return model.CardVotesFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) RemoveCardVote(cardID string) (*model.CardVotes, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/votes", "")
+	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	return cards, BuildResponse(r)
+	return model.CardVotesFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPatch(c.GetCardRoute(cardID)+queryParams, toJSON(cardPatch))
+func (c *Client) AddCardReaction(cardID, emoji string) (*model.CardVotes, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/reactions", toJSON(map[string]string{"emoji": emoji}))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var cardNew *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+	return model.CardVotesFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) RemoveCardReaction(cardID, emoji string) (*model.CardVotes, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/reactions/"+url.PathEscape(emoji), "")
+	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	return cardNew, BuildResponse(r)
+	return model.CardVotesFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
-	r, err := c.DoAPIGet(c.GetCardRoute(cardID), "")
+//
+// Backlinks.
+//
+
+func (c *Client) GetBoardBacklinks(boardID string) ([]*model.Backlink, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/backlinks", "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var card *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+	return model.BacklinksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetCardBacklinks(cardID string) ([]*model.Backlink, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/backlinks", "")
+	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
+	defer closeBody(r)
 
-	return card, BuildResponse(r)
+	return model.BacklinksFromJSON(r.Body), BuildResponse(r)
 }
 
 //
@@ -708,6 +1527,36 @@ func (c *Client) UndeleteBoard(boardID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) MoveBoard(boardID, toTeam string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/move?toTeam="+toTeam, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) FreezeBoard(boardID string, freeze *model.BoardFreeze) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/freeze", toJSON(freeze))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) UnfreezeBoard(boardID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/unfreeze", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) GetBoard(boardID, readToken string) (*model.Board, *Response) {
 	url := c.GetBoardRoute(boardID)
 	if readToken != "" {
@@ -1015,6 +1864,41 @@ func (c *Client) GetStatistics() (*model.BoardsStatistics, *Response) {
 	return stats, BuildResponse(r)
 }
 
+// GetHealthLive returns the liveness of the server.
+func (c *Client) GetHealthLive() (*model.Health, *Response) {
+	return c.getHealth("/health/live")
+}
+
+// GetHealthReady returns the readiness of the server and the status of
+// its components. When some component is unavailable, the health is
+// returned along with the error of the response.
+func (c *Client) GetHealthReady() (*model.Health, *Response) {
+	return c.getHealth("/health/ready")
+}
+
+func (c *Client) getHealth(route string) (*model.Health, *Response) {
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable {
+			var health *model.Health
+			if json.Unmarshal(apiErr.Body, &health) == nil {
+				return health, BuildErrorResponse(r, err)
+			}
+		}
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var health *model.Health
+	err = json.NewDecoder(r.Body).Decode(&health)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return health, BuildResponse(r)
+}
+
 func (c *Client) GetBoardsForCompliance(teamID string, page, perPage int) (*model.BoardsComplianceResponse, *Response) {
 	query := fmt.Sprintf("?team_id=%s&page=%d&per_page=%d", teamID, page, perPage)
 	r, err := c.DoAPIGet("/admin/boards"+query, "")
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "token")
+	client.Retry.MinBackoff = time.Millisecond
+	client.Retry.MaxBackoff = 10 * time.Millisecond
+	return client
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(toJSON(model.ErrorResponse{Error: message, ErrorCode: code})))
+}
+
+func TestAPIErrors(t *testing.T) {
+	testCases := []struct {
+		code     int
+		expected error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusRequestEntityTooLarge, ErrLimitExceeded},
+		{http.StatusNotImplemented, ErrNotImplemented},
+		{http.StatusInternalServerError, ErrServer},
+	}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.code), func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeError(w, tc.code, "something went wrong")
+			})
+
+			board, resp := client.GetBoard("board-id", "")
+			require.Nil(t, board)
+			require.Equal(t, tc.code, resp.StatusCode)
+			require.ErrorIs(t, resp.Error, tc.expected)
+
+			var apiErr *APIError
+			require.ErrorAs(t, resp.Error, &apiErr)
+			require.Equal(t, "something went wrong", apiErr.Message)
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("idempotent requests are retried on unavailable servers", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				writeError(w, http.StatusServiceUnavailable, "unavailable")
+				return
+			}
+			_, _ = w.Write([]byte(toJSON(model.Board{ID: "board-id"})))
+		})
+
+		board, resp := client.GetBoard("board-id", "")
+		require.NoError(t, resp.Error)
+		require.Equal(t, "board-id", board.ID)
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("other requests are not retried on unavailable servers", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			writeError(w, http.StatusServiceUnavailable, "unavailable")
+		})
+
+		_, resp := client.CreateBoard(&model.Board{ID: "board-id"})
+		require.ErrorIs(t, resp.Error, ErrServer)
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("rate limited requests are retried with their body", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var board model.Board
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&board))
+			require.Equal(t, "board-id", board.ID)
+
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				writeError(w, http.StatusTooManyRequests, "too many requests")
+				return
+			}
+			_, _ = w.Write([]byte(toJSON(board)))
+		})
+
+		board, resp := client.CreateBoard(&model.Board{ID: "board-id"})
+		require.NoError(t, resp.Error)
+		require.Equal(t, "board-id", board.ID)
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("retries are limited", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			writeError(w, http.StatusTooManyRequests, "too many requests")
+		})
+		client.Retry.MaxRetries = 2
+
+		_, resp := client.GetBoard("board-id", "")
+		require.ErrorIs(t, resp.Error, ErrLimitExceeded)
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			writeError(w, http.StatusNotFound, "not found")
+		})
+
+		_, resp := client.GetBoard("board-id", "")
+		require.ErrorIs(t, resp.Error, ErrNotFound)
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// the server hangs until the test ends
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, resp := client.WithContext(ctx).GetBoard("board-id", "")
+	require.ErrorIs(t, resp.Error, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// the original client is not bound to the context
+	require.Nil(t, client.ctx)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	require.Zero(t, parseRetryAfter(""))
+	require.Zero(t, parseRetryAfter("-1"))
+	require.Zero(t, parseRetryAfter("soon"))
+	require.Equal(t, 2*time.Second, parseRetryAfter("2"))
+
+	delay := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.Greater(t, delay, 59*time.Minute)
+}
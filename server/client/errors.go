@@ -0,0 +1,110 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// Errors returned by the server, by kind. An *APIError wraps the one
+// that matches its status code, so they can be checked with errors.Is.
+var (
+	ErrBadRequest     = errors.New("bad request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrLimitExceeded  = errors.New("limit exceeded")
+	ErrNotImplemented = errors.New("not implemented")
+	ErrServer         = errors.New("server error")
+)
+
+// APIError is an error response of the server.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the error message of the server.
+	Message string
+
+	// Body is the raw body of the response.
+	Body []byte
+
+	// RetryAfter is the delay the server asked to wait for before
+	// retrying the request, if any.
+	RetryAfter time.Duration
+}
+
+func newAPIError(rp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: rp.StatusCode,
+		Message:    string(body),
+		Body:       body,
+		RetryAfter: parseRetryAfter(rp.Header.Get("Retry-After")),
+	}
+
+	var errorResponse model.ErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Error != "" {
+		apiErr.Message = errorResponse.Error
+	}
+
+	return apiErr
+}
+
+// Error returns the body of the response, as the errors of the client
+// always did.
+func (e *APIError) Error() string {
+	return RequestReaderError{e.Body}.Error()
+}
+
+// Unwrap returns the kind of the error, if known.
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return ErrLimitExceeded
+	case http.StatusNotImplemented:
+		return ErrNotImplemented
+	}
+
+	if e.StatusCode >= http.StatusInternalServerError {
+		return ErrServer
+	}
+	return nil
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given
+// either in seconds or as a date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
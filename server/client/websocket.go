@@ -0,0 +1,529 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/focalboard/server/ws"
+)
+
+const (
+	// WSActionReconnect is the action of the event sent when the
+	// websocket client connects again after losing its connection. The
+	// changes made while it was disconnected are not sent, so the
+	// state kept by the client should be loaded again.
+	WSActionReconnect = "RECONNECT"
+
+	// WSMinReconnectDelay and WSMaxReconnectDelay are the default
+	// bounds of the delay between reconnection attempts.
+	WSMinReconnectDelay = time.Second
+	WSMaxReconnectDelay = time.Minute
+
+	wsEventBufferSize      = 256
+	wsHandshakeTimeout     = 10 * time.Second
+	wsCloseMessageDeadline = time.Second
+)
+
+var ErrWSClientClosed = errors.New("websocket client closed")
+
+// WSEvent is an event received from the websocket server.
+type WSEvent interface {
+	GetAction() string
+}
+
+// BlockEvent is sent when a block is created, updated or deleted.
+type BlockEvent ws.UpdateBlockMsg
+
+// BoardEvent is sent when a board is created, updated or deleted.
+type BoardEvent ws.UpdateBoardMsg
+
+// MemberEvent is sent when a board member is added, updated or
+// deleted.
+type MemberEvent ws.UpdateMemberMsg
+
+// CategoryEvent is sent when a category of the user changes, or when
+// boards are moved between the categories of the user.
+type CategoryEvent ws.UpdateCategoryMessage
+
+// CategoryReorderEvent is sent when the user reorders its categories.
+type CategoryReorderEvent ws.CategoryReorderMessage
+
+// CategoryBoardReorderEvent is sent when the user reorders the boards
+// of a category.
+type CategoryBoardReorderEvent ws.CategoryBoardReorderMessage
+
+// ClientConfigEvent is sent when the client configuration changes.
+type ClientConfigEvent ws.UpdateClientConfig
+
+// SubscriptionEvent is sent when a subscription of the user changes.
+type SubscriptionEvent ws.UpdateSubscription
+
+// CardLimitTimestampEvent is sent when the card limit timestamp
+// changes.
+type CardLimitTimestampEvent ws.UpdateCardLimitTimestamp
+
+// CardMirrorEvent is sent when a card mirror or the mirrored card
+// changes.
+type CardMirrorEvent ws.UpdateCardMirrorMsg
+
+// CommentReactionsEvent is sent when the reactions to a comment
+// change.
+type CommentReactionsEvent ws.UpdateCommentReactionsMsg
+
+// UnreadCountsEvent is sent when the unread counts of a board change.
+type UnreadCountsEvent ws.UpdateUnreadCountsMsg
+
+// CardVotesEvent is sent when the votes or the reactions of a card
+// change.
+type CardVotesEvent ws.UpdateCardVotesMsg
+
+// ReconnectEvent is sent when the websocket client connects again
+// after losing its connection.
+type ReconnectEvent struct {
+	Action string `json:"action"`
+}
+
+// UnknownEvent is an event with an action the client doesn't know.
+type UnknownEvent struct {
+	Action string          `json:"action"`
+	Data   json.RawMessage `json:"-"`
+}
+
+func (e *BlockEvent) GetAction() string                { return e.Action }
+func (e *BoardEvent) GetAction() string                { return e.Action }
+func (e *MemberEvent) GetAction() string               { return e.Action }
+func (e *CategoryEvent) GetAction() string             { return e.Action }
+func (e *CategoryReorderEvent) GetAction() string      { return e.Action }
+func (e *CategoryBoardReorderEvent) GetAction() string { return e.Action }
+func (e *ClientConfigEvent) GetAction() string         { return e.Action }
+func (e *SubscriptionEvent) GetAction() string         { return e.Action }
+func (e *CardLimitTimestampEvent) GetAction() string   { return e.Action }
+func (e *CardMirrorEvent) GetAction() string           { return e.Action }
+func (e *CommentReactionsEvent) GetAction() string     { return e.Action }
+func (e *UnreadCountsEvent) GetAction() string         { return e.Action }
+func (e *CardVotesEvent) GetAction() string            { return e.Action }
+func (e *ReconnectEvent) GetAction() string            { return e.Action }
+func (e *UnknownEvent) GetAction() string              { return e.Action }
+
+// ParseWSEvent returns the typed event of a websocket message.
+func ParseWSEvent(data []byte) (WSEvent, error) {
+	var message struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+
+	var event WSEvent
+	switch message.Action {
+	case ws.WebsocketActionUpdateBlock:
+		event = &BlockEvent{}
+	case ws.WebsocketActionUpdateBoard:
+		event = &BoardEvent{}
+	case ws.WebsocketActionUpdateMember, ws.WebsocketActionDeleteMember:
+		event = &MemberEvent{}
+	case ws.WebsocketActionUpdateCategory, ws.WebsocketActionUpdateCategoryBoard:
+		event = &CategoryEvent{}
+	case ws.WebsocketActionReorderCategories:
+		event = &CategoryReorderEvent{}
+	case ws.WebsocketActionReorderCategoryBoards:
+		event = &CategoryBoardReorderEvent{}
+	case ws.WebsocketActionUpdateConfig:
+		event = &ClientConfigEvent{}
+	case ws.WebsocketActionUpdateSubscription:
+		event = &SubscriptionEvent{}
+	case ws.WebsocketActionUpdateCardLimitTimestamp:
+		event = &CardLimitTimestampEvent{}
+	case ws.WebsocketActionUpdateCardMirror:
+		event = &CardMirrorEvent{}
+	case ws.WebsocketActionUpdateCommentReactions:
+		event = &CommentReactionsEvent{}
+	case ws.WebsocketActionUpdateUnreadCounts:
+		event = &UnreadCountsEvent{}
+	case ws.WebsocketActionUpdateCardVotes:
+		event = &CardVotesEvent{}
+	default:
+		return &UnknownEvent{Action: message.Action, Data: json.RawMessage(data)}, nil
+	}
+
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+type wsBlocksSubscription struct {
+	teamID    string
+	readToken string
+}
+
+// WSClient is a client of the websocket server of the standalone
+// server. It authenticates with the token of the session, reconnects
+// when it loses its connection and subscribes again to the teams and
+// blocks it was subscribed to.
+//
+// Events are delivered to the handlers registered with OnEvent and,
+// once Events has been called, to the events channel. Both are called
+// from a single goroutine, so a slow handler or an events channel that
+// isn't drained delays the events that follow.
+type WSClient struct {
+	URL    string
+	Token  string
+	Header http.Header
+
+	// MinReconnectDelay and MaxReconnectDelay bound the delay between
+	// reconnection attempts, which doubles after every failed attempt.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+
+	dialer *websocket.Dialer
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	running       bool
+	closed        bool
+	done          chan struct{}
+	teams         map[string]bool
+	blocks        map[string]wsBlocksSubscription
+	handlers      map[string][]func(WSEvent)
+	events        chan WSEvent
+	eventsEnabled bool
+}
+
+// NewWSClient creates a websocket client for the server with the given
+// URL, as passed to NewClient.
+func NewWSClient(serverURL, token string) *WSClient {
+	url := strings.TrimRight(serverURL, "/")
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	return &WSClient{
+		URL:               url + "/ws",
+		Token:             token,
+		Header:            http.Header{},
+		MinReconnectDelay: WSMinReconnectDelay,
+		MaxReconnectDelay: WSMaxReconnectDelay,
+		dialer: &websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: wsHandshakeTimeout,
+		},
+		done:     make(chan struct{}),
+		teams:    map[string]bool{},
+		blocks:   map[string]wsBlocksSubscription{},
+		handlers: map[string][]func(WSEvent){},
+		events:   make(chan WSEvent, wsEventBufferSize),
+	}
+}
+
+// NewWSClient creates a websocket client for the server of the client,
+// with its session token and headers.
+func (c *Client) NewWSClient() *WSClient {
+	wsClient := NewWSClient(c.URL, c.Token)
+	for k, v := range c.HTTPHeader {
+		wsClient.Header.Set(k, v)
+	}
+	return wsClient
+}
+
+// Connect connects the client to the websocket server and starts
+// receiving events. If the connection is lost afterwards, the client
+// reconnects until it is closed.
+func (wc *WSClient) Connect() error {
+	wc.mu.Lock()
+	if wc.closed {
+		wc.mu.Unlock()
+		return ErrWSClientClosed
+	}
+	if wc.running {
+		wc.mu.Unlock()
+		return nil
+	}
+	wc.running = true
+	wc.mu.Unlock()
+
+	conn, err := wc.connect()
+	if err != nil {
+		wc.mu.Lock()
+		wc.running = false
+		closed := wc.closed
+		wc.mu.Unlock()
+
+		// the client was closed while connecting
+		if closed {
+			close(wc.events)
+		}
+		return err
+	}
+
+	go wc.run(conn)
+	return nil
+}
+
+// Close closes the connection and stops reconnecting. The events
+// channel is closed once the last event has been delivered.
+func (wc *WSClient) Close() {
+	wc.mu.Lock()
+	if wc.closed {
+		wc.mu.Unlock()
+		return
+	}
+	wc.closed = true
+	close(wc.done)
+
+	conn := wc.conn
+	wc.conn = nil
+	running := wc.running
+	wc.mu.Unlock()
+
+	if conn != nil {
+		deadline := time.Now().Add(wsCloseMessageDeadline)
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+		_ = conn.Close()
+	}
+
+	if !running {
+		close(wc.events)
+	}
+}
+
+// Events returns the channel the events are sent to. The channel must
+// be drained, as events wait for a free slot before being sent.
+func (wc *WSClient) Events() <-chan WSEvent {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	wc.eventsEnabled = true
+	return wc.events
+}
+
+// OnEvent registers a handler for the events with the given action, or
+// for all the events if the action is empty.
+func (wc *WSClient) OnEvent(action string, handler func(WSEvent)) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	wc.handlers[action] = append(wc.handlers[action], handler)
+}
+
+// SubscribeTeam subscribes the client to the changes of the boards of
+// a team.
+func (wc *WSClient) SubscribeTeam(teamID string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	wc.teams[teamID] = true
+	return wc.send(ws.WebsocketCommand{Action: ws.WebsocketActionSubscribeTeam, TeamID: teamID})
+}
+
+// UnsubscribeTeam unsubscribes the client from the changes of the
+// boards of a team.
+func (wc *WSClient) UnsubscribeTeam(teamID string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	delete(wc.teams, teamID)
+	return wc.send(ws.WebsocketCommand{Action: ws.WebsocketActionUnsubscribeTeam, TeamID: teamID})
+}
+
+// SubscribeBlocks subscribes the client to the changes of blocks of a
+// shared board, using the read token of the board.
+func (wc *WSClient) SubscribeBlocks(teamID, readToken string, blockIDs []string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	for _, blockID := range blockIDs {
+		wc.blocks[blockID] = wsBlocksSubscription{teamID: teamID, readToken: readToken}
+	}
+	return wc.send(ws.WebsocketCommand{
+		Action:    ws.WebsocketActionSubscribeBlocks,
+		TeamID:    teamID,
+		ReadToken: readToken,
+		BlockIDs:  blockIDs,
+	})
+}
+
+// UnsubscribeBlocks unsubscribes the client from the changes of blocks
+// of a shared board.
+func (wc *WSClient) UnsubscribeBlocks(teamID, readToken string, blockIDs []string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	for _, blockID := range blockIDs {
+		delete(wc.blocks, blockID)
+	}
+	return wc.send(ws.WebsocketCommand{
+		Action:    ws.WebsocketActionUnsubscribeBlocks,
+		TeamID:    teamID,
+		ReadToken: readToken,
+		BlockIDs:  blockIDs,
+	})
+}
+
+// send sends a command if the client is connected. Subscriptions made
+// while disconnected are sent on reconnect. It must be called with the
+// lock held.
+func (wc *WSClient) send(command ws.WebsocketCommand) error {
+	if wc.closed {
+		return ErrWSClientClosed
+	}
+	if wc.conn == nil {
+		return nil
+	}
+	return wc.conn.WriteJSON(command)
+}
+
+// connect opens a connection, authenticates it and sends the current
+// subscriptions.
+func (wc *WSClient) connect() (*websocket.Conn, error) {
+	conn, resp, err := wc.dialer.Dial(wc.URL, wc.Header)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	if wc.closed {
+		_ = conn.Close()
+		return nil, ErrWSClientClosed
+	}
+
+	for _, command := range wc.connectCommands() {
+		if err := conn.WriteJSON(command); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	wc.conn = conn
+	return conn, nil
+}
+
+// connectCommands returns the commands that authenticate a connection
+// and restore the subscriptions. It must be called with the lock held.
+func (wc *WSClient) connectCommands() []ws.WebsocketCommand {
+	commands := []ws.WebsocketCommand{}
+	if wc.Token != "" {
+		commands = append(commands, ws.WebsocketCommand{Action: ws.WebsocketActionAuth, Token: wc.Token})
+	}
+
+	for teamID := range wc.teams {
+		commands = append(commands, ws.WebsocketCommand{Action: ws.WebsocketActionSubscribeTeam, TeamID: teamID})
+	}
+
+	blockIDs := map[wsBlocksSubscription][]string{}
+	for blockID, subscription := range wc.blocks {
+		blockIDs[subscription] = append(blockIDs[subscription], blockID)
+	}
+	for subscription, ids := range blockIDs {
+		commands = append(commands, ws.WebsocketCommand{
+			Action:    ws.WebsocketActionSubscribeBlocks,
+			TeamID:    subscription.teamID,
+			ReadToken: subscription.readToken,
+			BlockIDs:  ids,
+		})
+	}
+
+	return commands
+}
+
+func (wc *WSClient) run(conn *websocket.Conn) {
+	defer close(wc.events)
+
+	for conn != nil {
+		wc.read(conn)
+		conn = wc.reconnect()
+		if conn != nil {
+			wc.dispatch(&ReconnectEvent{Action: WSActionReconnect})
+		}
+	}
+}
+
+// read delivers the events received on a connection until it is lost.
+func (wc *WSClient) read(conn *websocket.Conn) {
+	defer func() {
+		_ = conn.Close()
+
+		wc.mu.Lock()
+		if wc.conn == conn {
+			wc.conn = nil
+		}
+		wc.mu.Unlock()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		event, err := ParseWSEvent(data)
+		if err != nil {
+			continue
+		}
+		wc.dispatch(event)
+	}
+}
+
+// reconnect tries to connect again until it succeeds or the client is
+// closed, in which case it returns nil.
+func (wc *WSClient) reconnect() *websocket.Conn {
+	delay := wc.MinReconnectDelay
+	if delay <= 0 {
+		delay = WSMinReconnectDelay
+	}
+
+	for {
+		select {
+		case <-wc.done:
+			return nil
+		case <-time.After(delay):
+		}
+
+		conn, err := wc.connect()
+		if err == nil {
+			return conn
+		}
+		if errors.Is(err, ErrWSClientClosed) {
+			return nil
+		}
+
+		delay *= 2
+		if delay > wc.MaxReconnectDelay {
+			delay = wc.MaxReconnectDelay
+		}
+	}
+}
+
+func (wc *WSClient) dispatch(event WSEvent) {
+	wc.mu.Lock()
+	handlers := make([]func(WSEvent), 0, len(wc.handlers[event.GetAction()])+len(wc.handlers[""]))
+	handlers = append(handlers, wc.handlers[event.GetAction()]...)
+	handlers = append(handlers, wc.handlers[""]...)
+	eventsEnabled := wc.eventsEnabled
+	wc.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+
+	if eventsEnabled {
+		select {
+		case wc.events <- event:
+		case <-wc.done:
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/ws"
+)
+
+// testWSServer records the commands of its connections and drops the
+// first connection after its first subscription.
+type testWSServer struct {
+	t           *testing.T
+	upgrader    websocket.Upgrader
+	mu          sync.Mutex
+	connections [][]ws.WebsocketCommand
+}
+
+func (s *testWSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	require.NoError(s.t, err)
+	defer conn.Close()
+
+	s.mu.Lock()
+	index := len(s.connections)
+	s.connections = append(s.connections, []ws.WebsocketCommand{})
+	s.mu.Unlock()
+
+	for {
+		var command ws.WebsocketCommand
+		if err := conn.ReadJSON(&command); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.connections[index] = append(s.connections[index], command)
+		s.mu.Unlock()
+
+		if command.Action != ws.WebsocketActionSubscribeTeam {
+			continue
+		}
+		if index == 0 {
+			return
+		}
+
+		message := ws.UpdateBlockMsg{
+			Action: ws.WebsocketActionUpdateBlock,
+			TeamID: command.TeamID,
+			Block:  &model.Block{ID: "block-id", BoardID: "board-id"},
+		}
+		if err := conn.WriteJSON(message); err != nil {
+			return
+		}
+	}
+}
+
+func (s *testWSServer) getConnections() [][]ws.WebsocketCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections
+}
+
+func TestParseWSEvent(t *testing.T) {
+	t.Run("typed event", func(t *testing.T) {
+		event, err := ParseWSEvent([]byte(`{"action":"UPDATE_CARD_VOTES","teamId":"team-id","boardId":"board-id","votes":{"cardId":"card-id","voteCount":2}}`))
+		require.NoError(t, err)
+
+		votesEvent, ok := event.(*CardVotesEvent)
+		require.True(t, ok)
+		require.Equal(t, ws.WebsocketActionUpdateCardVotes, votesEvent.GetAction())
+		require.Equal(t, "board-id", votesEvent.BoardID)
+		require.Equal(t, 2, votesEvent.Votes.VoteCount)
+	})
+
+	t.Run("member deletion", func(t *testing.T) {
+		event, err := ParseWSEvent([]byte(`{"action":"DELETE_MEMBER","teamId":"team-id","member":{"userId":"user-id"}}`))
+		require.NoError(t, err)
+		require.IsType(t, &MemberEvent{}, event)
+		require.Equal(t, ws.WebsocketActionDeleteMember, event.GetAction())
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		data := []byte(`{"action":"SOMETHING_NEW","value":1}`)
+		event, err := ParseWSEvent(data)
+		require.NoError(t, err)
+		require.Equal(t, &UnknownEvent{Action: "SOMETHING_NEW", Data: data}, event)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		_, err := ParseWSEvent([]byte(`not json`))
+		require.Error(t, err)
+	})
+}
+
+func TestWSClientReconnect(t *testing.T) {
+	server := &testWSServer{t: t}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ws", r.URL.Path)
+		server.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	wsClient := NewWSClient(httpServer.URL, "token")
+	wsClient.MinReconnectDelay = 10 * time.Millisecond
+
+	blockEvents := make(chan *BlockEvent, 1)
+	wsClient.OnEvent(ws.WebsocketActionUpdateBlock, func(event WSEvent) {
+		blockEvents <- event.(*BlockEvent)
+	})
+	events := wsClient.Events()
+
+	require.NoError(t, wsClient.Connect())
+	require.NoError(t, wsClient.SubscribeTeam("team-id"))
+
+	select {
+	case event := <-events:
+		require.Equal(t, WSActionReconnect, event.GetAction())
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "reconnect event not received")
+	}
+
+	select {
+	case event := <-blockEvents:
+		require.Equal(t, "team-id", event.TeamID)
+		require.Equal(t, "block-id", event.Block.ID)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "block event not received")
+	}
+
+	// the second connection authenticates and subscribes again
+	connections := server.getConnections()
+	require.Len(t, connections, 2)
+	for _, commands := range connections {
+		require.Equal(t, []ws.WebsocketCommand{
+			{Action: ws.WebsocketActionAuth, Token: "token"},
+			{Action: ws.WebsocketActionSubscribeTeam, TeamID: "team-id"},
+		}, commands)
+	}
+
+	wsClient.Close()
+	for range events {
+		// the events channel is closed once the client stops
+	}
+	require.ErrorIs(t, wsClient.SubscribeTeam("team-id"), ErrWSClientClosed)
+}
@@ -0,0 +1,227 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUsers(t *testing.T) {
+	th := SetupTestHelperWithLocalSocket(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("the admin routes are only served on the socket", func(t *testing.T) {
+		_, resp := th.Client.AdminGetUsers(false)
+		require.Error(t, resp.Error)
+	})
+
+	t.Run("create a user", func(t *testing.T) {
+		user, resp := th.AdminClient.AdminCreateUser(&model.AdminCreateUserRequest{
+			Username: "user3",
+			Email:    "user3@sample.com",
+			Password: password,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "user3", user.Username)
+		require.NotEmpty(t, user.ID)
+
+		_, resp = th.AdminClient.AdminCreateUser(&model.AdminCreateUserRequest{Username: "user4"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("list the users", func(t *testing.T) {
+		users, resp := th.AdminClient.AdminGetUsers(false)
+		th.CheckOK(resp)
+		require.Len(t, users, 3)
+		require.Equal(t, user1Username, users[0].Username)
+		require.Equal(t, "user3", users[2].Username)
+	})
+
+	t.Run("deactivate a user", func(t *testing.T) {
+		_, resp := th.AdminClient.AdminDeactivateUser(user2Username)
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.GetMe()
+		th.CheckUnauthorized(resp)
+
+		_, resp = th.Client2.Login(&model.LoginRequest{Type: "normal", Username: user2Username, Password: password})
+		require.Error(t, resp.Error)
+
+		users, resp := th.AdminClient.AdminGetUsers(false)
+		th.CheckOK(resp)
+		require.Len(t, users, 2)
+
+		users, resp = th.AdminClient.AdminGetUsers(true)
+		th.CheckOK(resp)
+		require.Len(t, users, 3)
+
+		_, resp = th.AdminClient.AdminDeactivateUser(user2Username)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("set the password of a user", func(t *testing.T) {
+		_, resp := th.AdminClient.AdminSetPassword("user3", "NewPa$$word")
+		th.CheckOK(resp)
+
+		th.Login(th.Client2, "user3", "NewPa$$word")
+	})
+}
+
+func TestAdminTeamsAndBoards(t *testing.T) {
+	th := SetupTestHelperWithLocalSocket(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("regenerate the signup token", func(t *testing.T) {
+		teams, resp := th.AdminClient.AdminGetTeams()
+		th.CheckOK(resp)
+		require.Len(t, teams, 1)
+		require.Equal(t, model.GlobalTeamID, teams[0].ID)
+
+		team, resp := th.AdminClient.AdminRegenerateSignupToken(model.GlobalTeamID)
+		th.CheckOK(resp)
+		require.NotEmpty(t, team.SignupToken)
+		require.NotEqual(t, teams[0].SignupToken, team.SignupToken)
+	})
+
+	t.Run("transfer a board", func(t *testing.T) {
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		user2 := th.GetUser2()
+
+		transferred, resp := th.AdminClient.AdminTransferBoard(board.ID, user2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, user2.ID, transferred.CreatedBy)
+
+		members, resp := th.Client2.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, members, 2)
+		for _, member := range members {
+			if member.UserID == user2.ID {
+				require.Equal(t, model.BoardRoleAdmin, member.Role())
+			} else {
+				require.Equal(t, model.BoardRoleEditor, member.Role())
+			}
+		}
+
+		_, resp = th.AdminClient.AdminTransferBoard(board.ID, "nonexistent-user")
+		th.CheckNotFound(resp)
+
+		_, resp = th.AdminClient.AdminTransferBoard(utils.NewID(utils.IDTypeBoard), user2.ID)
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestAdminTasks(t *testing.T) {
+	th := SetupTestHelperWithLocalSocket(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("clean up the sessions", func(t *testing.T) {
+		_, resp := th.AdminClient.AdminCleanUpSessions()
+		th.CheckOK(resp)
+
+		// the sessions are recent
+		_, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("run the data retention", func(t *testing.T) {
+		_, resp := th.AdminClient.AdminRunDataRetention(0)
+		th.CheckBadRequest(resp)
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		result, resp := th.AdminClient.AdminRunDataRetention(1)
+		th.CheckOK(resp)
+		require.Zero(t, result.Count)
+
+		_, resp = th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+	})
+
+	t.Run("reindex", func(t *testing.T) {
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+		link := utils.MakeCardLink(th.Server.Config().ServerRoot, testTeamID, board.ID, cards[0].ID)
+		_, resp := th.Client.InsertBlocks(board.ID, []*model.Block{{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: cards[1].ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeText,
+			Title:    "see " + link,
+		}}, false)
+		th.CheckOK(resp)
+
+		result, resp := th.AdminClient.AdminReindex()
+		th.CheckOK(resp)
+		require.EqualValues(t, 1, result.Count)
+	})
+
+	t.Run("check the integrity", func(t *testing.T) {
+		checks, resp := th.AdminClient.AdminCheckIntegrity()
+		th.CheckOK(resp)
+		require.NotEmpty(t, checks)
+		for _, check := range checks {
+			require.Zero(t, check.Count, check.Name)
+		}
+	})
+}
+
+func TestAdminJobs(t *testing.T) {
+	th := SetupTestHelperWithLocalSocket(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("the recurring tasks are scheduled as jobs", func(t *testing.T) {
+		jobs, resp := th.AdminClient.AdminGetJobs(model.QueryJobsOptions{Status: model.JobStatusPending})
+		th.CheckOK(resp)
+
+		types := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			types = append(types, job.Type)
+		}
+		require.ElementsMatch(t, []string{
+			model.JobTypeCleanUpSessions,
+			model.JobTypeUpdateCardLimitTimestamp,
+			model.JobTypeUnfreezeBoards,
+			model.JobTypeSendCardReminders,
+			model.JobTypeDataRetention,
+			model.JobTypeDeleteFinishedJobs,
+		}, types)
+
+		_, resp = th.AdminClient.AdminGetJobs(model.QueryJobsOptions{Status: "stuck"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("cancel and retry a job", func(t *testing.T) {
+		jobs, resp := th.AdminClient.AdminGetJobs(model.QueryJobsOptions{Type: model.JobTypeUnfreezeBoards})
+		th.CheckOK(resp)
+		require.Len(t, jobs, 1)
+		jobID := jobs[0].ID
+
+		_, resp = th.AdminClient.AdminRetryJob(jobID)
+		th.CheckBadRequest(resp)
+
+		job, resp := th.AdminClient.AdminCancelJob(jobID)
+		th.CheckOK(resp)
+		require.Equal(t, model.JobStatusCanceled, job.Status)
+
+		_, resp = th.AdminClient.AdminCancelJob(jobID)
+		th.CheckBadRequest(resp)
+
+		job, resp = th.AdminClient.AdminRetryJob(jobID)
+		th.CheckOK(resp)
+		require.Equal(t, model.JobStatusPending, job.Status)
+
+		job, resp = th.AdminClient.AdminGetJob(jobID)
+		th.CheckOK(resp)
+		require.Equal(t, model.JobStatusPending, job.Status)
+	})
+
+	t.Run("nonexistent job", func(t *testing.T) {
+		_, resp := th.AdminClient.AdminGetJob(utils.NewID(utils.IDTypeNone))
+		th.CheckNotFound(resp)
+
+		_, resp = th.AdminClient.AdminCancelJob(utils.NewID(utils.IDTypeNone))
+		th.CheckNotFound(resp)
+	})
+}
@@ -0,0 +1,104 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBacklinks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	user2 := th.GetUser2()
+
+	targetBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	sharedBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	privateBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	for _, boardID := range []string{targetBoard.ID, sharedBoard.ID} {
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      boardID,
+			UserID:       user2.ID,
+			SchemeViewer: true,
+		})
+		th.CheckOK(resp)
+	}
+
+	target, resp := th.Client.CreateCard(targetBoard.ID, &model.Card{Title: "target"}, false)
+	th.CheckOK(resp)
+	sharedCard, resp := th.Client.CreateCard(sharedBoard.ID, &model.Card{Title: "shared"}, false)
+	th.CheckOK(resp)
+	privateCard, resp := th.Client.CreateCard(privateBoard.ID, &model.Card{Title: "private"}, false)
+	th.CheckOK(resp)
+
+	cardLink := utils.MakeCardLink("http://localhost", testTeamID, targetBoard.ID, target.ID)
+	boardLink := utils.MakeBoardLink("http://localhost", testTeamID, targetBoard.ID)
+
+	t.Run("no backlinks", func(t *testing.T) {
+		backlinks, resp := th.Client.GetCardBacklinks(target.ID)
+		th.CheckOK(resp)
+		require.Empty(t, backlinks)
+	})
+
+	sharedText, resp := th.Client.InsertCardContent(sharedCard.ID, &model.Block{Type: model.TypeText, Title: "see " + cardLink}, -1)
+	th.CheckOK(resp)
+	_, resp = th.Client.InsertCardContent(privateCard.ID, &model.Block{Type: model.TypeText, Title: "see " + cardLink + " in " + boardLink}, -1)
+	th.CheckOK(resp)
+
+	t.Run("backlinks of a card", func(t *testing.T) {
+		backlinks, resp := th.Client.GetCardBacklinks(target.ID)
+		th.CheckOK(resp)
+		require.Len(t, backlinks, 2)
+
+		backlinks, resp = th.Client2.GetCardBacklinks(target.ID)
+		th.CheckOK(resp)
+		require.Len(t, backlinks, 1)
+		require.Equal(t, sharedBoard.ID, backlinks[0].BoardID)
+		require.Equal(t, sharedCard.ID, backlinks[0].CardID)
+		require.Equal(t, "shared", backlinks[0].CardTitle)
+	})
+
+	t.Run("backlinks of a board", func(t *testing.T) {
+		backlinks, resp := th.Client.GetBoardBacklinks(targetBoard.ID)
+		th.CheckOK(resp)
+		require.Len(t, backlinks, 1)
+		require.Equal(t, privateCard.ID, backlinks[0].CardID)
+
+		backlinks, resp = th.Client2.GetBoardBacklinks(targetBoard.ID)
+		th.CheckOK(resp)
+		require.Empty(t, backlinks)
+	})
+
+	t.Run("a user without access to the board cannot get backlinks", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardBacklinks(privateBoard.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetCardBacklinks(privateCard.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("patching and deleting the text updates the backlinks", func(t *testing.T) {
+		title := "see " + boardLink
+		_, resp := th.Client.PatchBlock(sharedBoard.ID, sharedText.ID, &model.BlockPatch{Title: &title}, false)
+		th.CheckOK(resp)
+
+		backlinks, resp := th.Client2.GetCardBacklinks(target.ID)
+		th.CheckOK(resp)
+		require.Empty(t, backlinks)
+
+		backlinks, resp = th.Client2.GetBoardBacklinks(targetBoard.ID)
+		th.CheckOK(resp)
+		require.Len(t, backlinks, 1)
+		require.Equal(t, sharedCard.ID, backlinks[0].CardID)
+
+		_, resp = th.Client.DeleteBlock(sharedBoard.ID, sharedText.ID, false)
+		th.CheckOK(resp)
+
+		backlinks, resp = th.Client2.GetBoardBacklinks(targetBoard.ID)
+		th.CheckOK(resp)
+		require.Empty(t, backlinks)
+	})
+}
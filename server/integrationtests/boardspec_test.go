@@ -0,0 +1,165 @@
+package integrationtests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client/boardspec"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+const testBoardSpec = `
+boards:
+  - team: %s
+    title: Roadmap
+    icon: "🗺️"
+    properties:
+      - name: Status
+        type: select
+        options:
+          - value: To Do
+            color: propColorRed
+          - value: Done
+      - name: Due
+        type: date
+    views:
+      - title: By status
+        type: board
+        groupBy: Status
+        visibleProperties: [Due]
+      - title: Calendar
+        type: calendar
+        dateDisplayProperty: Due
+        sort:
+          - property: title
+    members:
+      - user: %s
+        role: commenter
+    category: Planning
+`
+
+func TestBoardSpec(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	user2 := th.GetUser2()
+	spec, err := boardspec.Load(strings.NewReader(fmt.Sprintf(testBoardSpec, testTeamID, user2.ID)))
+	require.NoError(t, err)
+
+	// the plan has no changes once applied
+	applyBoardSpec := func(opts boardspec.Options) *boardspec.Plan {
+		plan, err := boardspec.NewPlan(th.Client, spec, opts)
+		require.NoError(t, err)
+		require.NoError(t, plan.Apply(th.Client))
+
+		replan, err := boardspec.NewPlan(th.Client, spec, opts)
+		require.NoError(t, err)
+		require.False(t, replan.HasChanges(), "changes after apply: %+v", replan.Boards[0].Changes)
+		return plan
+	}
+
+	plan := applyBoardSpec(boardspec.Options{})
+	require.Len(t, plan.Boards, 1)
+	require.Len(t, plan.Boards[0].Changes, 7)
+	boardID := plan.Boards[0].BoardID
+	require.NotEmpty(t, boardID)
+
+	getMember := func(userID string) *model.BoardMember {
+		members, resp := th.Client.GetMembersForBoard(boardID)
+		th.CheckOK(resp)
+		for _, member := range members {
+			if member.UserID == userID {
+				return member
+			}
+		}
+		require.Failf(t, "member not found", "user %s", userID)
+		return nil
+	}
+
+	t.Run("board is created", func(t *testing.T) {
+		board, resp := th.Client.GetBoard(boardID, "")
+		th.CheckOK(resp)
+		require.Equal(t, "Roadmap", board.Title)
+		require.Equal(t, "🗺️", board.Icon)
+		require.Len(t, board.CardProperties, 2)
+
+		schema, err := model.ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		views, resp := th.Client.GetViews(boardID)
+		th.CheckOK(resp)
+		require.Len(t, views, 2)
+		for _, view := range views {
+			switch view.Title {
+			case "By status":
+				require.Equal(t, "Status", schema[view.GroupByID].Name)
+				require.Len(t, view.VisiblePropertyIDs, 1)
+				require.Equal(t, "Due", schema[view.VisiblePropertyIDs[0]].Name)
+			case "Calendar":
+				require.Equal(t, model.ViewTypeCalendar, view.ViewType)
+				require.Equal(t, "Due", schema[view.DateDisplayPropertyID].Name)
+			}
+		}
+
+		require.Equal(t, model.BoardRoleCommenter, getMember(user2.ID).Role())
+
+		categories, resp := th.Client.GetUserCategoryBoards(testTeamID)
+		th.CheckOK(resp)
+		found := false
+		for _, category := range categories {
+			for _, metadata := range category.BoardMetadata {
+				if category.Name == "Planning" && metadata.BoardID == boardID {
+					found = true
+				}
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("changes of the spec are applied", func(t *testing.T) {
+		spec.Boards[0].Properties[0].Options = append(spec.Boards[0].Properties[0].Options, &boardspec.OptionSpec{Value: "Blocked"})
+		spec.Boards[0].Members[0].Role = model.BoardRoleEditor
+
+		plan := applyBoardSpec(boardspec.Options{})
+		require.Equal(t, boardID, plan.Boards[0].BoardID)
+		require.Len(t, plan.Boards[0].Changes, 2)
+
+		require.Equal(t, model.BoardRoleEditor, getMember(user2.ID).Role())
+	})
+
+	t.Run("drift is reported and pruned", func(t *testing.T) {
+		_, resp := th.Client.CreateView(boardID, &model.View{
+			BoardID:    boardID,
+			Title:      "Extra",
+			ViewFields: model.ViewFields{ViewType: model.ViewTypeTable},
+		})
+		th.CheckOK(resp)
+		_, resp = th.Client.PatchBoard(boardID, &model.BoardPatch{
+			UpdatedCardProperties: []map[string]interface{}{{"id": "notes-id", "name": "Notes", "type": "text"}},
+		})
+		th.CheckOK(resp)
+
+		plan, err := boardspec.NewPlan(th.Client, spec, boardspec.Options{})
+		require.NoError(t, err)
+		require.False(t, plan.HasChanges())
+		require.Len(t, plan.Drift, 1)
+		require.ElementsMatch(t, []*boardspec.Change{
+			{Action: boardspec.ActionDelete, Kind: boardspec.KindProperty, Name: "Notes"},
+			{Action: boardspec.ActionDelete, Kind: boardspec.KindView, Name: "Extra"},
+		}, plan.Drift[0].Changes)
+
+		plan = applyBoardSpec(boardspec.Options{Prune: true})
+		require.Len(t, plan.Boards[0].Changes, 2)
+		require.Empty(t, plan.Drift)
+
+		board, resp := th.Client.GetBoard(boardID, "")
+		th.CheckOK(resp)
+		require.Len(t, board.CardProperties, 2)
+
+		views, resp := th.Client.GetViews(boardID)
+		th.CheckOK(resp)
+		require.Len(t, views, 2)
+	})
+}
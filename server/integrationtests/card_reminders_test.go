@@ -0,0 +1,96 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardReminders(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card"}, false)
+	th.CheckOK(resp)
+
+	remindAt := utils.GetMillis() + time.Hour.Milliseconds()
+	note := "follow up"
+
+	t.Run("a user without access to the board cannot set a reminder", func(t *testing.T) {
+		_, resp := th.Client2.CreateCardReminder(card.ID, &model.CardReminderPatch{RemindAt: &remindAt})
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("a reminder needs a time in the future", func(t *testing.T) {
+		_, resp := th.Client.CreateCardReminder(card.ID, &model.CardReminderPatch{Note: &note})
+		th.CheckBadRequest(resp)
+
+		past := utils.GetMillis() - time.Hour.Milliseconds()
+		_, resp = th.Client.CreateCardReminder(card.ID, &model.CardReminderPatch{RemindAt: &past})
+		th.CheckBadRequest(resp)
+	})
+
+	reminder, resp := th.Client.CreateCardReminder(card.ID, &model.CardReminderPatch{RemindAt: &remindAt, Note: &note})
+	th.CheckOK(resp)
+	require.Equal(t, card.ID, reminder.CardID)
+	require.Equal(t, board.ID, reminder.BoardID)
+	require.Equal(t, remindAt, reminder.RemindAt)
+	require.Equal(t, note, reminder.Note)
+
+	t.Run("reminders are private", func(t *testing.T) {
+		reminders, resp := th.Client.GetMyCardReminders()
+		th.CheckOK(resp)
+		require.Len(t, reminders, 1)
+		require.Equal(t, reminder.ID, reminders[0].ID)
+
+		reminders, resp = th.Client2.GetMyCardReminders()
+		th.CheckOK(resp)
+		require.Empty(t, reminders)
+
+		_, resp = th.Client2.SnoozeCardReminder(reminder.ID, 10)
+		th.CheckNotFound(resp)
+
+		resp = th.Client2.DeleteCardReminder(reminder.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("edit and snooze a reminder", func(t *testing.T) {
+		emptyNote := ""
+		patched, resp := th.Client.PatchCardReminder(reminder.ID, &model.CardReminderPatch{Note: &emptyNote})
+		th.CheckOK(resp)
+		require.Empty(t, patched.Note)
+		require.Equal(t, remindAt, patched.RemindAt)
+
+		_, resp = th.Client.SnoozeCardReminder(reminder.ID, 0)
+		th.CheckBadRequest(resp)
+
+		snoozed, resp := th.Client.SnoozeCardReminder(reminder.ID, 10)
+		th.CheckOK(resp)
+		require.Less(t, snoozed.RemindAt, remindAt)
+	})
+
+	t.Run("delete a reminder", func(t *testing.T) {
+		resp := th.Client.DeleteCardReminder(reminder.ID)
+		th.CheckOK(resp)
+
+		reminders, resp := th.Client.GetMyCardReminders()
+		th.CheckOK(resp)
+		require.Empty(t, reminders)
+	})
+
+	t.Run("the reminders of a deleted card are removed", func(t *testing.T) {
+		_, resp := th.Client.CreateCardReminder(card.ID, &model.CardReminderPatch{RemindAt: &remindAt})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.DeleteBlock(board.ID, card.ID, false)
+		th.CheckOK(resp)
+
+		reminders, resp := th.Client.GetMyCardReminders()
+		th.CheckOK(resp)
+		require.Empty(t, reminders)
+	})
+}
@@ -0,0 +1,116 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardVotes(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	user2 := th.GetUser2()
+
+	card1, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 1"}, false)
+	th.CheckOK(resp)
+	card2, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card 2"}, false)
+	th.CheckOK(resp)
+
+	t.Run("a user without access to the board cannot vote", func(t *testing.T) {
+		_, resp := th.Client2.VoteForCard(card1.ID)
+		th.CheckForbidden(resp)
+	})
+
+	_, resp = th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:         board.ID,
+		UserID:          user2.ID,
+		SchemeCommenter: true,
+	})
+	th.CheckOK(resp)
+
+	t.Run("vote for cards", func(t *testing.T) {
+		votes, resp := th.Client.VoteForCard(card2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 1, votes.VoteCount)
+		require.True(t, votes.Voted)
+
+		votes, resp = th.Client2.VoteForCard(card2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, votes.VoteCount)
+		require.True(t, votes.Voted)
+
+		votes, resp = th.Client2.GetCardVotes(card1.ID)
+		th.CheckOK(resp)
+		require.Zero(t, votes.VoteCount)
+		require.False(t, votes.Voted)
+
+		card, resp := th.Client.GetCard(card2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, card.Votes.VoteCount)
+		require.True(t, card.Votes.Voted)
+	})
+
+	t.Run("remove a vote", func(t *testing.T) {
+		votes, resp := th.Client2.RemoveCardVote(card2.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 1, votes.VoteCount)
+		require.False(t, votes.Voted)
+
+		_, resp = th.Client2.RemoveCardVote(card2.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("react to a card", func(t *testing.T) {
+		_, resp := th.Client2.AddCardReaction(card1.ID, "two words")
+		th.CheckBadRequest(resp)
+
+		votes, resp := th.Client2.AddCardReaction(card1.ID, "tada")
+		th.CheckOK(resp)
+		require.Equal(t, []*model.CardReactionCount{{Emoji: "tada", Count: 1, Reacted: true}}, votes.Reactions)
+
+		votes, resp = th.Client.GetCardVotes(card1.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []*model.CardReactionCount{{Emoji: "tada", Count: 1}}, votes.Reactions)
+
+		votes, resp = th.Client2.RemoveCardReaction(card1.ID, "tada")
+		th.CheckOK(resp)
+		require.Empty(t, votes.Reactions)
+	})
+
+	t.Run("sort the cards of a view by votes", func(t *testing.T) {
+		view, resp := th.Client.CreateView(board.ID, &model.View{
+			Title: "most voted",
+			ViewFields: model.ViewFields{
+				ViewType:    model.ViewTypeTable,
+				SortOptions: []model.ViewSortOption{{PropertyID: model.ViewSortPropertyVotes, Reversed: true}},
+			},
+		})
+		th.CheckOK(resp)
+
+		cards, resp := th.Client.GetCardsForView(board.ID, view.ID, 0, 1)
+		th.CheckOK(resp)
+		require.Len(t, cards, 1)
+		require.Equal(t, card2.ID, cards[0].ID)
+		require.Equal(t, 1, cards[0].Votes.VoteCount)
+	})
+
+	t.Run("vote limit of the board", func(t *testing.T) {
+		_, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyVoteLimit: 1},
+		})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.VoteForCard(card1.ID)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.RemoveCardVote(card2.ID)
+		th.CheckOK(resp)
+
+		votes, resp := th.Client.VoteForCard(card1.ID)
+		th.CheckOK(resp)
+		require.True(t, votes.Voted)
+	})
+}
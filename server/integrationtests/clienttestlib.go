@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -68,6 +69,9 @@ type TestHelper struct {
 	Client  *client.Client
 	Client2 *client.Client
 
+	// AdminClient is the client of the local mode socket, if enabled
+	AdminClient *client.Client
+
 	origEnvUnitTesting string
 }
 
@@ -147,8 +151,12 @@ func newTestServerWithLicense(singleUserToken string, licenseType LicenseType) *
 		panic(err)
 	}
 
+	return newTestServerWithConfig(cfg, singleUserToken, licenseType)
+}
+
+func newTestServerWithConfig(cfg *config.Configuration, singleUserToken string, licenseType LicenseType) *server.Server {
 	logger, _ := mlog.NewLogger()
-	if err = logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
+	if err := logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
 		panic(err)
 	}
 	singleUser := len(singleUserToken) > 0
@@ -325,6 +333,29 @@ func SetupTestHelperWithLicense(t *testing.T, licenseType LicenseType) *TestHelp
 	return th
 }
 
+// SetupTestHelperWithLocalSocket returns a helper whose server has the
+// local mode enabled, with AdminClient connected to its socket.
+func SetupTestHelperWithLocalSocket(t *testing.T) *TestHelper {
+	origUnitTesting := os.Getenv("FOCALBOARD_UNIT_TESTING")
+	os.Setenv("FOCALBOARD_UNIT_TESTING", "1")
+
+	th := &TestHelper{
+		T:                  t,
+		origEnvUnitTesting: origUnitTesting,
+	}
+
+	cfg, err := getTestConfig()
+	require.NoError(t, err)
+	cfg.EnableLocalMode = true
+	cfg.LocalModeSocketLocation = filepath.Join(t.TempDir(), "focalboard_local.socket")
+
+	th.Server = newTestServerWithConfig(cfg, "", LicenseNone)
+	th.Client = client.NewClient(th.Server.Config().ServerRoot, "")
+	th.Client2 = client.NewClient(th.Server.Config().ServerRoot, "")
+	th.AdminClient = client.NewLocalClient(cfg.LocalModeSocketLocation)
+	return th
+}
+
 // Start starts the test server and ensures that it's correctly
 // responding to requests before returning.
 func (th *TestHelper) Start() *TestHelper {
@@ -533,30 +564,35 @@ func (th *TestHelper) CheckOK(r *client.Response) {
 
 func (th *TestHelper) CheckBadRequest(r *client.Response) {
 	require.Equal(th.T, http.StatusBadRequest, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrBadRequest)
 }
 
 func (th *TestHelper) CheckNotFound(r *client.Response) {
 	require.Equal(th.T, http.StatusNotFound, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrNotFound)
 }
 
 func (th *TestHelper) CheckUnauthorized(r *client.Response) {
 	require.Equal(th.T, http.StatusUnauthorized, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrUnauthorized)
 }
 
 func (th *TestHelper) CheckForbidden(r *client.Response) {
 	require.Equal(th.T, http.StatusForbidden, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrForbidden)
 }
 
 func (th *TestHelper) CheckRequestEntityTooLarge(r *client.Response) {
 	require.Equal(th.T, http.StatusRequestEntityTooLarge, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrLimitExceeded)
 }
 
 func (th *TestHelper) CheckNotImplemented(r *client.Response) {
 	require.Equal(th.T, http.StatusNotImplemented, r.StatusCode)
-	require.Error(th.T, r.Error)
+	require.ErrorIs(th.T, r.Error, client.ErrNotImplemented)
+}
+
+func (th *TestHelper) CheckServiceUnavailable(r *client.Response) {
+	require.Equal(th.T, http.StatusServiceUnavailable, r.StatusCode)
+	require.ErrorIs(th.T, r.Error, client.ErrServer)
 }
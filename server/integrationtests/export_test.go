@@ -63,4 +63,38 @@ func TestExportBoard(t *testing.T) {
 		require.Len(t, blocksImported, 1)
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
+	t.Run("export leaves out the private views of other users", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard("test-team", model.BoardTypeOpen)
+
+		views := []*model.Block{
+			{ID: utils.NewID(utils.IDTypeView), Title: "own view", Fields: map[string]interface{}{model.ViewFieldOwnerID: th.GetUser1().ID}},
+			{ID: utils.NewID(utils.IDTypeView), Title: "other view", Fields: map[string]interface{}{model.ViewFieldOwnerID: th.GetUser2().ID}},
+		}
+		for _, view := range views {
+			view.BoardID = board.ID
+			view.ParentID = board.ID
+			view.Type = model.TypeView
+			view.CreateAt = utils.GetMillis()
+			view.UpdateAt = utils.GetMillis()
+			require.NoError(t, th.Server.App().InsertBlock(view, th.GetUser1().ID))
+		}
+
+		buf, resp := th.Client.ExportBoardArchive(board.ID)
+		th.CheckOK(resp)
+		require.NotNil(t, buf)
+
+		resp = th.Client.ImportArchive(model.GlobalTeamID, bytes.NewReader(buf))
+		th.CheckOK(resp)
+
+		boardsImported, err := th.Server.App().GetBoardsForUserAndTeam(th.GetUser1().ID, model.GlobalTeamID, true)
+		require.NoError(t, err)
+		require.Len(t, boardsImported, 1)
+		blocksImported, err := th.Server.App().GetBlocksForBoard(boardsImported[0].ID)
+		require.NoError(t, err)
+		require.Len(t, blocksImported, 1)
+		require.Equal(t, "own view", blocksImported[0].Title)
+	})
 }
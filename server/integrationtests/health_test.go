@@ -0,0 +1,64 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	th := SetupTestHelper(t).Start()
+	defer th.TearDown()
+
+	t.Run("live", func(t *testing.T) {
+		health, resp := th.Client.GetHealthLive()
+		th.CheckOK(resp)
+		require.True(t, health.IsOK())
+		require.Empty(t, health.Components)
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		health, resp := th.Client.GetHealthReady()
+		th.CheckOK(resp)
+		require.True(t, health.IsOK(), health.Components)
+		for _, name := range []string{
+			model.HealthComponentDatabase,
+			model.HealthComponentMigrations,
+			model.HealthComponentFiles,
+			model.HealthComponentNotifications,
+			model.HealthComponentScheduler,
+		} {
+			require.Contains(t, health.Components, name)
+			require.Equal(t, model.HealthStatusOK, health.Components[name], name)
+		}
+	})
+
+	t.Run("probes don't need the CSRF header", func(t *testing.T) {
+		resp, err := http.Get(th.Server.Config().ServerRoot + "/api/v2/health/ready") //nolint:gosec
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+		var health *model.Health
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+		require.True(t, health.IsOK())
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		task := scheduler.CreateRecurringTask("stoppedTask", func() {}, time.Minute)
+		task.Cancel()
+		th.Server.App().SetScheduledTasks([]*scheduler.ScheduledTask{task})
+
+		health, resp := th.Client.GetHealthReady()
+		th.CheckServiceUnavailable(resp)
+		require.False(t, health.IsOK())
+		require.Equal(t, model.HealthStatusUnavailable, health.Components[model.HealthComponentScheduler])
+		require.Equal(t, model.HealthStatusOK, health.Components[model.HealthComponentDatabase])
+	})
+}
@@ -820,6 +820,152 @@ func TestPermissionsPatchBoardChannelId(t *testing.T) {
 	})
 }
 
+func TestPermissionsArchiveCards(t *testing.T) {
+	ttCases := []TestCase{
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userEditor, http.StatusOK, 1},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/archive", methodPost, "[\"block-4\"]", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userEditor, http.StatusOK, 1},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/archive", methodPost, "[\"block-3\"]", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userEditor, http.StatusOK, 1},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/cards/unarchive", methodPost, "[\"block-4\"]", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userEditor, http.StatusOK, 1},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/cards/unarchive", methodPost, "[\"block-3\"]", userAdmin, http.StatusOK, 1},
+	}
+
+	t.Run("plugin", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+	t.Run("local", func(t *testing.T) {
+		th := SetupTestHelperLocalMode(t)
+		defer th.TearDown()
+		clients := setupLocalClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+}
+
+func TestPermissionsFreezeBoard(t *testing.T) {
+	ttCases := []TestCase{
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/freeze", methodPost, "{\"reason\": \"test\"}", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/unfreeze", methodPost, "", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/unfreeze", methodPost, "", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/unfreeze", methodPost, "", userAdmin, http.StatusOK, 1},
+
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userAnon, http.StatusUnauthorized, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userNoTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userTeamMember, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userEditor, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userGuest, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/unfreeze", methodPost, "", userAdmin, http.StatusOK, 1},
+	}
+
+	t.Run("plugin", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+	t.Run("local", func(t *testing.T) {
+		th := SetupTestHelperLocalMode(t)
+		defer th.TearDown()
+		clients := setupLocalClients(th)
+		testData := setupData(t, th)
+		runTestCases(t, ttCases, testData, clients)
+	})
+}
+
 func TestPermissionsDeleteBoard(t *testing.T) {
 	ttCases := []TestCase{
 		{"/boards/{PRIVATE_BOARD_ID}", methodDelete, "", userAnon, http.StatusUnauthorized, 0},
@@ -1342,6 +1488,10 @@ func TestPermissionsPatchBoardBlock(t *testing.T) {
 
 		// Invalid boardID/blockID combination
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks/block-3", methodPatch, patchJSON, userAdmin, http.StatusNotFound, 0},
+
+		// Missing blocks are only reported to users that can change the board
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/missing-block", methodPatch, patchJSON, userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/missing-block", methodPatch, patchJSON, userEditor, http.StatusNotFound, 0},
 	}
 
 	t.Run("plugin", func(t *testing.T) {
@@ -1360,6 +1510,41 @@ func TestPermissionsPatchBoardBlock(t *testing.T) {
 	})
 }
 
+func TestPermissionsPatchOwnComment(t *testing.T) {
+	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
+		err := th.Server.App().InsertBlock(&model.Block{ID: "comment-1", Title: "Test", Type: model.TypeComment, BoardID: testData.publicBoard.ID, CreatedBy: userCommenterID}, userCommenterID)
+		require.NoError(t, err)
+	}
+
+	newTitle := "New Patch Title"
+	titleJSON := toJSON(t, model.BlockPatch{Title: &newTitle})
+	fieldsJSON := toJSON(t, model.BlockPatch{Title: &newTitle, UpdatedFields: map[string]interface{}{"resolvedAt": 1}})
+
+	ttCases := []TestCase{
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/comment-1", methodPatch, titleJSON, userViewer, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/comment-1", methodPatch, titleJSON, userCommenter, http.StatusOK, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/comment-1", methodPatch, fieldsJSON, userCommenter, http.StatusForbidden, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks/comment-1", methodPatch, titleJSON, userEditor, http.StatusOK, 0},
+	}
+
+	t.Run("plugin", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+		testData := setupData(t, th)
+		extraSetup(t, th, testData)
+		runTestCases(t, ttCases, testData, clients)
+	})
+	t.Run("local", func(t *testing.T) {
+		th := SetupTestHelperLocalMode(t)
+		defer th.TearDown()
+		clients := setupLocalClients(th)
+		testData := setupData(t, th)
+		extraSetup(t, th, testData)
+		runTestCases(t, ttCases, testData, clients)
+	})
+}
+
 func TestPermissionsDeleteBoardBlock(t *testing.T) {
 	extraSetup := func(t *testing.T, th *TestHelper, testData TestData) {
 		err := th.Server.App().InsertBlock(&model.Block{ID: "block-5", Title: "Test", Type: "card", BoardID: testData.publicTemplate.ID}, userAdmin)
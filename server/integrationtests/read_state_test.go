@@ -0,0 +1,77 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnreadCounts(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	user2 := th.GetUser2()
+
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       user2.ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	unreadCounts, resp := th.Client.MarkBoardViewed(board.ID)
+	th.CheckOK(resp)
+	require.Zero(t, unreadCounts.UnreadCount)
+
+	time.Sleep(10 * time.Millisecond)
+
+	card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "card"}, false)
+	th.CheckOK(resp)
+
+	_, resp = th.Client2.CreateCardComment(card.ID, &model.Comment{Text: "comment"})
+	th.CheckOK(resp)
+
+	t.Run("changes by other users are unread", func(t *testing.T) {
+		unreadCounts, resp := th.Client.GetBoardUnreadCounts(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, unreadCounts.UnreadCount)
+		require.Equal(t, map[string]int{card.ID: 2}, unreadCounts.Cards)
+
+		unreadCounts, resp = th.Client2.GetBoardUnreadCounts(board.ID)
+		th.CheckOK(resp)
+		require.Zero(t, unreadCounts.UnreadCount)
+
+		categoryBoards, resp := th.Client.GetUserCategoryBoards(testTeamID)
+		th.CheckOK(resp)
+		found := false
+		for _, categoryBoard := range categoryBoards {
+			for _, boardMetadata := range categoryBoard.BoardMetadata {
+				if boardMetadata.BoardID == board.ID {
+					require.Equal(t, 2, boardMetadata.UnreadCount)
+					found = true
+				}
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("mark a card viewed", func(t *testing.T) {
+		_, resp := th.Client.MarkCardViewed("nonexistent-card")
+		th.CheckBadRequest(resp)
+
+		unreadCounts, resp := th.Client.MarkCardViewed(card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, 2, unreadCounts.UnreadCount)
+		require.Empty(t, unreadCounts.Cards)
+	})
+
+	t.Run("mark the board viewed", func(t *testing.T) {
+		unreadCounts, resp := th.Client.MarkBoardViewed(board.ID)
+		th.CheckOK(resp)
+		require.Zero(t, unreadCounts.UnreadCount)
+		require.Empty(t, unreadCounts.Cards)
+	})
+}
@@ -0,0 +1,92 @@
+package integrationtests
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/ws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	copyConfig := func() *config.Configuration {
+		cfg := *th.Server.Config()
+		return &cfg
+	}
+
+	t.Run("feature flags are applied and broadcast", func(t *testing.T) {
+		wsClient := th.Client.NewWSClient()
+		defer wsClient.Close()
+
+		configEvents := make(chan *client.ClientConfigEvent, 10)
+		wsClient.OnEvent(ws.WebsocketActionUpdateConfig, func(event client.WSEvent) {
+			configEvents <- event.(*client.ClientConfigEvent)
+		})
+		require.NoError(t, wsClient.Connect())
+
+		// the connection is registered asynchronously, so the flag is
+		// changed until its change is received
+		attempt := 0
+		require.Eventually(t, func() bool {
+			attempt++
+			value := strconv.Itoa(attempt)
+
+			cfg := copyConfig()
+			cfg.FeatureFlags = map[string]string{"reloadTest": value}
+			restartRequired, err := th.Server.ReloadConfig(cfg)
+			require.NoError(t, err)
+			require.Empty(t, restartRequired)
+			require.Equal(t, value, th.Server.App().GetClientConfig().FeatureFlags["reloadTest"])
+
+			select {
+			case event := <-configEvents:
+				return event.ClientConfig.FeatureFlags["reloadTest"] == value
+			case <-time.After(100 * time.Millisecond):
+				return false
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("settings that need a restart are reported", func(t *testing.T) {
+		port := th.Server.Config().Port
+
+		cfg := copyConfig()
+		cfg.Port = port + 1
+		cfg.NotifyFreqCardSeconds = 60
+		restartRequired, err := th.Server.ReloadConfig(cfg)
+		require.NoError(t, err)
+		require.Equal(t, []string{"port"}, restartRequired)
+		require.Equal(t, port, th.Server.Config().Port)
+		require.Equal(t, 60, th.Server.Config().NotifyFreqCardSeconds)
+	})
+
+	t.Run("the configuration is replaced instead of modified", func(t *testing.T) {
+		oldCfg := th.Server.Config()
+		maxFileSize := oldCfg.MaxFileSize
+
+		cfg := copyConfig()
+		cfg.MaxFileSize = maxFileSize + 1
+		_, err := th.Server.ReloadConfig(cfg)
+		require.NoError(t, err)
+
+		require.Equal(t, maxFileSize, oldCfg.MaxFileSize)
+		require.Equal(t, maxFileSize+1, th.Server.Config().MaxFileSize)
+		require.Same(t, th.Server.Config(), th.Server.App().GetConfig())
+	})
+
+	t.Run("invalid configs are rejected", func(t *testing.T) {
+		cfg := copyConfig()
+		cfg.DBType = "oracle"
+		cfg.DataRetentionDays = 7
+		_, err := th.Server.ReloadConfig(cfg)
+		var invalidErr *config.InvalidConfigError
+		require.ErrorAs(t, err, &invalidErr)
+		require.NotEqual(t, 7, th.Server.Config().DataRetentionDays)
+	})
+}
@@ -0,0 +1,136 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateViews(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	user1 := th.GetUser1()
+	user2 := th.GetUser2()
+
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       user2.ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	view, resp := th.Client.CreateView(board.ID, &model.View{
+		Title:      "my view",
+		OwnerID:    user1.ID,
+		ViewFields: model.ViewFields{ViewType: model.ViewTypeTable},
+	})
+	th.CheckOK(resp)
+	require.Equal(t, user1.ID, view.OwnerID)
+
+	t.Run("private views are only visible to their owner", func(t *testing.T) {
+		views, resp := th.Client.GetViews(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, views, 1)
+
+		views, resp = th.Client2.GetViews(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, views)
+
+		_, resp = th.Client2.GetView(board.ID, view.ID)
+		th.CheckNotFound(resp)
+
+		blocks, resp := th.Client2.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, block := range blocks {
+			require.NotEqual(t, view.ID, block.ID)
+		}
+
+		title := "changed"
+		_, resp = th.Client2.PatchBlock(board.ID, view.ID, &model.BlockPatch{Title: &title}, false)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("private views can't be created for other users", func(t *testing.T) {
+		_, resp := th.Client2.CreateView(board.ID, &model.View{
+			OwnerID:    user1.ID,
+			ViewFields: model.ViewFields{ViewType: model.ViewTypeTable},
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the owner can't be changed by a block patch", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.ViewFieldOwnerID: user2.ID}}
+		_, resp := th.Client.PatchBlock(board.ID, view.ID, patch, false)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("share a private view", func(t *testing.T) {
+		_, resp := th.Client2.ShareView(board.ID, view.ID)
+		th.CheckNotFound(resp)
+
+		shared, resp := th.Client.ShareView(board.ID, view.ID)
+		th.CheckOK(resp)
+		require.Empty(t, shared.OwnerID)
+
+		shared, resp = th.Client2.GetView(board.ID, view.ID)
+		th.CheckOK(resp)
+		require.Equal(t, view.ID, shared.ID)
+
+		_, resp = th.Client.ShareView(board.ID, view.ID)
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestPrivateViewOfViewer(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+	card := cards[0]
+	user2 := th.GetUser2()
+
+	_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+		BoardID:      board.ID,
+		UserID:       user2.ID,
+		SchemeViewer: true,
+	})
+	th.CheckOK(resp)
+
+	view, resp := th.Client2.CreateView(board.ID, &model.View{
+		Title:      "my view",
+		OwnerID:    user2.ID,
+		ViewFields: model.ViewFields{ViewType: model.ViewTypeTable},
+	})
+	th.CheckOK(resp)
+
+	t.Run("the owner can change the view", func(t *testing.T) {
+		title := "renamed"
+		_, resp := th.Client2.PatchBlock(board.ID, view.ID, &model.BlockPatch{Title: &title}, false)
+		th.CheckOK(resp)
+	})
+
+	t.Run("the owner can't turn the view into a card", func(t *testing.T) {
+		blockType := model.BlockType(model.TypeCard)
+		_, resp := th.Client2.PatchBlock(board.ID, view.ID, &model.BlockPatch{Type: &blockType}, false)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("the owner can't turn the view into card content", func(t *testing.T) {
+		blockType := model.BlockType(model.TypeText)
+		patch := &model.BlockPatch{Type: &blockType, ParentID: &card.ID}
+		_, resp := th.Client2.PatchBlock(board.ID, view.ID, patch, false)
+		th.CheckForbidden(resp)
+	})
+
+	views, resp := th.Client2.GetViews(board.ID)
+	th.CheckOK(resp)
+	require.Len(t, views, 1)
+	require.Equal(t, user2.ID, views[0].OwnerID)
+
+	views, resp = th.Client.GetViews(board.ID)
+	th.CheckOK(resp)
+	require.Empty(t, views)
+}
@@ -0,0 +1,77 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/ws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWSClient(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+	wsClient := th.Client.NewWSClient()
+	defer wsClient.Close()
+
+	boardEvents := make(chan *client.BoardEvent, 10)
+	wsClient.OnEvent(ws.WebsocketActionUpdateBoard, func(event client.WSEvent) {
+		boardEvents <- event.(*client.BoardEvent)
+	})
+
+	require.NoError(t, wsClient.Connect())
+	require.NoError(t, wsClient.SubscribeTeam(testTeamID))
+
+	t.Run("board changes are received once subscribed", func(t *testing.T) {
+		// the subscription is processed asynchronously, so the board
+		// is patched until its changes are received
+		title := "patched"
+		require.Eventually(t, func() bool {
+			_, resp := th.Client.PatchBoard(board.ID, &model.BoardPatch{Title: &title})
+			th.CheckOK(resp)
+
+			// the changes of the board creation may be received too
+			for {
+				select {
+				case event := <-boardEvents:
+					require.Equal(t, testTeamID, event.TeamID)
+					require.Equal(t, board.ID, event.Board.ID)
+					if event.Board.Title == title {
+						return true
+					}
+				case <-time.After(100 * time.Millisecond):
+					return false
+				}
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("block changes are sent to the events channel", func(t *testing.T) {
+		// the channel is only enabled now, as its events would otherwise
+		// wait for the previous test to drain them
+		events := wsClient.Events()
+
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card"}, false)
+		th.CheckOK(resp)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event := <-events:
+				blockEvent, ok := event.(*client.BlockEvent)
+				if ok && blockEvent.Block.ID == card.ID {
+					require.Equal(t, board.ID, blockEvent.Block.BoardID)
+					return
+				}
+			case <-timeout:
+				require.Fail(t, "block event not received")
+				return
+			}
+		}
+	})
+}
@@ -67,19 +67,14 @@ func main() {
 	)
 	flag.Parse()
 
-	config, err := config.ReadConfigFile(*pConfigFilePath)
+	config, err := readConfigFile(*pConfigFilePath)
 	if err != nil {
 		log.Fatal("Unable to read the config file: ", err)
 		return
 	}
 
 	logger, _ := mlog.NewLogger()
-	cfgJSON := config.LoggingCfgJSON
-	if config.LoggingCfgFile == "" && cfgJSON == "" {
-		// if no logging defined, use default config (console output)
-		cfgJSON = defaultLoggingConfig()
-	}
-	err = logger.Configure(config.LoggingCfgFile, cfgJSON, nil)
+	err = logger.Configure(config.LoggingCfgFile, config.LoggingCfgJSON, nil)
 	if err != nil {
 		log.Fatal("Error in config file for logger: ", err)
 		return
@@ -115,22 +110,21 @@ func main() {
 	// Override config from commandline
 
 	if pDBType != nil && len(*pDBType) > 0 {
-		config.DBType = *pDBType
 		logger.Info("DBType from commandline", mlog.String("DBType", *pDBType))
 	}
 
 	if pDBConfig != nil && len(*pDBConfig) > 0 {
-		config.DBConfigString = *pDBConfig
 		// Don't echo, as the confix string may contain passwords
 		logger.Info("DBConfigString overridden from commandline")
 	}
 
 	if pPort != nil && *pPort > 0 && *pPort != config.Port {
-		// Override port
 		logger.Info("Port from commandline", mlog.Int("port", *pPort))
-		config.Port = *pPort
 	}
 
+	override := commandLineOverride(*pDBType, *pDBConfig, *pPort)
+	override(config)
+
 	db, err := server.NewStore(config, singleUser, logger)
 	if err != nil {
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
@@ -159,12 +153,57 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 
-	// Waiting for SIGINT (pkill -2)
-	<-stop
+	// Waiting for SIGINT (pkill -2), and reloading the config meanwhile
+	watchConfig(server, *pConfigFilePath, logger, stop, override)
 
 	_ = server.Shutdown()
 }
 
+// watchConfig reloads the config file when it changes, or on SIGHUP, until
+// stop receives a signal.
+func watchConfig(srv *server.Server, configFilePath string, logger *mlog.Logger, stop <-chan os.Signal, override func(*config.Configuration)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var changes <-chan struct{}
+	var watchErrors <-chan error
+	watcher, err := config.NewWatcher(configFilePath)
+	if err != nil {
+		logger.Warn("Unable to watch the config file, send SIGHUP to reload it", mlog.Err(err))
+	} else {
+		defer func() { _ = watcher.Close() }()
+		changes = watcher.Changes()
+		watchErrors = watcher.Errors()
+	}
+
+	reload := func() {
+		cfg, err := readConfigFile(configFilePath)
+		if err != nil {
+			logger.Error("Unable to read the config file", mlog.Err(err))
+			return
+		}
+		override(cfg)
+
+		if _, err := srv.ReloadConfig(cfg); err != nil {
+			logger.Error("Unable to reload the config file", mlog.Err(err))
+		}
+	}
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hup:
+			reload()
+		case <-changes:
+			reload()
+		case err := <-watchErrors:
+			logger.Warn("Error watching the config file", mlog.Err(err))
+		}
+	}
+}
+
 // StartServer starts the server
 //
 //export StartServer
@@ -268,6 +307,38 @@ func stopServer() {
 	pServer = nil
 }
 
+// readConfigFile reads the config file, and uses the default logging
+// config (console output) if no logging is defined.
+func readConfigFile(configFilePath string) (*config.Configuration, error) {
+	cfg, err := config.ReadConfigFile(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.LoggingCfgFile == "" && cfg.LoggingCfgJSON == "" {
+		cfg.LoggingCfgJSON = defaultLoggingConfig()
+	}
+	return cfg, nil
+}
+
+// commandLineOverride returns a function that applies the settings given
+// on the command line to a config, so that they also apply to the reloaded
+// configs.
+func commandLineOverride(dbType, dbConfig string, port int) func(*config.Configuration) {
+	return func(cfg *config.Configuration) {
+		if len(dbType) > 0 {
+			cfg.DBType = dbType
+		}
+
+		if len(dbConfig) > 0 {
+			cfg.DBConfigString = dbConfig
+		}
+
+		if port > 0 {
+			cfg.Port = port
+		}
+	}
+}
+
 func defaultLoggingConfig() string {
 	return `
 	{
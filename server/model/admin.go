@@ -0,0 +1,92 @@
+package model
+
+// IntegrityCheckSampleSize is the number of IDs of the rows failing an
+// integrity check that are reported.
+const IntegrityCheckSampleSize = 10
+
+// AdminCreateUserRequest is a request of the local admin API to create
+// a user
+// swagger:model
+type AdminCreateUserRequest struct {
+	// The username of the user
+	// required: true
+	Username string `json:"username"`
+
+	// The email of the user
+	// required: true
+	Email string `json:"email"`
+
+	// The password of the user
+	// required: true
+	Password string `json:"password"`
+}
+
+func (r *AdminCreateUserRequest) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("missing user")
+	}
+	if r.Username == "" {
+		return NewErrBadRequest("username is required")
+	}
+	if r.Email == "" {
+		return NewErrBadRequest("email is required")
+	}
+	if r.Password == "" {
+		return NewErrBadRequest("password is required")
+	}
+	return nil
+}
+
+// AdminTransferBoardRequest is a request of the local admin API to
+// transfer the ownership of a board
+// swagger:model
+type AdminTransferBoardRequest struct {
+	// The ID of the new owner of the board
+	// required: true
+	UserID string `json:"userId"`
+}
+
+// AdminDataRetentionRequest is a request of the local admin API to run
+// the data retention
+// swagger:model
+type AdminDataRetentionRequest struct {
+	// The boards and blocks not updated for this number of days are
+	// deleted
+	// required: true
+	Days int `json:"days"`
+}
+
+func (r *AdminDataRetentionRequest) IsValid() error {
+	if r == nil || r.Days <= 0 {
+		return NewErrBadRequest("a positive number of days is required")
+	}
+	return nil
+}
+
+// AdminTaskResult is the result of a task of the local admin API
+// swagger:model
+type AdminTaskResult struct {
+	// The number of rows the task processed
+	// required: true
+	Count int64 `json:"count"`
+}
+
+// IntegrityCheck is the result of a data integrity check
+// swagger:model
+type IntegrityCheck struct {
+	// The name of the check
+	// required: true
+	Name string `json:"name"`
+
+	// What the check looks for
+	// required: true
+	Description string `json:"description"`
+
+	// The number of rows failing the check
+	// required: true
+	Count int64 `json:"count"`
+
+	// The IDs of some of the rows failing the check
+	// required: false
+	SampleIDs []string `json:"sampleIds,omitempty"`
+}
@@ -158,6 +158,10 @@ func (b *Block) IsValid() error {
 		return ErrBlockFieldsSizeLimitExceeded
 	}
 
+	if def, ok := GetBlockTypeDefinition(b.Type); ok && def.Validate != nil {
+		return def.Validate(b)
+	}
+
 	return nil
 }
 
@@ -194,6 +198,9 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 		block.Title = *p.Title
 	}
 
+	if block.Fields == nil && len(p.UpdatedFields) > 0 {
+		block.Fields = map[string]interface{}{}
+	}
 	for key, field := range p.UpdatedFields {
 		block.Fields[key] = field
 	}
@@ -211,6 +218,8 @@ type QueryBlocksOptions struct {
 	BlockType BlockType // if not empty and not `TypeUnknown` then filter for records of specified block type
 	Page      int       // page number to select when paginating
 	PerPage   int       // number of blocks per page (default=-1, meaning unlimited)
+
+	ExcludeArchived bool // if true then filter out archived cards
 }
 
 // QuerySubtreeOptions are query options that can be passed to GetSubTree methods.
@@ -244,6 +253,28 @@ type QueryBlockHistoryChildOptions struct {
 	PerPage        int   // number of blocks per page (default=-1, meaning unlimited)
 }
 
+// changesFields returns true if the patch updates or removes any of
+// the given fields.
+func (p *BlockPatch) changesFields(keys ...string) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, key := range keys {
+		if _, ok := p.UpdatedFields[key]; ok {
+			return true
+		}
+
+		for _, deleted := range p.DeletedFields {
+			if deleted == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func StampModificationMetadata(userID string, blocks []*Block, auditRec *audit.Record) {
 	if userID == SingleUser {
 		userID = ""
@@ -290,3 +321,16 @@ func (b *Block) GetLimited() *Block {
 
 	return newBlock
 }
+
+func getInt64Field(fields map[string]interface{}, key string) int64 {
+	switch v := fields[key].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+)
+
+// linkRegexp matches the paths of the board and card links that
+// utils.MakeBoardLink and utils.MakeCardLink produce, and of the
+// links the webapp shows for a board, a view or a card.
+var linkRegexp = regexp.MustCompile(`/team/[^/\s]+/(b[a-z0-9]{26})\b(?:/[A-Za-z0-9]+(?:/(c[a-z0-9]{26})\b)?)?`)
+
+// BlockLink is a link from a text or comment block to a card or a board
+type BlockLink struct {
+	// The id of the block with the link
+	SourceID string
+
+	// The id of the board of the block with the link
+	SourceBoardID string
+
+	// The id of the card of the block with the link
+	SourceCardID string
+
+	// The id of the linked board
+	TargetBoardID string
+
+	// The id of the linked card, empty for links to a board
+	TargetCardID string
+
+	// The creation time in milliseconds since the current epoch
+	CreateAt int64
+}
+
+// Backlink is a card that links to a card or a board in its content
+// or comments
+// swagger:model
+type Backlink struct {
+	// The id of the board of the card with the link
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board of the card with the link
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The id of the card with the link
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The title of the card with the link
+	// required: true
+	CardTitle string `json:"cardTitle"`
+
+	// The last time the link was saved in milliseconds since the
+	// current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// ParseBlockLinks returns the links to other cards and boards in the
+// title of a block, without duplicates. Links of a card to itself or
+// to its own board are left out.
+func ParseBlockLinks(block *Block) []*BlockLink {
+	if !block.Type.HasLinks() {
+		return []*BlockLink{}
+	}
+
+	links := []*BlockLink{}
+	seen := map[string]bool{}
+	for _, match := range linkRegexp.FindAllStringSubmatch(block.Title, -1) {
+		boardID, cardID := match[1], match[2]
+		if cardID == block.ParentID || (cardID == "" && boardID == block.BoardID) {
+			continue
+		}
+
+		key := boardID + "/" + cardID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		links = append(links, &BlockLink{
+			SourceID:      block.ID,
+			SourceBoardID: block.BoardID,
+			SourceCardID:  block.ParentID,
+			TargetBoardID: boardID,
+			TargetCardID:  cardID,
+		})
+	}
+	return links
+}
+
+func BacklinksFromJSON(data io.Reader) []*Backlink {
+	var backlinks []*Backlink
+	_ = json.NewDecoder(data).Decode(&backlinks)
+	return backlinks
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockLinks(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	otherCardID := utils.NewID(utils.IDTypeCard)
+	viewID := utils.NewID(utils.IDTypeView)
+
+	newBlock := func(blockType BlockType, title string) *Block {
+		return &Block{
+			ID:       "block-id",
+			BoardID:  boardID,
+			ParentID: cardID,
+			Type:     blockType,
+			Title:    title,
+		}
+	}
+
+	t.Run("card and board links", func(t *testing.T) {
+		title := "see " + utils.MakeCardLink("http://localhost:8000", "0", otherBoardID, otherCardID) +
+			" and [the board](" + utils.MakeBoardLink("https://example.com/boards", "team-id", otherBoardID) + ")" +
+			" and /team/team-id/" + otherBoardID + "/" + viewID + "/" + otherCardID
+		links := ParseBlockLinks(newBlock(TypeText, title))
+
+		require.Equal(t, []*BlockLink{
+			{SourceID: "block-id", SourceBoardID: boardID, SourceCardID: cardID, TargetBoardID: otherBoardID, TargetCardID: otherCardID},
+			{SourceID: "block-id", SourceBoardID: boardID, SourceCardID: cardID, TargetBoardID: otherBoardID},
+		}, links)
+	})
+
+	t.Run("links to a view of a board", func(t *testing.T) {
+		links := ParseBlockLinks(newBlock(TypeComment, "/team/team-id/"+otherBoardID+"/"+viewID))
+		require.Len(t, links, 1)
+		require.Equal(t, otherBoardID, links[0].TargetBoardID)
+		require.Empty(t, links[0].TargetCardID)
+	})
+
+	t.Run("links to the card itself and its board are ignored", func(t *testing.T) {
+		title := utils.MakeCardLink("", "0", boardID, cardID) + " " + utils.MakeBoardLink("", "0", boardID)
+		require.Empty(t, ParseBlockLinks(newBlock(TypeText, title)))
+	})
+
+	t.Run("links of other block types are ignored", func(t *testing.T) {
+		title := utils.MakeCardLink("", "0", otherBoardID, otherCardID)
+		require.Empty(t, ParseBlockLinks(newBlock(TypeCheckbox, title)))
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		require.Empty(t, ParseBlockLinks(newBlock(TypeText, "/team/0/board-id/0/card-id")))
+		require.Empty(t, ParseBlockLinks(newBlock(TypeText, "/team/0/"+otherBoardID+"x")))
+	})
+}
@@ -26,6 +26,9 @@ func GenerateBlockIDs(blocks []*Block, logger mlog.LoggerIFace) []*Block {
 		if _, ok := referenceIDs[block.ParentID]; !ok {
 			referenceIDs[block.ParentID] = true
 		}
+		if threadID, ok := block.Fields[CommentFieldThreadID].(string); ok && threadID != "" {
+			referenceIDs[threadID] = true
+		}
 
 		if _, ok := block.Fields["contentOrder"]; ok {
 			contentOrder, typeOk := block.Fields["contentOrder"].([]interface{})
@@ -123,6 +126,10 @@ func GenerateBlockIDs(blocks []*Block, logger mlog.LoggerIFace) []*Block {
 			}
 		}
 
+		if threadID, ok := blockMod.Fields[CommentFieldThreadID].(string); ok && threadID != "" {
+			blockMod.Fields[CommentFieldThreadID] = getExistingOrOldID(threadID)
+		}
+
 		newBlocks[i] = blockMod
 	}
 
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestGenerateBlockIDsCommentThreads(t *testing.T) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	rootID := utils.NewID(utils.IDTypeBlock)
+	replyID := utils.NewID(utils.IDTypeBlock)
+	otherThreadID := utils.NewID(utils.IDTypeBlock)
+
+	card := &Block{ID: cardID, BoardID: boardID, ParentID: boardID, Type: TypeCard}
+	root := &Block{ID: rootID, BoardID: boardID, ParentID: cardID, Type: TypeComment}
+	reply := &Block{
+		ID:       replyID,
+		BoardID:  boardID,
+		ParentID: cardID,
+		Type:     TypeComment,
+		Fields:   map[string]interface{}{CommentFieldThreadID: rootID},
+	}
+	orphan := &Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  boardID,
+		ParentID: cardID,
+		Type:     TypeComment,
+		Fields:   map[string]interface{}{CommentFieldThreadID: otherThreadID},
+	}
+
+	blocks := GenerateBlockIDs([]*Block{card, root, reply, orphan}, &mlog.Logger{})
+
+	require.NotEqual(t, rootID, blocks[1].ID)
+	require.NotEqual(t, replyID, blocks[2].ID)
+	require.Equal(t, blocks[0].ID, blocks[2].ParentID)
+	require.Equal(t, blocks[1].ID, blocks[2].Fields[CommentFieldThreadID])
+
+	// references to comments that aren't copied are kept
+	require.Equal(t, otherThreadID, blocks[3].Fields[CommentFieldThreadID])
+
+	threads, err := NewCommentThreads(blocks, nil)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	require.Equal(t, blocks[1].ID, threads[0].ID)
+	require.Len(t, threads[0].Replies, 1)
+	require.Equal(t, blocks[2].ID, threads[0].Replies[0].ID)
+}
@@ -5,7 +5,10 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/mattermost/focalboard/server/utils"
 )
@@ -24,54 +27,166 @@ const (
 	TypeImage      = "image"
 	TypeAttachment = "attachment"
 	TypeDivider    = "divider"
+	TypeCode       = "code"
+	TypeTable      = "table"
+	TypeCallout    = "callout"
 )
 
+var ErrBlockTypeAlreadyRegistered = errors.New("block type already registered")
+
 func (bt BlockType) String() string {
 	return string(bt)
 }
 
+// BlockTypeDefinition describes how the server handles the blocks of a
+// type. Block types are registered with RegisterBlockType.
+type BlockTypeDefinition struct {
+	// Type is the block type.
+	Type BlockType
+
+	// IDType is the type of the IDs generated for new blocks.
+	IDType utils.IDType
+
+	// Content is true if the blocks are shown as the content of a card,
+	// and so are listed in its content order.
+	Content bool
+
+	// FileFields are the fields that hold the ID of the file of a
+	// block. The files are exported and imported with the archives.
+	FileFields []string
+
+	// Validate checks the fields of a block, and is optional.
+	Validate func(block *Block) error
+
+	// Links is true if the links to other cards and boards in the title
+	// of the blocks are saved as backlinks of the linked cards and boards.
+	Links bool
+
+	// NotifyHidden leaves the blocks out of the content changes of
+	// notifications.
+	NotifyHidden bool
+
+	// NotifyLabel, if set, is shown in change notifications instead of
+	// a diff of the text, as in "An image was added.".
+	NotifyLabel string
+
+	// NotifyText returns the text of a block to diff in change
+	// notifications, and is optional. The title is used by default.
+	NotifyText func(block *Block) string
+}
+
+var blockTypes = struct {
+	sync.RWMutex
+	definitions map[BlockType]*BlockTypeDefinition
+}{
+	definitions: map[BlockType]*BlockTypeDefinition{},
+}
+
+func init() {
+	builtins := []BlockTypeDefinition{
+		{Type: TypeBoard, IDType: utils.IDTypeBoard},
+		{Type: TypeCard, IDType: utils.IDTypeCard},
+		{Type: TypeView, IDType: utils.IDTypeView},
+		{Type: TypeText, IDType: utils.IDTypeBlock, Content: true, Links: true},
+		{Type: TypeCheckbox, IDType: utils.IDTypeBlock, Content: true},
+		{Type: TypeComment, IDType: utils.IDTypeBlock, Links: true, NotifyHidden: true},
+		{Type: TypeImage, IDType: utils.IDTypeAttachment, Content: true, FileFields: []string{"fileId", "attachmentId"}, NotifyLabel: "An image"},
+		{Type: TypeAttachment, IDType: utils.IDTypeAttachment, Content: true, FileFields: []string{"fileId", "attachmentId"}, NotifyLabel: "A file attachment"},
+		{Type: TypeDivider, IDType: utils.IDTypeBlock, Content: true, NotifyHidden: true},
+		codeBlockType,
+		tableBlockType,
+		calloutBlockType,
+	}
+
+	for _, def := range builtins {
+		if err := RegisterBlockType(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterBlockType adds a block type to the types handled by the
+// server. Types are case insensitive and can't be registered twice.
+func RegisterBlockType(def BlockTypeDefinition) error {
+	if def.Type == "" || def.Type == TypeUnknown {
+		return ErrInvalidBlockType{string(def.Type)}
+	}
+	if def.IDType == 0 {
+		def.IDType = utils.IDTypeBlock
+	}
+
+	key := BlockType(strings.ToLower(string(def.Type)))
+
+	blockTypes.Lock()
+	defer blockTypes.Unlock()
+
+	if _, ok := blockTypes.definitions[key]; ok {
+		return fmt.Errorf("%s: %w", def.Type, ErrBlockTypeAlreadyRegistered)
+	}
+	blockTypes.definitions[key] = &def
+	return nil
+}
+
+// GetBlockTypeDefinition returns the definition of a registered block
+// type.
+func GetBlockTypeDefinition(blockType BlockType) (*BlockTypeDefinition, bool) {
+	blockTypes.RLock()
+	defer blockTypes.RUnlock()
+
+	def, ok := blockTypes.definitions[BlockType(strings.ToLower(string(blockType)))]
+	return def, ok
+}
+
+// RegisteredBlockTypes returns the registered block types, sorted.
+func RegisteredBlockTypes() []BlockType {
+	blockTypes.RLock()
+	defer blockTypes.RUnlock()
+
+	types := make([]BlockType, 0, len(blockTypes.definitions))
+	for _, def := range blockTypes.definitions {
+		types = append(types, def.Type)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
 // BlockTypeFromString returns an appropriate BlockType for the specified string.
 func BlockTypeFromString(s string) (BlockType, error) {
-	switch strings.ToLower(s) {
-	case "board":
-		return TypeBoard, nil
-	case "card":
-		return TypeCard, nil
-	case "view":
-		return TypeView, nil
-	case "text":
-		return TypeText, nil
-	case "checkbox":
-		return TypeCheckbox, nil
-	case "comment":
-		return TypeComment, nil
-	case "image":
-		return TypeImage, nil
-	case "attachment":
-		return TypeAttachment, nil
-	case "divider":
-		return TypeDivider, nil
+	if def, ok := GetBlockTypeDefinition(BlockType(s)); ok {
+		return def.Type, nil
 	}
 	return TypeUnknown, ErrInvalidBlockType{s}
 }
 
 // BlockType2IDType returns an appropriate IDType for the specified BlockType.
 func BlockType2IDType(blockType BlockType) utils.IDType {
-	switch blockType {
-	case TypeBoard:
-		return utils.IDTypeBoard
-	case TypeCard:
-		return utils.IDTypeCard
-	case TypeView:
-		return utils.IDTypeView
-	case TypeText, TypeCheckbox, TypeComment, TypeDivider:
-		return utils.IDTypeBlock
-	case TypeImage, TypeAttachment:
-		return utils.IDTypeAttachment
+	if def, ok := GetBlockTypeDefinition(blockType); ok {
+		return def.IDType
 	}
 	return utils.IDTypeNone
 }
 
+// BlockTypeFileFields returns the fields that hold the ID of the file
+// of the blocks of the type, if any.
+func BlockTypeFileFields(blockType BlockType) []string {
+	if def, ok := GetBlockTypeDefinition(blockType); ok {
+		return def.FileFields
+	}
+	return nil
+}
+
+// HasFile returns true if blocks of the type refer to a file.
+func (bt BlockType) HasFile() bool {
+	return len(BlockTypeFileFields(bt)) != 0
+}
+
+// HasLinks returns true if the links in blocks of the type are saved as
+// backlinks.
+func (bt BlockType) HasLinks() bool {
+	def, ok := GetBlockTypeDefinition(bt)
+	return ok && def.Links
+}
+
 // ErrInvalidBlockType is returned wherever an invalid block type was provided.
 type ErrInvalidBlockType struct {
 	Type string
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/rivo/uniseg"
+)
+
+const (
+	// CodeBlockLanguageMaxRunes is the maximum length of the language of
+	// a code block.
+	CodeBlockLanguageMaxRunes = 64
+
+	// TableBlockMaxColumns is the maximum number of columns of a table
+	// block.
+	TableBlockMaxColumns = 64
+)
+
+// CalloutVariants are the styles of callout blocks. The empty variant
+// is shown as an info callout.
+var CalloutVariants = map[string]bool{
+	"":        true,
+	"info":    true,
+	"success": true,
+	"warning": true,
+	"error":   true,
+}
+
+// Code blocks hold the code in their title, and the optional language
+// to highlight it with in the "language" field.
+var codeBlockType = BlockTypeDefinition{
+	Type:    TypeCode,
+	IDType:  utils.IDTypeBlock,
+	Content: true,
+	Validate: func(block *Block) error {
+		language, err := getOptionalStringField(block, "language")
+		if err != nil {
+			return err
+		}
+		if utf8.RuneCountInString(language) > CodeBlockLanguageMaxRunes {
+			return NewErrBadRequest(fmt.Sprintf("code block language can't be longer than %d characters", CodeBlockLanguageMaxRunes))
+		}
+		return nil
+	},
+}
+
+// Table blocks hold their cells in the "rows" field, as a list of rows
+// of the same length. The "header" field is true if the first row is a
+// header.
+var tableBlockType = BlockTypeDefinition{
+	Type:    TypeTable,
+	IDType:  utils.IDTypeBlock,
+	Content: true,
+	Validate: func(block *Block) error {
+		if header, ok := block.Fields["header"]; ok {
+			if _, ok = header.(bool); !ok {
+				return NewErrBadRequest("table block header must be a boolean")
+			}
+		}
+		_, err := GetTableRows(block)
+		return err
+	},
+	NotifyText: func(block *Block) string {
+		rows, err := GetTableRows(block)
+		if err != nil {
+			return ""
+		}
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			lines = append(lines, strings.Join(row, " | "))
+		}
+		return strings.Join(lines, "\n")
+	},
+}
+
+// Callout blocks hold their text in the title, an optional icon in the
+// "icon" field and the optional style in the "variant" field.
+var calloutBlockType = BlockTypeDefinition{
+	Type:    TypeCallout,
+	IDType:  utils.IDTypeBlock,
+	Content: true,
+	Validate: func(block *Block) error {
+		icon, err := getOptionalStringField(block, "icon")
+		if err != nil {
+			return err
+		}
+		if uniseg.GraphemeClusterCount(icon) > 1 {
+			return NewErrBadRequest("callout block icon can have only one grapheme")
+		}
+
+		variant, err := getOptionalStringField(block, "variant")
+		if err != nil {
+			return err
+		}
+		if !CalloutVariants[variant] {
+			return NewErrBadRequest(fmt.Sprintf("invalid callout block variant %s", variant))
+		}
+		return nil
+	},
+	NotifyText: func(block *Block) string {
+		icon, _ := block.Fields["icon"].(string)
+		return strings.TrimSpace(icon + " " + block.Title)
+	},
+}
+
+// GetTableRows returns the cells of a table block, by row.
+func GetTableRows(block *Block) ([][]string, error) {
+	var rows [][]string
+	switch value := block.Fields["rows"].(type) {
+	case nil:
+		return [][]string{}, nil
+	case [][]string:
+		rows = value
+	case []interface{}:
+		rows = make([][]string, 0, len(value))
+		for _, rowValue := range value {
+			cells, ok := rowValue.([]interface{})
+			if !ok {
+				return nil, NewErrBadRequest("table block rows must be lists of cells")
+			}
+			row := make([]string, 0, len(cells))
+			for _, cellValue := range cells {
+				cell, ok := cellValue.(string)
+				if !ok {
+					return nil, NewErrBadRequest("table block cells must be strings")
+				}
+				row = append(row, cell)
+			}
+			rows = append(rows, row)
+		}
+	default:
+		return nil, NewErrBadRequest("table block rows must be a list")
+	}
+
+	for _, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, NewErrBadRequest("table block rows must have the same number of cells")
+		}
+		if len(row) > TableBlockMaxColumns {
+			return nil, NewErrBadRequest(fmt.Sprintf("table block can't have more than %d columns", TableBlockMaxColumns))
+		}
+	}
+
+	return rows, nil
+}
+
+func getOptionalStringField(block *Block, field string) (string, error) {
+	value, ok := block.Fields[field]
+	if !ok || value == nil {
+		return "", nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", NewErrBadRequest(fmt.Sprintf("%s block %s must be a string", block.Type, field))
+	}
+	return str, nil
+}
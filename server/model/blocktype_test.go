@@ -0,0 +1,136 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTypeRegistry(t *testing.T) {
+	t.Run("builtin types", func(t *testing.T) {
+		for _, blockType := range []BlockType{TypeBoard, TypeCard, TypeView, TypeText, TypeCheckbox, TypeComment,
+			TypeImage, TypeAttachment, TypeDivider, TypeCode, TypeTable, TypeCallout} {
+			parsed, err := BlockTypeFromString(string(blockType))
+			require.NoError(t, err)
+			require.Equal(t, blockType, parsed)
+			require.Contains(t, RegisteredBlockTypes(), blockType)
+		}
+	})
+
+	t.Run("types are case insensitive", func(t *testing.T) {
+		parsed, err := BlockTypeFromString("CallOut")
+		require.NoError(t, err)
+		require.Equal(t, BlockType(TypeCallout), parsed)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		parsed, err := BlockTypeFromString("not-a-type")
+		require.Error(t, err)
+		require.Equal(t, BlockType(TypeUnknown), parsed)
+		require.Equal(t, utils.IDTypeNone, BlockType2IDType("not-a-type"))
+	})
+
+	t.Run("id types", func(t *testing.T) {
+		require.Equal(t, utils.IDTypeBoard, BlockType2IDType(TypeBoard))
+		require.Equal(t, utils.IDTypeCard, BlockType2IDType(TypeCard))
+		require.Equal(t, utils.IDTypeAttachment, BlockType2IDType(TypeImage))
+		require.Equal(t, utils.IDTypeBlock, BlockType2IDType(TypeCode))
+		require.Equal(t, utils.IDTypeBlock, BlockType2IDType(TypeTable))
+		require.Equal(t, utils.IDTypeBlock, BlockType2IDType(TypeCallout))
+	})
+
+	t.Run("file fields", func(t *testing.T) {
+		require.True(t, BlockType(TypeImage).HasFile())
+		require.True(t, BlockType(TypeAttachment).HasFile())
+		require.False(t, BlockType(TypeCode).HasFile())
+		require.False(t, BlockType(TypeCard).HasFile())
+	})
+
+	t.Run("register a type", func(t *testing.T) {
+		err := RegisterBlockType(BlockTypeDefinition{Type: "test-registry-type", Content: true})
+		require.NoError(t, err)
+
+		def, ok := GetBlockTypeDefinition("test-registry-type")
+		require.True(t, ok)
+		require.Equal(t, utils.IDTypeBlock, def.IDType)
+		require.True(t, IsContentBlockType("test-registry-type"))
+
+		err = RegisterBlockType(BlockTypeDefinition{Type: "Test-Registry-Type"})
+		require.ErrorIs(t, err, ErrBlockTypeAlreadyRegistered)
+
+		err = RegisterBlockType(BlockTypeDefinition{Type: TypeUnknown})
+		require.ErrorAs(t, err, &ErrInvalidBlockType{})
+	})
+}
+
+func TestContentBlockTypes(t *testing.T) {
+	newBlock := func(blockType BlockType, fields map[string]interface{}) *Block {
+		return &Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  utils.NewID(utils.IDTypeBoard),
+			ParentID: utils.NewID(utils.IDTypeCard),
+			Type:     blockType,
+			Title:    "title",
+			Fields:   fields,
+			CreateAt: 1,
+			UpdateAt: 1,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		block  *Block
+		errMsg string
+	}{
+		{"code without language", newBlock(TypeCode, nil), ""},
+		{"code with language", newBlock(TypeCode, map[string]interface{}{"language": "go"}), ""},
+		{"code with invalid language", newBlock(TypeCode, map[string]interface{}{"language": 1}), "must be a string"},
+		{"empty table", newBlock(TypeTable, nil), ""},
+		{"table", newBlock(TypeTable, map[string]interface{}{
+			"header": true,
+			"rows":   []interface{}{[]interface{}{"a", "b"}, []interface{}{"c", "d"}},
+		}), ""},
+		{"table with invalid header", newBlock(TypeTable, map[string]interface{}{"header": "yes"}), "must be a boolean"},
+		{"table with uneven rows", newBlock(TypeTable, map[string]interface{}{
+			"rows": []interface{}{[]interface{}{"a", "b"}, []interface{}{"c"}},
+		}), "same number of cells"},
+		{"table with invalid cell", newBlock(TypeTable, map[string]interface{}{
+			"rows": []interface{}{[]interface{}{"a", 1}},
+		}), "must be strings"},
+		{"callout", newBlock(TypeCallout, map[string]interface{}{"icon": "💡", "variant": "warning"}), ""},
+		{"callout with long icon", newBlock(TypeCallout, map[string]interface{}{"icon": "ab"}), "one grapheme"},
+		{"callout with invalid variant", newBlock(TypeCallout, map[string]interface{}{"variant": "loud"}), "invalid callout block variant"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.block.IsValid()
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.errMsg)
+			require.True(t, IsErrBadRequest(err))
+		})
+	}
+
+	t.Run("content types", func(t *testing.T) {
+		require.True(t, IsContentBlockType(TypeCode))
+		require.True(t, IsContentBlockType(TypeTable))
+		require.True(t, IsContentBlockType(TypeCallout))
+		require.False(t, IsContentBlockType(TypeComment))
+	})
+
+	t.Run("table rows", func(t *testing.T) {
+		block := newBlock(TypeTable, map[string]interface{}{
+			"rows": []interface{}{[]interface{}{"a", "b"}, []interface{}{"c", "d"}},
+		})
+		rows, err := GetTableRows(block)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"a", "b"}, {"c", "d"}}, rows)
+
+		def, _ := GetBlockTypeDefinition(TypeTable)
+		require.Equal(t, "a | b\nc | d", def.NotifyText(block))
+	})
+}
@@ -2,8 +2,11 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 type BoardType string
@@ -103,6 +106,27 @@ type Board struct {
 	// The deleted time in miliseconds since the current epoch. Set to indicate this block is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// Marks the board as temporarily frozen. Only board admins can modify a frozen board
+	// required: false
+	Frozen bool `json:"frozen"`
+
+	// The reason shown to users while the board is frozen
+	// required: false
+	FrozenReason string `json:"frozenReason"`
+
+	// The time in miliseconds since the current epoch when the board is automatically unfrozen. Zero means no automatic unfreeze
+	// required: false
+	FrozenUntil int64 `json:"frozenUntil"`
+}
+
+// IsFrozen returns true if the board is frozen and its freeze has not
+// expired yet.
+func (b *Board) IsFrozen() bool {
+	if !b.Frozen {
+		return false
+	}
+	return b.FrozenUntil == 0 || b.FrozenUntil > utils.GetMillis()
 }
 
 // GetPropertyString returns the value of the specified property as a string,
@@ -166,6 +190,30 @@ type BoardPatch struct {
 	// The board removed card properties
 	// required: false
 	DeletedCardProperties []string `json:"deletedCardProperties"`
+
+	// Freezes or unfreezes the board
+	// required: false
+	Frozen *bool `json:"frozen"`
+
+	// The reason shown to users while the board is frozen
+	// required: false
+	FrozenReason *string `json:"frozenReason"`
+
+	// The time in miliseconds since the current epoch when the board is automatically unfrozen
+	// required: false
+	FrozenUntil *int64 `json:"frozenUntil"`
+}
+
+// BoardFreeze contains the parameters used to freeze a board
+// swagger:model
+type BoardFreeze struct {
+	// The reason shown to users while the board is frozen
+	// required: false
+	Reason string `json:"reason"`
+
+	// The time in miliseconds since the current epoch when the board is automatically unfrozen. Zero means no automatic unfreeze
+	// required: false
+	Until int64 `json:"until"`
 }
 
 // BoardMember stores the information of the membership of a user on a board
@@ -230,6 +278,14 @@ type BoardMetadata struct {
 	// The ID of the user that last modified the most recently modified descendant
 	// required: true
 	LastModifiedBy string `json:"lastModifiedBy"`
+
+	// The number of changes by other users since the user last viewed the board
+	// required: true
+	UnreadCount int `json:"unreadCount"`
+
+	// The number of changes by other users since the user last viewed each card, by card ID
+	// required: false
+	CardUnreadCounts map[string]int `json:"cardUnreadCounts,omitempty"`
 }
 
 func BoardFromJSON(data io.Reader) *Board {
@@ -256,6 +312,37 @@ func BoardMembersFromJSON(data io.Reader) []*BoardMember {
 	return boardMembers
 }
 
+// Role returns the highest role of the board member.
+func (m *BoardMember) Role() BoardRole {
+	switch {
+	case m.SchemeAdmin:
+		return BoardRoleAdmin
+	case m.SchemeEditor:
+		return BoardRoleEditor
+	case m.SchemeCommenter:
+		return BoardRoleCommenter
+	case m.SchemeViewer:
+		return BoardRoleViewer
+	}
+	return BoardRoleNone
+}
+
+// SetRole sets the scheme roles of the board member to the ones of a
+// role, which includes the roles below it.
+func (m *BoardMember) SetRole(role BoardRole) error {
+	switch role {
+	case BoardRoleViewer, BoardRoleCommenter, BoardRoleEditor, BoardRoleAdmin:
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid board role %q", role))
+	}
+
+	m.SchemeAdmin = role == BoardRoleAdmin
+	m.SchemeEditor = m.SchemeAdmin || role == BoardRoleEditor
+	m.SchemeCommenter = m.SchemeEditor || role == BoardRoleCommenter
+	m.SchemeViewer = true
+	return nil
+}
+
 func BoardMetadataFromJSON(data io.Reader) *BoardMetadata {
 	var boardMetadata *BoardMetadata
 	_ = json.NewDecoder(data).Decode(&boardMetadata)
@@ -292,6 +379,22 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 		board.ChannelID = *p.ChannelID
 	}
 
+	if p.Frozen != nil {
+		board.Frozen = *p.Frozen
+	}
+
+	if p.FrozenReason != nil {
+		board.FrozenReason = *p.FrozenReason
+	}
+
+	if p.FrozenUntil != nil {
+		board.FrozenUntil = *p.FrozenUntil
+	}
+
+	if len(p.UpdatedProperties) != 0 && board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+
 	for key, property := range p.UpdatedProperties {
 		board.Properties[key] = property
 	}
@@ -368,9 +471,19 @@ func (p *BoardPatch) IsValid() error {
 		return InvalidBoardErr{"invalid-board-minimum-role"}
 	}
 
+	if p.FrozenUntil != nil && *p.FrozenUntil < 0 {
+		return InvalidBoardErr{"invalid-board-frozen-until"}
+	}
+
 	return nil
 }
 
+// IsFreezeChange returns true if the patch modifies any of the board
+// freeze fields.
+func (p *BoardPatch) IsFreezeChange() bool {
+	return p.Frozen != nil || p.FrozenReason != nil || p.FrozenUntil != nil
+}
+
 type InvalidBoardErr struct {
 	msg string
 }
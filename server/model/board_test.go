@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardMemberRoles(t *testing.T) {
+	roles := []BoardRole{BoardRoleViewer, BoardRoleCommenter, BoardRoleEditor, BoardRoleAdmin}
+	for _, role := range roles {
+		t.Run(string(role), func(t *testing.T) {
+			member := &BoardMember{SchemeAdmin: true, SchemeEditor: true}
+			require.NoError(t, member.SetRole(role))
+			require.Equal(t, role, member.Role())
+			require.True(t, member.SchemeViewer)
+		})
+	}
+
+	t.Run("roles include the lower ones", func(t *testing.T) {
+		member := &BoardMember{}
+		require.NoError(t, member.SetRole(BoardRoleEditor))
+		require.True(t, member.SchemeCommenter)
+		require.False(t, member.SchemeAdmin)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		member := &BoardMember{}
+		require.Error(t, member.SetRole("owner"))
+		require.Equal(t, BoardRoleNone, member.Role())
+	})
+}
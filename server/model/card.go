@@ -84,6 +84,18 @@ type Card struct {
 	// The deleted time in milliseconds since the current epoch. Set to indicate this card is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// The archived time in milliseconds since the current epoch. Set to indicate this card is archived
+	// required: false
+	ArchivedAt int64 `json:"archivedAt,omitempty"`
+
+	// The id for user who archived this card
+	// required: false
+	ArchivedBy string `json:"archivedBy,omitempty"`
+
+	// The votes and reactions of the card, as seen by the current user
+	// required: false
+	Votes *CardVotes `json:"votes,omitempty"`
 }
 
 // Populate populates a Card with default values.
@@ -201,6 +213,11 @@ func Card2Block(card *Card) *Block {
 	fields["isTemplate"] = card.IsTemplate
 	fields["properties"] = card.Properties
 
+	if card.ArchivedAt != 0 {
+		fields[CardFieldArchivedAt] = card.ArchivedAt
+		fields[CardFieldArchivedBy] = card.ArchivedBy
+	}
+
 	return &Block{
 		ID:         card.ID,
 		ParentID:   card.BoardID,
@@ -261,6 +278,15 @@ func Block2Card(block *Block) (*Card, error) {
 		}
 	}
 
+	archivedBy := ""
+	if archivedByAny, ok := block.Fields[CardFieldArchivedBy]; ok {
+		if id, ok := archivedByAny.(string); ok {
+			archivedBy = id
+		} else {
+			return nil, ErrInvalidFieldType{CardFieldArchivedBy}
+		}
+	}
+
 	if props, ok := block.Fields["properties"]; ok {
 		if propMap, ok := props.(map[string]any); ok {
 			for k, v := range propMap {
@@ -284,6 +310,8 @@ func Block2Card(block *Block) (*Card, error) {
 		CreateAt:     block.CreateAt,
 		UpdateAt:     block.UpdateAt,
 		DeleteAt:     block.DeleteAt,
+		ArchivedAt:   getInt64Field(block.Fields, CardFieldArchivedAt),
+		ArchivedBy:   archivedBy,
 	}
 	card.Populate()
 	return card, nil
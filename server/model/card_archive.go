@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+)
+
+const (
+	// CardFieldArchivedAt is the card field that holds the time in
+	// milliseconds since the current epoch the card was archived at.
+	// It is only present on archived cards.
+	CardFieldArchivedAt = "archivedAt"
+
+	// CardFieldArchivedBy is the card field that holds the ID of the
+	// user that archived the card.
+	CardFieldArchivedBy = "archivedBy"
+
+	// CardArchiveMaxBulk is the maximum number of cards that can be
+	// archived or unarchived at once.
+	CardArchiveMaxBulk = 500
+)
+
+// ErrArchiveFieldsPatch is returned when a generic block patch tries
+// to change the archive state of a card.
+var ErrArchiveFieldsPatch = NewErrBadRequest("cards can only be archived or unarchived through the card archive API")
+
+// IsArchivedCard returns true if the block is a card that has been
+// archived.
+func IsArchivedCard(block *Block) bool {
+	return block != nil && block.Type == TypeCard && getInt64Field(block.Fields, CardFieldArchivedAt) != 0
+}
+
+// ExcludeArchivedCards returns the blocks without the archived cards
+// and the blocks that are direct children of them.
+func ExcludeArchivedCards(blocks []*Block) []*Block {
+	archivedCardIDs := map[string]bool{}
+	for _, block := range blocks {
+		if IsArchivedCard(block) {
+			archivedCardIDs[block.ID] = true
+		}
+	}
+
+	if len(archivedCardIDs) == 0 {
+		return blocks
+	}
+
+	result := make([]*Block, 0, len(blocks)-len(archivedCardIDs))
+	for _, block := range blocks {
+		if archivedCardIDs[block.ID] || archivedCardIDs[block.ParentID] {
+			continue
+		}
+		result = append(result, block)
+	}
+	return result
+}
+
+// ChangesArchiveState returns true if the patch updates or removes the
+// fields that hold the archive state of a card.
+func (p *BlockPatch) ChangesArchiveState() bool {
+	return p.changesFields(CardFieldArchivedAt, CardFieldArchivedBy)
+}
+
+// NewArchiveCardPatch returns the patch that archives a card.
+func NewArchiveCardPatch(userID string, archivedAt int64) BlockPatch {
+	return BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			CardFieldArchivedAt: archivedAt,
+			CardFieldArchivedBy: userID,
+		},
+	}
+}
+
+// NewUnarchiveCardPatch returns the patch that unarchives a card.
+func NewUnarchiveCardPatch() BlockPatch {
+	return BlockPatch{
+		DeletedFields: []string{CardFieldArchivedAt, CardFieldArchivedBy},
+	}
+}
+
+// ValidateCardArchiveIDs checks the card IDs of a bulk archive or
+// unarchive request.
+func ValidateCardArchiveIDs(cardIDs []string) error {
+	if len(cardIDs) == 0 {
+		return NewErrBadRequest("missing card IDs")
+	}
+
+	if len(cardIDs) > CardArchiveMaxBulk {
+		return NewErrBadRequest(fmt.Sprintf("can't archive or unarchive more than %d cards at once", CardArchiveMaxBulk))
+	}
+
+	for _, cardID := range cardIDs {
+		if cardID == "" {
+			return NewErrBadRequest("empty card ID")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsArchivedCard(t *testing.T) {
+	require.False(t, IsArchivedCard(nil))
+	require.False(t, IsArchivedCard(&Block{Type: TypeCard, Fields: map[string]interface{}{}}))
+	require.False(t, IsArchivedCard(&Block{Type: TypeText, Fields: map[string]interface{}{CardFieldArchivedAt: float64(1)}}))
+
+	// fields read from the database hold numbers as float64
+	require.True(t, IsArchivedCard(&Block{Type: TypeCard, Fields: map[string]interface{}{CardFieldArchivedAt: float64(1)}}))
+	require.True(t, IsArchivedCard(&Block{Type: TypeCard, Fields: map[string]interface{}{CardFieldArchivedAt: int64(1)}}))
+}
+
+func TestExcludeArchivedCards(t *testing.T) {
+	blocks := []*Block{
+		{ID: "board-1", Type: TypeBoard},
+		{ID: "card-1", ParentID: "board-1", Type: TypeCard, Fields: map[string]interface{}{CardFieldArchivedAt: float64(1)}},
+		{ID: "text-1", ParentID: "card-1", Type: TypeText},
+		{ID: "card-2", ParentID: "board-1", Type: TypeCard},
+		{ID: "text-2", ParentID: "card-2", Type: TypeText},
+	}
+
+	result := ExcludeArchivedCards(blocks)
+	ids := make([]string, 0, len(result))
+	for _, block := range result {
+		ids = append(ids, block.ID)
+	}
+	require.Equal(t, []string{"board-1", "card-2", "text-2"}, ids)
+}
+
+func TestChangesArchiveState(t *testing.T) {
+	title := "title"
+	require.False(t, (*BlockPatch)(nil).ChangesArchiveState())
+	require.False(t, (&BlockPatch{Title: &title, UpdatedFields: map[string]interface{}{"icon": "x"}}).ChangesArchiveState())
+
+	archivePatch := NewArchiveCardPatch("user-id", 1)
+	require.True(t, archivePatch.ChangesArchiveState())
+
+	unarchivePatch := NewUnarchiveCardPatch()
+	require.True(t, unarchivePatch.ChangesArchiveState())
+}
+
+func TestArchivedCardConversion(t *testing.T) {
+	block := &Block{
+		ID:   "card-1",
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			CardFieldArchivedAt: float64(1234),
+			CardFieldArchivedBy: "user-id",
+		},
+	}
+
+	card, err := Block2Card(block)
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), card.ArchivedAt)
+	require.Equal(t, "user-id", card.ArchivedBy)
+
+	block = Card2Block(card)
+	require.True(t, IsArchivedCard(block))
+	require.Equal(t, "user-id", block.Fields[CardFieldArchivedBy])
+
+	card.ArchivedAt = 0
+	block = Card2Block(card)
+	require.False(t, IsArchivedCard(block))
+	require.NotContains(t, block.Fields, CardFieldArchivedBy)
+}
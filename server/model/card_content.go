@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"sort"
+)
+
+// CardFieldContentOrder is the card field that holds the order of the
+// content blocks of the card.
+const CardFieldContentOrder = "contentOrder"
+
+// IsContentBlockType returns true if blocks of the type are shown as
+// the content of a card, and so are listed in its content order.
+func IsContentBlockType(blockType BlockType) bool {
+	def, ok := GetBlockTypeDefinition(blockType)
+	return ok && def.Content
+}
+
+// GetContentOrder returns the content order of a card block. Entries
+// are usually block IDs, and other entries are kept as they are.
+func GetContentOrder(card *Block) []interface{} {
+	switch order := card.Fields[CardFieldContentOrder].(type) {
+	case []interface{}:
+		return append([]interface{}{}, order...)
+	case []string:
+		result := make([]interface{}, 0, len(order))
+		for _, id := range order {
+			result = append(result, id)
+		}
+		return result
+	default:
+		return []interface{}{}
+	}
+}
+
+// InsertContentOrderID returns the content order with the block ID
+// inserted at the position. Negative or out of range positions append
+// the ID.
+func InsertContentOrderID(order []interface{}, blockID string, position int) []interface{} {
+	if position < 0 || position >= len(order) {
+		return append(order, blockID)
+	}
+
+	result := make([]interface{}, 0, len(order)+1)
+	result = append(result, order[:position]...)
+	result = append(result, blockID)
+	return append(result, order[position:]...)
+}
+
+// RemoveContentOrderID returns the content order without the block ID,
+// and whether the ID was found. IDs nested in lists of IDs are removed
+// too, and lists left empty are dropped.
+func RemoveContentOrderID(order []interface{}, blockID string) ([]interface{}, bool) {
+	found := false
+	result := make([]interface{}, 0, len(order))
+	for _, entry := range order {
+		switch v := entry.(type) {
+		case string:
+			if v == blockID {
+				found = true
+				continue
+			}
+		case []interface{}:
+			nested, nestedFound := RemoveContentOrderID(v, blockID)
+			if nestedFound {
+				found = true
+				if len(nested) == 0 {
+					continue
+				}
+				entry = nested
+			}
+		}
+		result = append(result, entry)
+	}
+	return result, found
+}
+
+// NewContentOrderPatch returns the patch that sets the content order
+// of a card.
+func NewContentOrderPatch(order []interface{}) *BlockPatch {
+	return &BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			CardFieldContentOrder: order,
+		},
+	}
+}
+
+// SortCardContent sorts content blocks in the content order of their
+// card. Blocks missing from the content order go last, oldest first.
+func SortCardContent(card *Block, blocks []*Block) []*Block {
+	positions := map[string]int{}
+	var addPositions func(order []interface{})
+	addPositions = func(order []interface{}) {
+		for _, entry := range order {
+			switch v := entry.(type) {
+			case string:
+				if _, ok := positions[v]; !ok {
+					positions[v] = len(positions)
+				}
+			case []interface{}:
+				addPositions(v)
+			}
+		}
+	}
+	addPositions(GetContentOrder(card))
+
+	sorted := append([]*Block{}, blocks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, iOk := positions[sorted[i].ID]
+		pj, jOk := positions[sorted[j].ID]
+		switch {
+		case iOk && jOk:
+			return pi < pj
+		case iOk != jOk:
+			return iOk
+		default:
+			return sorted[i].CreateAt < sorted[j].CreateAt
+		}
+	})
+	return sorted
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentOrder(t *testing.T) {
+	t.Run("get content order", func(t *testing.T) {
+		require.Equal(t, []interface{}{}, GetContentOrder(&Block{}))
+		require.Equal(t, []interface{}{"a", "b"}, GetContentOrder(&Block{Fields: map[string]interface{}{CardFieldContentOrder: []string{"a", "b"}}}))
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		order := []interface{}{"a", "b"}
+		require.Equal(t, []interface{}{"c", "a", "b"}, InsertContentOrderID(order, "c", 0))
+		require.Equal(t, []interface{}{"a", "c", "b"}, InsertContentOrderID(order, "c", 1))
+		require.Equal(t, []interface{}{"a", "b", "c"}, InsertContentOrderID(order, "c", 2))
+		require.Equal(t, []interface{}{"a", "b", "c"}, InsertContentOrderID([]interface{}{"a", "b"}, "c", -1))
+		require.Equal(t, []interface{}{"a", "b"}, order)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		order := []interface{}{"a", []interface{}{"b", "c"}, []interface{}{"d"}}
+
+		result, found := RemoveContentOrderID(order, "c")
+		require.True(t, found)
+		require.Equal(t, []interface{}{"a", []interface{}{"b"}, []interface{}{"d"}}, result)
+
+		result, found = RemoveContentOrderID(order, "d")
+		require.True(t, found)
+		require.Equal(t, []interface{}{"a", []interface{}{"b", "c"}}, result)
+
+		result, found = RemoveContentOrderID(order, "e")
+		require.False(t, found)
+		require.Equal(t, order, result)
+	})
+}
+
+func TestSortCardContent(t *testing.T) {
+	card := &Block{Fields: map[string]interface{}{
+		CardFieldContentOrder: []interface{}{"c", []interface{}{"a", "missing"}},
+	}}
+	blocks := []*Block{
+		{ID: "a", CreateAt: 1},
+		{ID: "d", CreateAt: 4},
+		{ID: "b", CreateAt: 2},
+		{ID: "c", CreateAt: 3},
+	}
+
+	sorted := SortCardContent(card, blocks)
+	ids := []string{}
+	for _, block := range sorted {
+		ids = append(ids, block.ID)
+	}
+	require.Equal(t, []string{"c", "a", "b", "d"}, ids)
+	require.Equal(t, "a", blocks[0].ID)
+}
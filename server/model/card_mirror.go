@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CardMirror is a reference that makes a card appear on a board other
+// than its primary board. The primary board owns the card and its
+// content, and the mirror holds the property values local to the
+// mirroring board
+// swagger:model
+type CardMirror struct {
+	// The ID of the board that mirrors the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the mirrored card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The property values of the card on the mirroring board, keyed
+	// by the mirroring board's property IDs
+	// required: true
+	Properties map[string]interface{} `json:"properties"`
+
+	// The ID of the user that created the mirror
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The ID of the last user that updated the mirror
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The deleted time in milliseconds since the current epoch. Set to indicate this mirror is deleted
+	// required: false
+	DeleteAt int64 `json:"deleteAt"`
+
+	// The mirrored card, as seen from the mirroring board
+	// required: false
+	Card *Card `json:"card,omitempty"`
+}
+
+// CardMirrorPatch is a patch for modifying the board local property
+// values of a card mirror
+// swagger:model
+type CardMirrorPatch struct {
+	// The board local property values to update
+	// required: false
+	UpdatedProperties map[string]interface{} `json:"updatedProperties"`
+
+	// The board local property values to remove
+	// required: false
+	DeletedProperties []string `json:"deletedProperties"`
+}
+
+func (m *CardMirror) IsValid() error {
+	if m == nil {
+		return NewErrBadRequest("missing card mirror")
+	}
+
+	if m.BoardID == "" {
+		return NewErrBadRequest("missing card mirror board ID")
+	}
+
+	if m.CardID == "" {
+		return NewErrBadRequest("missing card mirror card ID")
+	}
+
+	return nil
+}
+
+// Patch returns an updated version of the mirror.
+func (p *CardMirrorPatch) Patch(mirror *CardMirror) *CardMirror {
+	if mirror.Properties == nil {
+		mirror.Properties = map[string]interface{}{}
+	}
+
+	for key, value := range p.UpdatedProperties {
+		mirror.Properties[key] = value
+	}
+
+	for _, key := range p.DeletedProperties {
+		delete(mirror.Properties, key)
+	}
+
+	return mirror
+}
+
+// MirroredCard returns the card as seen from the mirroring board,
+// with the board local property values of the mirror.
+func (m *CardMirror) MirroredCard(card *Card) *Card {
+	mirrored := *card
+	mirrored.Properties = map[string]interface{}{}
+	for key, value := range m.Properties {
+		mirrored.Properties[key] = value
+	}
+	return &mirrored
+}
+
+func CardMirrorFromJSON(data io.Reader) *CardMirror {
+	var mirror *CardMirror
+	_ = json.NewDecoder(data).Decode(&mirror)
+	return mirror
+}
+
+func CardMirrorsFromJSON(data io.Reader) []*CardMirror {
+	var mirrors []*CardMirror
+	_ = json.NewDecoder(data).Decode(&mirrors)
+	return mirrors
+}
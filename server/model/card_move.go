@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+)
+
+const (
+	propTypeSelect      = "select"
+	propTypeMultiSelect = "multiSelect"
+)
+
+// CardMoveOptions contains the destination and property mapping
+// used to move or copy a card to another board
+// swagger:model
+type CardMoveOptions struct {
+	// The ID of the destination board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Maps source board property IDs to destination board property IDs.
+	// Properties not present in the map are matched by name and type,
+	// and an empty destination ID drops the property
+	// required: false
+	PropertyMapping map[string]string `json:"propertyMapping"`
+}
+
+func (o *CardMoveOptions) IsValid() error {
+	if o == nil {
+		return NewErrBadRequest("missing card move options")
+	}
+
+	if o.BoardID == "" {
+		return NewErrBadRequest("missing destination board ID")
+	}
+
+	return nil
+}
+
+// CardRedirect points from a card's previous board to the board the
+// card was moved to, so that old card links can still be resolved
+// swagger:model
+type CardRedirect struct {
+	// The ID of the moved card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the board the card was moved from
+	// required: true
+	SourceBoardID string `json:"sourceBoardId"`
+
+	// The ID of the board the card was moved to
+	// required: true
+	TargetBoardID string `json:"targetBoardId"`
+
+	// The ID of the user that moved the card
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// MapCardProperties translates the property values of a card from the
+// schema of the source board to the schema of the destination board.
+// Properties are matched through the explicit mapping first, then by
+// name and type. Select and multi select values are matched by option
+// label. Values that can't be mapped are dropped.
+func MapCardProperties(properties map[string]interface{}, from, to *Board, mapping map[string]string) (map[string]interface{}, error) {
+	fromSchema, err := ParsePropertySchema(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toSchema, err := ParsePropertySchema(to)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := map[string]interface{}{}
+	for fromID, value := range properties {
+		fromDef, ok := fromSchema[fromID]
+		if !ok {
+			continue
+		}
+
+		toID, explicit := mapping[fromID]
+		if !explicit {
+			toID = findMatchingProperty(fromDef, toSchema)
+		}
+		if toID == "" {
+			continue
+		}
+
+		toDef, ok := toSchema[toID]
+		if !ok {
+			continue
+		}
+
+		if mappedValue, ok := mapPropertyValue(value, fromDef, toDef); ok {
+			mapped[toID] = mappedValue
+		}
+	}
+
+	return mapped, nil
+}
+
+// findMatchingProperty returns the ID of the property of the schema with
+// the name and type of def. When several properties match, the first one
+// in the board's order is returned.
+func findMatchingProperty(def PropDef, schema PropSchema) string {
+	matchID := ""
+	matchIndex := 0
+	for id, candidate := range schema {
+		if candidate.Type != def.Type || !strings.EqualFold(candidate.Name, def.Name) {
+			continue
+		}
+		if matchID == "" || candidate.Index < matchIndex {
+			matchID = id
+			matchIndex = candidate.Index
+		}
+	}
+	return matchID
+}
+
+func isOptionProperty(def PropDef) bool {
+	return def.Type == propTypeSelect || def.Type == propTypeMultiSelect
+}
+
+func mapPropertyValue(value interface{}, from, to PropDef) (interface{}, bool) {
+	if !isOptionProperty(from) && !isOptionProperty(to) {
+		if from.Type != to.Type {
+			return nil, false
+		}
+		return value, true
+	}
+
+	if !isOptionProperty(from) || !isOptionProperty(to) {
+		return nil, false
+	}
+
+	var optionIDs []string
+	switch v := value.(type) {
+	case string:
+		optionIDs = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if id, ok := item.(string); ok {
+				optionIDs = append(optionIDs, id)
+			}
+		}
+	}
+
+	mappedIDs := []interface{}{}
+	for _, optionID := range optionIDs {
+		option, ok := from.Options[optionID]
+		if !ok {
+			continue
+		}
+		for _, candidate := range to.Options {
+			if strings.EqualFold(candidate.Value, option.Value) {
+				mappedIDs = append(mappedIDs, candidate.ID)
+				break
+			}
+		}
+	}
+
+	if len(mappedIDs) == 0 {
+		return nil, false
+	}
+
+	if to.Type == propTypeSelect {
+		return mappedIDs[0], true
+	}
+	return mappedIDs, true
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapCardProperties(t *testing.T) {
+	from := &Board{
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "from-status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "from-done", "value": "Done"},
+					map[string]interface{}{"id": "from-todo", "value": "To Do"},
+				},
+			},
+			{"id": "from-estimate", "name": "Estimate", "type": "number"},
+			{"id": "from-notes", "name": "Notes", "type": "text"},
+			{"id": "from-owner", "name": "Owner", "type": "person"},
+		},
+	}
+
+	to := &Board{
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "to-status",
+				"name": "status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "to-todo", "value": "to do"},
+				},
+			},
+			{"id": "to-estimate", "name": "Estimate", "type": "text"},
+			{"id": "to-description", "name": "Description", "type": "text"},
+			{"id": "to-owner", "name": "Owner", "type": "person"},
+		},
+	}
+
+	t.Run("map by name and type", func(t *testing.T) {
+		properties := map[string]interface{}{
+			"from-status":   "from-todo",
+			"from-estimate": "3",
+			"from-owner":    "user-id",
+		}
+
+		mapped, err := MapCardProperties(properties, from, to, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"to-status": "to-todo",
+			"to-owner":  "user-id",
+		}, mapped)
+	})
+
+	t.Run("explicit mapping", func(t *testing.T) {
+		properties := map[string]interface{}{
+			"from-notes": "some notes",
+			"from-owner": "user-id",
+		}
+		mapping := map[string]string{
+			"from-notes": "to-description",
+			"from-owner": "",
+		}
+
+		mapped, err := MapCardProperties(properties, from, to, mapping)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"to-description": "some notes",
+		}, mapped)
+	})
+
+	t.Run("option without matching label is dropped", func(t *testing.T) {
+		properties := map[string]interface{}{
+			"from-status": "from-done",
+		}
+
+		mapped, err := MapCardProperties(properties, from, to, nil)
+		require.NoError(t, err)
+		require.Empty(t, mapped)
+	})
+
+	t.Run("duplicate names map to the first property", func(t *testing.T) {
+		to := &Board{
+			CardProperties: []map[string]interface{}{
+				{"id": "to-notes-1", "name": "Notes", "type": "text"},
+				{"id": "to-notes-2", "name": "notes", "type": "text"},
+				{"id": "to-notes-3", "name": "Notes", "type": "text"},
+			},
+		}
+		properties := map[string]interface{}{
+			"from-notes": "some notes",
+		}
+
+		// the schema is a map, so the match is checked several times
+		for i := 0; i < 20; i++ {
+			mapped, err := MapCardProperties(properties, from, to, nil)
+			require.NoError(t, err)
+			require.Equal(t, map[string]interface{}{
+				"to-notes-1": "some notes",
+			}, mapped)
+		}
+	})
+}
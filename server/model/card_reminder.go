@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// CardReminderLocalTimeLayout is the layout of reminder times given in
+// the timezone of the user.
+const CardReminderLocalTimeLayout = "2006-01-02T15:04"
+
+// CardReminder is a private reminder that a user sets on a card.
+// swagger:model
+type CardReminder struct {
+	// The ID of the reminder
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the user that is reminded
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// An optional note delivered with the reminder
+	// required: false
+	Note string `json:"note,omitempty"`
+
+	// The time to deliver the reminder in milliseconds since the current epoch
+	// required: true
+	RemindAt int64 `json:"remindAt"`
+
+	// The time the reminder was delivered in milliseconds since the current epoch, zero if pending
+	// required: false
+	DeliveredAt int64 `json:"deliveredAt"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// CardReminderPatch is a patch for creating or editing a reminder.
+// The time can be given either in milliseconds since the current epoch
+// or as a local time in the timezone of the user
+// swagger:model
+type CardReminderPatch struct {
+	// The time to deliver the reminder in milliseconds since the current epoch
+	// required: false
+	RemindAt *int64 `json:"remindAt"`
+
+	// The time to deliver the reminder in the timezone of the user, as
+	// YYYY-MM-DDThh:mm
+	// required: false
+	LocalTime *string `json:"localTime"`
+
+	// The note delivered with the reminder
+	// required: false
+	Note *string `json:"note"`
+}
+
+// CardReminderSnooze postpones a reminder.
+// swagger:model
+type CardReminderSnooze struct {
+	// The number of minutes to postpone the reminder from now
+	// required: true
+	Minutes int `json:"minutes"`
+}
+
+func (r *CardReminder) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("missing card reminder")
+	}
+
+	if r.ID == "" {
+		return NewErrBadRequest("missing card reminder ID")
+	}
+
+	if r.UserID == "" {
+		return NewErrBadRequest("missing card reminder user ID")
+	}
+
+	if r.BoardID == "" {
+		return NewErrBadRequest("missing card reminder board ID")
+	}
+
+	if r.CardID == "" {
+		return NewErrBadRequest("missing card reminder card ID")
+	}
+
+	if r.RemindAt <= 0 {
+		return NewErrBadRequest("missing card reminder time")
+	}
+
+	return nil
+}
+
+// ChangesTime returns true if the patch sets the reminder time.
+func (p *CardReminderPatch) ChangesTime() bool {
+	return p.RemindAt != nil || p.LocalTime != nil
+}
+
+// Patch returns an updated version of the reminder. Local times are
+// resolved in the given location. Changing the time of a delivered
+// reminder schedules it again.
+func (p *CardReminderPatch) Patch(reminder *CardReminder, location *time.Location) (*CardReminder, error) {
+	if p.RemindAt != nil && p.LocalTime != nil {
+		return nil, NewErrBadRequest("reminder time and local time are mutually exclusive")
+	}
+
+	if p.RemindAt != nil {
+		reminder.RemindAt = *p.RemindAt
+	}
+
+	if p.LocalTime != nil {
+		localTime, err := time.ParseInLocation(CardReminderLocalTimeLayout, *p.LocalTime, location)
+		if err != nil {
+			return nil, NewErrBadRequest(fmt.Sprintf("invalid reminder local time %s", *p.LocalTime))
+		}
+		reminder.RemindAt = localTime.UnixMilli()
+	}
+
+	if p.ChangesTime() {
+		reminder.DeliveredAt = 0
+	}
+
+	if p.Note != nil {
+		reminder.Note = *p.Note
+	}
+
+	return reminder, nil
+}
+
+func CardReminderFromJSON(data io.Reader) *CardReminder {
+	var reminder *CardReminder
+	_ = json.NewDecoder(data).Decode(&reminder)
+	return reminder
+}
+
+func CardRemindersFromJSON(data io.Reader) []*CardReminder {
+	var reminders []*CardReminder
+	_ = json.NewDecoder(data).Decode(&reminders)
+	return reminders
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardReminderPatch(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("local time in the user timezone", func(t *testing.T) {
+		localTime := "2022-07-01T09:30"
+		reminder := &CardReminder{ID: "reminder-1", RemindAt: 100, DeliveredAt: 150}
+
+		reminder, err := (&CardReminderPatch{LocalTime: &localTime}).Patch(reminder, location)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2022, 7, 1, 13, 30, 0, 0, time.UTC).UnixMilli(), reminder.RemindAt)
+		require.Zero(t, reminder.DeliveredAt)
+	})
+
+	t.Run("time and local time are mutually exclusive", func(t *testing.T) {
+		localTime := "2022-07-01T09:30"
+		remindAt := int64(200)
+
+		_, err := (&CardReminderPatch{RemindAt: &remindAt, LocalTime: &localTime}).Patch(&CardReminder{}, location)
+		require.True(t, IsErrBadRequest(err))
+	})
+
+	t.Run("invalid local time", func(t *testing.T) {
+		localTime := "tomorrow"
+
+		_, err := (&CardReminderPatch{LocalTime: &localTime}).Patch(&CardReminder{}, location)
+		require.True(t, IsErrBadRequest(err))
+	})
+
+	t.Run("changing the note keeps the delivery", func(t *testing.T) {
+		note := "call back"
+		reminder := &CardReminder{ID: "reminder-1", RemindAt: 100, DeliveredAt: 150}
+
+		patch := &CardReminderPatch{Note: &note}
+		require.False(t, patch.ChangesTime())
+
+		reminder, err := patch.Patch(reminder, location)
+		require.NoError(t, err)
+		require.Equal(t, "call back", reminder.Note)
+		require.Equal(t, int64(100), reminder.RemindAt)
+		require.Equal(t, int64(150), reminder.DeliveredAt)
+	})
+}
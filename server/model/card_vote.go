@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+)
+
+const (
+	// BoardPropertyVoteLimit is the board property with the maximum
+	// number of cards of the board a user can vote for. Zero or unset
+	// means there is no limit.
+	BoardPropertyVoteLimit = "voteLimit"
+
+	// ViewSortPropertyVotes is the pseudo-property to sort the cards of
+	// a view by their number of votes.
+	ViewSortPropertyVotes = "__votes"
+)
+
+// CardVote is the vote of a user for a card
+// swagger:model
+type CardVote struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board the card belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user that voted
+	// required: true
+	UserID string `json:"userId"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// CardReaction is an emoji reaction of a user to a card
+// swagger:model
+type CardReaction struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board the card belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user that reacted
+	// required: true
+	UserID string `json:"userId"`
+
+	// The emoji of the reaction
+	// required: true
+	Emoji string `json:"emoji"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// CardVotes are the votes and reactions of a card, as seen by a user
+// swagger:model
+type CardVotes struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The number of votes for the card
+	// required: true
+	VoteCount int `json:"voteCount"`
+
+	// True if the user voted for the card
+	// required: true
+	Voted bool `json:"voted"`
+
+	// The reactions to the card, grouped by emoji, the first reacted first
+	// required: true
+	Reactions []*CardReactionCount `json:"reactions"`
+}
+
+// CardReactionCount is the number of reactions to a card with the same
+// emoji
+// swagger:model
+type CardReactionCount struct {
+	// The emoji of the reactions
+	// required: true
+	Emoji string `json:"emoji"`
+
+	// The number of reactions
+	// required: true
+	Count int `json:"count"`
+
+	// True if the user reacted with this emoji
+	// required: true
+	Reacted bool `json:"reacted"`
+}
+
+func (v *CardVote) IsValid() error {
+	if v == nil {
+		return NewErrBadRequest("missing card vote")
+	}
+
+	if v.CardID == "" {
+		return NewErrBadRequest("missing card vote card ID")
+	}
+
+	if v.BoardID == "" {
+		return NewErrBadRequest("missing card vote board ID")
+	}
+
+	if v.UserID == "" {
+		return NewErrBadRequest("missing card vote user ID")
+	}
+
+	return nil
+}
+
+func (r *CardReaction) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("missing card reaction")
+	}
+
+	if r.CardID == "" {
+		return NewErrBadRequest("missing card reaction card ID")
+	}
+
+	if r.BoardID == "" {
+		return NewErrBadRequest("missing card reaction board ID")
+	}
+
+	if r.UserID == "" {
+		return NewErrBadRequest("missing card reaction user ID")
+	}
+
+	return ValidateReactionEmoji(r.Emoji)
+}
+
+// NewCardVotes returns empty votes for a card.
+func NewCardVotes(cardID string) *CardVotes {
+	return &CardVotes{
+		CardID:    cardID,
+		Reactions: []*CardReactionCount{},
+	}
+}
+
+// AddReaction counts a reaction to the card, grouped by emoji.
+func (v *CardVotes) AddReaction(emoji string, reacted bool) {
+	for _, reaction := range v.Reactions {
+		if reaction.Emoji == emoji {
+			reaction.Count++
+			reaction.Reacted = reaction.Reacted || reacted
+			return
+		}
+	}
+
+	v.Reactions = append(v.Reactions, &CardReactionCount{
+		Emoji:   emoji,
+		Count:   1,
+		Reacted: reacted,
+	})
+}
+
+// Anonymous returns a copy of the votes without the state of the user,
+// to be shared with all the members of the board.
+func (v *CardVotes) Anonymous() *CardVotes {
+	votes := NewCardVotes(v.CardID)
+	votes.VoteCount = v.VoteCount
+	for _, reaction := range v.Reactions {
+		votes.Reactions = append(votes.Reactions, &CardReactionCount{
+			Emoji: reaction.Emoji,
+			Count: reaction.Count,
+		})
+	}
+	return votes
+}
+
+// GetVoteLimit returns the maximum number of cards of the board a user
+// can vote for, or zero if there is no limit.
+func (b *Board) GetVoteLimit() int {
+	switch limit := b.Properties[BoardPropertyVoteLimit].(type) {
+	case float64:
+		return int(limit)
+	case int:
+		return limit
+	case int64:
+		return int(limit)
+	case string:
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return 0
+		}
+		return value
+	default:
+		return 0
+	}
+}
+
+// SortCardsForView sorts the cards by the vote counts when the sort
+// options of the view start with the votes pseudo-property. Cards with
+// the same number of votes keep their order. Other sort properties are
+// applied by the clients.
+func SortCardsForView(cards []*Card, sortOptions []ViewSortOption) {
+	if len(sortOptions) == 0 || sortOptions[0].PropertyID != ViewSortPropertyVotes {
+		return
+	}
+
+	reversed := sortOptions[0].Reversed
+	voteCount := func(card *Card) int {
+		if card.Votes == nil {
+			return 0
+		}
+		return card.Votes.VoteCount
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if reversed {
+			return voteCount(cards[i]) > voteCount(cards[j])
+		}
+		return voteCount(cards[i]) < voteCount(cards[j])
+	})
+}
+
+func CardVotesFromJSON(data io.Reader) *CardVotes {
+	var votes *CardVotes
+	_ = json.NewDecoder(data).Decode(&votes)
+	return votes
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardVotes(t *testing.T) {
+	t.Run("reactions are grouped by emoji", func(t *testing.T) {
+		votes := NewCardVotes("card-1")
+		votes.AddReaction("+1", false)
+		votes.AddReaction("tada", false)
+		votes.AddReaction("+1", true)
+
+		require.Equal(t, []*CardReactionCount{
+			{Emoji: "+1", Count: 2, Reacted: true},
+			{Emoji: "tada", Count: 1},
+		}, votes.Reactions)
+	})
+
+	t.Run("anonymous votes", func(t *testing.T) {
+		votes := NewCardVotes("card-1")
+		votes.VoteCount = 3
+		votes.Voted = true
+		votes.AddReaction("+1", true)
+
+		anonymous := votes.Anonymous()
+		require.Equal(t, 3, anonymous.VoteCount)
+		require.False(t, anonymous.Voted)
+		require.Equal(t, []*CardReactionCount{{Emoji: "+1", Count: 1}}, anonymous.Reactions)
+		require.True(t, votes.Reactions[0].Reacted)
+	})
+}
+
+func TestBoardVoteLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected int
+	}{
+		{"unset", nil, 0},
+		{"json number", float64(3), 3},
+		{"string", "5", 5},
+		{"invalid string", "many", 0},
+		{"invalid type", true, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board := &Board{Properties: map[string]interface{}{}}
+			if tc.value != nil {
+				board.Properties[BoardPropertyVoteLimit] = tc.value
+			}
+			require.Equal(t, tc.expected, board.GetVoteLimit())
+		})
+	}
+}
+
+func TestSortCardsForView(t *testing.T) {
+	withVotes := func(id string, count int) *Card {
+		return &Card{ID: id, Votes: &CardVotes{CardID: id, VoteCount: count}}
+	}
+
+	newCards := func() []*Card {
+		return []*Card{withVotes("card-1", 1), {ID: "card-2"}, withVotes("card-3", 5), withVotes("card-4", 1)}
+	}
+
+	ids := func(cards []*Card) []string {
+		result := []string{}
+		for _, card := range cards {
+			result = append(result, card.ID)
+		}
+		return result
+	}
+
+	t.Run("most voted first", func(t *testing.T) {
+		cards := newCards()
+		SortCardsForView(cards, []ViewSortOption{{PropertyID: ViewSortPropertyVotes, Reversed: true}})
+		require.Equal(t, []string{"card-3", "card-1", "card-4", "card-2"}, ids(cards))
+	})
+
+	t.Run("least voted first", func(t *testing.T) {
+		cards := newCards()
+		SortCardsForView(cards, []ViewSortOption{{PropertyID: ViewSortPropertyVotes}})
+		require.Equal(t, []string{"card-2", "card-1", "card-4", "card-3"}, ids(cards))
+	})
+
+	t.Run("other sorts keep the order", func(t *testing.T) {
+		cards := newCards()
+		SortCardsForView(cards, []ViewSortOption{{PropertyID: "title"}, {PropertyID: ViewSortPropertyVotes}})
+		require.Equal(t, []string{"card-1", "card-2", "card-3", "card-4"}, ids(cards))
+	})
+}
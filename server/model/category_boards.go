@@ -23,6 +23,7 @@ type BoardCategoryWebsocketData struct {
 }
 
 type CategoryBoardMetadata struct {
-	BoardID string `json:"boardID"`
-	Hidden  bool   `json:"hidden"`
+	BoardID     string `json:"boardID"`
+	Hidden      bool   `json:"hidden"`
+	UnreadCount int    `json:"unreadCount"`
 }
@@ -0,0 +1,445 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// CommentFieldThreadID is the comment field that holds the ID of
+	// the comment a reply belongs to. Replies keep the card as their
+	// parent, so the card content is loaded the same way with or
+	// without threads, and only the root comment of a thread lacks it.
+	CommentFieldThreadID = "threadId"
+
+	// CommentFieldResolvedAt is the comment field that holds the time
+	// in milliseconds since the current epoch a thread was resolved at.
+	// It is only present on the root comment of resolved threads.
+	CommentFieldResolvedAt = "resolvedAt"
+
+	// CommentFieldResolvedBy is the comment field that holds the ID of
+	// the user that resolved a thread.
+	CommentFieldResolvedBy = "resolvedBy"
+
+	// CommentFieldEditedAt is the comment field that holds the time in
+	// milliseconds since the current epoch the text of a comment was
+	// last edited at.
+	CommentFieldEditedAt = "editedAt"
+
+	// CommentReactionEmojiMaxRunes is the maximum length of the emoji
+	// of a reaction.
+	CommentReactionEmojiMaxRunes = 64
+)
+
+// ErrCommentFieldsPatch is returned when a generic block patch tries
+// to change the thread, resolution or edit time of a comment.
+var ErrCommentFieldsPatch = NewErrBadRequest("comment threads can only be changed through the comments API")
+
+// Comment is a comment of a card, with its reactions and, for the root
+// comment of a thread, its replies
+// swagger:model
+type Comment struct {
+	// The id of the comment
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the comment belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card the comment belongs to
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the root comment of the thread. Empty for root comments
+	// required: false
+	ThreadID string `json:"threadId,omitempty"`
+
+	// The text of the comment
+	// required: true
+	Text string `json:"text"`
+
+	// The id of the user that created the comment
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user that last modified the comment
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The time in milliseconds since the current epoch the text was last edited at. Zero if never edited
+	// required: false
+	EditedAt int64 `json:"editedAt,omitempty"`
+
+	// The time in milliseconds since the current epoch the thread was resolved at. Zero if not resolved
+	// required: false
+	ResolvedAt int64 `json:"resolvedAt,omitempty"`
+
+	// The id of the user that resolved the thread
+	// required: false
+	ResolvedBy string `json:"resolvedBy,omitempty"`
+
+	// The reactions to the comment, grouped by emoji
+	// required: true
+	Reactions []*CommentReactionSummary `json:"reactions"`
+
+	// The replies to the comment, oldest first
+	// required: false
+	Replies []*Comment `json:"replies,omitempty"`
+}
+
+// CommentPatch is a patch for modifying the text of a comment
+// swagger:model
+type CommentPatch struct {
+	// The updated text of the comment
+	// required: true
+	Text *string `json:"text"`
+}
+
+// CommentReaction is an emoji reaction of a user to a comment
+// swagger:model
+type CommentReaction struct {
+	// The id of the comment
+	// required: true
+	CommentID string `json:"commentId"`
+
+	// The id of the board the comment belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user that reacted
+	// required: true
+	UserID string `json:"userId"`
+
+	// The emoji of the reaction
+	// required: true
+	Emoji string `json:"emoji"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// CommentReactionSummary is the list of users that reacted to a
+// comment with the same emoji
+// swagger:model
+type CommentReactionSummary struct {
+	// The emoji of the reactions
+	// required: true
+	Emoji string `json:"emoji"`
+
+	// The ids of the users that reacted, in reaction order
+	// required: true
+	UserIDs []string `json:"userIds"`
+}
+
+// CommentEdit is a version of the text of a comment
+// swagger:model
+type CommentEdit struct {
+	// The text of the comment in this version
+	// required: true
+	Text string `json:"text"`
+
+	// The id of the user that wrote this version
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The time in milliseconds since the current epoch of this version
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (r *CommentReaction) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("missing comment reaction")
+	}
+
+	if r.CommentID == "" {
+		return NewErrBadRequest("missing comment reaction comment ID")
+	}
+
+	if r.UserID == "" {
+		return NewErrBadRequest("missing comment reaction user ID")
+	}
+
+	return ValidateReactionEmoji(r.Emoji)
+}
+
+// ValidateReactionEmoji checks the emoji of a reaction, which is either
+// an emoji name like `+1` or the emoji character itself.
+func ValidateReactionEmoji(emoji string) error {
+	if emoji == "" {
+		return NewErrBadRequest("missing reaction emoji")
+	}
+
+	if utf8.RuneCountInString(emoji) > CommentReactionEmojiMaxRunes {
+		return NewErrBadRequest(fmt.Sprintf("reaction emoji can't be longer than %d characters", CommentReactionEmojiMaxRunes))
+	}
+
+	if strings.ContainsAny(emoji, " \t\r\n/") {
+		return NewErrBadRequest("invalid reaction emoji")
+	}
+
+	return nil
+}
+
+// CommentThreadID returns the ID of the root comment of the thread the
+// comment belongs to, which is the comment itself for root comments.
+func CommentThreadID(block *Block) string {
+	if threadID, ok := block.Fields[CommentFieldThreadID].(string); ok && threadID != "" {
+		return threadID
+	}
+	return block.ID
+}
+
+// IsCommentReply returns true if the block is a comment that replies
+// to another comment.
+func IsCommentReply(block *Block) bool {
+	return block != nil && block.Type == TypeComment && CommentThreadID(block) != block.ID
+}
+
+// IsResolvedComment returns true if the block is the root comment of a
+// resolved thread.
+func IsResolvedComment(block *Block) bool {
+	return block != nil && block.Type == TypeComment && getInt64Field(block.Fields, CommentFieldResolvedAt) != 0
+}
+
+// ChangesCommentState returns true if the patch updates or removes the
+// fields that hold the thread, the resolution or the edit time of a
+// comment.
+func (p *BlockPatch) ChangesCommentState() bool {
+	return p.changesFields(CommentFieldThreadID, CommentFieldResolvedAt, CommentFieldResolvedBy, CommentFieldEditedAt)
+}
+
+// ChangesOnlyTitle returns true if the patch leaves everything but the
+// title of the block untouched.
+func (p *BlockPatch) ChangesOnlyTitle() bool {
+	if p == nil {
+		return true
+	}
+	return p.ParentID == nil && p.Schema == nil && p.Type == nil &&
+		len(p.UpdatedFields) == 0 && len(p.DeletedFields) == 0
+}
+
+// StampCommentEdit records the edit time on a patch that changes the
+// text of a comment, so the edit is visible on the comment.
+func (p *BlockPatch) StampCommentEdit(block *Block, editedAt int64) {
+	if block == nil || block.Type != TypeComment || p.Title == nil || *p.Title == block.Title {
+		return
+	}
+
+	if p.UpdatedFields == nil {
+		p.UpdatedFields = map[string]interface{}{}
+	}
+	p.UpdatedFields[CommentFieldEditedAt] = editedAt
+}
+
+// NewResolveCommentPatch returns the patch that resolves a thread.
+func NewResolveCommentPatch(userID string, resolvedAt int64) BlockPatch {
+	return BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			CommentFieldResolvedAt: resolvedAt,
+			CommentFieldResolvedBy: userID,
+		},
+	}
+}
+
+// NewUnresolveCommentPatch returns the patch that reopens a thread.
+func NewUnresolveCommentPatch() BlockPatch {
+	return BlockPatch{
+		DeletedFields: []string{CommentFieldResolvedAt, CommentFieldResolvedBy},
+	}
+}
+
+// Block2Comment converts a comment block to a comment, without its
+// reactions and replies.
+func Block2Comment(block *Block) (*Comment, error) {
+	if block.Type != TypeComment {
+		return nil, NewErrBadRequest(fmt.Sprintf("block %s is not a comment", block.ID))
+	}
+
+	comment := &Comment{
+		ID:         block.ID,
+		BoardID:    block.BoardID,
+		CardID:     block.ParentID,
+		Text:       block.Title,
+		CreatedBy:  block.CreatedBy,
+		ModifiedBy: block.ModifiedBy,
+		CreateAt:   block.CreateAt,
+		UpdateAt:   block.UpdateAt,
+		EditedAt:   getInt64Field(block.Fields, CommentFieldEditedAt),
+		ResolvedAt: getInt64Field(block.Fields, CommentFieldResolvedAt),
+		Reactions:  []*CommentReactionSummary{},
+	}
+
+	if IsCommentReply(block) {
+		comment.ThreadID = CommentThreadID(block)
+	}
+
+	if comment.ResolvedAt != 0 {
+		comment.ResolvedBy, _ = block.Fields[CommentFieldResolvedBy].(string)
+	}
+
+	return comment, nil
+}
+
+// NewCommentThreads returns the threads of the comments of a card,
+// oldest first, with their replies and the reactions to them. Replies
+// whose root comment is missing are left out.
+func NewCommentThreads(comments []*Block, reactions []*CommentReaction) ([]*Comment, error) {
+	roots := []*Comment{}
+	replies := map[string][]*Comment{}
+	byID := map[string]*Comment{}
+
+	for _, block := range comments {
+		if block.Type != TypeComment {
+			continue
+		}
+
+		comment, err := Block2Comment(block)
+		if err != nil {
+			return nil, err
+		}
+		byID[comment.ID] = comment
+
+		if comment.ThreadID == "" {
+			roots = append(roots, comment)
+		} else {
+			replies[comment.ThreadID] = append(replies[comment.ThreadID], comment)
+		}
+	}
+
+	sortComments(roots)
+	for _, root := range roots {
+		root.Replies = append([]*Comment{}, replies[root.ID]...)
+		sortComments(root.Replies)
+	}
+
+	sortedReactions := append([]*CommentReaction{}, reactions...)
+	sort.SliceStable(sortedReactions, func(i, j int) bool {
+		return sortedReactions[i].CreateAt < sortedReactions[j].CreateAt
+	})
+	for _, reaction := range sortedReactions {
+		if comment, ok := byID[reaction.CommentID]; ok {
+			comment.AddReaction(reaction)
+		}
+	}
+
+	return roots, nil
+}
+
+// NewCommentThread returns the thread of the root comment with the
+// given ID, built out of the comments of its card and the reactions to
+// them.
+func NewCommentThread(threadID string, comments []*Block, reactions []*CommentReaction) (*Comment, error) {
+	threads, err := NewCommentThreads(comments, reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thread := range threads {
+		if thread.ID == threadID {
+			return thread, nil
+		}
+	}
+
+	return nil, NewErrNotFound("comment thread " + threadID)
+}
+
+// CommentThreadParticipants returns the IDs of the users that wrote the
+// comments of a thread, in order of first participation.
+func CommentThreadParticipants(thread *Comment) []string {
+	participants := []string{thread.CreatedBy}
+	seen := map[string]bool{thread.CreatedBy: true}
+	for _, reply := range thread.Replies {
+		if !seen[reply.CreatedBy] {
+			seen[reply.CreatedBy] = true
+			participants = append(participants, reply.CreatedBy)
+		}
+	}
+	return participants
+}
+
+// NewCommentEdits returns the versions of the text of a comment out of
+// its block history, oldest first. Versions that didn't change the text
+// are left out.
+func NewCommentEdits(history []*Block) []*CommentEdit {
+	edits := []*CommentEdit{}
+	for _, block := range history {
+		if block.DeleteAt != 0 {
+			continue
+		}
+
+		if len(edits) != 0 && edits[len(edits)-1].Text == block.Title {
+			continue
+		}
+
+		edits = append(edits, &CommentEdit{
+			Text:       block.Title,
+			ModifiedBy: block.ModifiedBy,
+			UpdateAt:   block.UpdateAt,
+		})
+	}
+	return edits
+}
+
+func sortComments(comments []*Comment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreateAt < comments[j].CreateAt
+	})
+}
+
+// AddReaction adds a reaction to the reactions of the comment, grouped
+// by emoji.
+func (c *Comment) AddReaction(reaction *CommentReaction) {
+	for _, summary := range c.Reactions {
+		if summary.Emoji == reaction.Emoji {
+			summary.UserIDs = append(summary.UserIDs, reaction.UserID)
+			return
+		}
+	}
+
+	c.Reactions = append(c.Reactions, &CommentReactionSummary{
+		Emoji:   reaction.Emoji,
+		UserIDs: []string{reaction.UserID},
+	})
+}
+
+func CommentFromJSON(data io.Reader) *Comment {
+	var comment *Comment
+	_ = json.NewDecoder(data).Decode(&comment)
+	return comment
+}
+
+func CommentsFromJSON(data io.Reader) []*Comment {
+	var comments []*Comment
+	_ = json.NewDecoder(data).Decode(&comments)
+	return comments
+}
+
+func CommentEditsFromJSON(data io.Reader) []*CommentEdit {
+	var edits []*CommentEdit
+	_ = json.NewDecoder(data).Decode(&edits)
+	return edits
+}
+
+func CommentReactionSummariesFromJSON(data io.Reader) []*CommentReactionSummary {
+	var reactions []*CommentReactionSummary
+	_ = json.NewDecoder(data).Decode(&reactions)
+	return reactions
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReactionEmoji(t *testing.T) {
+	require.NoError(t, ValidateReactionEmoji("+1"))
+	require.NoError(t, ValidateReactionEmoji("🎉"))
+	require.True(t, IsErrBadRequest(ValidateReactionEmoji("")))
+	require.True(t, IsErrBadRequest(ValidateReactionEmoji("thumbs up")))
+	require.True(t, IsErrBadRequest(ValidateReactionEmoji("a/b")))
+	require.True(t, IsErrBadRequest(ValidateReactionEmoji(strings.Repeat("a", CommentReactionEmojiMaxRunes+1))))
+}
+
+func TestCommentThreadID(t *testing.T) {
+	root := &Block{ID: "comment-1", Type: TypeComment}
+	reply := &Block{ID: "comment-2", Type: TypeComment, Fields: map[string]interface{}{CommentFieldThreadID: "comment-1"}}
+
+	require.Equal(t, "comment-1", CommentThreadID(root))
+	require.Equal(t, "comment-1", CommentThreadID(reply))
+	require.False(t, IsCommentReply(root))
+	require.True(t, IsCommentReply(reply))
+	require.False(t, IsCommentReply(&Block{ID: "card-1", Type: TypeCard, Fields: reply.Fields}))
+}
+
+func TestNewCommentThread(t *testing.T) {
+	comments := []*Block{
+		{ID: "comment-3", Type: TypeComment, CreatedBy: "user-1", CreateAt: 300, Fields: map[string]interface{}{CommentFieldThreadID: "comment-1"}},
+		{ID: "comment-1", Type: TypeComment, ParentID: "card-1", CreatedBy: "user-1", CreateAt: 100, Title: "root", Fields: map[string]interface{}{
+			CommentFieldResolvedAt: float64(400),
+			CommentFieldResolvedBy: "user-2",
+			CommentFieldEditedAt:   float64(150),
+		}},
+		{ID: "comment-2", Type: TypeComment, CreatedBy: "user-2", CreateAt: 200, Fields: map[string]interface{}{CommentFieldThreadID: "comment-1"}},
+		{ID: "comment-4", Type: TypeComment, CreatedBy: "user-3", CreateAt: 250},
+	}
+	reactions := []*CommentReaction{
+		{CommentID: "comment-2", UserID: "user-1", Emoji: "+1", CreateAt: 3},
+		{CommentID: "comment-4", UserID: "user-1", Emoji: "+1", CreateAt: 2},
+		{CommentID: "comment-1", UserID: "user-2", Emoji: "tada", CreateAt: 1},
+	}
+
+	t.Run("nonexistent thread", func(t *testing.T) {
+		thread, err := NewCommentThread("comment-5", comments, nil)
+		require.True(t, IsErrNotFound(err))
+		require.Nil(t, thread)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		thread, err := NewCommentThread("comment-1", comments, reactions)
+		require.NoError(t, err)
+		require.Equal(t, "comment-1", thread.ID)
+		require.Equal(t, "card-1", thread.CardID)
+		require.Equal(t, "root", thread.Text)
+		require.Empty(t, thread.ThreadID)
+		require.Equal(t, int64(150), thread.EditedAt)
+		require.Equal(t, int64(400), thread.ResolvedAt)
+		require.Equal(t, "user-2", thread.ResolvedBy)
+		require.Equal(t, []*CommentReactionSummary{{Emoji: "tada", UserIDs: []string{"user-2"}}}, thread.Reactions)
+
+		require.Len(t, thread.Replies, 2)
+		require.Equal(t, "comment-2", thread.Replies[0].ID)
+		require.Equal(t, "comment-1", thread.Replies[0].ThreadID)
+		require.Equal(t, []*CommentReactionSummary{{Emoji: "+1", UserIDs: []string{"user-1"}}}, thread.Replies[0].Reactions)
+		require.Equal(t, "comment-3", thread.Replies[1].ID)
+		require.Empty(t, thread.Replies[1].Reactions)
+
+		require.Equal(t, []string{"user-1", "user-2"}, CommentThreadParticipants(thread))
+	})
+}
+
+func TestNewCommentEdits(t *testing.T) {
+	history := []*Block{
+		{Title: "first", ModifiedBy: "user-1", UpdateAt: 100},
+		{Title: "first", ModifiedBy: "user-2", UpdateAt: 200},
+		{Title: "second", ModifiedBy: "user-1", UpdateAt: 300},
+		{Title: "second", ModifiedBy: "user-1", UpdateAt: 400, DeleteAt: 400},
+	}
+
+	require.Equal(t, []*CommentEdit{
+		{Text: "first", ModifiedBy: "user-1", UpdateAt: 100},
+		{Text: "second", ModifiedBy: "user-1", UpdateAt: 300},
+	}, NewCommentEdits(history))
+}
+
+func TestCommentPatches(t *testing.T) {
+	t.Run("changes comment state", func(t *testing.T) {
+		var nilPatch *BlockPatch
+		require.False(t, nilPatch.ChangesCommentState())
+		require.False(t, (&BlockPatch{UpdatedFields: map[string]interface{}{"icon": "x"}}).ChangesCommentState())
+		require.True(t, (&BlockPatch{UpdatedFields: map[string]interface{}{CommentFieldThreadID: "comment-1"}}).ChangesCommentState())
+		require.True(t, (&BlockPatch{DeletedFields: []string{CommentFieldEditedAt}}).ChangesCommentState())
+
+		resolve := NewResolveCommentPatch("user-1", 100)
+		require.True(t, resolve.ChangesCommentState())
+		unresolve := NewUnresolveCommentPatch()
+		require.True(t, unresolve.ChangesCommentState())
+	})
+
+	t.Run("changes only title", func(t *testing.T) {
+		title := "edited text"
+		parentID := "card-2"
+
+		var nilPatch *BlockPatch
+		require.True(t, nilPatch.ChangesOnlyTitle())
+		require.True(t, (&BlockPatch{Title: &title}).ChangesOnlyTitle())
+		require.False(t, (&BlockPatch{Title: &title, ParentID: &parentID}).ChangesOnlyTitle())
+		require.False(t, (&BlockPatch{UpdatedFields: map[string]interface{}{"icon": "x"}}).ChangesOnlyTitle())
+		require.False(t, (&BlockPatch{DeletedFields: []string{"icon"}}).ChangesOnlyTitle())
+	})
+
+	t.Run("stamp comment edit", func(t *testing.T) {
+		comment := &Block{ID: "comment-1", Type: TypeComment, Title: "text"}
+		same := "text"
+		edited := "edited text"
+
+		patch := &BlockPatch{Title: &same}
+		patch.StampCommentEdit(comment, 100)
+		require.Nil(t, patch.UpdatedFields)
+
+		patch = &BlockPatch{Title: &edited}
+		patch.StampCommentEdit(&Block{ID: "card-1", Type: TypeCard}, 100)
+		require.Nil(t, patch.UpdatedFields)
+
+		patch.StampCommentEdit(comment, 100)
+		require.Equal(t, int64(100), patch.UpdatedFields[CommentFieldEditedAt])
+	})
+}
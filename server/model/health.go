@@ -0,0 +1,45 @@
+package model
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// Health components checked for the readiness of the server.
+const (
+	HealthComponentDatabase      = "database"
+	HealthComponentMigrations    = "migrations"
+	HealthComponentFiles         = "files"
+	HealthComponentNotifications = "notifications"
+	HealthComponentScheduler     = "scheduler"
+)
+
+// Health is the status of the server and of the components it depends on
+// swagger:model
+type Health struct {
+	// The status of the server, ok if all its components are ok
+	// required: true
+	Status string `json:"status"`
+
+	// The status of each component, ok or unavailable, by name
+	// required: false
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// IsOK returns true if all the components of the server are ok.
+func (h *Health) IsOK() bool {
+	return h.Status == HealthStatusOK
+}
+
+// MigrationStatus is the version of the database schema compared to the
+// version the server needs
+// swagger:model
+type MigrationStatus struct {
+	// The version of the last migration applied to the database
+	// required: true
+	AppliedVersion int `json:"appliedVersion"`
+
+	// The version of the last migration known to the server
+	// required: true
+	LatestVersion int `json:"latestVersion"`
+}
@@ -38,6 +38,10 @@ type ExportArchiveOptions struct {
 	// BoardIDs is the list of boards to include in the archive.
 	// Empty slice means export all boards from workspace/team.
 	BoardIDs []string
+
+	// UserID is the user exporting the boards. The private views of
+	// other users are left out of the archive.
+	UserID string
 }
 
 // ImportArchiveOptions provides options when importing an archive.
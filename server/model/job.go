@@ -0,0 +1,129 @@
+package model
+
+import "fmt"
+
+type JobStatus string
+
+const (
+	JobStatusPending  JobStatus = "pending"
+	JobStatusRunning  JobStatus = "running"
+	JobStatusSuccess  JobStatus = "success"
+	JobStatusError    JobStatus = "error"
+	JobStatusCanceled JobStatus = "canceled"
+)
+
+const (
+	JobTypeCleanUpSessions          = "clean_up_sessions"
+	JobTypeUpdateCardLimitTimestamp = "update_card_limit_timestamp"
+	JobTypeUnfreezeBoards           = "unfreeze_boards"
+	JobTypeSendCardReminders        = "send_card_reminders"
+	JobTypeDataRetention            = "data_retention"
+	JobTypeDeleteFinishedJobs       = "delete_finished_jobs"
+)
+
+// DefaultJobMaxAttempts is the number of times a job is run before it
+// is marked as failed.
+const DefaultJobMaxAttempts = 3
+
+// Job is a unit of background work, persisted so that it can be listed,
+// retried, and resumed by another server after a crash.
+// swagger:model
+type Job struct {
+	// The ID of the job
+	// required: true
+	ID string `json:"id"`
+
+	// The type of the job, which selects the worker that runs it
+	// required: true
+	Type string `json:"type"`
+
+	// The status of the job
+	// required: true
+	Status JobStatus `json:"status"`
+
+	// The parameters of the job
+	// required: false
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	// The progress of the running job, in percent
+	// required: true
+	Progress int `json:"progress"`
+
+	// The number of times the job has been started
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The number of times the job is started before it is marked as failed
+	// required: true
+	MaxAttempts int `json:"maxAttempts"`
+
+	// The error of the last failed attempt
+	// required: false
+	LastError string `json:"lastError,omitempty"`
+
+	// The ID of the server worker running the job
+	// required: false
+	WorkerID string `json:"workerId,omitempty"`
+
+	// The time from which the job can run in milliseconds since the current epoch
+	// required: true
+	ScheduledAt int64 `json:"scheduledAt"`
+
+	// The time the last attempt started in milliseconds since the current epoch
+	// required: false
+	StartAt int64 `json:"startAt"`
+
+	// The time the job finished in milliseconds since the current epoch
+	// required: false
+	FinishAt int64 `json:"finishAt"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch,
+	// which running jobs update as a heartbeat
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (j *Job) IsValid() error {
+	if j.ID == "" {
+		return NewErrBadRequest("job ID is required")
+	}
+	if j.Type == "" {
+		return NewErrBadRequest("job type is required")
+	}
+	if !j.Status.IsValid() {
+		return NewErrBadRequest(fmt.Sprintf("invalid job status %q", j.Status))
+	}
+	if j.MaxAttempts <= 0 {
+		return NewErrBadRequest("a positive number of attempts is required")
+	}
+	return nil
+}
+
+// IsFinished returns true if the job won't run again unless retried.
+func (j *Job) IsFinished() bool {
+	switch j.Status {
+	case JobStatusSuccess, JobStatusError, JobStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobStatusPending, JobStatusRunning, JobStatusSuccess, JobStatusError, JobStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// QueryJobsOptions are query options that can be passed to GetJobs.
+type QueryJobsOptions struct {
+	Type    string    // if not empty then filter for jobs of specified type
+	Status  JobStatus // if not empty then filter for jobs with specified status
+	Page    int       // page number to select when paginating
+	PerPage int       // number of jobs per page (default=-1, meaning unlimited)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobIsValid(t *testing.T) {
+	job := &Job{ID: "job-1", Type: JobTypeUnfreezeBoards, Status: JobStatusPending, MaxAttempts: 1}
+	require.NoError(t, job.IsValid())
+
+	job.Status = "stuck"
+	require.True(t, IsErrBadRequest(job.IsValid()))
+
+	job.Status = JobStatusPending
+	job.MaxAttempts = 0
+	require.True(t, IsErrBadRequest(job.IsValid()))
+}
+
+func TestJobIsFinished(t *testing.T) {
+	for status, finished := range map[JobStatus]bool{
+		JobStatusPending:  false,
+		JobStatusRunning:  false,
+		JobStatusSuccess:  true,
+		JobStatusError:    true,
+		JobStatusCanceled: true,
+	} {
+		require.Equal(t, finished, (&Job{Status: status}).IsFinished(), status)
+	}
+}
@@ -22,4 +22,7 @@ var (
 	PermissionManageBoardProperties = &mmModel.Permission{Id: "manage_board_properties", Name: "", Description: "", Scope: ""}
 	PermissionCommentBoardCards     = &mmModel.Permission{Id: "comment_board_cards", Name: "", Description: "", Scope: ""}
 	PermissionDeleteOthersComments  = &mmModel.Permission{Id: "delete_others_comments", Name: "", Description: "", Scope: ""}
+	PermissionFreezeBoard           = &mmModel.Permission{Id: "freeze_board", Name: "", Description: "", Scope: ""}
+	PermissionArchiveBoardCards     = &mmModel.Permission{Id: "archive_board_cards", Name: "", Description: "", Scope: ""}
+	PermissionUnarchiveBoardCards   = &mmModel.Permission{Id: "unarchive_board_cards", Name: "", Description: "", Scope: ""}
 )
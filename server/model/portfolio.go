@@ -0,0 +1,577 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	PortfolioMaxBoards      = 100
+	PortfolioDefaultPerPage = 100
+	PortfolioMaxPerPage     = 1000
+
+	// built-in columns that every portfolio row has, regardless of the
+	// properties of its board.
+	PortfolioColumnTitle    = "title"
+	PortfolioColumnBoard    = "board"
+	PortfolioColumnCreateAt = "createAt"
+	PortfolioColumnUpdateAt = "updateAt"
+
+	PortfolioFilterIncludes    = "includes"
+	PortfolioFilterNotIncludes = "notIncludes"
+	PortfolioFilterContains    = "contains"
+	PortfolioFilterIsEmpty     = "isEmpty"
+	PortfolioFilterIsNotEmpty  = "isNotEmpty"
+)
+
+// PortfolioQuery describes a query for the cards of several boards,
+// returned as rows with a common set of columns
+// swagger:model
+type PortfolioQuery struct {
+	// The IDs of the boards to query. Either this or the category ID
+	// must be set
+	// required: false
+	BoardIDs []string `json:"boardIds"`
+
+	// The ID of a category of the user whose boards should be queried
+	// required: false
+	CategoryID string `json:"categoryId"`
+
+	// The property library describing the columns of the result. If
+	// empty, the properties that all boards have in common are used
+	// required: false
+	Columns []PortfolioColumn `json:"columns"`
+
+	// The filters that rows must match
+	// required: false
+	Filters []PortfolioFilter `json:"filters"`
+
+	// The sort order of the rows, applied in order
+	// required: false
+	Sort []PortfolioSort `json:"sort"`
+
+	// The name of the column to group rows by
+	// required: false
+	GroupBy string `json:"groupBy"`
+
+	// The page of rows to return, starting at 0
+	// required: false
+	Page int `json:"page"`
+
+	// The number of rows per page
+	// required: false
+	PerPage int `json:"perPage"`
+}
+
+// PortfolioColumn is a normalized column of a portfolio, matched to
+// the board properties with the same name or one of its aliases
+// swagger:model
+type PortfolioColumn struct {
+	// The name of the column
+	// required: true
+	Name string `json:"name"`
+
+	// The property type that matching properties must have. If empty,
+	// any type matches
+	// required: false
+	Type string `json:"type,omitempty"`
+
+	// Alternative property names that match the column
+	// required: false
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// PortfolioFilter is a condition on the values of a column
+// swagger:model
+type PortfolioFilter struct {
+	// The name of the column
+	// required: true
+	Column string `json:"column"`
+
+	// The condition: includes, notIncludes, contains, isEmpty or isNotEmpty
+	// required: true
+	Condition string `json:"condition"`
+
+	// The values to compare with, matched case insensitively
+	// required: false
+	Values []string `json:"values"`
+}
+
+// PortfolioSort is a sort criteria for the rows of a portfolio
+// swagger:model
+type PortfolioSort struct {
+	// The name of the column
+	// required: true
+	Column string `json:"column"`
+
+	// True to sort in descending order
+	// required: false
+	Reversed bool `json:"reversed"`
+}
+
+// PortfolioRow is a card of a portfolio with its normalized values
+// swagger:model
+type PortfolioRow struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board of the card
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The values of the card keyed by column name. Option values are
+	// replaced by their labels
+	// required: true
+	Values map[string]interface{} `json:"values"`
+
+	// The group value of the row, when grouping
+	// required: false
+	Group string `json:"group,omitempty"`
+}
+
+// PortfolioGroup is a group of rows sharing the same column value
+// swagger:model
+type PortfolioGroup struct {
+	// The group value
+	// required: true
+	Value string `json:"value"`
+
+	// The number of rows in the group, across all pages
+	// required: true
+	Count int `json:"count"`
+}
+
+// PortfolioResult is the result of a portfolio query
+// swagger:model
+type PortfolioResult struct {
+	// The IDs of the boards included in the result
+	// required: true
+	BoardIDs []string `json:"boardIds"`
+
+	// The columns of the rows
+	// required: true
+	Columns []PortfolioColumn `json:"columns"`
+
+	// The rows of the requested page
+	// required: true
+	Rows []*PortfolioRow `json:"rows"`
+
+	// The groups of rows, when grouping
+	// required: false
+	Groups []PortfolioGroup `json:"groups,omitempty"`
+
+	// The number of rows matching the filters, across all pages
+	// required: true
+	Total int `json:"total"`
+
+	// The page of rows returned
+	// required: true
+	Page int `json:"page"`
+
+	// The number of rows per page
+	// required: true
+	PerPage int `json:"perPage"`
+
+	// True if there are more rows after this page
+	// required: true
+	HasNext bool `json:"hasNext"`
+}
+
+func (q *PortfolioQuery) IsValid() error {
+	if q == nil {
+		return NewErrBadRequest("missing portfolio query")
+	}
+
+	if len(q.BoardIDs) == 0 && q.CategoryID == "" {
+		return NewErrBadRequest("either board IDs or a category ID must be provided")
+	}
+
+	if len(q.BoardIDs) != 0 && q.CategoryID != "" {
+		return NewErrBadRequest("board IDs and category ID are mutually exclusive")
+	}
+
+	if len(q.BoardIDs) > PortfolioMaxBoards {
+		return NewErrBadRequest(fmt.Sprintf("a portfolio can't include more than %d boards", PortfolioMaxBoards))
+	}
+
+	if q.Page < 0 || q.PerPage < 0 || q.PerPage > PortfolioMaxPerPage {
+		return NewErrBadRequest("invalid portfolio pagination")
+	}
+
+	for _, column := range q.Columns {
+		if strings.TrimSpace(column.Name) == "" {
+			return NewErrBadRequest("portfolio columns must have a name")
+		}
+	}
+
+	for _, filter := range q.Filters {
+		switch filter.Condition {
+		case PortfolioFilterIncludes, PortfolioFilterNotIncludes, PortfolioFilterContains,
+			PortfolioFilterIsEmpty, PortfolioFilterIsNotEmpty:
+		default:
+			return NewErrBadRequest(fmt.Sprintf("invalid portfolio filter condition %q", filter.Condition))
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the property definition matches the column
+// by name or alias, and by type if the column has one.
+func (c PortfolioColumn) Matches(pd PropDef) bool {
+	if c.Type != "" && c.Type != pd.Type {
+		return false
+	}
+
+	if strings.EqualFold(strings.TrimSpace(pd.Name), strings.TrimSpace(c.Name)) {
+		return true
+	}
+
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(strings.TrimSpace(pd.Name), strings.TrimSpace(alias)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PortfolioColumnsForBoards returns a column for each property that
+// all the boards have, with the same name and type. Columns are in the
+// property order of the first board.
+func PortfolioColumnsForBoards(boards []*Board) []PortfolioColumn {
+	columns := []PortfolioColumn{}
+	if len(boards) == 0 {
+		return columns
+	}
+
+	for _, prop := range boards[0].CardProperties {
+		column := PortfolioColumn{
+			Name: getMapString("name", prop),
+			Type: getMapString("type", prop),
+		}
+		if column.Name == "" {
+			continue
+		}
+
+		shared := true
+		for _, board := range boards[1:] {
+			if _, ok := column.matchingProperty(board); !ok {
+				shared = false
+				break
+			}
+		}
+
+		if shared {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func (c PortfolioColumn) matchingProperty(board *Board) (PropDef, bool) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return PropDef{}, false
+	}
+
+	return c.matchingPropDef(schema)
+}
+
+func (c PortfolioColumn) matchingPropDef(schema PropSchema) (PropDef, bool) {
+	// iterate in board order so that the match is deterministic
+	defs := make([]PropDef, 0, len(schema))
+	for _, pd := range schema {
+		defs = append(defs, pd)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Index < defs[j].Index })
+
+	for _, pd := range defs {
+		if c.Matches(pd) {
+			return pd, true
+		}
+	}
+	return PropDef{}, false
+}
+
+// NewPortfolioRows converts the cards of a board into portfolio rows,
+// with the values of the board properties that match the columns.
+func NewPortfolioRows(board *Board, cards []*Card, columns []PortfolioColumn) ([]*PortfolioRow, error) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	columnDefs := map[string]PropDef{}
+	for _, column := range columns {
+		if pd, ok := column.matchingPropDef(schema); ok {
+			columnDefs[column.Name] = pd
+		}
+	}
+
+	rows := make([]*PortfolioRow, 0, len(cards))
+	for _, card := range cards {
+		row := &PortfolioRow{
+			CardID:     card.ID,
+			BoardID:    board.ID,
+			BoardTitle: board.Title,
+			Title:      card.Title,
+			Icon:       card.Icon,
+			CreateAt:   card.CreateAt,
+			UpdateAt:   card.UpdateAt,
+			Values:     map[string]interface{}{},
+		}
+
+		for columnName, pd := range columnDefs {
+			value, ok := card.Properties[pd.ID]
+			if !ok {
+				continue
+			}
+			row.Values[columnName] = normalizePortfolioValue(pd, value)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// normalizePortfolioValue replaces option IDs with their labels, so
+// that values from different boards can be compared.
+func normalizePortfolioValue(pd PropDef, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if opt, ok := pd.Options[v]; ok {
+			return opt.Value
+		}
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s := fmt.Sprintf("%v", item)
+			if opt, ok := pd.Options[s]; ok {
+				s = opt.Value
+			}
+			values = append(values, s)
+		}
+		return values
+	default:
+		return value
+	}
+}
+
+// ApplyPortfolioQuery filters, sorts, groups and paginates the rows of
+// a portfolio.
+func ApplyPortfolioQuery(rows []*PortfolioRow, query *PortfolioQuery) ([]*PortfolioRow, []PortfolioGroup, int) {
+	filtered := make([]*PortfolioRow, 0, len(rows))
+	for _, row := range rows {
+		if row.matchesFilters(query.Filters) {
+			filtered = append(filtered, row)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		for _, s := range query.Sort {
+			cmp := comparePortfolioValues(filtered[i].sortValue(s.Column), filtered[j].sortValue(s.Column), s.Reversed)
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	var groups []PortfolioGroup
+	if query.GroupBy != "" {
+		counts := map[string]int{}
+		for _, row := range filtered {
+			row.Group = strings.Join(row.stringValues(query.GroupBy), ", ")
+			counts[row.Group]++
+		}
+
+		groups = make([]PortfolioGroup, 0, len(counts))
+		for value, count := range counts {
+			groups = append(groups, PortfolioGroup{Value: value, Count: count})
+		}
+		// rows without a value are grouped last
+		sort.Slice(groups, func(i, j int) bool {
+			if groups[i].Value == "" || groups[j].Value == "" {
+				return groups[j].Value == ""
+			}
+			return comparePortfolioValues(groups[i].Value, groups[j].Value, false) < 0
+		})
+
+		groupIndex := map[string]int{}
+		for i, group := range groups {
+			groupIndex[group.Value] = i
+		}
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return groupIndex[filtered[i].Group] < groupIndex[filtered[j].Group]
+		})
+	}
+
+	total := len(filtered)
+	perPage := query.PerPage
+	if perPage == 0 {
+		perPage = PortfolioDefaultPerPage
+	}
+
+	start := query.Page * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	return filtered[start:end], groups, total
+}
+
+func (r *PortfolioRow) matchesFilters(filters []PortfolioFilter) bool {
+	for _, filter := range filters {
+		values := r.stringValues(filter.Column)
+
+		switch filter.Condition {
+		case PortfolioFilterIsEmpty:
+			if len(values) != 0 {
+				return false
+			}
+		case PortfolioFilterIsNotEmpty:
+			if len(values) == 0 {
+				return false
+			}
+		case PortfolioFilterIncludes:
+			if !matchesAny(values, filter.Values, strings.EqualFold) {
+				return false
+			}
+		case PortfolioFilterNotIncludes:
+			if matchesAny(values, filter.Values, strings.EqualFold) {
+				return false
+			}
+		case PortfolioFilterContains:
+			if !matchesAny(values, filter.Values, func(value, search string) bool {
+				return strings.Contains(strings.ToLower(value), strings.ToLower(search))
+			}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stringValues returns the non empty values of a column as strings.
+func (r *PortfolioRow) stringValues(column string) []string {
+	var value interface{}
+	switch column {
+	case PortfolioColumnTitle:
+		value = r.Title
+	case PortfolioColumnBoard:
+		value = r.BoardTitle
+	case PortfolioColumnCreateAt:
+		value = r.CreateAt
+	case PortfolioColumnUpdateAt:
+		value = r.UpdateAt
+	default:
+		value = r.Values[column]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return []string{}
+	case []string:
+		values := make([]string, 0, len(v))
+		for _, s := range v {
+			if s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := fmt.Sprintf("%v", item); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		if s := fmt.Sprintf("%v", v); s != "" {
+			return []string{s}
+		}
+		return []string{}
+	}
+}
+
+func (r *PortfolioRow) sortValue(column string) string {
+	return strings.Join(r.stringValues(column), ", ")
+}
+
+// comparePortfolioValues compares numbers numerically and other values
+// case insensitively. Empty values are always ordered last.
+func comparePortfolioValues(a, b string, reversed bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	cmp := strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	if numA, err := strconv.ParseFloat(a, 64); err == nil {
+		if numB, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case numA < numB:
+				cmp = -1
+			case numA > numB:
+				cmp = 1
+			default:
+				cmp = 0
+			}
+		}
+	}
+
+	if reversed {
+		return -cmp
+	}
+	return cmp
+}
+
+func matchesAny(values, searches []string, match func(value, search string) bool) bool {
+	for _, value := range values {
+		for _, search := range searches {
+			if match(value, search) {
+				return true
+			}
+		}
+	}
+	return false
+}
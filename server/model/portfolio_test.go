@@ -0,0 +1,185 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortfolioQueryIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   *PortfolioQuery
+		isValid bool
+	}{
+		{name: "nil query", query: nil},
+		{name: "no boards", query: &PortfolioQuery{}},
+		{name: "boards and category", query: &PortfolioQuery{BoardIDs: []string{"board-1"}, CategoryID: "category-1"}},
+		{name: "invalid pagination", query: &PortfolioQuery{BoardIDs: []string{"board-1"}, PerPage: PortfolioMaxPerPage + 1}},
+		{name: "column without name", query: &PortfolioQuery{BoardIDs: []string{"board-1"}, Columns: []PortfolioColumn{{Type: "select"}}}},
+		{name: "invalid filter", query: &PortfolioQuery{BoardIDs: []string{"board-1"}, Filters: []PortfolioFilter{{Column: "Status", Condition: "is"}}}},
+		{name: "boards", query: &PortfolioQuery{BoardIDs: []string{"board-1"}}, isValid: true},
+		{name: "category", query: &PortfolioQuery{CategoryID: "category-1"}, isValid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.query.IsValid()
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.True(t, IsErrBadRequest(err))
+			}
+		})
+	}
+}
+
+func TestPortfolioColumnsForBoards(t *testing.T) {
+	board1 := &Board{
+		ID: "board-1",
+		CardProperties: []map[string]interface{}{
+			{"id": "status-1", "name": "Status", "type": "select"},
+			{"id": "estimate-1", "name": "Estimate", "type": "number"},
+			{"id": "owner-1", "name": "Owner", "type": "person"},
+		},
+	}
+	board2 := &Board{
+		ID: "board-2",
+		CardProperties: []map[string]interface{}{
+			{"id": "estimate-2", "name": "estimate", "type": "number"},
+			{"id": "status-2", "name": "status", "type": "select"},
+			{"id": "owner-2", "name": "Owner", "type": "text"},
+		},
+	}
+
+	columns := PortfolioColumnsForBoards([]*Board{board1, board2})
+	require.Equal(t, []PortfolioColumn{
+		{Name: "Status", Type: "select"},
+		{Name: "Estimate", Type: "number"},
+	}, columns)
+
+	require.Empty(t, PortfolioColumnsForBoards(nil))
+}
+
+func TestNewPortfolioRows(t *testing.T) {
+	board := &Board{
+		ID:    "board-1",
+		Title: "Team A",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-1",
+				"name": "State",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "option-1", "value": "In progress"},
+				},
+			},
+			{
+				"id":   "labels-1",
+				"name": "Labels",
+				"type": "multiSelect",
+				"options": []interface{}{
+					map[string]interface{}{"id": "option-2", "value": "Backend"},
+					map[string]interface{}{"id": "option-3", "value": "Frontend"},
+				},
+			},
+		},
+	}
+	cards := []*Card{
+		{
+			ID:    "card-1",
+			Title: "Epic 1",
+			Properties: map[string]any{
+				"status-1": "option-1",
+				"labels-1": []interface{}{"option-2", "option-3"},
+			},
+		},
+	}
+	columns := []PortfolioColumn{
+		{Name: "Status", Aliases: []string{"state"}},
+		{Name: "Labels", Type: "multiSelect"},
+		{Name: "Estimate"},
+	}
+
+	rows, err := NewPortfolioRows(board, cards, columns)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "card-1", rows[0].CardID)
+	require.Equal(t, "board-1", rows[0].BoardID)
+	require.Equal(t, "Team A", rows[0].BoardTitle)
+	require.Equal(t, map[string]interface{}{
+		"Status": "In progress",
+		"Labels": []string{"Backend", "Frontend"},
+	}, rows[0].Values)
+}
+
+func TestApplyPortfolioQuery(t *testing.T) {
+	newRows := func() []*PortfolioRow {
+		return []*PortfolioRow{
+			{CardID: "card-1", Title: "Epic 1", Values: map[string]interface{}{"Status": "Done", "Estimate": "10"}},
+			{CardID: "card-2", Title: "Epic 2", Values: map[string]interface{}{"Status": "In progress", "Estimate": "9"}},
+			{CardID: "card-3", Title: "Epic 3", Values: map[string]interface{}{"Status": "Done", "Estimate": "2"}},
+			{CardID: "card-4", Title: "Epic 4", Values: map[string]interface{}{}},
+		}
+	}
+	cardIDs := func(rows []*PortfolioRow) []string {
+		ids := make([]string, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.CardID)
+		}
+		return ids
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		rows, _, total := ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			Filters: []PortfolioFilter{{Column: "Status", Condition: PortfolioFilterIncludes, Values: []string{"done"}}},
+		})
+		require.Equal(t, 2, total)
+		require.Equal(t, []string{"card-1", "card-3"}, cardIDs(rows))
+
+		rows, _, _ = ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			Filters: []PortfolioFilter{{Column: "Status", Condition: PortfolioFilterIsEmpty}},
+		})
+		require.Equal(t, []string{"card-4"}, cardIDs(rows))
+
+		rows, _, _ = ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			Filters: []PortfolioFilter{{Column: PortfolioColumnTitle, Condition: PortfolioFilterContains, Values: []string{"epic 2"}}},
+		})
+		require.Equal(t, []string{"card-2"}, cardIDs(rows))
+	})
+
+	t.Run("sort numbers with empty values last", func(t *testing.T) {
+		rows, _, _ := ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			Sort: []PortfolioSort{{Column: "Estimate"}},
+		})
+		require.Equal(t, []string{"card-3", "card-2", "card-1", "card-4"}, cardIDs(rows))
+
+		rows, _, _ = ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			Sort: []PortfolioSort{{Column: "Estimate", Reversed: true}},
+		})
+		require.Equal(t, []string{"card-1", "card-2", "card-3", "card-4"}, cardIDs(rows))
+	})
+
+	t.Run("group", func(t *testing.T) {
+		rows, groups, _ := ApplyPortfolioQuery(newRows(), &PortfolioQuery{
+			GroupBy: "Status",
+			Sort:    []PortfolioSort{{Column: PortfolioColumnTitle, Reversed: true}},
+		})
+		require.Equal(t, []PortfolioGroup{
+			{Value: "Done", Count: 2},
+			{Value: "In progress", Count: 1},
+			{Value: "", Count: 1},
+		}, groups)
+		require.Equal(t, []string{"card-3", "card-1", "card-2", "card-4"}, cardIDs(rows))
+		require.Equal(t, "Done", rows[0].Group)
+	})
+
+	t.Run("paginate", func(t *testing.T) {
+		rows, _, total := ApplyPortfolioQuery(newRows(), &PortfolioQuery{Page: 1, PerPage: 3})
+		require.Equal(t, 4, total)
+		require.Equal(t, []string{"card-4"}, cardIDs(rows))
+
+		rows, _, _ = ApplyPortfolioQuery(newRows(), &PortfolioQuery{Page: 2, PerPage: 3})
+		require.Empty(t, rows)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ReadState is the last time a user viewed a board or one of its cards.
+// swagger:model
+type ReadState struct {
+	// The ID of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the card, empty for the board itself
+	// required: false
+	CardID string `json:"cardId,omitempty"`
+
+	// The last time the user viewed the board or the card
+	// required: true
+	ViewedAt int64 `json:"viewedAt"`
+}
+
+// IsValid checks that the read state refers to a user and a board.
+func (rs *ReadState) IsValid() error {
+	if rs.UserID == "" {
+		return NewErrBadRequest("invalid read state, missing user ID")
+	}
+	if rs.BoardID == "" {
+		return NewErrBadRequest("invalid read state, missing board ID")
+	}
+	if rs.ViewedAt <= 0 {
+		return NewErrBadRequest("invalid read state, missing view time")
+	}
+	return nil
+}
+
+// BoardUnreadCounts contains the number of changes made by other users
+// to a board and to its cards since the user last viewed them.
+// swagger:model
+type BoardUnreadCounts struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of changes to the board since the user last viewed it
+	// required: true
+	UnreadCount int `json:"unreadCount"`
+
+	// The number of changes to each card with unread changes since the
+	// user last viewed it, by card ID
+	// required: true
+	Cards map[string]int `json:"cards"`
+}
+
+func BoardUnreadCountsFromJSON(data io.Reader) *BoardUnreadCounts {
+	var counts *BoardUnreadCounts
+	_ = json.NewDecoder(data).Decode(&counts)
+	return counts
+}
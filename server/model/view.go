@@ -0,0 +1,787 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+var ErrNotViewBlock = errors.New("not a view block")
+
+type ViewType string
+
+const (
+	ViewTypeBoard    ViewType = "board"
+	ViewTypeTable    ViewType = "table"
+	ViewTypeGallery  ViewType = "gallery"
+	ViewTypeCalendar ViewType = "calendar"
+)
+
+const (
+	ViewFilterOperationAnd = "and"
+	ViewFilterOperationOr  = "or"
+)
+
+// viewTitlePropertyID is the ID the webapp uses to filter views by card
+// title. Other built in columns, such as the title and badges columns,
+// use IDs prefixed with "__".
+const viewTitlePropertyID = "title"
+
+var viewFilterConditions = map[string]bool{
+	"includes":      true,
+	"notIncludes":   true,
+	"isEmpty":       true,
+	"isNotEmpty":    true,
+	"isSet":         true,
+	"isNotSet":      true,
+	"is":            true,
+	"contains":      true,
+	"notContains":   true,
+	"startsWith":    true,
+	"notStartsWith": true,
+	"endsWith":      true,
+	"notEndsWith":   true,
+	"isBefore":      true,
+	"isAfter":       true,
+}
+
+// ViewSortOption is a sort criteria of a view
+// swagger:model
+type ViewSortOption struct {
+	// The ID of the property to sort by
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// True to sort in descending order
+	// required: false
+	Reversed bool `json:"reversed"`
+}
+
+// ViewFilter is either a group of filters, combined with an operation,
+// or a filter clause on a property of the cards
+// swagger:model
+type ViewFilter struct {
+	// The operation to combine the filters of a group with, "and" or "or"
+	// required: false
+	Operation string `json:"operation,omitempty"`
+
+	// The filters of a group
+	// required: false
+	Filters []*ViewFilter `json:"filters,omitempty"`
+
+	// The ID of the property of a filter clause
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The condition of a filter clause
+	// required: false
+	Condition string `json:"condition,omitempty"`
+
+	// The values of a filter clause
+	// required: false
+	Values []string `json:"values,omitempty"`
+}
+
+// ViewKanbanCalculation is the calculation shown on a column of a board
+// view
+// swagger:model
+type ViewKanbanCalculation struct {
+	// The calculation
+	// required: true
+	Calculation string `json:"calculation"`
+
+	// The ID of the property to calculate
+	// required: true
+	PropertyID string `json:"propertyId"`
+}
+
+// ViewFields are the settings of a view, stored in the fields of its
+// block.
+type ViewFields struct {
+	// The type of the view
+	// required: true
+	ViewType ViewType `json:"viewType"`
+
+	// The ID of the property to group cards by
+	// required: false
+	GroupByID string `json:"groupById"`
+
+	// The ID of the date property to show cards by on calendar views
+	// required: false
+	DateDisplayPropertyID string `json:"dateDisplayPropertyId"`
+
+	// The sort criteria of the view
+	// required: false
+	SortOptions []ViewSortOption `json:"sortOptions"`
+
+	// The IDs of the properties shown on the view
+	// required: false
+	VisiblePropertyIDs []string `json:"visiblePropertyIds"`
+
+	// The IDs of the options of the group by property shown on the view
+	// required: false
+	VisibleOptionIDs []string `json:"visibleOptionIds"`
+
+	// The IDs of the options of the group by property hidden on the view
+	// required: false
+	HiddenOptionIDs []string `json:"hiddenOptionIds"`
+
+	// The IDs of the options of the group by property collapsed on the view
+	// required: false
+	CollapsedOptionIDs []string `json:"collapsedOptionIds"`
+
+	// The filter of the view
+	// required: false
+	Filter *ViewFilter `json:"filter"`
+
+	// The manual order of the cards of the view
+	// required: false
+	CardOrder []string `json:"cardOrder"`
+
+	// The widths of the columns of table views, keyed by property ID
+	// required: false
+	ColumnWidths map[string]float64 `json:"columnWidths"`
+
+	// The calculations of the columns of table views, keyed by property ID
+	// required: false
+	ColumnCalculations map[string]string `json:"columnCalculations"`
+
+	// The calculations of the columns of board views, keyed by option ID
+	// required: false
+	KanbanCalculations map[string]ViewKanbanCalculation `json:"kanbanCalculations"`
+
+	// The ID of the card template used by default on the view
+	// required: false
+	DefaultTemplateID string `json:"defaultTemplateId"`
+}
+
+// View represents a view of the cards of a board.
+// swagger:model
+type View struct {
+	// The id for this view
+	// required: false
+	ID string `json:"id"`
+
+	// The id for board this view belongs to
+	// required: false
+	BoardID string `json:"boardId"`
+
+	// The id for user who created this view
+	// required: false
+	CreatedBy string `json:"createdBy"`
+
+	// The id for user who last modified this view
+	// required: false
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The display title
+	// required: false
+	Title string `json:"title"`
+
+	// The id for the user that owns this view if it's private. Private
+	// views are only visible to their owner
+	// required: false
+	OwnerID string `json:"ownerId,omitempty"`
+
+	ViewFields
+
+	// The creation time in milliseconds since the current epoch
+	// required: false
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: false
+	UpdateAt int64 `json:"updateAt"`
+
+	// The deleted time in milliseconds since the current epoch. Set to indicate this view is deleted
+	// required: false
+	DeleteAt int64 `json:"deleteAt"`
+}
+
+// ViewPatch is a patch for modifying views
+// swagger:model
+type ViewPatch struct {
+	// The display title
+	// required: false
+	Title *string `json:"title"`
+
+	// The type of the view
+	// required: false
+	ViewType *ViewType `json:"viewType"`
+
+	// The ID of the property to group cards by
+	// required: false
+	GroupByID *string `json:"groupById"`
+
+	// The ID of the date property to show cards by on calendar views
+	// required: false
+	DateDisplayPropertyID *string `json:"dateDisplayPropertyId"`
+
+	// The sort criteria of the view
+	// required: false
+	SortOptions *[]ViewSortOption `json:"sortOptions"`
+
+	// The IDs of the properties shown on the view
+	// required: false
+	VisiblePropertyIDs *[]string `json:"visiblePropertyIds"`
+
+	// The IDs of the options of the group by property shown on the view
+	// required: false
+	VisibleOptionIDs *[]string `json:"visibleOptionIds"`
+
+	// The IDs of the options of the group by property hidden on the view
+	// required: false
+	HiddenOptionIDs *[]string `json:"hiddenOptionIds"`
+
+	// The IDs of the options of the group by property collapsed on the view
+	// required: false
+	CollapsedOptionIDs *[]string `json:"collapsedOptionIds"`
+
+	// The filter of the view
+	// required: false
+	Filter *ViewFilter `json:"filter"`
+
+	// The manual order of the cards of the view
+	// required: false
+	CardOrder *[]string `json:"cardOrder"`
+
+	// The widths of the columns of table views, keyed by property ID
+	// required: false
+	ColumnWidths *map[string]float64 `json:"columnWidths"`
+
+	// The calculations of the columns of table views, keyed by property ID
+	// required: false
+	ColumnCalculations *map[string]string `json:"columnCalculations"`
+
+	// The calculations of the columns of board views, keyed by option ID
+	// required: false
+	KanbanCalculations *map[string]ViewKanbanCalculation `json:"kanbanCalculations"`
+
+	// The ID of the card template used by default on the view
+	// required: false
+	DefaultTemplateID *string `json:"defaultTemplateId"`
+}
+
+// ViewDuplicateOptions contains the destination of a view duplication
+// swagger:model
+type ViewDuplicateOptions struct {
+	// The ID of the board to duplicate the view to. Empty to duplicate
+	// the view on its own board
+	// required: false
+	BoardID string `json:"boardId"`
+}
+
+// MarshalJSON encodes groups and clauses with only the fields they use,
+// as the webapp tells them apart by the fields present.
+func (f ViewFilter) MarshalJSON() ([]byte, error) {
+	if f.IsGroup() {
+		filters := f.Filters
+		if filters == nil {
+			filters = []*ViewFilter{}
+		}
+		return json.Marshal(struct {
+			Operation string        `json:"operation"`
+			Filters   []*ViewFilter `json:"filters"`
+		}{f.Operation, filters})
+	}
+
+	values := f.Values
+	if values == nil {
+		values = []string{}
+	}
+	return json.Marshal(struct {
+		PropertyID string   `json:"propertyId"`
+		Condition  string   `json:"condition"`
+		Values     []string `json:"values"`
+	}{f.PropertyID, f.Condition, values})
+}
+
+// IsGroup returns true if the filter is a group of filters.
+func (f *ViewFilter) IsGroup() bool {
+	return f.Operation != "" || f.Filters != nil
+}
+
+func (f *ViewFilter) isValid() error {
+	if f == nil {
+		return NewErrBadRequest("invalid view, empty filter")
+	}
+
+	if f.IsGroup() {
+		if f.Operation != ViewFilterOperationAnd && f.Operation != ViewFilterOperationOr {
+			return NewErrBadRequest(fmt.Sprintf("invalid view, invalid filter operation %q", f.Operation))
+		}
+		for _, filter := range f.Filters {
+			if err := filter.isValid(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if f.PropertyID == "" {
+		return NewErrBadRequest("invalid view, filter property ID is missing")
+	}
+	if !viewFilterConditions[f.Condition] {
+		return NewErrBadRequest(fmt.Sprintf("invalid view, invalid filter condition %q", f.Condition))
+	}
+	return nil
+}
+
+// Populate populates a View with default values.
+func (v *View) Populate() {
+	if v.ID == "" {
+		v.ID = utils.NewID(utils.IDTypeView)
+	}
+	if v.ViewType == "" {
+		v.ViewType = ViewTypeBoard
+	}
+	if v.SortOptions == nil {
+		v.SortOptions = []ViewSortOption{}
+	}
+	if v.VisiblePropertyIDs == nil {
+		v.VisiblePropertyIDs = []string{}
+	}
+	if v.VisibleOptionIDs == nil {
+		v.VisibleOptionIDs = []string{}
+	}
+	if v.HiddenOptionIDs == nil {
+		v.HiddenOptionIDs = []string{}
+	}
+	if v.CollapsedOptionIDs == nil {
+		v.CollapsedOptionIDs = []string{}
+	}
+	if v.Filter == nil {
+		v.Filter = &ViewFilter{Operation: ViewFilterOperationAnd, Filters: []*ViewFilter{}}
+	}
+	if v.CardOrder == nil {
+		v.CardOrder = []string{}
+	}
+	if v.ColumnWidths == nil {
+		v.ColumnWidths = map[string]float64{}
+	}
+	if v.ColumnCalculations == nil {
+		v.ColumnCalculations = map[string]string{}
+	}
+	if v.KanbanCalculations == nil {
+		v.KanbanCalculations = map[string]ViewKanbanCalculation{}
+	}
+	now := utils.GetMillis()
+	if v.CreateAt == 0 {
+		v.CreateAt = now
+	}
+	if v.UpdateAt == 0 {
+		v.UpdateAt = now
+	}
+}
+
+// IsValid returns an error if the View has invalid field values.
+func (v *View) IsValid() error {
+	if v.ID == "" {
+		return NewErrBadRequest("invalid view, ID is missing")
+	}
+	if v.BoardID == "" {
+		return NewErrBadRequest("invalid view, BoardID is missing")
+	}
+
+	switch v.ViewType {
+	case ViewTypeBoard, ViewTypeTable, ViewTypeGallery, ViewTypeCalendar:
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid view, invalid view type %q", v.ViewType))
+	}
+
+	for _, option := range v.SortOptions {
+		if option.PropertyID == "" {
+			return NewErrBadRequest("invalid view, sort property ID is missing")
+		}
+	}
+
+	if v.Filter != nil {
+		if !v.Filter.IsGroup() {
+			return NewErrBadRequest("invalid view, the filter must be a group")
+		}
+		if err := v.Filter.isValid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Patch returns an updated version of the view.
+func (p *ViewPatch) Patch(view *View) *View {
+	if p.Title != nil {
+		view.Title = *p.Title
+	}
+	if p.ViewType != nil {
+		view.ViewType = *p.ViewType
+	}
+	if p.GroupByID != nil {
+		view.GroupByID = *p.GroupByID
+	}
+	if p.DateDisplayPropertyID != nil {
+		view.DateDisplayPropertyID = *p.DateDisplayPropertyID
+	}
+	if p.SortOptions != nil {
+		view.SortOptions = *p.SortOptions
+	}
+	if p.VisiblePropertyIDs != nil {
+		view.VisiblePropertyIDs = *p.VisiblePropertyIDs
+	}
+	if p.VisibleOptionIDs != nil {
+		view.VisibleOptionIDs = *p.VisibleOptionIDs
+	}
+	if p.HiddenOptionIDs != nil {
+		view.HiddenOptionIDs = *p.HiddenOptionIDs
+	}
+	if p.CollapsedOptionIDs != nil {
+		view.CollapsedOptionIDs = *p.CollapsedOptionIDs
+	}
+	if p.Filter != nil {
+		view.Filter = p.Filter
+	}
+	if p.CardOrder != nil {
+		view.CardOrder = *p.CardOrder
+	}
+	if p.ColumnWidths != nil {
+		view.ColumnWidths = *p.ColumnWidths
+	}
+	if p.ColumnCalculations != nil {
+		view.ColumnCalculations = *p.ColumnCalculations
+	}
+	if p.KanbanCalculations != nil {
+		view.KanbanCalculations = *p.KanbanCalculations
+	}
+	if p.DefaultTemplateID != nil {
+		view.DefaultTemplateID = *p.DefaultTemplateID
+	}
+
+	view.Populate()
+	return view
+}
+
+// CleanForBoard removes the references to properties and options that
+// are not part of the property schema of the board, and returns true if
+// the view changed.
+func (v *View) CleanForBoard(board *Board) (bool, error) {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return false, err
+	}
+
+	hasProperty := func(id string) bool {
+		if isBuiltinViewProperty(id) {
+			return true
+		}
+		_, ok := schema[id]
+		return ok
+	}
+
+	changed := false
+	if v.GroupByID != "" && !hasProperty(v.GroupByID) {
+		v.GroupByID = ""
+		changed = true
+	}
+	if v.DateDisplayPropertyID != "" && !hasProperty(v.DateDisplayPropertyID) {
+		v.DateDisplayPropertyID = ""
+		changed = true
+	}
+
+	sortOptions := []ViewSortOption{}
+	for _, option := range v.SortOptions {
+		if hasProperty(option.PropertyID) {
+			sortOptions = append(sortOptions, option)
+		}
+	}
+	if len(sortOptions) != len(v.SortOptions) {
+		v.SortOptions = sortOptions
+		changed = true
+	}
+
+	var propertiesChanged bool
+	v.VisiblePropertyIDs, propertiesChanged = filterIDs(v.VisiblePropertyIDs, hasProperty)
+	changed = changed || propertiesChanged
+
+	// option IDs refer to the options of the group by property, and the
+	// empty ID to the cards without a value
+	groupBy := schema[v.GroupByID]
+	hasOption := func(id string) bool {
+		if id == "" {
+			return true
+		}
+		_, ok := groupBy.Options[id]
+		return ok
+	}
+	for _, optionIDs := range []*[]string{&v.VisibleOptionIDs, &v.HiddenOptionIDs, &v.CollapsedOptionIDs} {
+		var optionsChanged bool
+		*optionIDs, optionsChanged = filterIDs(*optionIDs, hasOption)
+		changed = changed || optionsChanged
+	}
+
+	if v.Filter != nil && v.Filter.cleanForSchema(hasProperty) {
+		changed = true
+	}
+
+	for id := range v.ColumnWidths {
+		if !hasProperty(id) {
+			delete(v.ColumnWidths, id)
+			changed = true
+		}
+	}
+	for id := range v.ColumnCalculations {
+		if !hasProperty(id) {
+			delete(v.ColumnCalculations, id)
+			changed = true
+		}
+	}
+	for optionID, calculation := range v.KanbanCalculations {
+		if !hasOption(optionID) || !hasProperty(calculation.PropertyID) {
+			delete(v.KanbanCalculations, optionID)
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// cleanForSchema removes the filter clauses on missing properties from
+// a filter group, and returns true if the filter changed.
+func (f *ViewFilter) cleanForSchema(hasProperty func(string) bool) bool {
+	if !f.IsGroup() {
+		return false
+	}
+
+	changed := false
+	filters := make([]*ViewFilter, 0, len(f.Filters))
+	for _, filter := range f.Filters {
+		if filter == nil || (!filter.IsGroup() && !hasProperty(filter.PropertyID)) {
+			changed = true
+			continue
+		}
+		if filter.cleanForSchema(hasProperty) {
+			changed = true
+		}
+		filters = append(filters, filter)
+	}
+	f.Filters = filters
+	return changed
+}
+
+// mapProperties rewrites the property IDs of a filter and, for clauses
+// on option properties, its option values.
+func (f *ViewFilter) mapProperties(properties map[string]string, options map[string]string) {
+	if f.IsGroup() {
+		for _, filter := range f.Filters {
+			filter.mapProperties(properties, options)
+		}
+		return
+	}
+
+	if id, ok := properties[f.PropertyID]; ok {
+		f.PropertyID = id
+	}
+	f.Values = mapIDs(f.Values, options)
+}
+
+// MapViewToBoard rewrites the property and option IDs of a view from
+// the property schema of the source board to the property schema of the
+// destination board. Properties are matched by name and type, and
+// options by label. References that can't be mapped are removed, as
+// well as the card order and the default template, which belong to the
+// source board.
+func MapViewToBoard(view *View, from, to *Board) error {
+	fromSchema, err := ParsePropertySchema(from)
+	if err != nil {
+		return err
+	}
+
+	toSchema, err := ParsePropertySchema(to)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]string{}
+	options := map[string]string{}
+	for fromID, fromDef := range fromSchema {
+		toID := findMatchingProperty(fromDef, toSchema)
+		if toID == "" {
+			continue
+		}
+		properties[fromID] = toID
+
+		for optionID, option := range fromDef.Options {
+			for toOptionID, toOption := range toSchema[toID].Options {
+				if strings.EqualFold(option.Value, toOption.Value) {
+					options[optionID] = toOptionID
+					break
+				}
+			}
+		}
+	}
+
+	view.BoardID = to.ID
+	view.CardOrder = []string{}
+	view.DefaultTemplateID = ""
+
+	view.GroupByID = mapID(view.GroupByID, properties)
+	view.DateDisplayPropertyID = mapID(view.DateDisplayPropertyID, properties)
+	for i := range view.SortOptions {
+		view.SortOptions[i].PropertyID = mapID(view.SortOptions[i].PropertyID, properties)
+	}
+	view.VisiblePropertyIDs = mapIDs(view.VisiblePropertyIDs, properties)
+	view.VisibleOptionIDs = mapIDs(view.VisibleOptionIDs, options)
+	view.HiddenOptionIDs = mapIDs(view.HiddenOptionIDs, options)
+	view.CollapsedOptionIDs = mapIDs(view.CollapsedOptionIDs, options)
+	if view.Filter != nil {
+		view.Filter.mapProperties(properties, options)
+	}
+
+	columnWidths := map[string]float64{}
+	for id, width := range view.ColumnWidths {
+		columnWidths[mapID(id, properties)] = width
+	}
+	view.ColumnWidths = columnWidths
+
+	columnCalculations := map[string]string{}
+	for id, calculation := range view.ColumnCalculations {
+		columnCalculations[mapID(id, properties)] = calculation
+	}
+	view.ColumnCalculations = columnCalculations
+
+	kanbanCalculations := map[string]ViewKanbanCalculation{}
+	for optionID, calculation := range view.KanbanCalculations {
+		calculation.PropertyID = mapID(calculation.PropertyID, properties)
+		kanbanCalculations[mapID(optionID, options)] = calculation
+	}
+	view.KanbanCalculations = kanbanCalculations
+
+	_, err = view.CleanForBoard(to)
+	return err
+}
+
+func isBuiltinViewProperty(id string) bool {
+	return id == viewTitlePropertyID || strings.HasPrefix(id, "__")
+}
+
+func mapID(id string, mapping map[string]string) string {
+	if mapped, ok := mapping[id]; ok {
+		return mapped
+	}
+	return id
+}
+
+func mapIDs(ids []string, mapping map[string]string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, mapID(id, mapping))
+	}
+	return result
+}
+
+// filterIDs returns the IDs that pass the check, and true if any ID was
+// removed.
+func filterIDs(ids []string, keep func(string) bool) ([]string, bool) {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if keep(id) {
+			result = append(result, id)
+		}
+	}
+	return result, len(result) != len(ids)
+}
+
+// View2Block converts a view to a block.
+func View2Block(view *View) (*Block, error) {
+	data, err := json.Marshal(view.ViewFields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if view.OwnerID != "" {
+		fields[ViewFieldOwnerID] = view.OwnerID
+	}
+
+	return &Block{
+		ID:         view.ID,
+		ParentID:   view.BoardID,
+		CreatedBy:  view.CreatedBy,
+		ModifiedBy: view.ModifiedBy,
+		Schema:     1,
+		Type:       TypeView,
+		Title:      view.Title,
+		Fields:     fields,
+		CreateAt:   view.CreateAt,
+		UpdateAt:   view.UpdateAt,
+		DeleteAt:   view.DeleteAt,
+		BoardID:    view.BoardID,
+	}, nil
+}
+
+// Block2View converts a block to a view.
+func Block2View(block *Block) (*View, error) {
+	if block.Type != TypeView {
+		return nil, fmt.Errorf("cannot convert block to view: %w", ErrNotViewBlock)
+	}
+
+	view := &View{
+		ID:         block.ID,
+		BoardID:    block.BoardID,
+		CreatedBy:  block.CreatedBy,
+		ModifiedBy: block.ModifiedBy,
+		Title:      block.Title,
+		OwnerID:    GetViewOwnerID(block),
+		CreateAt:   block.CreateAt,
+		UpdateAt:   block.UpdateAt,
+		DeleteAt:   block.DeleteAt,
+	}
+
+	data, err := json.Marshal(block.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &view.ViewFields); err != nil {
+		return nil, fmt.Errorf("cannot convert block to view: %w", err)
+	}
+
+	view.Populate()
+	return view, nil
+}
+
+// View2BlockPatch converts the patched version of a view to the
+// patch of its block. The owner of the view and the fields of the block
+// not known to the view are left as they are.
+func View2BlockPatch(view *View) (*BlockPatch, error) {
+	block, err := View2Block(view)
+	if err != nil {
+		return nil, err
+	}
+	delete(block.Fields, ViewFieldOwnerID)
+
+	return &BlockPatch{
+		Title:         &block.Title,
+		UpdatedFields: block.Fields,
+	}, nil
+}
+
+func ViewFromJSON(data io.Reader) *View {
+	var view *View
+	_ = json.NewDecoder(data).Decode(&view)
+	return view
+}
+
+func ViewsFromJSON(data io.Reader) []*View {
+	var views []*View
+	_ = json.NewDecoder(data).Decode(&views)
+	return views
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ViewFieldOwnerID is the view field that holds the ID of the user that
+// owns a private view. Views without it are shared with everyone on the
+// board.
+const ViewFieldOwnerID = "ownerId"
+
+// ErrViewOwnerPatch is returned when a generic block patch tries to
+// change the owner of a view.
+var ErrViewOwnerPatch = NewErrBadRequest("private views can only be shared through the views API")
+
+// ErrPrivateViewKindPatch is returned when a patch tries to change the
+// type or the parent of a private view, which would make it visible to
+// everyone on the board.
+var ErrPrivateViewKindPatch = NewErrBadRequest("the type and parent of private views cannot be changed")
+
+// GetViewOwnerID returns the ID of the owner of a private view, or an
+// empty string for shared views and other blocks.
+func GetViewOwnerID(block *Block) string {
+	if block == nil || block.Type != TypeView {
+		return ""
+	}
+
+	ownerID, _ := block.Fields[ViewFieldOwnerID].(string)
+	return ownerID
+}
+
+// IsBlockVisibleTo returns false if the block is a private view that
+// belongs to another user.
+func IsBlockVisibleTo(block *Block, userID string) bool {
+	ownerID := GetViewOwnerID(block)
+	return ownerID == "" || ownerID == userID
+}
+
+// FilterPrivateViews returns the blocks without the private views that
+// belong to other users.
+func FilterPrivateViews(blocks []*Block, userID string) []*Block {
+	result := make([]*Block, 0, len(blocks))
+	for _, block := range blocks {
+		if IsBlockVisibleTo(block, userID) {
+			result = append(result, block)
+		}
+	}
+	return result
+}
+
+// ChangesViewOwner returns true if the patch updates or removes the
+// field that holds the owner of a private view.
+func (p *BlockPatch) ChangesViewOwner() bool {
+	return p.changesFields(ViewFieldOwnerID)
+}
+
+// ChangesPrivateViewKind returns true if the block is a private view and
+// the patch changes its type or its parent.
+func (p *BlockPatch) ChangesPrivateViewKind(block *Block) bool {
+	if p == nil || GetViewOwnerID(block) == "" {
+		return false
+	}
+	return p.Type != nil || p.ParentID != nil
+}
+
+// NewShareViewPatch returns the patch that turns a private view into a
+// shared one.
+func NewShareViewPatch() BlockPatch {
+	return BlockPatch{
+		DeletedFields: []string{ViewFieldOwnerID},
+	}
+}
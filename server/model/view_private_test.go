@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateViews(t *testing.T) {
+	shared := &Block{ID: "view-1", Type: TypeView, Fields: map[string]interface{}{"viewType": "board"}}
+	own := &Block{ID: "view-2", Type: TypeView, Fields: map[string]interface{}{"viewType": "board", ViewFieldOwnerID: "user-1"}}
+	others := &Block{ID: "view-3", Type: TypeView, Fields: map[string]interface{}{"viewType": "board", ViewFieldOwnerID: "user-2"}}
+	card := &Block{ID: "card-1", Type: TypeCard, Fields: map[string]interface{}{ViewFieldOwnerID: "user-2"}}
+
+	t.Run("owner", func(t *testing.T) {
+		require.Empty(t, GetViewOwnerID(nil))
+		require.Empty(t, GetViewOwnerID(shared))
+		require.Equal(t, "user-1", GetViewOwnerID(own))
+		require.Empty(t, GetViewOwnerID(card))
+	})
+
+	t.Run("visibility", func(t *testing.T) {
+		require.True(t, IsBlockVisibleTo(shared, "user-1"))
+		require.True(t, IsBlockVisibleTo(own, "user-1"))
+		require.False(t, IsBlockVisibleTo(others, "user-1"))
+		require.True(t, IsBlockVisibleTo(card, "user-1"))
+		require.False(t, IsBlockVisibleTo(own, ""))
+
+		filtered := FilterPrivateViews([]*Block{shared, own, others, card}, "user-1")
+		require.Equal(t, []*Block{shared, own, card}, filtered)
+	})
+
+	t.Run("patches", func(t *testing.T) {
+		var nilPatch *BlockPatch
+		require.False(t, nilPatch.ChangesViewOwner())
+		require.False(t, (&BlockPatch{UpdatedFields: map[string]interface{}{"viewType": "table"}}).ChangesViewOwner())
+		require.True(t, (&BlockPatch{UpdatedFields: map[string]interface{}{ViewFieldOwnerID: "user-2"}}).ChangesViewOwner())
+
+		share := NewShareViewPatch()
+		require.True(t, share.ChangesViewOwner())
+
+		cardType := BlockType(TypeCard)
+		parentID := "card-1"
+		title := "renamed"
+		require.False(t, nilPatch.ChangesPrivateViewKind(own))
+		require.False(t, (&BlockPatch{Title: &title}).ChangesPrivateViewKind(own))
+		require.True(t, (&BlockPatch{Type: &cardType}).ChangesPrivateViewKind(own))
+		require.True(t, (&BlockPatch{ParentID: &parentID}).ChangesPrivateViewKind(own))
+		require.False(t, (&BlockPatch{Type: &cardType}).ChangesPrivateViewKind(shared))
+		require.False(t, (&BlockPatch{ParentID: &parentID}).ChangesPrivateViewKind(card))
+	})
+
+	t.Run("view conversion", func(t *testing.T) {
+		view, err := Block2View(own)
+		require.NoError(t, err)
+		require.Equal(t, "user-1", view.OwnerID)
+
+		block, err := View2Block(view)
+		require.NoError(t, err)
+		require.Equal(t, "user-1", block.Fields[ViewFieldOwnerID])
+
+		patch, err := View2BlockPatch(view)
+		require.NoError(t, err)
+		require.False(t, patch.ChangesViewOwner())
+	})
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestViewBoard(id string, propertyIDs ...string) *Board {
+	board := &Board{ID: id, CardProperties: []map[string]interface{}{}}
+	for _, propertyID := range propertyIDs {
+		board.CardProperties = append(board.CardProperties, map[string]interface{}{
+			"id":   propertyID,
+			"name": "name " + propertyID,
+			"type": "select",
+			"options": []interface{}{
+				map[string]interface{}{"id": propertyID + "-option", "value": "option"},
+			},
+		})
+	}
+	return board
+}
+
+func TestBlock2View(t *testing.T) {
+	block := &Block{
+		ID:      "view-1",
+		BoardID: "board-1",
+		Type:    TypeView,
+		Title:   "my view",
+		Fields: map[string]interface{}{
+			"viewType":           "table",
+			"sortOptions":        []interface{}{map[string]interface{}{"propertyId": "prop-1", "reversed": true}},
+			"visiblePropertyIds": []interface{}{"prop-1"},
+			"columnWidths":       map[string]interface{}{"__title": float64(280)},
+			"filter": map[string]interface{}{
+				"operation": "or",
+				"filters": []interface{}{
+					map[string]interface{}{"propertyId": "prop-1", "condition": "includes", "values": []interface{}{"a"}},
+					map[string]interface{}{"operation": "and", "filters": []interface{}{}},
+				},
+			},
+		},
+	}
+
+	t.Run("not a view", func(t *testing.T) {
+		view, err := Block2View(&Block{ID: "card-1", Type: TypeCard})
+		require.ErrorIs(t, err, ErrNotViewBlock)
+		require.Nil(t, view)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		view, err := Block2View(block)
+		require.NoError(t, err)
+		require.Equal(t, "my view", view.Title)
+		require.Equal(t, ViewTypeTable, view.ViewType)
+		require.Equal(t, []ViewSortOption{{PropertyID: "prop-1", Reversed: true}}, view.SortOptions)
+		require.Equal(t, float64(280), view.ColumnWidths["__title"])
+		require.Equal(t, []string{}, view.CardOrder)
+		require.True(t, view.Filter.IsGroup())
+		require.Len(t, view.Filter.Filters, 2)
+		require.False(t, view.Filter.Filters[0].IsGroup())
+		require.True(t, view.Filter.Filters[1].IsGroup())
+		require.NoError(t, view.IsValid())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		view, err := Block2View(block)
+		require.NoError(t, err)
+
+		newBlock, err := View2Block(view)
+		require.NoError(t, err)
+		require.Equal(t, block.ID, newBlock.ID)
+		require.Equal(t, block.BoardID, newBlock.ParentID)
+
+		filter := newBlock.Fields["filter"].(map[string]interface{})
+		filters := filter["filters"].([]interface{})
+		require.Equal(t, map[string]interface{}{"propertyId": "prop-1", "condition": "includes", "values": []interface{}{"a"}}, filters[0])
+		require.Equal(t, map[string]interface{}{"operation": "and", "filters": []interface{}{}}, filters[1])
+	})
+}
+
+func TestViewIsValid(t *testing.T) {
+	newView := func() *View {
+		view := &View{ID: "view-1", BoardID: "board-1"}
+		view.Populate()
+		return view
+	}
+
+	require.NoError(t, newView().IsValid())
+
+	view := newView()
+	view.ViewType = "list"
+	require.True(t, IsErrBadRequest(view.IsValid()))
+
+	view = newView()
+	view.SortOptions = []ViewSortOption{{}}
+	require.True(t, IsErrBadRequest(view.IsValid()))
+
+	view = newView()
+	view.Filter = &ViewFilter{PropertyID: "prop-1", Condition: "is"}
+	require.True(t, IsErrBadRequest(view.IsValid()))
+
+	view = newView()
+	view.Filter.Filters = []*ViewFilter{{PropertyID: "prop-1", Condition: "matches"}}
+	require.True(t, IsErrBadRequest(view.IsValid()))
+
+	view = newView()
+	view.Filter.Operation = "xor"
+	require.True(t, IsErrBadRequest(view.IsValid()))
+}
+
+func TestViewCleanForBoard(t *testing.T) {
+	board := newTestViewBoard("board-1", "prop-1")
+
+	t.Run("nothing to clean", func(t *testing.T) {
+		view := &View{BoardID: "board-1", ViewFields: ViewFields{
+			GroupByID:          "prop-1",
+			VisiblePropertyIDs: []string{"__title", "prop-1"},
+			VisibleOptionIDs:   []string{"", "prop-1-option"},
+		}}
+		view.Populate()
+
+		changed, err := view.CleanForBoard(board)
+		require.NoError(t, err)
+		require.False(t, changed)
+	})
+
+	t.Run("deleted properties", func(t *testing.T) {
+		view := &View{BoardID: "board-1", ViewFields: ViewFields{
+			GroupByID:          "prop-2",
+			SortOptions:        []ViewSortOption{{PropertyID: "prop-2"}, {PropertyID: "prop-1"}},
+			VisiblePropertyIDs: []string{"prop-1", "prop-2"},
+			VisibleOptionIDs:   []string{"prop-2-option"},
+			Filter: &ViewFilter{Operation: "and", Filters: []*ViewFilter{
+				{PropertyID: "prop-2", Condition: "includes"},
+				{PropertyID: "title", Condition: "contains"},
+				{Operation: "or", Filters: []*ViewFilter{{PropertyID: "prop-2", Condition: "isSet"}}},
+			}},
+			ColumnWidths:       map[string]float64{"__title": 100, "prop-2": 50},
+			ColumnCalculations: map[string]string{"prop-2": "count"},
+		}}
+		view.Populate()
+
+		changed, err := view.CleanForBoard(board)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Empty(t, view.GroupByID)
+		require.Equal(t, []ViewSortOption{{PropertyID: "prop-1"}}, view.SortOptions)
+		require.Equal(t, []string{"prop-1"}, view.VisiblePropertyIDs)
+		require.Empty(t, view.VisibleOptionIDs)
+		require.Len(t, view.Filter.Filters, 2)
+		require.Equal(t, "title", view.Filter.Filters[0].PropertyID)
+		require.Empty(t, view.Filter.Filters[1].Filters)
+		require.Equal(t, map[string]float64{"__title": 100}, view.ColumnWidths)
+		require.Empty(t, view.ColumnCalculations)
+	})
+}
+
+func TestMapViewToBoard(t *testing.T) {
+	from := newTestViewBoard("board-1", "prop-1", "prop-2")
+	to := newTestViewBoard("board-2", "other-prop")
+	to.CardProperties[0]["name"] = "name prop-1"
+
+	view := &View{ID: "view-1", BoardID: "board-1", ViewFields: ViewFields{
+		GroupByID:          "prop-1",
+		VisiblePropertyIDs: []string{"__title", "prop-1", "prop-2"},
+		VisibleOptionIDs:   []string{"prop-1-option"},
+		CardOrder:          []string{"card-1"},
+		DefaultTemplateID:  "template-1",
+		Filter: &ViewFilter{Operation: "and", Filters: []*ViewFilter{
+			{PropertyID: "prop-1", Condition: "includes", Values: []string{"prop-1-option"}},
+		}},
+	}}
+	view.Populate()
+
+	require.NoError(t, MapViewToBoard(view, from, to))
+	require.Equal(t, "board-2", view.BoardID)
+	require.Equal(t, "other-prop", view.GroupByID)
+	require.Equal(t, []string{"__title", "other-prop"}, view.VisiblePropertyIDs)
+	require.Equal(t, []string{"other-prop-option"}, view.VisibleOptionIDs)
+	require.Empty(t, view.CardOrder)
+	require.Empty(t, view.DefaultTemplateID)
+	require.Equal(t, &ViewFilter{PropertyID: "other-prop", Condition: "includes", Values: []string{"other-prop-option"}}, view.Filter.Filters[0])
+}
+
+func TestViewFilterJSON(t *testing.T) {
+	data, err := json.Marshal(&ViewFilter{Operation: "and"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"operation": "and", "filters": []}`, string(data))
+
+	data, err = json.Marshal(&ViewFilter{PropertyID: "prop-1", Condition: "isSet"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"propertyId": "prop-1", "condition": "isSet", "values": []}`, string(data))
+}
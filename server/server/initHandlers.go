@@ -1,6 +1,6 @@
 package server
 
 func (s *Server) initHandlers() {
-	cfg := s.config
+	cfg := s.Config()
 	s.api.MattermostAuth = cfg.AuthMode == MattermostAuthMod
 }
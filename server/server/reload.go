@@ -0,0 +1,140 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/metrics"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// liveSettings are the configuration settings that ReloadConfig applies
+// while the server runs. Changes to the other settings need a restart.
+var liveSettings = map[string]bool{
+	"LoggingCfgFile":           true,
+	"LoggingCfgJSON":           true,
+	"AuditCfgFile":             true,
+	"AuditCfgJSON":             true,
+	"NotifyFreqCardSeconds":    true,
+	"NotifyFreqBoardSeconds":   true,
+	"FeatureFlags":             true,
+	"EnableDataRetention":      true,
+	"DataRetentionDays":        true,
+	"PrometheusAddress":        true,
+	"WebhookUpdate":            true,
+	"EnablePublicSharedBoards": true,
+	"TeammateNameDisplay":      true,
+	"ShowEmailAddress":         true,
+	"ShowFullName":             true,
+	"MaxFileSize":              true,
+	"ShutdownTimeoutSeconds":   true,
+}
+
+// ReloadConfig validates a new configuration and applies the settings
+// that can change while the server runs. The current configuration is
+// left untouched, as handlers read it concurrently: a copy with the
+// changed settings replaces it. It returns the names of the changed
+// settings that need a restart, which keep their current values.
+func (s *Server) ReloadConfig(newCfg *config.Configuration) ([]string, error) {
+	if err := newCfg.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	cfg := *s.Config()
+	oldClientConfig := *s.app.GetClientConfig()
+
+	if newCfg.LoggingCfgFile != cfg.LoggingCfgFile || newCfg.LoggingCfgJSON != cfg.LoggingCfgJSON {
+		logger, ok := s.logger.(*mlog.Logger)
+		if !ok {
+			return nil, fmt.Errorf("the logger cannot be configured")
+		}
+		if err := logger.Configure(newCfg.LoggingCfgFile, newCfg.LoggingCfgJSON, nil); err != nil {
+			return nil, fmt.Errorf("cannot configure the logger: %w", err)
+		}
+		cfg.LoggingCfgFile = newCfg.LoggingCfgFile
+		cfg.LoggingCfgJSON = newCfg.LoggingCfgJSON
+	}
+
+	if newCfg.AuditCfgFile != cfg.AuditCfgFile || newCfg.AuditCfgJSON != cfg.AuditCfgJSON {
+		if err := s.auditService.Configure(newCfg.AuditCfgFile, newCfg.AuditCfgJSON); err != nil {
+			return nil, fmt.Errorf("cannot configure the audit service: %w", err)
+		}
+		cfg.AuditCfgFile = newCfg.AuditCfgFile
+		cfg.AuditCfgJSON = newCfg.AuditCfgJSON
+	}
+
+	if newCfg.NotifyFreqCardSeconds != cfg.NotifyFreqCardSeconds || newCfg.NotifyFreqBoardSeconds != cfg.NotifyFreqBoardSeconds {
+		s.notificationService.SetNotifyFrequencies(newCfg.NotifyFreqCardSeconds, newCfg.NotifyFreqBoardSeconds)
+		cfg.NotifyFreqCardSeconds = newCfg.NotifyFreqCardSeconds
+		cfg.NotifyFreqBoardSeconds = newCfg.NotifyFreqBoardSeconds
+	}
+
+	if newCfg.PrometheusAddress != cfg.PrometheusAddress {
+		s.servicesStartStopMutex.Lock()
+		if cfg.PrometheusAddress != "" {
+			if err := s.metricsServer.Shutdown(); err != nil {
+				s.logger.Warn("Error occurred when shutting down the metrics server", mlog.Err(err))
+			}
+		}
+		cfg.PrometheusAddress = newCfg.PrometheusAddress
+		s.metricsServer = metrics.NewMetricsServer(cfg.PrometheusAddress, s.metricsService, s.logger)
+		s.startMetricsServer(cfg.PrometheusAddress)
+		s.servicesStartStopMutex.Unlock()
+	}
+
+	cfg.FeatureFlags = newCfg.FeatureFlags
+	cfg.EnableDataRetention = newCfg.EnableDataRetention
+	cfg.DataRetentionDays = newCfg.DataRetentionDays
+	cfg.WebhookUpdate = newCfg.WebhookUpdate
+	cfg.EnablePublicSharedBoards = newCfg.EnablePublicSharedBoards
+	cfg.TeammateNameDisplay = newCfg.TeammateNameDisplay
+	cfg.ShowEmailAddress = newCfg.ShowEmailAddress
+	cfg.ShowFullName = newCfg.ShowFullName
+	cfg.MaxFileSize = newCfg.MaxFileSize
+	cfg.ShutdownTimeoutSeconds = newCfg.ShutdownTimeoutSeconds
+
+	s.configMux.Lock()
+	s.config = &cfg
+	s.configMux.Unlock()
+
+	s.UpdateAppConfig()
+	if clientConfig := *s.app.GetClientConfig(); !reflect.DeepEqual(clientConfig, oldClientConfig) {
+		s.wsAdapter.BroadcastConfigChange(clientConfig)
+	}
+
+	restartRequired := restartRequiredSettings(&cfg, newCfg)
+	if len(restartRequired) > 0 {
+		s.logger.Warn("Some changed settings require a restart",
+			mlog.String("settings", strings.Join(restartRequired, ", ")),
+		)
+	}
+	s.logger.Info("Reloaded the configuration")
+
+	return restartRequired, nil
+}
+
+// restartRequiredSettings returns the JSON names of the settings that
+// differ and that cannot be applied while the server runs.
+func restartRequiredSettings(cfg, newCfg *config.Configuration) []string {
+	var names []string
+
+	current := reflect.ValueOf(cfg).Elem()
+	changed := reflect.ValueOf(newCfg).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		if liveSettings[field.Name] {
+			continue
+		}
+		if !reflect.DeepEqual(current.Field(i).Interface(), changed.Field(i).Interface()) {
+			names = append(names, strings.Split(field.Tag.Get("json"), ",")[0])
+		}
+	}
+
+	return names
+}
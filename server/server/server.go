@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -20,6 +21,8 @@ import (
 	appModel "github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/jobs"
+	"github.com/mattermost/focalboard/server/services/lease"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
@@ -31,22 +34,28 @@ import (
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/mattermost/focalboard/server/web"
 	"github.com/mattermost/focalboard/server/ws"
-	"github.com/oklog/run"
 
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency           = 10 * time.Minute
+	updateMetricsTaskFrequency            = 15 * time.Minute
+	updateCardLimitTimestampTaskFrequency = 15 * time.Minute
+	unfreezeBoardsTaskFrequency           = 1 * time.Minute
+	cardRemindersTaskFrequency            = 1 * time.Minute
+	dataRetentionTaskFrequency            = 24 * time.Hour
 
-	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
+	// schedulerLeaseName is the lease held by the server that schedules
+	// the recurring jobs, when several servers share the database.
+	schedulerLeaseName = "scheduler"
 
 	MattermostAuthMod = "mattermost"
 )
 
 type Server struct {
+	configMux              sync.RWMutex
 	config                 *config.Configuration
 	wsAdapter              ws.Adapter
 	webServer              *web.Server
@@ -54,13 +63,15 @@ type Server struct {
 	filesBackend           filestore.FileBackend
 	telemetry              *telemetry.Service
 	logger                 mlog.LoggerIFace
-	cleanUpSessionsTask    *scheduler.ScheduledTask
+	jobsService            *jobs.Service
+	schedulerLease         *lease.Lease
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
 	servicesStartStopMutex sync.Mutex
+	reloadMutex            sync.Mutex
 
 	localRouter     *mux.Router
 	localModeServer *http.Server
@@ -178,7 +189,6 @@ func New(params Params) (*Server, error) {
 	}
 	telemetryOpts := telemetryOptions{
 		app:         app,
-		cfg:         params.Cfg,
 		telemetryID: telemetryID,
 		serverID:    params.ServerID,
 		logger:      params.Logger,
@@ -186,6 +196,23 @@ func New(params Params) (*Server, error) {
 	}
 	telemetryService := initTelemetry(telemetryOpts)
 
+	// the node ID identifies this server among the ones sharing the
+	// database
+	nodeID := utils.NewID(utils.IDTypeNone)
+	schedulerLease := lease.New(lease.Params{
+		Store:    params.DBStore,
+		Logger:   params.Logger,
+		Name:     schedulerLeaseName,
+		HolderID: nodeID,
+	})
+	jobsService := jobs.New(jobs.Params{
+		Store:    params.DBStore,
+		Logger:   params.Logger,
+		WorkerID: nodeID,
+		Workers:  params.Cfg.JobWorkers,
+		Leader:   schedulerLease,
+	})
+
 	server := Server{
 		config:              params.Cfg,
 		wsAdapter:           wsAdapter,
@@ -197,6 +224,8 @@ func New(params Params) (*Server, error) {
 		metricsService:      metricsService,
 		auditService:        auditService,
 		notificationService: notificationService,
+		jobsService:         jobsService,
+		schedulerLease:      schedulerLease,
 		logger:              params.Logger,
 		localRouter:         localRouter,
 		api:                 focalboardAPI,
@@ -247,114 +276,157 @@ func (s *Server) Start() error {
 	s.servicesStartStopMutex.Lock()
 	defer s.servicesStartStopMutex.Unlock()
 
-	if s.config.EnableLocalMode {
+	if s.Config().EnableLocalMode {
 		if err := s.startLocalModeServer(); err != nil {
 			return err
 		}
 	}
 
-	if s.config.AuthMode != MattermostAuthMod {
-		s.cleanUpSessionsTask = scheduler.CreateRecurringTask("cleanUpSessions", func() {
-			secondsAgo := minSessionExpiryTime
-			if secondsAgo < s.config.SessionExpireTime {
-				secondsAgo = s.config.SessionExpireTime
-			}
-
-			if err := s.store.CleanUpSessions(secondsAgo); err != nil {
-				s.logger.Error("Unable to clean up the sessions", mlog.Err(err))
-			}
-		}, cleanupSessionTaskFrequency)
+	if s.Config().AuthMode != MattermostAuthMod {
+		s.jobsService.RegisterRecurring(appModel.JobTypeCleanUpSessions, cleanupSessionTaskFrequency, jobs.Func(s.app.CleanUpSessions))
+		s.jobsService.RegisterRecurring(appModel.JobTypeDataRetention, dataRetentionTaskFrequency, jobs.Func(s.app.RunScheduledDataRetention))
 	}
+	s.jobsService.RegisterRecurring(appModel.JobTypeUpdateCardLimitTimestamp, updateCardLimitTimestampTaskFrequency, jobs.Func(s.app.UpdateCardLimitTimestamp))
+	s.jobsService.RegisterRecurring(appModel.JobTypeUnfreezeBoards, unfreezeBoardsTaskFrequency, jobs.Func(s.app.UnfreezeExpiredBoards))
+	s.jobsService.RegisterRecurring(appModel.JobTypeSendCardReminders, cardRemindersTaskFrequency, jobs.Func(s.app.SendDueCardReminders))
+	s.schedulerLease.Start()
+	s.jobsService.Start()
 
-	metricsUpdater := func() {
-		blockCounts, err := s.store.GetBlockCountsByType()
-		if err != nil {
-			s.logger.Error("Error updating metrics", mlog.String("group", "blocks"), mlog.Err(err))
-			return
-		}
-		s.logger.Log(mlog.LvlFBMetrics, "Block metrics collected", mlog.Map("block_counts", blockCounts))
-		for blockType, count := range blockCounts {
-			s.metricsService.ObserveBlockCount(blockType, count)
+	// the metrics are observed by each server, so they are updated by
+	// every node instead of by a job
+	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", func() {
+		if err := s.updateMetrics(); err != nil {
+			s.logger.Error("Error updating metrics", mlog.Err(err))
 		}
-		boardCount, err := s.store.GetBoardCount()
-		if err != nil {
-			s.logger.Error("Error updating metrics", mlog.String("group", "boards"), mlog.Err(err))
-			return
-		}
-		s.logger.Log(mlog.LvlFBMetrics, "Board metrics collected", mlog.Int64("board_count", boardCount))
-		s.metricsService.ObserveBoardCount(boardCount)
-		teamCount, err := s.store.GetTeamCount()
-		if err != nil {
-			s.logger.Error("Error updating metrics", mlog.String("group", "teams"), mlog.Err(err))
-			return
-		}
-		s.logger.Log(mlog.LvlFBMetrics, "Team metrics collected", mlog.Int64("team_count", teamCount))
-		s.metricsService.ObserveTeamCount(teamCount)
-	}
-	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
-	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
+	}, updateMetricsTaskFrequency)
+
+	s.app.SetScheduledTasks([]*scheduler.ScheduledTask{s.jobsService.PollTask(), s.metricsUpdaterTask})
 
-	if s.config.Telemetry {
+	if s.Config().Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
 	}
 
-	var group run.Group
-	if s.config.PrometheusAddress != "" {
-		group.Add(func() error {
-			if err := s.metricsServer.Run(); err != nil {
-				return errors.Wrap(err, "PromServer Run")
-			}
-			return nil
-		}, func(error) {
-			_ = s.metricsServer.Shutdown()
-		})
+	s.startMetricsServer(s.Config().PrometheusAddress)
+	return nil
+}
 
-		if err := group.Run(); err != nil {
-			return err
-		}
+func (s *Server) updateMetrics() error {
+	blockCounts, err := s.store.GetBlockCountsByType()
+	if err != nil {
+		return fmt.Errorf("cannot count the blocks: %w", err)
+	}
+	s.logger.Log(mlog.LvlFBMetrics, "Block metrics collected", mlog.Map("block_counts", blockCounts))
+	for blockType, count := range blockCounts {
+		s.metricsService.ObserveBlockCount(blockType, count)
+	}
+	boardCount, err := s.store.GetBoardCount()
+	if err != nil {
+		return fmt.Errorf("cannot count the boards: %w", err)
+	}
+	s.logger.Log(mlog.LvlFBMetrics, "Board metrics collected", mlog.Int64("board_count", boardCount))
+	s.metricsService.ObserveBoardCount(boardCount)
+	teamCount, err := s.store.GetTeamCount()
+	if err != nil {
+		return fmt.Errorf("cannot count the teams: %w", err)
 	}
+	s.logger.Log(mlog.LvlFBMetrics, "Team metrics collected", mlog.Int64("team_count", teamCount))
+	s.metricsService.ObserveTeamCount(teamCount)
 	return nil
 }
 
+// startMetricsServer runs the prometheus server in the background, if an
+// address is configured.
+func (s *Server) startMetricsServer(address string) {
+	if address == "" {
+		return
+	}
+
+	metricsServer := s.metricsServer
+	s.logger.Info("Starting metrics server", mlog.String("address", address))
+	go func() {
+		if err := metricsServer.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Error running the metrics server", mlog.Err(err))
+		}
+	}()
+}
+
+// Shutdown stops the server in stages: it stops accepting connections
+// and waits for the requests in flight, tells the websocket clients that
+// the server is going away, drains the queued notifications, flushes the
+// audit records and telemetry, and closes the store. The draining stages
+// share the timeout set by ShutdownTimeoutSeconds.
 func (s *Server) Shutdown() error {
-	if err := s.webServer.Shutdown(); err != nil {
-		return err
+	timeout := time.Duration(s.Config().ShutdownTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = config.DefaultShutdownTimeoutSeconds * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	s.logger.Info("Server.Shutdown started", mlog.Duration("timeout", timeout))
+
+	if err := s.webServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("Requests in flight were cut off on shutdown", mlog.Err(err))
+	} else {
+		s.logger.Debug("Requests in flight drained")
 	}
 
-	s.stopLocalModeServer()
+	s.stopLocalModeServer(ctx)
 
 	s.servicesStartStopMutex.Lock()
 	defer s.servicesStartStopMutex.Unlock()
 
-	if s.cleanUpSessionsTask != nil {
-		s.cleanUpSessionsTask.Cancel()
+	if s.jobsService.Shutdown(ctx) {
+		s.logger.Debug("Running jobs finished")
+	} else {
+		s.logger.Warn("Running jobs were interrupted on shutdown, they will be run again")
 	}
+	s.schedulerLease.Stop()
 
 	if s.metricsUpdaterTask != nil {
 		s.metricsUpdaterTask.Cancel()
 	}
 
-	if err := s.telemetry.Shutdown(); err != nil {
-		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
+	if closer, ok := s.wsAdapter.(ws.ConnectionCloser); ok {
+		count := closer.CloseConnections()
+		s.logger.Debug("Closed the websocket connections", mlog.Int("count", count))
 	}
 
-	if err := s.auditService.Shutdown(); err != nil {
-		s.logger.Warn("Error occurred when shutting down audit service", mlog.Err(err))
+	s.app.Shutdown(ctx)
+
+	if err := s.notificationService.Drain(ctx); err != nil {
+		s.logger.Warn("Pending notifications were not all sent on shutdown", mlog.Err(err))
 	}
 
 	if err := s.notificationService.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down notification service", mlog.Err(err))
 	}
 
-	s.app.Shutdown()
+	if s.Config().PrometheusAddress != "" {
+		if err := s.metricsServer.Shutdown(); err != nil {
+			s.logger.Warn("Error occurred when shutting down the metrics server", mlog.Err(err))
+		}
+	}
+
+	if err := s.telemetry.Shutdown(); err != nil {
+		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
+	}
+
+	if err := s.auditService.Shutdown(); err != nil {
+		s.logger.Warn("Error occurred when shutting down audit service", mlog.Err(err))
+	}
 
 	defer s.logger.Info("Server.Shutdown")
 
 	return s.store.Shutdown()
 }
 
+// Config returns the current configuration. ReloadConfig replaces it
+// with a new one instead of modifying it.
 func (s *Server) Config() *config.Configuration {
+	s.configMux.RLock()
+	defer s.configMux.RUnlock()
 	return s.config
 }
 
@@ -371,7 +443,7 @@ func (s *Server) Store() store.Store {
 }
 
 func (s *Server) UpdateAppConfig() {
-	s.app.SetConfig(s.config)
+	s.app.SetConfig(s.Config())
 }
 
 // Local server
@@ -384,13 +456,13 @@ func (s *Server) startLocalModeServer() error {
 
 	// TODO: Close and delete socket file on shutdown
 	// Delete existing socket if it exists
-	if _, err := os.Stat(s.config.LocalModeSocketLocation); err == nil {
-		if err := syscall.Unlink(s.config.LocalModeSocketLocation); err != nil {
+	if _, err := os.Stat(s.Config().LocalModeSocketLocation); err == nil {
+		if err := syscall.Unlink(s.Config().LocalModeSocketLocation); err != nil {
 			s.logger.Error("Unable to unlink socket.", mlog.Err(err))
 		}
 	}
 
-	socket := s.config.LocalModeSocketLocation
+	socket := s.Config().LocalModeSocketLocation
 	unixListener, err := net.Listen("unix", socket)
 	if err != nil {
 		return err
@@ -410,9 +482,11 @@ func (s *Server) startLocalModeServer() error {
 	return nil
 }
 
-func (s *Server) stopLocalModeServer() {
+func (s *Server) stopLocalModeServer(ctx context.Context) {
 	if s.localModeServer != nil {
-		_ = s.localModeServer.Close()
+		if err := s.localModeServer.Shutdown(ctx); err != nil {
+			_ = s.localModeServer.Close()
+		}
 		s.localModeServer = nil
 	}
 }
@@ -423,7 +497,6 @@ func (s *Server) GetRootRouter() *mux.Router {
 
 type telemetryOptions struct {
 	app         *app.App
-	cfg         *config.Configuration
 	telemetryID string
 	serverID    string
 	logger      mlog.LoggerIFace
@@ -444,13 +517,14 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 		}, nil
 	})
 	telemetryService.RegisterTracker("config", func() (telemetry.Tracker, error) {
+		cfg := opts.app.GetConfig()
 		return map[string]interface{}{
-			"serverRoot":                 opts.cfg.ServerRoot == config.DefaultServerRoot,
-			"port":                       opts.cfg.Port == config.DefaultPort,
-			"useSSL":                     opts.cfg.UseSSL,
-			"dbType":                     opts.cfg.DBType,
+			"serverRoot":                 cfg.ServerRoot == config.DefaultServerRoot,
+			"port":                       cfg.Port == config.DefaultPort,
+			"useSSL":                     cfg.UseSSL,
+			"dbType":                     cfg.DBType,
 			"single_user":                opts.singleUser,
-			"allow_public_shared_boards": opts.cfg.EnablePublicSharedBoards,
+			"allow_public_shared_boards": cfg.EnablePublicSharedBoards,
 		}, nil
 	})
 	telemetryService.RegisterTracker("activity", func() (telemetry.Tracker, error) {
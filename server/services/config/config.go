@@ -1,14 +1,25 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"net/url"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 const (
-	DefaultServerRoot = "http://localhost:8000"
-	DefaultPort       = 8000
+	DefaultServerRoot             = "http://localhost:8000"
+	DefaultPort                   = 8000
+	DefaultShutdownTimeoutSeconds = 30
+	DefaultJobWorkers             = 2
+)
+
+var (
+	validDBTypes      = []string{"sqlite3", "postgres", "mysql"}
+	validAuthModes    = []string{"native", "mattermost"}
+	validFilesDrivers = []string{"local", "amazons3"}
 )
 
 type AmazonS3Config struct {
@@ -67,6 +78,9 @@ type Configuration struct {
 
 	NotifyFreqCardSeconds  int `json:"notify_freq_card_seconds" mapstructure:"notify_freq_card_seconds"`
 	NotifyFreqBoardSeconds int `json:"notify_freq_board_seconds" mapstructure:"notify_freq_board_seconds"`
+
+	ShutdownTimeoutSeconds int `json:"shutdownTimeoutSeconds" mapstructure:"shutdownTimeoutSeconds"`
+	JobWorkers             int `json:"jobWorkers" mapstructure:"jobWorkers"`
 }
 
 // ReadConfigFile read the configuration from the filesystem.
@@ -107,6 +121,8 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("TeammateNameDisplay", "username")
 	viper.SetDefault("ShowEmailAddress", false)
 	viper.SetDefault("ShowFullName", false)
+	viper.SetDefault("ShutdownTimeoutSeconds", DefaultShutdownTimeoutSeconds)
+	viper.SetDefault("JobWorkers", DefaultJobWorkers)
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -126,6 +142,87 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	return &configuration, nil
 }
 
+// InvalidConfigError lists the problems found validating a configuration.
+type InvalidConfigError struct {
+	Problems []string
+}
+
+func (e *InvalidConfigError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// IsValid checks the configuration for values the server cannot start
+// or run with, and returns an *InvalidConfigError listing all of them.
+func (c *Configuration) IsValid() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if u, err := url.Parse(c.ServerRoot); err != nil || u.Scheme == "" || u.Host == "" {
+		addProblem("serverRoot %q is not an absolute URL", c.ServerRoot)
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		addProblem("port %d is out of range", c.Port)
+	}
+	if !contains(validDBTypes, c.DBType) {
+		addProblem("dbtype %q is not one of %s", c.DBType, strings.Join(validDBTypes, ", "))
+	}
+	if c.DBConfigString == "" {
+		addProblem("dbconfig is required")
+	}
+	if !contains(validAuthModes, c.AuthMode) {
+		addProblem("authMode %q is not one of %s", c.AuthMode, strings.Join(validAuthModes, ", "))
+	}
+	if !contains(validFilesDrivers, c.FilesDriver) {
+		addProblem("filesdriver %q is not one of %s", c.FilesDriver, strings.Join(validFilesDrivers, ", "))
+	}
+	if c.FilesDriver == "local" && c.FilesPath == "" {
+		addProblem("filespath is required with the local files driver")
+	}
+	if c.FilesDriver == "amazons3" && c.FilesS3Config.Bucket == "" {
+		addProblem("filess3config.bucket is required with the amazons3 files driver")
+	}
+	if c.MaxFileSize < 0 {
+		addProblem("maxfilesize cannot be negative")
+	}
+	if c.SessionExpireTime <= 0 {
+		addProblem("session_expire_time must be positive")
+	}
+	if c.SessionRefreshTime < 0 {
+		addProblem("session_refresh_time cannot be negative")
+	}
+	if c.EnableLocalMode && c.LocalModeSocketLocation == "" {
+		addProblem("localModeSocketLocation is required when local mode is enabled")
+	}
+	if c.EnableDataRetention && c.DataRetentionDays <= 0 {
+		addProblem("data_retention_days must be positive when data retention is enabled")
+	}
+	if c.NotifyFreqCardSeconds < 0 || c.NotifyFreqBoardSeconds < 0 {
+		addProblem("notification frequencies cannot be negative")
+	}
+	if c.ShutdownTimeoutSeconds < 0 {
+		addProblem("shutdownTimeoutSeconds cannot be negative")
+	}
+	if c.JobWorkers < 0 {
+		addProblem("jobWorkers cannot be negative")
+	}
+
+	if len(problems) > 0 {
+		return &InvalidConfigError{Problems: problems}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
 	return clean
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Configuration {
+	return &Configuration{
+		ServerRoot:         DefaultServerRoot,
+		Port:               DefaultPort,
+		DBType:             "sqlite3",
+		DBConfigString:     "./focalboard.db",
+		FilesDriver:        "local",
+		FilesPath:          "./files",
+		SessionExpireTime:  60 * 60 * 24 * 30,
+		SessionRefreshTime: 60 * 60 * 5,
+		AuthMode:           "native",
+	}
+}
+
+func TestConfigurationIsValid(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, validConfig().IsValid())
+	})
+
+	t.Run("lists all the problems", func(t *testing.T) {
+		config := validConfig()
+		config.ServerRoot = "localhost"
+		config.DBType = "oracle"
+		config.FilesDriver = "amazons3"
+		config.EnableLocalMode = true
+		config.LocalModeSocketLocation = ""
+
+		err := config.IsValid()
+		var invalidErr *InvalidConfigError
+		require.True(t, errors.As(err, &invalidErr))
+		require.Len(t, invalidErr.Problems, 4)
+		require.Contains(t, invalidErr.Problems[0], "serverRoot")
+		require.Contains(t, invalidErr.Problems[1], "dbtype")
+		require.Contains(t, invalidErr.Problems[2], "bucket")
+		require.Contains(t, invalidErr.Problems[3], "localModeSocketLocation")
+	})
+
+	t.Run("data retention", func(t *testing.T) {
+		config := validConfig()
+		config.DataRetentionDays = 0
+		require.NoError(t, config.IsValid())
+
+		config.EnableDataRetention = true
+		require.Error(t, config.IsValid())
+	})
+
+	t.Run("shutdown timeout", func(t *testing.T) {
+		config := validConfig()
+		config.ShutdownTimeoutSeconds = 0
+		require.NoError(t, config.IsValid())
+
+		config.ShutdownTimeoutSeconds = -1
+		require.ErrorContains(t, config.IsValid(), "shutdownTimeoutSeconds")
+	})
+
+	t.Run("job workers", func(t *testing.T) {
+		config := validConfig()
+		config.JobWorkers = 0
+		require.NoError(t, config.IsValid())
+
+		config.JobWorkers = -1
+		require.ErrorContains(t, config.IsValid(), "jobWorkers")
+	})
+}
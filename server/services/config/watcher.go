@@ -0,0 +1,110 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDelay is the time without changes to the configuration file
+// after which a change is reported, as editors often write it in several
+// steps.
+const watchDelay = 500 * time.Millisecond
+
+// Watcher reports the changes to a configuration file.
+type Watcher struct {
+	path    string
+	watcher *fsnotify.Watcher
+	changes chan struct{}
+	errors  chan error
+	done    chan struct{}
+}
+
+// NewWatcher watches the configuration file at path, or the default one
+// of ReadConfigFile if path is empty. The directory of the file is
+// watched, so that files replaced by editors are still watched.
+func NewWatcher(path string) (*Watcher, error) {
+	if path == "" {
+		path = "./config.json"
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fsWatcher.Add(filepath.Dir(absPath)); err != nil {
+		_ = fsWatcher.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:    absPath,
+		watcher: fsWatcher,
+		changes: make(chan struct{}, 1),
+		errors:  make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+	go w.watch()
+	return w, nil
+}
+
+// Changes returns the channel on which the changes to the file are
+// reported. Changes are coalesced until they are received.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Errors returns the channel on which the errors watching the file are
+// reported.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching the file.
+func (w *Watcher) Close() error {
+	err := w.watcher.Close()
+	<-w.done
+	return err
+}
+
+func (w *Watcher) watch() {
+	defer close(w.done)
+
+	timer := time.NewTimer(watchDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			timer.Reset(watchDelay)
+
+		case <-timer.C:
+			select {
+			case w.changes <- struct{}{}:
+			default:
+			}
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			select {
+			case w.errors <- err:
+			default:
+			}
+		}
+	}
+}
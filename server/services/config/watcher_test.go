@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"port": 8000}`), 0600))
+
+	watcher, err := NewWatcher(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, watcher.Close()) }()
+
+	t.Run("other files are ignored", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), []byte(`{}`), 0600))
+
+		select {
+		case <-watcher.Changes():
+			require.Fail(t, "change reported for another file")
+		case <-time.After(2 * watchDelay):
+		}
+	})
+
+	t.Run("writes are reported once", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, os.WriteFile(path, []byte(`{"port": 8001}`), 0600))
+		}
+
+		select {
+		case <-watcher.Changes():
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "change not reported")
+		}
+
+		select {
+		case <-watcher.Changes():
+			require.Fail(t, "change reported twice")
+		case <-time.After(2 * watchDelay):
+		}
+	})
+
+	t.Run("replaced files are reported", func(t *testing.T) {
+		tmpPath := filepath.Join(dir, "config.json.tmp")
+		require.NoError(t, os.WriteFile(tmpPath, []byte(`{"port": 8002}`), 0600))
+		require.NoError(t, os.Rename(tmpPath, path))
+
+		select {
+		case <-watcher.Changes():
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "change not reported")
+		}
+	})
+}
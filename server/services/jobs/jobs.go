@@ -0,0 +1,387 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	DefaultWorkers      = 2
+	DefaultPollInterval = 5 * time.Second
+
+	// staleJobTimeout is the time after which a running job without a
+	// heartbeat is given to another worker.
+	staleJobTimeout = 2 * time.Minute
+
+	// retryDelay is multiplied by the number of attempts to delay the
+	// next attempt of a failed job.
+	retryDelay = time.Minute
+
+	// finishedJobsRetention is the time finished jobs are kept for.
+	finishedJobsRetention      = 7 * 24 * time.Hour
+	deleteFinishedJobsInterval = time.Hour
+)
+
+// ProgressFunc reports the progress of a job, in percent.
+type ProgressFunc func(percent int)
+
+// JobFunc runs a job. The context is cancelled when the job is cancelled
+// or the server shuts down.
+type JobFunc func(ctx context.Context, job *model.Job, progress ProgressFunc) error
+
+// Func adapts a function that neither reports its progress nor stops
+// when cancelled to a JobFunc.
+func Func(fn func() error) JobFunc {
+	return func(context.Context, *model.Job, ProgressFunc) error {
+		return fn()
+	}
+}
+
+type Store interface {
+	CreateJob(job *model.Job) (*model.Job, error)
+	HasActiveJob(jobType string) (bool, error)
+	ClaimNextJob(jobTypes []string, workerID string, now int64) (*model.Job, error)
+	UpdateJobProgress(jobID, workerID string, progress int, now int64) (bool, error)
+	FinishJob(job *model.Job, now int64) (bool, error)
+	ResetStaleJobs(staleBefore, now int64) (int64, error)
+	DeleteFinishedJobs(finishedBefore int64) (int64, error)
+}
+
+// Leader tells whether this server is the one scheduling the recurring
+// jobs, when several servers share the database.
+type Leader interface {
+	IsHeld() bool
+}
+
+type Params struct {
+	Store        Store
+	Logger       mlog.LoggerIFace
+	WorkerID     string
+	Workers      int
+	PollInterval time.Duration
+	// Leader is optional, without it the server schedules the recurring
+	// jobs as if it were alone.
+	Leader Leader
+}
+
+// Service runs the jobs stored in the database with a pool of workers.
+// Several servers can share the database, each job is claimed by a
+// single worker.
+type Service struct {
+	store        Store
+	logger       mlog.LoggerIFace
+	workerID     string
+	workers      int
+	pollInterval time.Duration
+	leader       Leader
+
+	mux       sync.RWMutex
+	jobFuncs  map[string]JobFunc
+	recurring map[string]time.Duration
+
+	running  int32
+	wg       sync.WaitGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+	pollTask *scheduler.ScheduledTask
+}
+
+func New(params Params) *Service {
+	workers := params.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	pollInterval := params.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	workerID := params.WorkerID
+	if workerID == "" {
+		workerID = utils.NewID(utils.IDTypeNone)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		store:        params.Store,
+		logger:       params.Logger,
+		workerID:     workerID,
+		workers:      workers,
+		pollInterval: pollInterval,
+		leader:       params.Leader,
+		jobFuncs:     make(map[string]JobFunc),
+		recurring:    make(map[string]time.Duration),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	s.RegisterRecurring(model.JobTypeDeleteFinishedJobs, deleteFinishedJobsInterval, s.deleteFinishedJobs)
+	return s
+}
+
+// Register sets the function that runs the jobs of a type.
+func (s *Service) Register(jobType string, fn JobFunc) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.jobFuncs[jobType] = fn
+}
+
+// RegisterRecurring sets the function that runs the jobs of a type, and
+// keeps a job of that type scheduled at the given interval.
+func (s *Service) RegisterRecurring(jobType string, interval time.Duration, fn JobFunc) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.jobFuncs[jobType] = fn
+	s.recurring[jobType] = interval
+}
+
+// Start polls for due jobs in the background.
+func (s *Service) Start() {
+	s.logger.Info("Starting the jobs service",
+		mlog.String("workerID", s.workerID),
+		mlog.Int("workers", s.workers),
+	)
+	s.poll()
+	s.pollTask = scheduler.CreateRecurringTask("jobs", s.poll, s.pollInterval)
+}
+
+// PollTask returns the task that polls for due jobs, whose liveness is
+// part of the health of the server.
+func (s *Service) PollTask() *scheduler.ScheduledTask {
+	return s.pollTask
+}
+
+// Shutdown stops claiming jobs and waits for the running ones until the
+// context is done, then cancels them. It returns false if some jobs
+// were still running, in which case they are given back to the workers
+// once their heartbeat is stale.
+func (s *Service) Shutdown(ctx context.Context) bool {
+	if s.pollTask != nil {
+		s.pollTask.Cancel()
+		s.pollTask = nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return true
+	case <-ctx.Done():
+		s.cancel()
+		return false
+	}
+}
+
+func (s *Service) poll() {
+	now := utils.GetMillis()
+	count, err := s.store.ResetStaleJobs(now-staleJobTimeout.Milliseconds(), now)
+	if err != nil {
+		s.logger.Error("Unable to reset the stale jobs", mlog.Err(err))
+	} else if count > 0 {
+		s.logger.Warn("Reset jobs whose worker stopped responding", mlog.Int64("count", count))
+	}
+
+	s.mux.RLock()
+	jobTypes := make([]string, 0, len(s.jobFuncs))
+	for jobType := range s.jobFuncs {
+		jobTypes = append(jobTypes, jobType)
+	}
+	recurring := make(map[string]time.Duration, len(s.recurring))
+	for jobType, interval := range s.recurring {
+		recurring[jobType] = interval
+	}
+	s.mux.RUnlock()
+
+	if s.isLeader() {
+		for jobType, interval := range recurring {
+			s.scheduleRecurring(jobType, interval)
+		}
+	}
+
+	for atomic.LoadInt32(&s.running) < int32(s.workers) {
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		job, err := s.store.ClaimNextJob(jobTypes, s.workerID, utils.GetMillis())
+		if model.IsErrNotFound(err) {
+			return
+		}
+		if err != nil {
+			s.logger.Error("Unable to claim a job", mlog.Err(err))
+			return
+		}
+
+		atomic.AddInt32(&s.running, 1)
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer atomic.AddInt32(&s.running, -1)
+			s.run(job)
+		}()
+	}
+}
+
+// scheduleRecurring creates the next job of a recurring type, unless one
+// is already pending or running.
+func (s *Service) scheduleRecurring(jobType string, interval time.Duration) {
+	active, err := s.store.HasActiveJob(jobType)
+	if err != nil {
+		s.logger.Error("Unable to check the active jobs", mlog.String("type", jobType), mlog.Err(err))
+		return
+	}
+	if active {
+		return
+	}
+
+	job := &model.Job{
+		ID:          utils.NewID(utils.IDTypeNone),
+		Type:        jobType,
+		ScheduledAt: utils.GetMillis() + interval.Milliseconds(),
+	}
+	if _, err := s.store.CreateJob(job); err != nil {
+		s.logger.Error("Unable to schedule a recurring job", mlog.String("type", jobType), mlog.Err(err))
+	}
+}
+
+func (s *Service) run(job *model.Job) {
+	s.mux.RLock()
+	fn := s.jobFuncs[job.Type]
+	interval, recurring := s.recurring[job.Type]
+	s.mux.RUnlock()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	var progress int32
+	report := func(percent int) {
+		atomic.StoreInt32(&progress, int32(percent))
+		if ok, err := s.store.UpdateJobProgress(job.ID, s.workerID, percent, utils.GetMillis()); err != nil {
+			s.logger.Warn("Unable to save the progress of a job", mlog.String("jobID", job.ID), mlog.Err(err))
+		} else if !ok {
+			cancel()
+		}
+	}
+
+	heartbeatDone := make(chan struct{})
+	defer close(heartbeatDone)
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				report(int(atomic.LoadInt32(&progress)))
+			case <-heartbeatDone:
+				return
+			}
+		}
+	}()
+
+	s.logger.Debug("Running job",
+		mlog.String("jobID", job.ID),
+		mlog.String("type", job.Type),
+		mlog.Int("attempt", job.Attempts),
+	)
+	err := s.runFunc(ctx, fn, job, report)
+
+	now := utils.GetMillis()
+	job.Progress = int(atomic.LoadInt32(&progress))
+	switch {
+	case err == nil:
+		job.Status = model.JobStatusSuccess
+		job.Progress = 100
+		job.LastError = ""
+		job.FinishAt = now
+	case s.ctx.Err() != nil:
+		job.Status = model.JobStatusPending
+		job.LastError = "interrupted by the server shutdown"
+		job.ScheduledAt = now
+	case job.Attempts < job.MaxAttempts:
+		job.Status = model.JobStatusPending
+		job.LastError = err.Error()
+		job.ScheduledAt = now + int64(job.Attempts)*retryDelay.Milliseconds()
+	default:
+		job.Status = model.JobStatusError
+		job.LastError = err.Error()
+		job.FinishAt = now
+	}
+
+	ok, finishErr := s.store.FinishJob(job, now)
+	if finishErr != nil {
+		s.logger.Error("Unable to save the outcome of a job", mlog.String("jobID", job.ID), mlog.Err(finishErr))
+		return
+	}
+	if !ok {
+		s.logger.Info("Job was cancelled while running", mlog.String("jobID", job.ID), mlog.String("type", job.Type))
+		return
+	}
+
+	fields := []mlog.Field{
+		mlog.String("jobID", job.ID),
+		mlog.String("type", job.Type),
+		mlog.Int("attempt", job.Attempts),
+		mlog.String("status", string(job.Status)),
+	}
+	if err != nil {
+		s.logger.Warn("Job failed", append(fields, mlog.Err(err))...)
+	} else {
+		s.logger.Debug("Job succeeded", fields...)
+	}
+
+	if recurring && job.IsFinished() && s.isLeader() {
+		s.scheduleRecurring(job.Type, interval)
+	}
+}
+
+// isLeader returns true if this server schedules the recurring jobs. The
+// other servers still run them, but leave their scheduling to the leader
+// so that a single job of each type is pending at a time.
+func (s *Service) isLeader() bool {
+	return s.leader == nil || s.leader.IsHeld()
+}
+
+// runFunc runs the function of a job, reporting a panic as an error.
+func (s *Service) runFunc(ctx context.Context, fn JobFunc, job *model.Job, progress ProgressFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Job panic",
+				mlog.String("jobID", job.ID),
+				mlog.Any("panic", r),
+				mlog.String("stack", string(debug.Stack())),
+			)
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	if fn == nil {
+		return fmt.Errorf("no worker for job type %s", job.Type)
+	}
+	return fn(ctx, job, progress)
+}
+
+func (s *Service) deleteFinishedJobs(_ context.Context, _ *model.Job, _ ProgressFunc) error {
+	count, err := s.store.DeleteFinishedJobs(utils.GetMillis() - finishedJobsRetention.Milliseconds())
+	if err != nil {
+		return err
+	}
+	s.logger.Debug("Deleted finished jobs", mlog.Int64("count", count))
+	return nil
+}
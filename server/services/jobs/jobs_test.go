@@ -0,0 +1,315 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// memoryStore keeps jobs in memory, with the same claiming rules as the
+// SQL store.
+type memoryStore struct {
+	mux  sync.Mutex
+	jobs map[string]*model.Job
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{jobs: make(map[string]*model.Job)}
+}
+
+func (ms *memoryStore) get(jobID string) model.Job {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	return *ms.jobs[jobID]
+}
+
+func (ms *memoryStore) ofType(jobType string) []model.Job {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	var jobs []model.Job
+	for _, job := range ms.jobs {
+		if job.Type == jobType {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs
+}
+
+func (ms *memoryStore) cancel(jobID string) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	ms.jobs[jobID].Status = model.JobStatusCanceled
+}
+
+func (ms *memoryStore) CreateJob(job *model.Job) (*model.Job, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	if job.Status == "" {
+		job.Status = model.JobStatusPending
+	}
+	if job.MaxAttempts == 0 {
+		job.MaxAttempts = model.DefaultJobMaxAttempts
+	}
+	stored := *job
+	ms.jobs[job.ID] = &stored
+	return job, nil
+}
+
+func (ms *memoryStore) HasActiveJob(jobType string) (bool, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	for _, job := range ms.jobs {
+		if job.Type == jobType && !job.IsFinished() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (ms *memoryStore) ClaimNextJob(jobTypes []string, workerID string, now int64) (*model.Job, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	var due []*model.Job
+	for _, job := range ms.jobs {
+		for _, jobType := range jobTypes {
+			if job.Type == jobType && job.Status == model.JobStatusPending && job.ScheduledAt <= now {
+				due = append(due, job)
+			}
+		}
+	}
+	if len(due) == 0 {
+		return nil, model.NewErrNotFound("due job")
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ScheduledAt < due[j].ScheduledAt })
+
+	job := due[0]
+	job.Status = model.JobStatusRunning
+	job.WorkerID = workerID
+	job.Attempts++
+	job.StartAt = now
+	job.UpdateAt = now
+	claimed := *job
+	return &claimed, nil
+}
+
+func (ms *memoryStore) UpdateJobProgress(jobID, workerID string, progress int, now int64) (bool, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	job := ms.jobs[jobID]
+	if job.WorkerID != workerID || job.Status != model.JobStatusRunning {
+		return false, nil
+	}
+	job.Progress = progress
+	job.UpdateAt = now
+	return true, nil
+}
+
+func (ms *memoryStore) FinishJob(job *model.Job, now int64) (bool, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	stored := ms.jobs[job.ID]
+	if stored.WorkerID != job.WorkerID || stored.Status != model.JobStatusRunning {
+		return false, nil
+	}
+	finished := *job
+	finished.UpdateAt = now
+	ms.jobs[job.ID] = &finished
+	return true, nil
+}
+
+func (ms *memoryStore) ResetStaleJobs(staleBefore, now int64) (int64, error) {
+	return 0, nil
+}
+
+func (ms *memoryStore) DeleteFinishedJobs(finishedBefore int64) (int64, error) {
+	return 0, nil
+}
+
+func setupTestService(t *testing.T) (*Service, *memoryStore) {
+	store := newMemoryStore()
+	service := New(Params{
+		Store:        store,
+		Logger:       mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+		Workers:      2,
+		PollInterval: 10 * time.Millisecond,
+	})
+	t.Cleanup(func() { service.Shutdown(context.Background()) })
+	return service, store
+}
+
+func createTestJob(t *testing.T, store *memoryStore, jobType string, maxAttempts int) string {
+	job := &model.Job{
+		ID:          utils.NewID(utils.IDTypeNone),
+		Type:        jobType,
+		MaxAttempts: maxAttempts,
+		ScheduledAt: utils.GetMillis(),
+	}
+	_, err := store.CreateJob(job)
+	require.NoError(t, err)
+	return job.ID
+}
+
+func waitForStatus(t *testing.T, store *memoryStore, jobID string, status model.JobStatus) model.Job {
+	require.Eventually(t, func() bool {
+		return store.get(jobID).Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return store.get(jobID)
+}
+
+func TestRunJobs(t *testing.T) {
+	service, store := setupTestService(t)
+
+	service.Register("succeeds", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		progress(50)
+		return nil
+	})
+	service.Register("fails", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		return errors.New("boom")
+	})
+	service.Register("panics", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		panic("oh no!")
+	})
+
+	succeeds := createTestJob(t, store, "succeeds", 1)
+	retried := createTestJob(t, store, "fails", 2)
+	fails := createTestJob(t, store, "fails", 1)
+	panics := createTestJob(t, store, "panics", 1)
+	unknown := createTestJob(t, store, "unknown", 1)
+
+	service.Start()
+
+	t.Run("successful job", func(t *testing.T) {
+		job := waitForStatus(t, store, succeeds, model.JobStatusSuccess)
+		require.Equal(t, 100, job.Progress)
+		require.Equal(t, 1, job.Attempts)
+		require.NotZero(t, job.FinishAt)
+	})
+
+	t.Run("failed job with attempts left", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return store.get(retried).Attempts == 1 && store.get(retried).Status == model.JobStatusPending
+		}, 5*time.Second, 10*time.Millisecond)
+		job := store.get(retried)
+		require.Equal(t, "boom", job.LastError)
+		require.Greater(t, job.ScheduledAt, utils.GetMillis())
+	})
+
+	t.Run("failed job", func(t *testing.T) {
+		job := waitForStatus(t, store, fails, model.JobStatusError)
+		require.Equal(t, "boom", job.LastError)
+	})
+
+	t.Run("panicking job", func(t *testing.T) {
+		job := waitForStatus(t, store, panics, model.JobStatusError)
+		require.Contains(t, job.LastError, "oh no!")
+	})
+
+	t.Run("jobs without a worker aren't claimed", func(t *testing.T) {
+		require.Equal(t, model.JobStatusPending, store.get(unknown).Status)
+	})
+}
+
+func TestCancelRunningJob(t *testing.T) {
+	service, store := setupTestService(t)
+
+	started := make(chan struct{})
+	service.Register("waits", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	jobID := createTestJob(t, store, "waits", 1)
+	service.Start()
+	<-started
+
+	store.cancel(jobID)
+	require.True(t, service.Shutdown(context.Background()), "the job should stop on the next heartbeat")
+	require.Equal(t, model.JobStatusCanceled, store.get(jobID).Status)
+}
+
+func TestRecurringJobs(t *testing.T) {
+	service, store := setupTestService(t)
+
+	service.RegisterRecurring("recurring", time.Hour, func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		return nil
+	})
+	service.Start()
+
+	jobs := store.ofType("recurring")
+	require.Len(t, jobs, 1)
+	require.Equal(t, model.JobStatusPending, jobs[0].Status)
+	require.Greater(t, jobs[0].ScheduledAt, utils.GetMillis()+time.Minute.Milliseconds())
+
+	require.Len(t, store.ofType(model.JobTypeDeleteFinishedJobs), 1)
+}
+
+func TestShutdown(t *testing.T) {
+	service, store := setupTestService(t)
+
+	started := make(chan struct{})
+	service.Register("ignores cancellation", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	service.Register("stops", func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	slow := createTestJob(t, store, "ignores cancellation", 1)
+	stopped := createTestJob(t, store, "stops", 1)
+	service.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.False(t, service.Shutdown(ctx))
+
+	job := waitForStatus(t, store, stopped, model.JobStatusPending)
+	require.Equal(t, "interrupted by the server shutdown", job.LastError)
+	waitForStatus(t, store, slow, model.JobStatusSuccess)
+}
+
+type testLeader bool
+
+func (l testLeader) IsHeld() bool {
+	return bool(l)
+}
+
+func TestRecurringJobsLeader(t *testing.T) {
+	store := newMemoryStore()
+	newService := func(leader Leader) *Service {
+		service := New(Params{
+			Store:        store,
+			Logger:       mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+			PollInterval: 10 * time.Millisecond,
+			Leader:       leader,
+		})
+		t.Cleanup(func() { service.Shutdown(context.Background()) })
+		service.RegisterRecurring("recurring", time.Hour, func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+			return nil
+		})
+		return service
+	}
+
+	newService(testLeader(false)).Start()
+	require.Empty(t, store.ofType("recurring"), "only the leader should schedule recurring jobs")
+
+	newService(testLeader(true)).Start()
+	require.Len(t, store.ofType("recurring"), 1)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package lease
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const DefaultDuration = 30 * time.Second
+
+type Store interface {
+	AcquireLease(name, holderID string, expireAt, now int64) (bool, error)
+	ReleaseLease(name, holderID string) error
+}
+
+type Params struct {
+	Store    Store
+	Logger   mlog.LoggerIFace
+	Name     string
+	HolderID string
+	Duration time.Duration
+}
+
+// Lease is a named lease stored in the database, which is held by a
+// single server at a time when several servers share the database. The
+// holder renews it in the background; when the holder stops responding,
+// another server takes it over once it expires. The servers are expected
+// to have their clocks in sync.
+type Lease struct {
+	store    Store
+	logger   mlog.LoggerIFace
+	name     string
+	holderID string
+	duration time.Duration
+
+	mux      sync.Mutex
+	expireAt time.Time
+	task     *scheduler.ScheduledTask
+	stopped  bool
+}
+
+func New(params Params) *Lease {
+	duration := params.Duration
+	if duration <= 0 {
+		duration = DefaultDuration
+	}
+
+	return &Lease{
+		store:    params.Store,
+		logger:   params.Logger,
+		name:     params.Name,
+		holderID: params.HolderID,
+		duration: duration,
+	}
+}
+
+// Start tries to acquire the lease right away, then keeps trying to
+// acquire or renew it in the background, several times per duration.
+func (l *Lease) Start() {
+	l.renew()
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.task == nil && !l.stopped {
+		l.task = scheduler.CreateRecurringTask("lease "+l.name, l.renew, l.duration/3)
+	}
+}
+
+// Stop stops renewing the lease and releases it, so that another server
+// can take it over without waiting for it to expire.
+func (l *Lease) Stop() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.stopped = true
+	if l.task != nil {
+		l.task.Cancel()
+		l.task = nil
+	}
+
+	if l.expireAt.IsZero() {
+		return
+	}
+	l.expireAt = time.Time{}
+	if err := l.store.ReleaseLease(l.name, l.holderID); err != nil {
+		l.logger.Warn("Unable to release the lease", mlog.String("name", l.name), mlog.Err(err))
+		return
+	}
+	l.logger.Info("Released the lease", mlog.String("name", l.name), mlog.String("holderID", l.holderID))
+}
+
+// IsHeld returns true if this server holds the lease. The lease is
+// considered lost as soon as it could have expired in the database, even
+// if its renewal is late.
+func (l *Lease) IsHeld() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return time.Now().Before(l.expireAt)
+}
+
+func (l *Lease) renew() {
+	now := time.Now()
+	expireAt := now.Add(l.duration)
+	ok, err := l.store.AcquireLease(l.name, l.holderID, utils.GetMillisForTime(expireAt), utils.GetMillisForTime(now))
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.stopped {
+		// the lease was released while it was being renewed
+		if ok {
+			if err := l.store.ReleaseLease(l.name, l.holderID); err != nil {
+				l.logger.Warn("Unable to release the lease", mlog.String("name", l.name), mlog.Err(err))
+			}
+		}
+		return
+	}
+
+	held := now.Before(l.expireAt)
+	switch {
+	case err != nil:
+		// keep the current expiration, the lease is lost if the
+		// database remains unavailable until then
+		l.logger.Warn("Unable to renew the lease", mlog.String("name", l.name), mlog.Err(err))
+	case ok:
+		l.expireAt = expireAt
+		if !held {
+			l.logger.Info("Acquired the lease", mlog.String("name", l.name), mlog.String("holderID", l.holderID))
+		}
+	default:
+		l.expireAt = time.Time{}
+		if held {
+			l.logger.Warn("Lost the lease to another server", mlog.String("name", l.name))
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package lease
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// memoryStore keeps leases in memory, with the same rules as the SQL
+// store.
+type memoryStore struct {
+	mux     sync.Mutex
+	holders map[string]string
+	expires map[string]int64
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		holders: make(map[string]string),
+		expires: make(map[string]int64),
+	}
+}
+
+func (ms *memoryStore) setErr(err error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	ms.err = err
+}
+
+func (ms *memoryStore) AcquireLease(name, holderID string, expireAt, now int64) (bool, error) {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	if ms.err != nil {
+		return false, ms.err
+	}
+	holder, ok := ms.holders[name]
+	if ok && holder != holderID && ms.expires[name] >= now {
+		return false, nil
+	}
+	ms.holders[name] = holderID
+	ms.expires[name] = expireAt
+	return true, nil
+}
+
+func (ms *memoryStore) ReleaseLease(name, holderID string) error {
+	ms.mux.Lock()
+	defer ms.mux.Unlock()
+	if ms.holders[name] == holderID {
+		delete(ms.holders, name)
+		delete(ms.expires, name)
+	}
+	return nil
+}
+
+func newTestLease(t *testing.T, store Store, holderID string, duration time.Duration) *Lease {
+	l := New(Params{
+		Store:    store,
+		Logger:   mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+		Name:     "scheduler",
+		HolderID: holderID,
+		Duration: duration,
+	})
+	t.Cleanup(l.Stop)
+	return l
+}
+
+func TestLease(t *testing.T) {
+	t.Run("single holder", func(t *testing.T) {
+		store := newMemoryStore()
+		first := newTestLease(t, store, "node-1", time.Minute)
+		second := newTestLease(t, store, "node-2", time.Minute)
+
+		first.Start()
+		second.Start()
+		require.True(t, first.IsHeld())
+		require.False(t, second.IsHeld())
+	})
+
+	t.Run("failover on release", func(t *testing.T) {
+		store := newMemoryStore()
+		first := newTestLease(t, store, "node-1", 150*time.Millisecond)
+		second := newTestLease(t, store, "node-2", 150*time.Millisecond)
+
+		first.Start()
+		second.Start()
+		require.True(t, first.IsHeld())
+
+		first.Stop()
+		require.False(t, first.IsHeld())
+		require.Eventually(t, second.IsHeld, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("failover on expiration", func(t *testing.T) {
+		store := newMemoryStore()
+		first := newTestLease(t, store, "node-1", time.Minute)
+		first.Start()
+		require.True(t, first.IsHeld())
+
+		// the holder stops renewing without releasing the lease
+		first.mux.Lock()
+		first.task.Cancel()
+		first.task = nil
+		first.mux.Unlock()
+		store.mux.Lock()
+		store.expires["scheduler"] = 0
+		store.mux.Unlock()
+
+		second := newTestLease(t, store, "node-2", 150*time.Millisecond)
+		second.Start()
+		require.True(t, second.IsHeld())
+	})
+
+	t.Run("lost when the database is unavailable", func(t *testing.T) {
+		store := newMemoryStore()
+		l := newTestLease(t, store, "node-1", 150*time.Millisecond)
+		l.Start()
+		require.True(t, l.IsHeld())
+
+		store.setErr(errors.New("database is down"))
+		require.Eventually(t, func() bool { return !l.IsHeld() }, time.Second, 10*time.Millisecond)
+
+		store.setErr(nil)
+		require.Eventually(t, l.IsHeld, time.Second, 10*time.Millisecond)
+	})
+}
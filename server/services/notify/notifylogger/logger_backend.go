@@ -54,6 +54,26 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 	return nil
 }
 
+func (b *Backend) CardReminderDue(evt notify.CardReminderEvent) error {
+	var board string
+	var card string
+
+	if evt.Board != nil {
+		board = evt.Board.Title
+	}
+	if evt.Card != nil {
+		card = evt.Card.Title
+	}
+
+	b.logger.Log(b.level, "Card reminder event",
+		mlog.String("board", board),
+		mlog.String("card", card),
+		mlog.String("user_id", evt.Reminder.UserID),
+		mlog.String("reminder_id", evt.Reminder.ID),
+	)
+	return nil
+}
+
 func (b *Backend) Name() string {
 	return backendName
 }
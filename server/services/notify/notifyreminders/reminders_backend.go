@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyReminders"
+)
+
+type BackendParams struct {
+	Permissions permissions.PermissionsService
+	Delivery    ReminderDelivery
+	Logger      mlog.LoggerIFace
+}
+
+// Backend provides the notification backend for card reminders.
+type Backend struct {
+	permissions permissions.PermissionsService
+	delivery    ReminderDelivery
+	logger      mlog.LoggerIFace
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged is a no-op, reminders are only delivered when due.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	return nil
+}
+
+func (b *Backend) CardReminderDue(evt notify.CardReminderEvent) error {
+	// make sure the user can still see the card.
+	if !b.permissions.HasPermissionToBoard(evt.Reminder.UserID, evt.Board.ID, model.PermissionViewBoard) {
+		b.logger.Debug("CardReminderDue - skipping non-board member",
+			mlog.String("user_id", evt.Reminder.UserID),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+
+	return b.delivery.ReminderDeliver(evt)
+}
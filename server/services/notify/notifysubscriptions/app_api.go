@@ -13,6 +13,7 @@ type AppAPI interface {
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryNewestChildren(parentID string, opts model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error)
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
+	GetBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error)
 
 	GetUserByID(userID string) (*model.User, error)
 
@@ -308,26 +308,26 @@ func appendContentChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Dif
 			opString = "modified"
 		}
 
+		def, ok := model.GetBlockTypeDefinition(child.BlockType)
+		if !ok {
+			def = &model.BlockTypeDefinition{Type: child.BlockType}
+		}
+
 		var newTitle, oldTitle string
 		if child.OldBlock != nil {
-			oldTitle = child.OldBlock.Title
+			oldTitle = blockNotifyText(def, child.OldBlock)
 		}
 		if child.NewBlock != nil {
-			newTitle = child.NewBlock.Title
+			newTitle = blockNotifyText(def, child.NewBlock)
 		}
 
-		switch child.BlockType {
-		case model.TypeDivider, model.TypeComment:
+		switch {
+		case def.NotifyHidden:
 			// do nothing
 			continue
-		case model.TypeImage:
+		case def.NotifyLabel != "":
 			if newTitle == "" {
-				newTitle = "An image was " + opString + "." // TODO: localize when i18n added to server
-			}
-			oldTitle = ""
-		case model.TypeAttachment:
-			if newTitle == "" {
-				newTitle = "A file attachment was " + opString + "." // TODO: localize when i18n added to server
+				newTitle = def.NotifyLabel + " was " + opString + "." // TODO: localize when i18n added to server
 			}
 			oldTitle = ""
 		default:
@@ -364,3 +364,12 @@ func appendContentChanges(fields []*mm_model.SlackAttachmentField, cardDiff *Dif
 	}
 	return fields
 }
+
+// blockNotifyText returns the text of a content block to show in
+// notifications.
+func blockNotifyText(def *model.BlockTypeDefinition, block *model.Block) string {
+	if def.NotifyText != nil {
+		return def.NotifyText(block)
+	}
+	return block.Title
+}
@@ -4,6 +4,7 @@
 package notifysubscriptions
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -25,6 +26,8 @@ const (
 
 var (
 	errEnqueueNotifyHintTimeout = errors.New("enqueue notify hint timed out")
+	errNotifierStopped          = errors.New("notifier is not running")
+	errHintQueueFull            = errors.New("notify hint queue is full")
 )
 
 // notifier provides block change notifications for subscribers. Block change events are batched
@@ -39,8 +42,9 @@ type notifier struct {
 
 	hints chan *model.NotificationHint
 
-	mux  sync.Mutex
-	done chan struct{}
+	mux     sync.Mutex
+	done    chan struct{}
+	stopped chan struct{}
 }
 
 func newNotifier(params BackendParams) *notifier {
@@ -61,7 +65,8 @@ func (n *notifier) start() {
 
 	if n.done == nil {
 		n.done = make(chan struct{})
-		go n.loop()
+		n.stopped = make(chan struct{})
+		go n.loop(n.done, n.stopped)
 	}
 }
 
@@ -75,8 +80,66 @@ func (n *notifier) stop() {
 	}
 }
 
-func (n *notifier) loop() {
-	done := n.done
+// checkHealth returns an error if the notifier isn't running, or if its
+// loop doesn't keep up with the hints.
+func (n *notifier) checkHealth() error {
+	n.mux.Lock()
+	running := n.done != nil
+	n.mux.Unlock()
+
+	if !running {
+		return errNotifierStopped
+	}
+	if len(n.hints) == cap(n.hints) {
+		return errHintQueueFull
+	}
+	return nil
+}
+
+// drain stops the notifier, waits for the notification being sent if
+// any, and sends the notifications that are due, for as long as the
+// context allows. The hints that aren't due yet are kept for the next
+// start. It returns the number of hints processed.
+func (n *notifier) drain(ctx context.Context) (int, error) {
+	n.mux.Lock()
+	stopped := n.stopped
+	if n.done != nil {
+		close(n.done)
+		n.done = nil
+	}
+	n.mux.Unlock()
+
+	if stopped != nil {
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		hint, err := n.store.GetNextNotificationHint(false)
+		switch {
+		case model.IsErrNotFound(err):
+			return count, nil
+		case err != nil:
+			return count, err
+		case hint.NotifyAt > utils.GetMillis():
+			return count, nil
+		}
+
+		n.notify()
+		count++
+	}
+}
+
+func (n *notifier) loop(done, stopped chan struct{}) {
+	defer close(stopped)
 	var nextNotify time.Time
 
 	for {
@@ -4,9 +4,11 @@
 package notifysubscriptions
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -38,8 +40,8 @@ type Backend struct {
 	delivery               SubscriptionDelivery
 	notifier               *notifier
 	logger                 mlog.LoggerIFace
-	notifyFreqCardSeconds  int
-	notifyFreqBoardSeconds int
+	notifyFreqCardSeconds  int64
+	notifyFreqBoardSeconds int64
 }
 
 func New(params BackendParams) *Backend {
@@ -49,20 +51,31 @@ func New(params BackendParams) *Backend {
 		permissions:            params.Permissions,
 		notifier:               newNotifier(params),
 		logger:                 params.Logger,
-		notifyFreqCardSeconds:  params.NotifyFreqCardSeconds,
-		notifyFreqBoardSeconds: params.NotifyFreqBoardSeconds,
+		notifyFreqCardSeconds:  int64(params.NotifyFreqCardSeconds),
+		notifyFreqBoardSeconds: int64(params.NotifyFreqBoardSeconds),
 	}
 }
 
 func (b *Backend) Start() error {
 	b.logger.Debug("Starting subscriptions backend",
-		mlog.Int("freq_card", b.notifyFreqCardSeconds),
-		mlog.Int("freq_board", b.notifyFreqBoardSeconds),
+		mlog.Int64("freq_card", atomic.LoadInt64(&b.notifyFreqCardSeconds)),
+		mlog.Int64("freq_board", atomic.LoadInt64(&b.notifyFreqBoardSeconds)),
 	)
 	b.notifier.start()
 	return nil
 }
 
+// SetNotifyFrequencies changes the delay after the last change of a
+// card before its notifications are sent.
+func (b *Backend) SetNotifyFrequencies(cardSeconds, boardSeconds int) {
+	atomic.StoreInt64(&b.notifyFreqCardSeconds, int64(cardSeconds))
+	atomic.StoreInt64(&b.notifyFreqBoardSeconds, int64(boardSeconds))
+	b.logger.Debug("Changed the subscriptions notification frequencies",
+		mlog.Int("freq_card", cardSeconds),
+		mlog.Int("freq_board", boardSeconds),
+	)
+}
+
 func (b *Backend) ShutDown() error {
 	b.logger.Debug("Stopping subscriptions backend")
 	b.notifier.stop()
@@ -70,6 +83,20 @@ func (b *Backend) ShutDown() error {
 	return nil
 }
 
+// Drain sends the notifications that are due before the server shuts
+// down, for as long as the context allows.
+func (b *Backend) Drain(ctx context.Context) error {
+	count, err := b.notifier.drain(ctx)
+	b.logger.Debug("Drained the subscriptions notifications", mlog.Int("hints", count))
+	return err
+}
+
+// CheckHealth returns an error if the notifier that sends the batched
+// notifications isn't able to keep up.
+func (b *Backend) CheckHealth() error {
+	return b.notifier.checkHealth()
+}
+
 func (b *Backend) Name() string {
 	return backendName
 }
@@ -87,7 +114,7 @@ func (b *Backend) getBlockUpdateFreq(blockType model.BlockType) time.Duration {
 
 	switch blockType {
 	case model.TypeCard:
-		return time.Second * time.Duration(b.notifyFreqCardSeconds)
+		return time.Second * time.Duration(atomic.LoadInt64(&b.notifyFreqCardSeconds))
 	default:
 		return defBlockNotificationFreq
 	}
@@ -130,10 +157,18 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 		merr.Append(fmt.Errorf("cannot notify board subscribers for board %s: %w", evt.Board.ID, err))
 	}
 
-	if evt.Card == nil {
+	// subscriptions to archived cards are paused until the card is
+	// unarchived
+	if evt.Card == nil || model.IsArchivedCard(evt.Card) {
 		return merr.ErrorOrNil()
 	}
 
+	// participants of a comment thread follow the card, so they are
+	// notified of the replies to the thread
+	if evt.Action == notify.Add && model.IsCommentReply(evt.BlockChanged) {
+		b.subscribeThreadParticipants(evt)
+	}
+
 	// notify card subscribers
 	subs, err = b.appAPI.GetSubscribersForBlock(evt.Card.ID)
 	if err != nil {
@@ -179,6 +214,53 @@ func (b *Backend) notifySubscribers(subs []*model.Subscriber, blockID string, id
 	return nil
 }
 
+// subscribeThreadParticipants subscribes the users that wrote the
+// comments of the thread a reply belongs to to the card of the thread.
+func (b *Backend) subscribeThreadParticipants(evt notify.BlockChangeEvent) {
+	comments, err := b.appAPI.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   evt.Board.ID,
+		ParentID:  evt.Card.ID,
+		BlockType: model.TypeComment,
+	})
+	if err != nil {
+		b.logger.Warn("Cannot fetch comment thread to subscribe participants",
+			mlog.String("card_id", evt.Card.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	thread, err := model.NewCommentThread(model.CommentThreadID(evt.BlockChanged), comments, nil)
+	if err != nil {
+		b.logger.Warn("Cannot build comment thread to subscribe participants",
+			mlog.String("block_id", evt.BlockChanged.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	for _, userID := range model.CommentThreadParticipants(thread) {
+		if !b.permissions.HasPermissionToBoard(userID, evt.Board.ID, model.PermissionViewBoard) {
+			continue
+		}
+
+		sub := &model.Subscription{
+			BlockType:      model.TypeCard,
+			BlockID:        evt.Card.ID,
+			SubscriberType: model.SubTypeUser,
+			SubscriberID:   userID,
+		}
+
+		if _, err = b.appAPI.CreateSubscription(sub); err != nil {
+			b.logger.Warn("Cannot subscribe thread participant to card",
+				mlog.String("user_id", userID),
+				mlog.String("card_id", evt.Card.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
 // OnMention satisfies the `MentionListener` interface and is called whenever a @mention notification
 // is sent. Here we create a subscription for the mentioned user to the card.
 func (b *Backend) OnMention(userID string, evt notify.BlockChangeEvent) {
@@ -0,0 +1,95 @@
+package notifysubscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+
+	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type testAppAPI struct {
+	AppAPI
+	blocks        []*model.Block
+	subscriptions []*model.Subscription
+}
+
+func (a *testAppAPI) GetBlocks(_ model.QueryBlocksOptions) ([]*model.Block, error) {
+	return a.blocks, nil
+}
+
+func (a *testAppAPI) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
+	a.subscriptions = append(a.subscriptions, sub)
+	return sub, nil
+}
+
+type testPermissions struct {
+	permissions.PermissionsService
+	boardMembers map[string]bool
+}
+
+func (p *testPermissions) HasPermissionToBoard(userID, _ string, _ *mmModel.Permission) bool {
+	return p.boardMembers[userID]
+}
+
+func TestSubscribeThreadParticipants(t *testing.T) {
+	board := &model.Board{ID: "board-1"}
+	card := &model.Block{ID: "card-1", BoardID: board.ID, Type: model.TypeCard}
+	comment := func(id, userID, threadID string, createAt int64) *model.Block {
+		block := &model.Block{
+			ID:        id,
+			BoardID:   board.ID,
+			ParentID:  card.ID,
+			Type:      model.TypeComment,
+			CreatedBy: userID,
+			CreateAt:  createAt,
+			Fields:    map[string]interface{}{},
+		}
+		if threadID != "" {
+			block.Fields[model.CommentFieldThreadID] = threadID
+		}
+		return block
+	}
+
+	root := comment("comment-1", "user-1", "", 1)
+	reply := comment("comment-2", "user-2", root.ID, 2)
+	formerMemberReply := comment("comment-3", "user-3", root.ID, 3)
+	otherThread := comment("comment-4", "user-4", "", 4)
+	newReply := comment("comment-5", "user-5", root.ID, 5)
+
+	appAPI := &testAppAPI{blocks: []*model.Block{root, reply, formerMemberReply, otherThread, newReply}}
+	backend := New(BackendParams{
+		AppAPI: appAPI,
+		Permissions: &testPermissions{boardMembers: map[string]bool{
+			"user-1": true,
+			"user-2": true,
+			"user-4": true,
+			"user-5": true,
+		}},
+		Logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+	})
+
+	backend.subscribeThreadParticipants(notify.BlockChangeEvent{
+		Action:       notify.Add,
+		Board:        board,
+		Card:         card,
+		BlockChanged: newReply,
+		ModifiedBy:   &model.BoardMember{UserID: "user-5"},
+	})
+
+	subscriberIDs := []string{}
+	for _, sub := range appAPI.subscriptions {
+		require.Equal(t, card.ID, sub.BlockID)
+		require.Equal(t, model.BlockType(model.TypeCard), sub.BlockType)
+		subscriberIDs = append(subscriberIDs, sub.SubscriberID)
+	}
+
+	// the participants that can still see the board follow the card,
+	// the authors of other threads don't
+	require.Equal(t, []string{"user-1", "user-2", "user-5"}, subscriberIDs)
+}
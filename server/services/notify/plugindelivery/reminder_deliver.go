@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+const (
+	// TODO: localize these when i18n is available.
+	defReminderTemplate     = "Reminder about the card [%s](%s) in board [%s](%s)"
+	defReminderNoteTemplate = "\n> %s"
+)
+
+// ReminderDeliver sends a due card reminder to its user via the plugin API.
+func (pd *PluginDelivery) ReminderDeliver(evt notify.CardReminderEvent) error {
+	channel, err := pd.getDirectChannel(evt.TeamID, evt.Reminder.UserID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+	boardLink := utils.MakeBoardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID)
+
+	message := fmt.Sprintf(defReminderTemplate, evt.Card.Title, link, evt.Board.Title, boardLink)
+	if evt.Reminder.Note != "" {
+		message += fmt.Sprintf(defReminderNoteTemplate, evt.Reminder.Note)
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   message,
+	}
+
+	_, err = pd.api.CreatePost(post)
+	return err
+}
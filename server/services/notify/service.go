@@ -4,6 +4,8 @@
 package notify
 
 import (
+	"context"
+	"fmt"
 	"sync"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -38,6 +40,38 @@ type Backend interface {
 	Name() string
 }
 
+// CardReminderEvent is a card reminder that is due.
+type CardReminderEvent struct {
+	TeamID   string
+	Board    *model.Board
+	Card     *model.Block
+	Reminder *model.CardReminder
+}
+
+// ReminderBackend is implemented by the backends that can deliver card
+// reminders.
+type ReminderBackend interface {
+	CardReminderDue(evt CardReminderEvent) error
+}
+
+// FrequencyBackend is implemented by the backends that batch
+// notifications, whose frequencies can change while they run.
+type FrequencyBackend interface {
+	SetNotifyFrequencies(cardSeconds, boardSeconds int)
+}
+
+// HealthBackend is implemented by the backends that can check whether
+// they are able to deliver notifications.
+type HealthBackend interface {
+	CheckHealth() error
+}
+
+// DrainBackend is implemented by the backends that queue notifications,
+// so that the ones that are due are sent before the server shuts down.
+type DrainBackend interface {
+	Drain(ctx context.Context) error
+}
+
 // Service is a service that sends notifications based on block activity using one or more backends.
 type Service struct {
 	mux      sync.RWMutex
@@ -106,3 +140,79 @@ func (s *Service) BlockChanged(evt BlockChangeEvent) {
 		}
 	}
 }
+
+// SetNotifyFrequencies changes the frequencies of the backends that
+// batch notifications.
+func (s *Service) SetNotifyFrequencies(cardSeconds, boardSeconds int) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		if frequencyBackend, ok := backend.(FrequencyBackend); ok {
+			frequencyBackend.SetNotifyFrequencies(cardSeconds, boardSeconds)
+		}
+	}
+}
+
+// Drain lets the backends that queue notifications send the ones that
+// are due, for as long as the context allows.
+func (s *Service) Drain(ctx context.Context) error {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	merr := merror.New()
+	for _, backend := range s.backends {
+		drainBackend, ok := backend.(DrainBackend)
+		if !ok {
+			continue
+		}
+
+		if err := drainBackend.Drain(ctx); err != nil {
+			merr.Append(fmt.Errorf("%s: %w", backend.Name(), err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// CheckHealth checks the backends that can report their health, and
+// returns an error listing the ones that cannot deliver notifications.
+func (s *Service) CheckHealth() error {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	merr := merror.New()
+	for _, backend := range s.backends {
+		healthBackend, ok := backend.(HealthBackend)
+		if !ok {
+			continue
+		}
+
+		if err := healthBackend.CheckHealth(); err != nil {
+			merr.Append(fmt.Errorf("%s: %w", backend.Name(), err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// CardReminderDue should be called when a card reminder is due. The
+// backends that deliver reminders are informed of the event.
+func (s *Service) CardReminderDue(evt CardReminderEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		reminderBackend, ok := backend.(ReminderBackend)
+		if !ok {
+			continue
+		}
+
+		if err := reminderBackend.CardReminderDue(evt); err != nil {
+			s.logger.Error("Error delivering card reminder",
+				mlog.String("backend", backend.Name()),
+				mlog.String("reminder_id", evt.Reminder.ID),
+				mlog.String("card_id", evt.Reminder.CardID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
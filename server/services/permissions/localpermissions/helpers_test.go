@@ -42,6 +42,11 @@ func (th *TestHelper) checkBoardPermissions(roleName string, member *model.Board
 				Return(member, nil).
 				Times(1)
 
+			th.store.EXPECT().
+				GetBoard(member.BoardID).
+				Return(&model.Board{ID: member.BoardID}, nil).
+				AnyTimes()
+
 			hasPermission := th.permissions.HasPermissionToBoard(member.UserID, member.BoardID, p)
 			assert.True(t, hasPermission)
 		})
@@ -54,6 +59,11 @@ func (th *TestHelper) checkBoardPermissions(roleName string, member *model.Board
 				Return(member, nil).
 				Times(1)
 
+			th.store.EXPECT().
+				GetBoard(member.BoardID).
+				Return(&model.Board{ID: member.BoardID}, nil).
+				AnyTimes()
+
 			hasPermission := th.permissions.HasPermissionToBoard(member.UserID, member.BoardID, p)
 			assert.False(t, hasPermission)
 		})
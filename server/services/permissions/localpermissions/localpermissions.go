@@ -45,6 +45,21 @@ func (s *Service) HasPermissionToChannel(userID, channelID string, permission *m
 }
 
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
+	return s.hasPermissionToBoard(userID, boardID, nil, permission)
+}
+
+// HasPermissionToLoadedBoard checks the permissions to a board the caller
+// already loaded, which saves a query when the board could be frozen.
+func (s *Service) HasPermissionToLoadedBoard(userID string, board *model.Board, permission *mmModel.Permission) bool {
+	if board == nil {
+		return false
+	}
+	return s.hasPermissionToBoard(userID, board.ID, board, permission)
+}
+
+// hasPermissionToBoard checks the permissions to a board, which is only
+// loaded if it isn't given and its frozen state matters.
+func (s *Service) hasPermissionToBoard(userID, boardID string, board *model.Board, permission *mmModel.Permission) bool {
 	if userID == "" || boardID == "" || permission == nil {
 		return false
 	}
@@ -73,10 +88,25 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		member.SchemeViewer = true
 	}
 
+	if !hasRolePermission(member, permission) {
+		return false
+	}
+
+	// frozen boards can only be modified by their admins
+	if !member.SchemeAdmin && permissions.IsFreezablePermission(permission) {
+		if board == nil {
+			return !s.isBoardFrozen(boardID)
+		}
+		return !board.IsFrozen()
+	}
+	return true
+}
+
+func hasRolePermission(member *model.BoardMember, permission *mmModel.Permission) bool {
 	switch permission {
-	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
+	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments, model.PermissionFreezeBoard:
 		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
+	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties, model.PermissionArchiveBoardCards, model.PermissionUnarchiveBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor
 	case model.PermissionCommentBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
@@ -86,3 +116,20 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 }
+
+func (s *Service) isBoardFrozen(boardID string) bool {
+	board, err := s.store.GetBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return false
+	}
+	if err != nil {
+		s.logger.Error("error getting board",
+			mlog.String("boardID", boardID),
+			mlog.Err(err),
+		)
+		// if we can't verify the board state, we err on the
+		// side of caution and consider it frozen
+		return true
+	}
+	return board.IsFrozen()
+}
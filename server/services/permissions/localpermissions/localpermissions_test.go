@@ -69,6 +69,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
 		}
@@ -87,6 +89,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
 		}
@@ -118,6 +122,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
@@ -141,12 +147,82 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
 		th.checkBoardPermissions("viewer", member, hasPermissionTo, hasNotPermissionTo)
 	})
 
+	t.Run("frozen board", func(t *testing.T) {
+		frozenBoard := &model.Board{
+			ID:           "frozen-board-id",
+			Frozen:       true,
+			FrozenReason: "release in progress",
+		}
+
+		testCases := []struct {
+			name          string
+			member        *model.BoardMember
+			permission    *mmModel.Permission
+			hasPermission bool
+		}{
+			{"admin can manage cards", &model.BoardMember{SchemeAdmin: true}, model.PermissionManageBoardCards, true},
+			{"admin can freeze", &model.BoardMember{SchemeAdmin: true}, model.PermissionFreezeBoard, true},
+			{"editor can't manage cards", &model.BoardMember{SchemeEditor: true}, model.PermissionManageBoardCards, false},
+			{"editor can't manage properties", &model.BoardMember{SchemeEditor: true}, model.PermissionManageBoardProperties, false},
+			{"editor can't freeze", &model.BoardMember{SchemeEditor: true}, model.PermissionFreezeBoard, false},
+			{"commenter can't comment", &model.BoardMember{SchemeCommenter: true}, model.PermissionCommentBoardCards, false},
+			{"viewer can view", &model.BoardMember{SchemeViewer: true}, model.PermissionViewBoard, true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.member.UserID = "user-id"
+				tc.member.BoardID = frozenBoard.ID
+
+				th.store.EXPECT().
+					GetMemberForBoard(tc.member.BoardID, tc.member.UserID).
+					Return(tc.member, nil).
+					Times(1)
+
+				th.store.EXPECT().
+					GetBoard(frozenBoard.ID).
+					Return(frozenBoard, nil).
+					MaxTimes(1)
+
+				hasPermission := th.permissions.HasPermissionToBoard(tc.member.UserID, tc.member.BoardID, tc.permission)
+				assert.Equal(t, tc.hasPermission, hasPermission)
+			})
+		}
+	})
+
+	t.Run("loaded board", func(t *testing.T) {
+		frozenBoard := &model.Board{ID: "frozen-board-id", Frozen: true}
+		editor := &model.BoardMember{UserID: "user-id", BoardID: frozenBoard.ID, SchemeEditor: true}
+
+		// the board isn't loaded again
+		th.store.EXPECT().
+			GetMemberForBoard(frozenBoard.ID, editor.UserID).
+			Return(editor, nil).
+			Times(2)
+
+		assert.False(t, th.permissions.HasPermissionToLoadedBoard(editor.UserID, frozenBoard, model.PermissionManageBoardCards))
+		assert.True(t, th.permissions.HasPermissionToLoadedBoard(editor.UserID, frozenBoard, model.PermissionViewBoard))
+	})
+
+	t.Run("board isn't loaded without the role permission", func(t *testing.T) {
+		viewer := &model.BoardMember{UserID: "user-id", BoardID: "board-id", SchemeViewer: true}
+
+		th.store.EXPECT().
+			GetMemberForBoard(viewer.BoardID, viewer.UserID).
+			Return(viewer, nil).
+			Times(1)
+
+		assert.False(t, th.permissions.HasPermissionToBoard(viewer.UserID, viewer.BoardID, model.PermissionManageBoardCards))
+	})
+
 	t.Run("Manage Team Permission ", func(t *testing.T) {
 		member := &model.BoardMember{
 			UserID:       "user-id",
@@ -164,6 +240,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
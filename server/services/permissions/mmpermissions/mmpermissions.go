@@ -77,6 +77,17 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return false
 	}
 
+	return s.HasPermissionToLoadedBoard(userID, board, permission)
+}
+
+// HasPermissionToLoadedBoard checks the permissions to a board the caller
+// already loaded.
+func (s *Service) HasPermissionToLoadedBoard(userID string, board *model.Board, permission *mmModel.Permission) bool {
+	if userID == "" || board == nil || permission == nil {
+		return false
+	}
+	boardID := board.ID
+
 	// we need to check that the user has permission to see the team
 	// regardless of its local permissions to the board
 	if !s.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
@@ -113,10 +124,15 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		return true
 	}
 
+	// frozen boards can only be modified by their admins
+	if !member.SchemeAdmin && board.IsFrozen() && permissions.IsFreezablePermission(permission) {
+		return false
+	}
+
 	switch permission {
-	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
+	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments, model.PermissionFreezeBoard:
 		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
+	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties, model.PermissionArchiveBoardCards, model.PermissionUnarchiveBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor
 	case model.PermissionCommentBoardCards:
 		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
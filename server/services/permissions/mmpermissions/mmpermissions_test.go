@@ -142,6 +142,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
 		}
@@ -160,6 +162,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
 		}
@@ -191,6 +195,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
@@ -214,6 +220,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
@@ -233,6 +241,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionArchiveBoardCards,
+			model.PermissionUnarchiveBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
 		}
@@ -240,4 +250,85 @@ func TestHasPermissionToBoard(t *testing.T) {
 		hasNotPermissionTo := []*mmModel.Permission{}
 		th.checkBoardPermissions("elevated-admin", member, teamID, hasPermissionTo, hasNotPermissionTo)
 	})
+
+	t.Run("frozen board", func(t *testing.T) {
+		frozenBoard := &model.Board{ID: boardID, TeamID: teamID, Frozen: true}
+
+		testCases := []struct {
+			name          string
+			member        *model.BoardMember
+			permission    *mmModel.Permission
+			hasPermission bool
+		}{
+			{"admin can manage cards", &model.BoardMember{SchemeAdmin: true}, model.PermissionManageBoardCards, true},
+			{"admin can freeze", &model.BoardMember{SchemeAdmin: true}, model.PermissionFreezeBoard, true},
+			{"editor can't manage cards", &model.BoardMember{SchemeEditor: true}, model.PermissionManageBoardCards, false},
+			{"editor can't manage properties", &model.BoardMember{SchemeEditor: true}, model.PermissionManageBoardProperties, false},
+			{"commenter can't comment", &model.BoardMember{SchemeCommenter: true}, model.PermissionCommentBoardCards, false},
+			{"viewer can view", &model.BoardMember{SchemeViewer: true}, model.PermissionViewBoard, true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.member.UserID = userID
+				tc.member.BoardID = boardID
+
+				th.store.EXPECT().
+					GetBoard(boardID).
+					Return(frozenBoard, nil).
+					Times(1)
+
+				th.api.EXPECT().
+					HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).
+					Return(true).
+					Times(1)
+
+				th.store.EXPECT().
+					GetMemberForBoard(boardID, userID).
+					Return(tc.member, nil).
+					Times(1)
+
+				if !tc.member.SchemeAdmin {
+					th.api.EXPECT().
+						HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).
+						Return(false).
+						Times(1)
+				}
+
+				hasPermission := th.permissions.HasPermissionToBoard(userID, boardID, tc.permission)
+				assert.Equal(t, tc.hasPermission, hasPermission)
+			})
+		}
+	})
+
+	t.Run("expired board freeze", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:       userID,
+			BoardID:      boardID,
+			SchemeEditor: true,
+		}
+
+		th.store.EXPECT().
+			GetBoard(boardID).
+			Return(&model.Board{ID: boardID, TeamID: teamID, Frozen: true, FrozenUntil: 1}, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).
+			Return(true).
+			Times(1)
+
+		th.store.EXPECT().
+			GetMemberForBoard(boardID, userID).
+			Return(member, nil).
+			Times(1)
+
+		th.api.EXPECT().
+			HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).
+			Return(false).
+			Times(1)
+
+		hasPermission := th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards)
+		assert.True(t, hasPermission)
+	})
 }
@@ -17,8 +17,36 @@ type PermissionsService interface {
 	HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool
 }
 
+// BoardPermissionsService is implemented by the permissions services
+// that can check the permissions to a board the caller already loaded,
+// without querying it again.
+type BoardPermissionsService interface {
+	HasPermissionToLoadedBoard(userID string, board *model.Board, permission *mmModel.Permission) bool
+}
+
+// HasPermissionToLoadedBoard checks the permissions to a board the caller
+// already loaded, or to its ID if the service can't use the board.
+func HasPermissionToLoadedBoard(service PermissionsService, userID string, board *model.Board, permission *mmModel.Permission) bool {
+	if boardService, ok := service.(BoardPermissionsService); ok {
+		return boardService.HasPermissionToLoadedBoard(userID, board, permission)
+	}
+	return service.HasPermissionToBoard(userID, board.ID, permission)
+}
+
 type Store interface {
 	GetBoard(boardID string) (*model.Board, error)
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
 }
+
+// IsFreezablePermission returns true if the permission is revoked
+// from non admin members while a board is frozen.
+func IsFreezablePermission(permission *mmModel.Permission) bool {
+	switch permission {
+	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties, model.PermissionCommentBoardCards,
+		model.PermissionArchiveBoardCards, model.PermissionUnarchiveBoardCards:
+		return true
+	default:
+		return false
+	}
+}
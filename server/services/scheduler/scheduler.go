@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,6 +18,7 @@ type ScheduledTask struct {
 	function  func()
 	cancel    chan struct{}
 	cancelled chan struct{}
+	lastRun   int64
 }
 
 func CreateTask(name string, function TaskFunc, timeToExecution time.Duration) *ScheduledTask {
@@ -35,6 +37,7 @@ func createTask(name string, function TaskFunc, interval time.Duration, recurrin
 		function:  function,
 		cancel:    make(chan struct{}),
 		cancelled: make(chan struct{}),
+		lastRun:   time.Now().UnixNano(),
 	}
 
 	go func() {
@@ -47,6 +50,7 @@ func createTask(name string, function TaskFunc, interval time.Duration, recurrin
 			select {
 			case <-ticker.C:
 				function()
+				atomic.StoreInt64(&task.lastRun, time.Now().UnixNano())
 			case <-task.cancel:
 				return
 			}
@@ -65,6 +69,25 @@ func (task *ScheduledTask) Cancel() {
 	<-task.cancelled
 }
 
+// LastRun returns when the task last finished running its function, or
+// when it was created if it hasn't run yet.
+func (task *ScheduledTask) LastRun() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&task.lastRun))
+}
+
+// IsAlive returns false if a recurring task has been cancelled, or hasn't
+// finished running its function for more than two intervals, which
+// happens when the function is stuck.
+func (task *ScheduledTask) IsAlive() bool {
+	select {
+	case <-task.cancelled:
+		return false
+	default:
+	}
+
+	return time.Since(task.LastRun()) <= 2*task.Interval
+}
+
 func (task *ScheduledTask) String() string {
 	return fmt.Sprintf(
 		"%s\nInterval: %s\nRecurring: %t\n",
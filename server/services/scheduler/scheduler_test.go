@@ -79,3 +79,32 @@ func TestCancelTask(t *testing.T) {
 	time.Sleep(taskTime + taskWait)
 	assert.EqualValues(t, 0, atomic.LoadInt32(executionCount))
 }
+
+func TestTaskIsAlive(t *testing.T) {
+	taskTime := time.Millisecond * 50
+
+	t.Run("running task", func(t *testing.T) {
+		task := CreateRecurringTask("Test Task", func() {}, taskTime)
+		defer task.Cancel()
+
+		time.Sleep(taskTime * 3)
+		assert.True(t, task.IsAlive())
+		assert.WithinDuration(t, time.Now(), task.LastRun(), taskTime*2)
+	})
+
+	t.Run("stuck task", func(t *testing.T) {
+		release := make(chan struct{})
+		task := CreateRecurringTask("Test Task", func() { <-release }, taskTime)
+		defer task.Cancel()
+		defer close(release)
+
+		time.Sleep(taskTime * 4)
+		assert.False(t, task.IsAlive())
+	})
+
+	t.Run("cancelled task", func(t *testing.T) {
+		task := CreateRecurringTask("Test Task", func() {}, taskTime)
+		task.Cancel()
+		assert.False(t, task.IsAlive())
+	})
+}
@@ -124,6 +124,14 @@ func (s *MattermostAuthLayer) UpdateUser(user *model.User) (*model.User, error)
 	return nil, store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
+func (s *MattermostAuthLayer) GetAllUsers(includeDeleted bool) ([]*model.User, error) {
+	return nil, store.NewNotSupportedError("no user listing from focalboard, list them using mattermost")
+}
+
+func (s *MattermostAuthLayer) DeactivateUser(userID string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
 func (s *MattermostAuthLayer) UpdateUserPassword(username, password string) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
@@ -596,6 +604,21 @@ func (s *MattermostAuthLayer) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 	return nil
 }
 
+func (s *MattermostAuthLayer) UpdateFileInfoPath(id, path string) error {
+	query := s.getQueryBuilder().
+		Update("FileInfo").
+		Set("Path", path).
+		Set("UpdateAt", utils.GetMillis()).
+		Where(sq.Eq{"Id": id})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("failed to update fileinfo path", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
+
 func (s *MattermostAuthLayer) GetLicense() *mmModel.License {
 	return s.servicesAPI.GetLicense()
 }
@@ -620,6 +643,9 @@ func boardFields(prefix string) []string { //nolint:unparam
 		"create_at",
 		"update_at",
 		"delete_at",
+		"COALESCE(frozen, false)",
+		"COALESCE(frozen_reason, '')",
+		"COALESCE(frozen_until, 0)",
 	}
 
 	if prefix == "" {
@@ -922,6 +948,9 @@ func (s *MattermostAuthLayer) boardsFromRows(rows *sql.Rows, removeDuplicates bo
 			&board.CreateAt,
 			&board.UpdateAt,
 			&board.DeleteAt,
+			&board.Frozen,
+			&board.FrozenReason,
+			&board.FrozenUntil,
 		)
 		if err != nil {
 			s.logger.Error("boardsFromRows scan error", mlog.Err(err))
@@ -36,6 +36,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcquireLease mocks base method.
+func (m *MockStore) AcquireLease(arg0, arg1 string, arg2, arg3 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease.
+func (mr *MockStoreMockRecorder) AcquireLease(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockStore)(nil).AcquireLease), arg0, arg1, arg2, arg3)
+}
+
 // AddUpdateCategoryBoard mocks base method.
 func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1 string, arg2 []string) error {
 	m.ctrl.T.Helper()
@@ -65,6 +80,51 @@ func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1)
 }
 
+// CancelJob mocks base method.
+func (m *MockStore) CancelJob(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockStoreMockRecorder) CancelJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockStore)(nil).CancelJob), arg0, arg1)
+}
+
+// CheckIntegrity mocks base method.
+func (m *MockStore) CheckIntegrity() ([]*model.IntegrityCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIntegrity")
+	ret0, _ := ret[0].([]*model.IntegrityCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIntegrity indicates an expected call of CheckIntegrity.
+func (mr *MockStoreMockRecorder) CheckIntegrity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIntegrity", reflect.TypeOf((*MockStore)(nil).CheckIntegrity))
+}
+
+// ClaimNextJob mocks base method.
+func (m *MockStore) ClaimNextJob(arg0 []string, arg1 string, arg2 int64) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNextJob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNextJob indicates an expected call of ClaimNextJob.
+func (mr *MockStoreMockRecorder) ClaimNextJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNextJob", reflect.TypeOf((*MockStore)(nil).ClaimNextJob), arg0, arg1, arg2)
+}
+
 // CleanUpSessions mocks base method.
 func (m *MockStore) CleanUpSessions(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -124,6 +184,21 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateJob mocks base method.
+func (m *MockStore) CreateJob(arg0 *model.Job) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockStoreMockRecorder) CreateJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockStore)(nil).CreateJob), arg0)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -196,6 +271,20 @@ func (mr *MockStoreMockRecorder) DBVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBVersion", reflect.TypeOf((*MockStore)(nil).DBVersion))
 }
 
+// DeactivateUser mocks base method.
+func (m *MockStore) DeactivateUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockStoreMockRecorder) DeactivateUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockStore)(nil).DeactivateUser), arg0)
+}
+
 // DeleteBlock mocks base method.
 func (m *MockStore) DeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -266,6 +355,104 @@ func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
 }
 
+// DeleteCardContent mocks base method.
+func (m *MockStore) DeleteCardContent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardContent indicates an expected call of DeleteCardContent.
+func (mr *MockStoreMockRecorder) DeleteCardContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardContent", reflect.TypeOf((*MockStore)(nil).DeleteCardContent), arg0, arg1, arg2)
+}
+
+// DeleteCardMirror mocks base method.
+func (m *MockStore) DeleteCardMirror(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardMirror", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardMirror indicates an expected call of DeleteCardMirror.
+func (mr *MockStoreMockRecorder) DeleteCardMirror(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardMirror", reflect.TypeOf((*MockStore)(nil).DeleteCardMirror), arg0, arg1, arg2)
+}
+
+// DeleteCardReaction mocks base method.
+func (m *MockStore) DeleteCardReaction(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardReaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardReaction indicates an expected call of DeleteCardReaction.
+func (mr *MockStoreMockRecorder) DeleteCardReaction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardReaction", reflect.TypeOf((*MockStore)(nil).DeleteCardReaction), arg0, arg1, arg2)
+}
+
+// DeleteCardReminder mocks base method.
+func (m *MockStore) DeleteCardReminder(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardReminder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardReminder indicates an expected call of DeleteCardReminder.
+func (mr *MockStoreMockRecorder) DeleteCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardReminder", reflect.TypeOf((*MockStore)(nil).DeleteCardReminder), arg0)
+}
+
+// DeleteCardRemindersForCard mocks base method.
+func (m *MockStore) DeleteCardRemindersForCard(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardRemindersForCard", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardRemindersForCard indicates an expected call of DeleteCardRemindersForCard.
+func (mr *MockStoreMockRecorder) DeleteCardRemindersForCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardRemindersForCard", reflect.TypeOf((*MockStore)(nil).DeleteCardRemindersForCard), arg0)
+}
+
+// DeleteCardVote mocks base method.
+func (m *MockStore) DeleteCardVote(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardVote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardVote indicates an expected call of DeleteCardVote.
+func (mr *MockStoreMockRecorder) DeleteCardVote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardVote", reflect.TypeOf((*MockStore)(nil).DeleteCardVote), arg0, arg1)
+}
+
+// DeleteCardVotesForCard mocks base method.
+func (m *MockStore) DeleteCardVotesForCard(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardVotesForCard", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardVotesForCard indicates an expected call of DeleteCardVotesForCard.
+func (mr *MockStoreMockRecorder) DeleteCardVotesForCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardVotesForCard", reflect.TypeOf((*MockStore)(nil).DeleteCardVotesForCard), arg0)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockStore) DeleteCategory(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -280,6 +467,35 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteCommentReaction mocks base method.
+func (m *MockStore) DeleteCommentReaction(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommentReaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCommentReaction indicates an expected call of DeleteCommentReaction.
+func (mr *MockStoreMockRecorder) DeleteCommentReaction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommentReaction", reflect.TypeOf((*MockStore)(nil).DeleteCommentReaction), arg0, arg1, arg2)
+}
+
+// DeleteFinishedJobs mocks base method.
+func (m *MockStore) DeleteFinishedJobs(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedJobs", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedJobs indicates an expected call of DeleteFinishedJobs.
+func (mr *MockStoreMockRecorder) DeleteFinishedJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedJobs", reflect.TypeOf((*MockStore)(nil).DeleteFinishedJobs), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -367,6 +583,21 @@ func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3)
 }
 
+// FinishJob mocks base method.
+func (m *MockStore) FinishJob(arg0 *model.Job, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishJob", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishJob indicates an expected call of FinishJob.
+func (mr *MockStoreMockRecorder) FinishJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishJob", reflect.TypeOf((*MockStore)(nil).FinishJob), arg0, arg1)
+}
+
 // GetActiveUserCount mocks base method.
 func (m *MockStore) GetActiveUserCount(arg0 int64) (int, error) {
 	m.ctrl.T.Helper()
@@ -397,6 +628,36 @@ func (mr *MockStoreMockRecorder) GetAllTeams() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockStore)(nil).GetAllTeams))
 }
 
+// GetAllUsers mocks base method.
+func (m *MockStore) GetAllUsers(arg0 bool) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockStoreMockRecorder) GetAllUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockStore)(nil).GetAllUsers), arg0)
+}
+
+// GetBacklinks mocks base method.
+func (m *MockStore) GetBacklinks(arg0, arg1 string) ([]*model.Backlink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Backlink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockStoreMockRecorder) GetBacklinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockStore)(nil).GetBacklinks), arg0, arg1)
+}
+
 // GetBlock mocks base method.
 func (m *MockStore) GetBlock(arg0 string) (*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -671,6 +932,21 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardUnreadCounts mocks base method.
+func (m *MockStore) GetBoardUnreadCounts(arg0 string, arg1 []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardUnreadCounts indicates an expected call of GetBoardUnreadCounts.
+func (mr *MockStoreMockRecorder) GetBoardUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardUnreadCounts", reflect.TypeOf((*MockStore)(nil).GetBoardUnreadCounts), arg0, arg1)
+}
+
 // GetBoardsComplianceHistory mocks base method.
 func (m *MockStore) GetBoardsComplianceHistory(arg0 model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	m.ctrl.T.Helper()
@@ -733,6 +1009,21 @@ func (mr *MockStoreMockRecorder) GetBoardsInTeamByIds(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsInTeamByIds", reflect.TypeOf((*MockStore)(nil).GetBoardsInTeamByIds), arg0, arg1)
 }
 
+// GetBoardsWithExpiredFreeze mocks base method.
+func (m *MockStore) GetBoardsWithExpiredFreeze(arg0 int64) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsWithExpiredFreeze", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsWithExpiredFreeze indicates an expected call of GetBoardsWithExpiredFreeze.
+func (mr *MockStoreMockRecorder) GetBoardsWithExpiredFreeze(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsWithExpiredFreeze", reflect.TypeOf((*MockStore)(nil).GetBoardsWithExpiredFreeze), arg0)
+}
+
 // GetCardLimitTimestamp mocks base method.
 func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
 	m.ctrl.T.Helper()
@@ -748,6 +1039,126 @@ func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
 }
 
+// GetCardMirror mocks base method.
+func (m *MockStore) GetCardMirror(arg0, arg1 string) (*model.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardMirror", arg0, arg1)
+	ret0, _ := ret[0].(*model.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardMirror indicates an expected call of GetCardMirror.
+func (mr *MockStoreMockRecorder) GetCardMirror(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardMirror", reflect.TypeOf((*MockStore)(nil).GetCardMirror), arg0, arg1)
+}
+
+// GetCardMirrorsForBoard mocks base method.
+func (m *MockStore) GetCardMirrorsForBoard(arg0 string) ([]*model.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardMirrorsForBoard", arg0)
+	ret0, _ := ret[0].([]*model.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardMirrorsForBoard indicates an expected call of GetCardMirrorsForBoard.
+func (mr *MockStoreMockRecorder) GetCardMirrorsForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardMirrorsForBoard", reflect.TypeOf((*MockStore)(nil).GetCardMirrorsForBoard), arg0)
+}
+
+// GetCardMirrorsForCard mocks base method.
+func (m *MockStore) GetCardMirrorsForCard(arg0 string) ([]*model.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardMirrorsForCard", arg0)
+	ret0, _ := ret[0].([]*model.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardMirrorsForCard indicates an expected call of GetCardMirrorsForCard.
+func (mr *MockStoreMockRecorder) GetCardMirrorsForCard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardMirrorsForCard", reflect.TypeOf((*MockStore)(nil).GetCardMirrorsForCard), arg0)
+}
+
+// GetCardRedirect mocks base method.
+func (m *MockStore) GetCardRedirect(arg0, arg1 string) (*model.CardRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRedirect", arg0, arg1)
+	ret0, _ := ret[0].(*model.CardRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRedirect indicates an expected call of GetCardRedirect.
+func (mr *MockStoreMockRecorder) GetCardRedirect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRedirect", reflect.TypeOf((*MockStore)(nil).GetCardRedirect), arg0, arg1)
+}
+
+// GetCardReminder mocks base method.
+func (m *MockStore) GetCardReminder(arg0 string) (*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardReminder", arg0)
+	ret0, _ := ret[0].(*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardReminder indicates an expected call of GetCardReminder.
+func (mr *MockStoreMockRecorder) GetCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardReminder", reflect.TypeOf((*MockStore)(nil).GetCardReminder), arg0)
+}
+
+// GetCardRemindersForUser mocks base method.
+func (m *MockStore) GetCardRemindersForUser(arg0 string) ([]*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRemindersForUser", arg0)
+	ret0, _ := ret[0].([]*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRemindersForUser indicates an expected call of GetCardRemindersForUser.
+func (mr *MockStoreMockRecorder) GetCardRemindersForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRemindersForUser", reflect.TypeOf((*MockStore)(nil).GetCardRemindersForUser), arg0)
+}
+
+// GetCardUnreadCounts mocks base method.
+func (m *MockStore) GetCardUnreadCounts(arg0, arg1 string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardUnreadCounts indicates an expected call of GetCardUnreadCounts.
+func (mr *MockStoreMockRecorder) GetCardUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardUnreadCounts", reflect.TypeOf((*MockStore)(nil).GetCardUnreadCounts), arg0, arg1)
+}
+
+// GetCardVotes mocks base method.
+func (m *MockStore) GetCardVotes(arg0 []string, arg1 string) (map[string]*model.CardVotes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardVotes", arg0, arg1)
+	ret0, _ := ret[0].(map[string]*model.CardVotes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardVotes indicates an expected call of GetCardVotes.
+func (mr *MockStoreMockRecorder) GetCardVotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardVotes", reflect.TypeOf((*MockStore)(nil).GetCardVotes), arg0, arg1)
+}
+
 // GetCategory mocks base method.
 func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	m.ctrl.T.Helper()
@@ -793,6 +1204,36 @@ func (mr *MockStoreMockRecorder) GetCloudLimits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudLimits", reflect.TypeOf((*MockStore)(nil).GetCloudLimits))
 }
 
+// GetCommentReactions mocks base method.
+func (m *MockStore) GetCommentReactions(arg0 []string) ([]*model.CommentReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentReactions", arg0)
+	ret0, _ := ret[0].([]*model.CommentReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentReactions indicates an expected call of GetCommentReactions.
+func (mr *MockStoreMockRecorder) GetCommentReactions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentReactions", reflect.TypeOf((*MockStore)(nil).GetCommentReactions), arg0)
+}
+
+// GetDueCardReminders mocks base method.
+func (m *MockStore) GetDueCardReminders(arg0 int64) ([]*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCardReminders", arg0)
+	ret0, _ := ret[0].([]*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCardReminders indicates an expected call of GetDueCardReminders.
+func (mr *MockStoreMockRecorder) GetDueCardReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCardReminders", reflect.TypeOf((*MockStore)(nil).GetDueCardReminders), arg0)
+}
+
 // GetFileInfo mocks base method.
 func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
 	m.ctrl.T.Helper()
@@ -808,6 +1249,36 @@ func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
 }
 
+// GetJob mocks base method.
+func (m *MockStore) GetJob(arg0 string) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockStoreMockRecorder) GetJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockStore)(nil).GetJob), arg0)
+}
+
+// GetJobs mocks base method.
+func (m *MockStore) GetJobs(arg0 model.QueryJobsOptions) ([]*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobs", arg0)
+	ret0, _ := ret[0].([]*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobs indicates an expected call of GetJobs.
+func (mr *MockStoreMockRecorder) GetJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockStore)(nil).GetJobs), arg0)
+}
+
 // GetLicense mocks base method.
 func (m *MockStore) GetLicense() *model0.License {
 	m.ctrl.T.Helper()
@@ -867,6 +1338,21 @@ func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
 }
 
+// GetMigrationStatus mocks base method.
+func (m *MockStore) GetMigrationStatus() (*model.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationStatus")
+	ret0, _ := ret[0].(*model.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationStatus indicates an expected call of GetMigrationStatus.
+func (mr *MockStoreMockRecorder) GetMigrationStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationStatus", reflect.TypeOf((*MockStore)(nil).GetMigrationStatus))
+}
+
 // GetNextNotificationHint mocks base method.
 func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -897,6 +1383,21 @@ func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
 }
 
+// GetReadState mocks base method.
+func (m *MockStore) GetReadState(arg0, arg1, arg2 string) (*model.ReadState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ReadState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadState indicates an expected call of GetReadState.
+func (mr *MockStoreMockRecorder) GetReadState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadState", reflect.TypeOf((*MockStore)(nil).GetReadState), arg0, arg1, arg2)
+}
+
 // GetRegisteredUserCount mocks base method.
 func (m *MockStore) GetRegisteredUserCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -1287,6 +1788,21 @@ func (mr *MockStoreMockRecorder) GetUsersList(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0, arg1, arg2)
 }
 
+// HasActiveJob mocks base method.
+func (m *MockStore) HasActiveJob(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveJob", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveJob indicates an expected call of HasActiveJob.
+func (mr *MockStoreMockRecorder) HasActiveJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveJob", reflect.TypeOf((*MockStore)(nil).HasActiveJob), arg0)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1346,6 +1862,79 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// InsertCardContent mocks base method.
+func (m *MockStore) InsertCardContent(arg0 *model.Block, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCardContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCardContent indicates an expected call of InsertCardContent.
+func (mr *MockStoreMockRecorder) InsertCardContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCardContent", reflect.TypeOf((*MockStore)(nil).InsertCardContent), arg0, arg1, arg2)
+}
+
+// MarkCardReminderDelivered mocks base method.
+func (m *MockStore) MarkCardReminderDelivered(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCardReminderDelivered", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkCardReminderDelivered indicates an expected call of MarkCardReminderDelivered.
+func (mr *MockStoreMockRecorder) MarkCardReminderDelivered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCardReminderDelivered", reflect.TypeOf((*MockStore)(nil).MarkCardReminderDelivered), arg0, arg1)
+}
+
+// MoveBoardToTeam mocks base method.
+func (m *MockStore) MoveBoardToTeam(arg0, arg1, arg2 string, arg3 []string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveBoardToTeam", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveBoardToTeam indicates an expected call of MoveBoardToTeam.
+func (mr *MockStoreMockRecorder) MoveBoardToTeam(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBoardToTeam", reflect.TypeOf((*MockStore)(nil).MoveBoardToTeam), arg0, arg1, arg2, arg3)
+}
+
+// MoveCardContent mocks base method.
+func (m *MockStore) MoveCardContent(arg0, arg1 string, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCardContent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCardContent indicates an expected call of MoveCardContent.
+func (mr *MockStoreMockRecorder) MoveCardContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCardContent", reflect.TypeOf((*MockStore)(nil).MoveCardContent), arg0, arg1, arg2, arg3)
+}
+
+// MoveCardToBoard mocks base method.
+func (m *MockStore) MoveCardToBoard(arg0 *model.Block, arg1, arg2 string) (*model.CardRedirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCardToBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CardRedirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCardToBoard indicates an expected call of MoveCardToBoard.
+func (mr *MockStoreMockRecorder) MoveCardToBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCardToBoard", reflect.TypeOf((*MockStore)(nil).MoveCardToBoard), arg0, arg1, arg2)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1433,6 +2022,21 @@ func (mr *MockStoreMockRecorder) PostMessage(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockStore)(nil).PostMessage), arg0, arg1, arg2)
 }
 
+// RebuildBlockLinks mocks base method.
+func (m *MockStore) RebuildBlockLinks(arg0 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildBlockLinks", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildBlockLinks indicates an expected call of RebuildBlockLinks.
+func (mr *MockStoreMockRecorder) RebuildBlockLinks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildBlockLinks", reflect.TypeOf((*MockStore)(nil).RebuildBlockLinks), arg0)
+}
+
 // RefreshSession mocks base method.
 func (m *MockStore) RefreshSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1447,6 +2051,20 @@ func (mr *MockStoreMockRecorder) RefreshSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockStore)(nil).RefreshSession), arg0)
 }
 
+// ReleaseLease mocks base method.
+func (m *MockStore) ReleaseLease(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLease", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLease indicates an expected call of ReleaseLease.
+func (mr *MockStoreMockRecorder) ReleaseLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLease", reflect.TypeOf((*MockStore)(nil).ReleaseLease), arg0, arg1)
+}
+
 // RemoveDefaultTemplates mocks base method.
 func (m *MockStore) RemoveDefaultTemplates(arg0 []*model.Board) error {
 	m.ctrl.T.Helper()
@@ -1491,6 +2109,36 @@ func (mr *MockStoreMockRecorder) ReorderCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategoryBoards", reflect.TypeOf((*MockStore)(nil).ReorderCategoryBoards), arg0, arg1)
 }
 
+// ResetStaleJobs mocks base method.
+func (m *MockStore) ResetStaleJobs(arg0, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetStaleJobs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetStaleJobs indicates an expected call of ResetStaleJobs.
+func (mr *MockStoreMockRecorder) ResetStaleJobs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetStaleJobs", reflect.TypeOf((*MockStore)(nil).ResetStaleJobs), arg0, arg1)
+}
+
+// RetryJob mocks base method.
+func (m *MockStore) RetryJob(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryJob", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryJob indicates an expected call of RetryJob.
+func (mr *MockStoreMockRecorder) RetryJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryJob", reflect.TypeOf((*MockStore)(nil).RetryJob), arg0, arg1)
+}
+
 // RunDataRetention mocks base method.
 func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1506,6 +2154,79 @@ func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
 }
 
+// SaveCardMirror mocks base method.
+func (m *MockStore) SaveCardMirror(arg0 *model.CardMirror) (*model.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardMirror", arg0)
+	ret0, _ := ret[0].(*model.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCardMirror indicates an expected call of SaveCardMirror.
+func (mr *MockStoreMockRecorder) SaveCardMirror(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardMirror", reflect.TypeOf((*MockStore)(nil).SaveCardMirror), arg0)
+}
+
+// SaveCardReaction mocks base method.
+func (m *MockStore) SaveCardReaction(arg0 *model.CardReaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardReaction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCardReaction indicates an expected call of SaveCardReaction.
+func (mr *MockStoreMockRecorder) SaveCardReaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardReaction", reflect.TypeOf((*MockStore)(nil).SaveCardReaction), arg0)
+}
+
+// SaveCardReminder mocks base method.
+func (m *MockStore) SaveCardReminder(arg0 *model.CardReminder) (*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardReminder", arg0)
+	ret0, _ := ret[0].(*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCardReminder indicates an expected call of SaveCardReminder.
+func (mr *MockStoreMockRecorder) SaveCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardReminder", reflect.TypeOf((*MockStore)(nil).SaveCardReminder), arg0)
+}
+
+// SaveCardVote mocks base method.
+func (m *MockStore) SaveCardVote(arg0 *model.CardVote, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardVote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCardVote indicates an expected call of SaveCardVote.
+func (mr *MockStoreMockRecorder) SaveCardVote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardVote", reflect.TypeOf((*MockStore)(nil).SaveCardVote), arg0, arg1)
+}
+
+// SaveCommentReaction mocks base method.
+func (m *MockStore) SaveCommentReaction(arg0 *model.CommentReaction) (*model.CommentReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommentReaction", arg0)
+	ret0, _ := ret[0].(*model.CommentReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCommentReaction indicates an expected call of SaveCommentReaction.
+func (mr *MockStoreMockRecorder) SaveCommentReaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommentReaction", reflect.TypeOf((*MockStore)(nil).SaveCommentReaction), arg0)
+}
+
 // SaveFileInfo mocks base method.
 func (m *MockStore) SaveFileInfo(arg0 *model0.FileInfo) error {
 	m.ctrl.T.Helper()
@@ -1535,6 +2256,20 @@ func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
 }
 
+// SaveReadState mocks base method.
+func (m *MockStore) SaveReadState(arg0 *model.ReadState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReadState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReadState indicates an expected call of SaveReadState.
+func (mr *MockStoreMockRecorder) SaveReadState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReadState", reflect.TypeOf((*MockStore)(nil).SaveReadState), arg0)
+}
+
 // SearchBoardsForUser mocks base method.
 func (m *MockStore) SearchBoardsForUser(arg0 string, arg1 model.BoardSearchField, arg2 string, arg3 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1565,6 +2300,21 @@ func (mr *MockStoreMockRecorder) SearchBoardsForUserInTeam(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUserInTeam", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUserInTeam), arg0, arg1, arg2)
 }
 
+// SearchCardsForBoard mocks base method.
+func (m *MockStore) SearchCardsForBoard(arg0, arg1 string) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCardsForBoard", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCardsForBoard indicates an expected call of SearchCardsForBoard.
+func (mr *MockStoreMockRecorder) SearchCardsForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCardsForBoard", reflect.TypeOf((*MockStore)(nil).SearchCardsForBoard), arg0, arg1)
+}
+
 // SearchUserChannels mocks base method.
 func (m *MockStore) SearchUserChannels(arg0, arg1, arg2 string) ([]*model0.Channel, error) {
 	m.ctrl.T.Helper()
@@ -1651,6 +2401,21 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// TransferBoardOwnership mocks base method.
+func (m *MockStore) TransferBoardOwnership(arg0, arg1 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBoardOwnership", arg0, arg1)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBoardOwnership indicates an expected call of TransferBoardOwnership.
+func (mr *MockStoreMockRecorder) TransferBoardOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBoardOwnership", reflect.TypeOf((*MockStore)(nil).TransferBoardOwnership), arg0, arg1)
+}
+
 // UndeleteBlock mocks base method.
 func (m *MockStore) UndeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1708,6 +2473,35 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateFileInfoPath mocks base method.
+func (m *MockStore) UpdateFileInfoPath(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileInfoPath", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileInfoPath indicates an expected call of UpdateFileInfoPath.
+func (mr *MockStoreMockRecorder) UpdateFileInfoPath(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileInfoPath", reflect.TypeOf((*MockStore)(nil).UpdateFileInfoPath), arg0, arg1)
+}
+
+// UpdateJobProgress mocks base method.
+func (m *MockStore) UpdateJobProgress(arg0, arg1 string, arg2 int, arg3 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobProgress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobProgress indicates an expected call of UpdateJobProgress.
+func (mr *MockStoreMockRecorder) UpdateJobProgress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobProgress", reflect.TypeOf((*MockStore)(nil).UpdateJobProgress), arg0, arg1, arg2, arg3)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
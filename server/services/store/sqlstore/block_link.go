@@ -0,0 +1,210 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveBlockLinks replaces the links saved for a block with the links
+// in its current title.
+func (s *SQLStore) saveBlockLinks(db sq.BaseRunner, block *model.Block) error {
+	if err := s.deleteBlockLinks(db, []string{block.ID}); err != nil {
+		return err
+	}
+
+	links := model.ParseBlockLinks(block)
+	if len(links) == 0 {
+		return nil
+	}
+
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"block_links").
+		Columns(
+			"source_id",
+			"target_board_id",
+			"target_card_id",
+			"source_board_id",
+			"source_card_id",
+			"create_at",
+		)
+
+	for _, link := range links {
+		link.CreateAt = now
+		query = query.Values(
+			link.SourceID,
+			link.TargetBoardID,
+			link.TargetCardID,
+			link.SourceBoardID,
+			link.SourceCardID,
+			link.CreateAt,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveBlockLinks error", mlog.String("blockID", block.ID), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *SQLStore) deleteBlockLinks(db sq.BaseRunner, sourceIDs []string) error {
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "block_links").
+		Where(sq.Eq{"source_id": sourceIDs})
+
+	if _, err := query.Exec(); err != nil {
+		return fmt.Errorf("cannot delete links of blocks: %w", err)
+	}
+
+	return nil
+}
+
+// getBacklinks returns the cards that link to a card, or to a board if
+// cardID is empty, most recently linked first. Links from deleted
+// blocks and boards are left out.
+func (s *SQLStore) getBacklinks(db sq.BaseRunner, boardID, cardID string) ([]*model.Backlink, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"sb.board_id",
+			"b.title",
+			"l.source_card_id",
+			"COALESCE(c.title, '')",
+			"MAX(l.create_at)",
+		).
+		From(s.tablePrefix+"block_links AS l").
+		Join(s.tablePrefix+"blocks AS sb ON sb.id = l.source_id").
+		Join(s.tablePrefix+"boards AS b ON b.id = sb.board_id").
+		LeftJoin(s.tablePrefix+"blocks AS c ON c.id = l.source_card_id").
+		Where(sq.Eq{"COALESCE(b.delete_at, 0)": 0}).
+		GroupBy("sb.board_id", "b.title", "l.source_card_id", "c.title").
+		OrderBy("MAX(l.create_at) DESC", "l.source_card_id")
+
+	if cardID != "" {
+		query = query.Where(sq.Eq{"l.target_card_id": cardID})
+	} else {
+		query = query.
+			Where(sq.Eq{"l.target_board_id": boardID}).
+			Where(sq.Eq{"l.target_card_id": ""})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBacklinks error", mlog.String("boardID", boardID), mlog.String("cardID", cardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	backlinks := []*model.Backlink{}
+	for rows.Next() {
+		var backlink model.Backlink
+		err = rows.Scan(
+			&backlink.BoardID,
+			&backlink.BoardTitle,
+			&backlink.CardID,
+			&backlink.CardTitle,
+			&backlink.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("getBacklinks scan error", mlog.Err(err))
+			return nil, err
+		}
+		backlinks = append(backlinks, &backlink)
+	}
+
+	return backlinks, nil
+}
+
+// moveBlockLinks updates the board of the links from and to a card that
+// has been moved to another board.
+func (s *SQLStore) moveBlockLinks(db sq.BaseRunner, cardID, toBoardID string) error {
+	updates := []struct {
+		boardColumn string
+		cardColumn  string
+	}{
+		{"source_board_id", "source_card_id"},
+		{"target_board_id", "target_card_id"},
+	}
+
+	for _, update := range updates {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"block_links").
+			Set(update.boardColumn, toBoardID).
+			Where(sq.Eq{update.cardColumn: cardID})
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("moveBlockLinks error", mlog.String("cardID", cardID), mlog.String("column", update.boardColumn), mlog.Err(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildBlockLinks saves again the links of all the blocks, by batches
+// of blocks, and deletes the links of blocks that no longer exist. It
+// returns the number of blocks whose links were saved.
+func (s *SQLStore) rebuildBlockLinks(db sq.BaseRunner, batchSize int) (int64, error) {
+	linkTypes := []model.BlockType{}
+	for _, blockType := range model.RegisteredBlockTypes() {
+		if blockType.HasLinks() {
+			linkTypes = append(linkTypes, blockType)
+		}
+	}
+
+	var count int64
+	lastID := ""
+	for {
+		query := s.getQueryBuilder(db).
+			Select(s.blockFields("")...).
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"type": linkTypes}).
+			Where(sq.Gt{"id": lastID}).
+			OrderBy("id").
+			Limit(uint64(batchSize))
+
+		rows, err := query.Query()
+		if err != nil {
+			s.logger.Error("rebuildBlockLinks error", mlog.Err(err))
+			return count, err
+		}
+		blocks, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return count, err
+		}
+
+		for _, block := range blocks {
+			if err := s.saveBlockLinks(db, block); err != nil {
+				return count, err
+			}
+		}
+		count += int64(len(blocks))
+
+		if len(blocks) < batchSize {
+			break
+		}
+		lastID = blocks[len(blocks)-1].ID
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "block_links").
+		Where(sq.Expr("source_id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks)"))
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		return count, fmt.Errorf("cannot delete the links of missing blocks: %w", err)
+	}
+
+	return count, nil
+}
@@ -70,6 +70,10 @@ func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([
 		query = query.Where(sq.Eq{"type": opts.BlockType})
 	}
 
+	if opts.ExcludeArchived {
+		query = query.Where(s.notArchivedCondition(""))
+	}
+
 	if opts.Page != 0 {
 		query = query.Offset(uint64(opts.Page * opts.PerPage))
 	}
@@ -89,6 +93,45 @@ func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([
 	return s.blocksFromRows(rows)
 }
 
+// notArchivedCondition returns the condition that filters out the
+// archived cards, which are the only blocks with the archivedAt field.
+func (s *SQLStore) notArchivedCondition(tableAlias string) string {
+	if tableAlias != "" && !strings.HasSuffix(tableAlias, ".") {
+		tableAlias += "."
+	}
+
+	if s.dbType == model.PostgresDBType {
+		return tableAlias + "fields->>'" + model.CardFieldArchivedAt + "' IS NULL"
+	}
+	return "JSON_EXTRACT(" + tableAlias + "fields, '$." + model.CardFieldArchivedAt + "') IS NULL"
+}
+
+// searchCardsForBoard returns the cards of a board whose title
+// contains all the words of the term, archived cards included.
+func (s *SQLStore) searchCardsForBoard(db sq.BaseRunner, boardID, term string) ([]*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard}).
+		OrderBy("update_at DESC")
+
+	conditions := sq.And{}
+	for _, word := range strings.Fields(term) {
+		conditions = append(conditions, sq.Like{"lower(title)": "%" + strings.ToLower(word) + "%"})
+	}
+	query = query.Where(conditions)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchCardsForBoard ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
 func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentID string, blockType string) ([]*model.Block, error) {
 	opts := model.QueryBlocksOptions{
 		BoardID:   boardID,
@@ -311,9 +354,28 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 		return err
 	}
 
+	if blockLinksChanged(existingBlock, block) {
+		return s.saveBlockLinks(db, block)
+	}
+
 	return nil
 }
 
+// blockLinksChanged returns true if the links of a block need to be
+// saved again after an update.
+func blockLinksChanged(existingBlock, block *model.Block) bool {
+	if existingBlock == nil {
+		return block.Type.HasLinks()
+	}
+	if !existingBlock.Type.HasLinks() && !block.Type.HasLinks() {
+		return false
+	}
+	return existingBlock.Type != block.Type ||
+		existingBlock.Title != block.Title ||
+		existingBlock.ParentID != block.ParentID ||
+		existingBlock.BoardID != block.BoardID
+}
+
 func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *model.BlockPatch, userID string) error {
 	existingBlock, err := s.getBlock(db, blockID)
 	if err != nil {
@@ -443,6 +505,16 @@ func (s *SQLStore) deleteBlockAndChildren(db sq.BaseRunner, blockID string, modi
 		return err
 	}
 
+	if err := s.deleteBlockLinks(db, []string{blockID}); err != nil {
+		return err
+	}
+
+	if block.Type == model.TypeCard {
+		if err := s.deleteCardMirrorsForCard(db, blockID); err != nil {
+			return err
+		}
+	}
+
 	if keepChildren {
 		return nil
 	}
@@ -519,6 +591,12 @@ func (s *SQLStore) undeleteBlock(db sq.BaseRunner, blockID string, modifiedBy st
 		return err
 	}
 
+	if block.Type.HasLinks() {
+		if err := s.saveBlockLinks(db, block); err != nil {
+			return err
+		}
+	}
+
 	return s.undeleteBlockChildren(db, block.BoardID, block.ID, modifiedBy)
 }
 
@@ -971,7 +1049,11 @@ func (s *SQLStore) deleteBlockChildren(db sq.BaseRunner, boardID string, parentI
 	}
 
 	fileIDs := make([]string, 0, len(blocks))
+	linkSourceIDs := []string{}
 	for _, block := range blocks {
+		if block.Type.HasLinks() {
+			linkSourceIDs = append(linkSourceIDs, block.ID)
+		}
 		fileIDWithExtention, fileIDExists := block.Fields["fileId"]
 		if fileIDExists {
 			fileIDs = append(fileIDs, retrieveFileIDFromBlockFieldStorage(fileIDWithExtention.(string)))
@@ -1005,7 +1087,7 @@ func (s *SQLStore) deleteBlockChildren(db sq.BaseRunner, boardID string, parentI
 		return err
 	}
 
-	return nil
+	return s.deleteBlockLinks(db, linkSourceIDs)
 }
 
 func (s *SQLStore) undeleteBlockChildren(db sq.BaseRunner, boardID string, parentID string, modifiedBy string) error {
@@ -1096,5 +1178,31 @@ func (s *SQLStore) undeleteBlockChildren(db sq.BaseRunner, boardID string, paren
 	rowsAffected, _ = result.RowsAffected()
 	s.logger.Debug("undeleteBlockChildren - insertHistoryQuery", mlog.Int64("rows_affected", rowsAffected))
 
+	return s.undeleteBlockChildrenLinks(db, boardID, parentID)
+}
+
+// undeleteBlockChildrenLinks saves again the links of the restored
+// children of a block, or of all the blocks of the board if parentID
+// is empty.
+func (s *SQLStore) undeleteBlockChildrenLinks(db sq.BaseRunner, boardID string, parentID string) error {
+	var blocks []*model.Block
+	var err error
+	if parentID != "" {
+		blocks, err = s.getBlocksWithParent(db, boardID, parentID)
+	} else {
+		blocks, err = s.getBlocksForBoard(db, boardID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if !block.Type.HasLinks() {
+			continue
+		}
+		if err := s.saveBlockLinks(db, block); err != nil {
+			return err
+		}
+	}
 	return nil
 }
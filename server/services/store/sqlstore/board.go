@@ -41,6 +41,9 @@ func boardFields(tableAlias string) []string {
 		tableAlias + "create_at",
 		tableAlias + "update_at",
 		tableAlias + "delete_at",
+		"COALESCE(" + tableAlias + "frozen, false)",
+		"COALESCE(" + tableAlias + "frozen_reason, '')",
+		"COALESCE(" + tableAlias + "frozen_until, 0)",
 	}
 }
 
@@ -64,6 +67,9 @@ func boardHistoryFields() []string {
 		"COALESCE(create_at, 0)",
 		"COALESCE(update_at, 0)",
 		"COALESCE(delete_at, 0)",
+		"COALESCE(frozen, false)",
+		"COALESCE(frozen_reason, '')",
+		"COALESCE(frozen_until, 0)",
 	}
 
 	return fields
@@ -107,6 +113,9 @@ func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
 			&board.CreateAt,
 			&board.UpdateAt,
 			&board.DeleteAt,
+			&board.Frozen,
+			&board.FrozenReason,
+			&board.FrozenUntil,
 		)
 		if err != nil {
 			s.logger.Error("boardsFromRows scan error", mlog.Err(err))
@@ -294,6 +303,26 @@ func (s *SQLStore) getBoardsInTeamByIds(db sq.BaseRunner, boardIDs []string, tea
 	return boards, nil
 }
 
+// getBoardsWithExpiredFreeze returns the frozen boards which have an
+// automatic unfreeze time previous to `before`.
+func (s *SQLStore) getBoardsWithExpiredFreeze(db sq.BaseRunner, before int64) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"frozen": true}).
+		Where(sq.Gt{"frozen_until": 0}).
+		Where(sq.LtOrEq{"frozen_until": before})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsWithExpiredFreeze ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
 func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, error) {
 	// Generate tracking IDs for in-built templates
 	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
@@ -355,6 +384,9 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		"create_at":        board.CreateAt,
 		"update_at":        board.UpdateAt,
 		"delete_at":        board.DeleteAt,
+		"frozen":           board.Frozen,
+		"frozen_reason":    board.FrozenReason,
+		"frozen_until":     board.FrozenUntil,
 	}
 
 	if existingBoard != nil {
@@ -373,7 +405,10 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			Set("properties", propertiesBytes).
 			Set("card_properties", cardPropertiesBytes).
 			Set("update_at", board.UpdateAt).
-			Set("delete_at", board.DeleteAt)
+			Set("delete_at", board.DeleteAt).
+			Set("frozen", board.Frozen).
+			Set("frozen_reason", board.FrozenReason).
+			Set("frozen_until", board.FrozenUntil)
 
 		if _, err := query.Exec(); err != nil {
 			s.logger.Error(`InsertBoard error occurred while updating existing board`, mlog.String("boardID", board.ID), mlog.Err(err))
@@ -411,6 +446,96 @@ func (s *SQLStore) patchBoard(db sq.BaseRunner, boardID string, boardPatch *mode
 	return s.insertBoard(db, board, userID)
 }
 
+// moveBoardToTeam changes the team of a board, detaching it from its
+// linked channel if any. The board keeps its ID, blocks and history. The
+// removed users lose their membership and the board leaves their
+// sidebar categories.
+func (s *SQLStore) moveBoardToTeam(db sq.BaseRunner, boardID, toTeamID, userID string, removedUserIDs []string) (*model.Board, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"boards").
+		Set("team_id", toTeamID).
+		Where(sq.Eq{"id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("moveBoardToTeam error updating board team", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, fmt.Errorf("moveBoardToTeam error occurred while updating board %s team: %w", boardID, err)
+	}
+
+	for _, removedUserID := range removedUserIDs {
+		if err := s.deleteMember(db, boardID, removedUserID); err != nil {
+			return nil, fmt.Errorf("moveBoardToTeam error occurred while removing member %s of board %s: %w", removedUserID, boardID, err)
+		}
+	}
+
+	if len(removedUserIDs) > 0 {
+		deleteCategoryBoards := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + "category_boards").
+			Where(sq.Eq{"board_id": boardID}).
+			Where(sq.Eq{"user_id": removedUserIDs})
+
+		if _, err := deleteCategoryBoards.Exec(); err != nil {
+			s.logger.Error("moveBoardToTeam error deleting category boards", mlog.String("boardID", boardID), mlog.Err(err))
+			return nil, fmt.Errorf("moveBoardToTeam error occurred while deleting board %s categories: %w", boardID, err)
+		}
+	}
+
+	board.TeamID = toTeamID
+	board.ChannelID = ""
+
+	// insertBoard updates the remaining fields and records the
+	// change in the board history
+	return s.insertBoard(db, board, userID)
+}
+
+// transferBoardOwnership makes a user the creator and an admin of a
+// board. The previous creator stays a member of the board as an editor.
+func (s *SQLStore) transferBoardOwnership(db sq.BaseRunner, boardID, userID string) (*model.Board, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousOwnerID := board.CreatedBy
+	if previousOwnerID != userID {
+		member, err := s.getMemberForBoard(db, boardID, previousOwnerID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		if member != nil && member.SchemeAdmin {
+			_ = member.SetRole(model.BoardRoleEditor)
+			if _, err := s.saveMember(db, member); err != nil {
+				return nil, fmt.Errorf("cannot demote the previous owner of board %s: %w", boardID, err)
+			}
+		}
+	}
+
+	owner := &model.BoardMember{BoardID: boardID, UserID: userID}
+	_ = owner.SetRole(model.BoardRoleAdmin)
+	if _, err := s.saveMember(db, owner); err != nil {
+		return nil, fmt.Errorf("cannot save the new owner of board %s: %w", boardID, err)
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"boards").
+		Set("created_by", userID).
+		Where(sq.Eq{"id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("transferBoardOwnership error updating board creator", mlog.String("boardID", boardID), mlog.Err(err))
+		return nil, fmt.Errorf("transferBoardOwnership error occurred while updating board %s creator: %w", boardID, err)
+	}
+
+	board.CreatedBy = userID
+
+	// insertBoard records the change in the board history
+	return s.insertBoard(db, board, userID)
+}
+
 func (s *SQLStore) deleteBoard(db sq.BaseRunner, boardID, userID string) error {
 	return s.deleteBoardAndChildren(db, boardID, userID, false)
 }
@@ -451,6 +576,9 @@ func (s *SQLStore) deleteBoardAndChildren(db sq.BaseRunner, boardID, userID stri
 		"create_at":        board.CreateAt,
 		"update_at":        now,
 		"delete_at":        now,
+		"frozen":           board.Frozen,
+		"frozen_reason":    board.FrozenReason,
+		"frozen_until":     board.FrozenUntil,
 	}
 
 	// writing board history
@@ -475,9 +603,52 @@ func (s *SQLStore) deleteBoardAndChildren(db sq.BaseRunner, boardID, userID stri
 		return nil
 	}
 
+	if err := s.deleteBoardData(db, boardID); err != nil {
+		return err
+	}
+
 	return s.deleteBlockChildren(db, boardID, "", userID)
 }
 
+// boardDataTables are the tables that hold data attached to a board and
+// its cards, with the column that holds the ID of the board. Their rows
+// are deleted along with the board.
+var boardDataTables = []RetentionTableDeletionInfo{
+	{Table: "read_states", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_reminders", PrimaryKeys: []string{"id"}, BoardIDColumn: "board_id"},
+	{Table: "card_votes", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "card_reactions", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "comment_reactions", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+	{Table: "block_links", PrimaryKeys: []string{"source_board_id"}, BoardIDColumn: "source_board_id"},
+	{Table: "block_links", PrimaryKeys: []string{"target_board_id"}, BoardIDColumn: "target_board_id"},
+	{Table: "card_mirrors", PrimaryKeys: []string{"board_id"}, BoardIDColumn: "board_id"},
+}
+
+// deleteBoardData deletes the data attached to a board and its cards,
+// including the mirrors of its cards on other boards.
+func (s *SQLStore) deleteBoardData(db sq.BaseRunner, boardID string) error {
+	cardsQuery := "SELECT id FROM " + s.tablePrefix + "blocks WHERE board_id = ? AND type = ?"
+	mirrorsQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_mirrors").
+		Where(sq.Expr("card_id IN ("+cardsQuery+")", boardID, model.TypeCard))
+
+	if _, err := mirrorsQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot delete the mirrors of the cards of board %s: %w", boardID, err)
+	}
+
+	for _, table := range boardDataTables {
+		query := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + table.Table).
+			Where(sq.Eq{table.BoardIDColumn: boardID})
+
+		if _, err := query.Exec(); err != nil {
+			return fmt.Errorf("cannot delete %s of board %s: %w", table.Table, boardID, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *SQLStore) insertBoardWithAdmin(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
 	newBoard, err := s.insertBoard(db, board, userID)
 	if err != nil {
@@ -833,6 +1004,9 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		"create_at",
 		"update_at",
 		"delete_at",
+		"frozen",
+		"frozen_reason",
+		"frozen_until",
 	}
 
 	values := []interface{}{
@@ -854,6 +1028,9 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		board.CreateAt,
 		now,
 		0,
+		board.Frozen,
+		board.FrozenReason,
+		board.FrozenUntil,
 	}
 	insertHistoryQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "boards_history").
 		Columns(columns...).
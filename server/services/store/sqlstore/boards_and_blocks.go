@@ -157,6 +157,11 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 	board.CreatedBy = userID
 	board.ChannelID = ""
 
+	// copies never inherit the freeze of the original board
+	board.Frozen = false
+	board.FrozenReason = ""
+	board.FrozenUntil = 0
+
 	if toTeam != "" {
 		board.TeamID = toTeam
 	}
@@ -166,8 +171,9 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 	if err != nil {
 		return nil, nil, err
 	}
+	// the private views of other users aren't copied
 	newBlocks := []*model.Block{}
-	for _, b := range blocks {
+	for _, b := range model.FilterPrivateViews(blocks, userID) {
 		if b.Type != model.TypeComment {
 			newBlocks = append(newBlocks, b)
 		}
@@ -0,0 +1,119 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getContentCard returns the card and, on MySQL and Postgres, locks its
+// row until the end of the transaction, so concurrent changes to the
+// content order of the card don't overwrite each other. SQLite already
+// serializes the transactions that write.
+func (s *SQLStore) getContentCard(db sq.BaseRunner, cardID string) (*model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"id": cardID})
+	if s.dbType != model.SqliteDBType {
+		query = query.Suffix("FOR UPDATE")
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getContentCard ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, model.NewErrNotFound("block ID=" + cardID)
+	}
+	card := blocks[0]
+
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+
+	return card, nil
+}
+
+func (s *SQLStore) getCardContentBlock(db sq.BaseRunner, cardID, blockID string) (*model.Block, error) {
+	block, err := s.getBlock(db, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.ParentID != cardID || !model.IsContentBlockType(block.Type) {
+		message := fmt.Sprintf("content block ID=%s on CardID=%s", blockID, cardID)
+		return nil, model.NewErrNotFound(message)
+	}
+
+	return block, nil
+}
+
+// insertCardContent inserts a content block of a card and adds it to
+// the content order of the card at the position.
+func (s *SQLStore) insertCardContent(db sq.BaseRunner, block *model.Block, position int, userID string) error {
+	card, err := s.getContentCard(db, block.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.insertBlock(db, block, userID); err != nil {
+		return err
+	}
+
+	contentOrder, _ := model.RemoveContentOrderID(model.GetContentOrder(card), block.ID)
+	contentOrder = model.InsertContentOrderID(contentOrder, block.ID, position)
+	return s.patchBlock(db, card.ID, model.NewContentOrderPatch(contentOrder), userID)
+}
+
+// moveCardContent moves a content block of a card to the position in
+// the content order of the card.
+func (s *SQLStore) moveCardContent(db sq.BaseRunner, cardID, blockID string, position int, userID string) error {
+	card, err := s.getContentCard(db, cardID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.getCardContentBlock(db, cardID, blockID); err != nil {
+		return err
+	}
+
+	contentOrder, _ := model.RemoveContentOrderID(model.GetContentOrder(card), blockID)
+	contentOrder = model.InsertContentOrderID(contentOrder, blockID, position)
+	return s.patchBlock(db, card.ID, model.NewContentOrderPatch(contentOrder), userID)
+}
+
+// deleteCardContent deletes a content block of a card and removes it
+// from the content order of the card.
+func (s *SQLStore) deleteCardContent(db sq.BaseRunner, cardID, blockID, userID string) error {
+	card, err := s.getContentCard(db, cardID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.getCardContentBlock(db, cardID, blockID); err != nil {
+		return err
+	}
+
+	if err = s.deleteBlock(db, blockID, userID); err != nil {
+		return err
+	}
+
+	contentOrder, found := model.RemoveContentOrderID(model.GetContentOrder(card), blockID)
+	if !found {
+		return nil
+	}
+	return s.patchBlock(db, card.ID, model.NewContentOrderPatch(contentOrder), userID)
+}
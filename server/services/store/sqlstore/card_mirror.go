@@ -0,0 +1,239 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func cardMirrorFields() []string {
+	return []string{
+		"board_id",
+		"card_id",
+		"COALESCE(properties, '{}')",
+		"created_by",
+		"modified_by",
+		"create_at",
+		"update_at",
+		"delete_at",
+	}
+}
+
+func (s *SQLStore) cardMirrorsFromRows(rows *sql.Rows) ([]*model.CardMirror, error) {
+	mirrors := []*model.CardMirror{}
+
+	for rows.Next() {
+		var mirror model.CardMirror
+		var propertiesBytes []byte
+
+		err := rows.Scan(
+			&mirror.BoardID,
+			&mirror.CardID,
+			&propertiesBytes,
+			&mirror.CreatedBy,
+			&mirror.ModifiedBy,
+			&mirror.CreateAt,
+			&mirror.UpdateAt,
+			&mirror.DeleteAt,
+		)
+		if err != nil {
+			s.logger.Error("cardMirrorsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		if err := json.Unmarshal(propertiesBytes, &mirror.Properties); err != nil {
+			s.logger.Error("cardMirrorsFromRows unmarshal properties error", mlog.Err(err))
+			return nil, err
+		}
+
+		mirrors = append(mirrors, &mirror)
+	}
+
+	return mirrors, nil
+}
+
+// saveCardMirror creates or updates a card mirror. Saving a previously
+// deleted mirror restores it.
+func (s *SQLStore) saveCardMirror(db sq.BaseRunner, mirror *model.CardMirror) (*model.CardMirror, error) {
+	if err := mirror.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if mirror.Properties == nil {
+		mirror.Properties = map[string]interface{}{}
+	}
+
+	propertiesBytes, err := s.MarshalJSONB(mirror.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	if mirror.CreateAt == 0 {
+		mirror.CreateAt = now
+	}
+	mirror.UpdateAt = now
+	mirror.DeleteAt = 0
+	if mirror.ModifiedBy == "" {
+		mirror.ModifiedBy = mirror.CreatedBy
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_mirrors").
+		Columns(
+			"board_id",
+			"card_id",
+			"properties",
+			"created_by",
+			"modified_by",
+			"create_at",
+			"update_at",
+			"delete_at",
+		).
+		Values(
+			mirror.BoardID,
+			mirror.CardID,
+			propertiesBytes,
+			mirror.CreatedBy,
+			mirror.ModifiedBy,
+			mirror.CreateAt,
+			mirror.UpdateAt,
+			mirror.DeleteAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE properties = ?, modified_by = ?, update_at = ?, delete_at = 0",
+			propertiesBytes, mirror.ModifiedBy, mirror.UpdateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (board_id, card_id)
+			 DO UPDATE SET properties = EXCLUDED.properties, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at, delete_at = 0`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveCardMirror error",
+			mlog.String("boardID", mirror.BoardID),
+			mlog.String("cardID", mirror.CardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+func (s *SQLStore) getCardMirror(db sq.BaseRunner, boardID, cardID string) (*model.CardMirror, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardMirrorFields()...).
+		From(s.tablePrefix + "card_mirrors").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardMirror error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	mirrors, err := s.cardMirrorsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mirrors) == 0 {
+		message := fmt.Sprintf("card mirror BoardID=%s CardID=%s", boardID, cardID)
+		return nil, model.NewErrNotFound(message)
+	}
+
+	return mirrors[0], nil
+}
+
+func (s *SQLStore) getCardMirrorsForBoard(db sq.BaseRunner, boardID string) ([]*model.CardMirror, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardMirrorFields()...).
+		From(s.tablePrefix + "card_mirrors").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("create_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardMirrorsForBoard error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardMirrorsFromRows(rows)
+}
+
+func (s *SQLStore) getCardMirrorsForCard(db sq.BaseRunner, cardID string) ([]*model.CardMirror, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardMirrorFields()...).
+		From(s.tablePrefix + "card_mirrors").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("create_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardMirrorsForCard error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardMirrorsFromRows(rows)
+}
+
+func (s *SQLStore) deleteCardMirror(db sq.BaseRunner, boardID, cardID, userID string) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_mirrors").
+		Set("modified_by", userID).
+		Set("update_at", now).
+		Set("delete_at", now).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		message := fmt.Sprintf("card mirror BoardID=%s CardID=%s", boardID, cardID)
+		return model.NewErrNotFound(message)
+	}
+
+	return nil
+}
+
+// deleteCardMirrorsForCard deletes the mirrors of a card on every board.
+func (s *SQLStore) deleteCardMirrorsForCard(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_mirrors").
+		Where(sq.Eq{"card_id": cardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteCardMirrorsForCard error", mlog.String("cardID", cardID), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
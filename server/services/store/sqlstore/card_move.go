@@ -0,0 +1,210 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// moveCardToBoard moves a card and its children to another board,
+// keeping their IDs and history, and leaves a redirect on the source
+// board. The card's fields are stored as passed, so the caller is
+// responsible of mapping its properties to the destination board.
+func (s *SQLStore) moveCardToBoard(db sq.BaseRunner, card *model.Block, toBoardID, userID string) (*model.CardRedirect, error) {
+	fromBoardID := card.BoardID
+
+	blocks, err := s.getSubTree2(db, fromBoardID, card.ID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		message := fmt.Sprintf("card BoardID=%s CardID=%s", fromBoardID, card.ID)
+		return nil, model.NewErrNotFound(message)
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks").
+		Set("board_id", toBoardID).
+		Where(sq.Eq{"board_id": fromBoardID}).
+		Where(sq.Or{sq.Eq{"id": card.ID}, sq.Eq{"parent_id": card.ID}})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("moveCardToBoard error updating blocks board", mlog.String("cardID", card.ID), mlog.Err(err))
+		return nil, fmt.Errorf("moveCardToBoard error occurred while updating card %s board: %w", card.ID, err)
+	}
+
+	commentIDs := []string{}
+	for _, block := range blocks {
+		if block.ID == card.ID {
+			block = card
+		}
+		block.BoardID = toBoardID
+		if block.Type == model.TypeComment {
+			commentIDs = append(commentIDs, block.ID)
+		}
+
+		// insertBlock updates the remaining fields and records the
+		// change in the block history
+		if err := s.insertBlock(db, block, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.moveCardData(db, card.ID, commentIDs, toBoardID); err != nil {
+		return nil, err
+	}
+
+	if err := s.moveBlockLinks(db, card.ID, toBoardID); err != nil {
+		return nil, err
+	}
+
+	redirect := &model.CardRedirect{
+		CardID:        card.ID,
+		SourceBoardID: fromBoardID,
+		TargetBoardID: toBoardID,
+		CreatedBy:     userID,
+		CreateAt:      utils.GetMillis(),
+	}
+
+	if err := s.saveCardRedirect(db, redirect); err != nil {
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+// moveCardData moves the votes, reactions, reminders and read states of
+// a card, and the reactions to its comments, to the new board of the
+// card, and deletes the mirror of the card on that board.
+func (s *SQLStore) moveCardData(db sq.BaseRunner, cardID string, commentIDs []string, toBoardID string) error {
+	// read states left on the new board by an earlier stay of the card
+	// would collide with the moved ones
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "read_states").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"board_id": toBoardID})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot delete the stale read states of card %s: %w", cardID, err)
+	}
+
+	// a mirror of the card on its new board would show it twice there
+	deleteMirrorsQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_mirrors").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"board_id": toBoardID})
+
+	if _, err := deleteMirrorsQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot delete the mirror of card %s on its new board: %w", cardID, err)
+	}
+
+	type tableUpdate struct {
+		table string
+		where sq.Eq
+	}
+	updates := []tableUpdate{
+		{"card_votes", sq.Eq{"card_id": cardID}},
+		{"card_reactions", sq.Eq{"card_id": cardID}},
+		{"card_reminders", sq.Eq{"card_id": cardID}},
+		{"read_states", sq.Eq{"card_id": cardID}},
+	}
+	if len(commentIDs) > 0 {
+		updates = append(updates, tableUpdate{"comment_reactions", sq.Eq{"comment_id": commentIDs}})
+	}
+
+	for _, update := range updates {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+update.table).
+			Set("board_id", toBoardID).
+			Where(update.where)
+
+		if _, err := query.Exec(); err != nil {
+			return fmt.Errorf("cannot move the %s of card %s: %w", update.table, cardID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLStore) saveCardRedirect(db sq.BaseRunner, redirect *model.CardRedirect) error {
+	// a card that comes back to a board doesn't need a redirect there
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_redirects").
+		Where(sq.Eq{"card_id": redirect.CardID}).
+		Where(sq.Eq{"source_board_id": []string{redirect.SourceBoardID, redirect.TargetBoardID}})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		return err
+	}
+
+	// previous redirects are updated so they point directly to the
+	// card's current board
+	updateQuery := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_redirects").
+		Set("target_board_id", redirect.TargetBoardID).
+		Where(sq.Eq{"card_id": redirect.CardID}).
+		Where(sq.Eq{"target_board_id": redirect.SourceBoardID})
+
+	if _, err := updateQuery.Exec(); err != nil {
+		return err
+	}
+
+	insertQuery := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_redirects").
+		Columns(
+			"source_board_id",
+			"card_id",
+			"target_board_id",
+			"created_by",
+			"create_at",
+		).
+		Values(
+			redirect.SourceBoardID,
+			redirect.CardID,
+			redirect.TargetBoardID,
+			redirect.CreatedBy,
+			redirect.CreateAt,
+		)
+
+	_, err := insertQuery.Exec()
+	return err
+}
+
+func (s *SQLStore) getCardRedirect(db sq.BaseRunner, boardID, cardID string) (*model.CardRedirect, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"source_board_id",
+			"card_id",
+			"target_board_id",
+			"created_by",
+			"create_at",
+		).
+		From(s.tablePrefix + "card_redirects").
+		Where(sq.Eq{"source_board_id": boardID}).
+		Where(sq.Eq{"card_id": cardID})
+
+	redirect := model.CardRedirect{}
+	err := query.QueryRow().Scan(
+		&redirect.SourceBoardID,
+		&redirect.CardID,
+		&redirect.TargetBoardID,
+		&redirect.CreatedBy,
+		&redirect.CreateAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		message := fmt.Sprintf("card redirect BoardID=%s CardID=%s", boardID, cardID)
+		return nil, model.NewErrNotFound(message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &redirect, nil
+}
@@ -0,0 +1,235 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func cardReminderFields() []string {
+	return []string{
+		"id",
+		"user_id",
+		"board_id",
+		"card_id",
+		"COALESCE(note, '')",
+		"remind_at",
+		"delivered_at",
+		"create_at",
+		"update_at",
+	}
+}
+
+func (s *SQLStore) cardRemindersFromRows(rows *sql.Rows) ([]*model.CardReminder, error) {
+	reminders := []*model.CardReminder{}
+
+	for rows.Next() {
+		var reminder model.CardReminder
+
+		err := rows.Scan(
+			&reminder.ID,
+			&reminder.UserID,
+			&reminder.BoardID,
+			&reminder.CardID,
+			&reminder.Note,
+			&reminder.RemindAt,
+			&reminder.DeliveredAt,
+			&reminder.CreateAt,
+			&reminder.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("cardRemindersFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		reminders = append(reminders, &reminder)
+	}
+
+	return reminders, nil
+}
+
+// saveCardReminder creates or updates a card reminder.
+func (s *SQLStore) saveCardReminder(db sq.BaseRunner, reminder *model.CardReminder) (*model.CardReminder, error) {
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	if reminder.CreateAt == 0 {
+		reminder.CreateAt = now
+	}
+	reminder.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_reminders").
+		Columns(
+			"id",
+			"user_id",
+			"board_id",
+			"card_id",
+			"note",
+			"remind_at",
+			"delivered_at",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			reminder.ID,
+			reminder.UserID,
+			reminder.BoardID,
+			reminder.CardID,
+			reminder.Note,
+			reminder.RemindAt,
+			reminder.DeliveredAt,
+			reminder.CreateAt,
+			reminder.UpdateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE note = ?, remind_at = ?, delivered_at = ?, update_at = ?",
+			reminder.Note, reminder.RemindAt, reminder.DeliveredAt, reminder.UpdateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (id)
+			 DO UPDATE SET note = EXCLUDED.note, remind_at = EXCLUDED.remind_at, delivered_at = EXCLUDED.delivered_at, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveCardReminder error",
+			mlog.String("reminderID", reminder.ID),
+			mlog.String("cardID", reminder.CardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+func (s *SQLStore) getCardReminder(db sq.BaseRunner, reminderID string) (*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields()...).
+		From(s.tablePrefix + "card_reminders").
+		Where(sq.Eq{"id": reminderID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardReminder error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	reminders, err := s.cardRemindersFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reminders) == 0 {
+		return nil, model.NewErrNotFound("card reminder ID=" + reminderID)
+	}
+
+	return reminders[0], nil
+}
+
+func (s *SQLStore) getCardRemindersForUser(db sq.BaseRunner, userID string) ([]*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields()...).
+		From(s.tablePrefix+"card_reminders").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("remind_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardRemindersForUser error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRemindersFromRows(rows)
+}
+
+// getDueCardReminders returns the pending reminders whose time has
+// come, oldest first.
+func (s *SQLStore) getDueCardReminders(db sq.BaseRunner, now int64) ([]*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields()...).
+		From(s.tablePrefix+"card_reminders").
+		Where(sq.Eq{"delivered_at": 0}).
+		Where(sq.LtOrEq{"remind_at": now}).
+		OrderBy("remind_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getDueCardReminders error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRemindersFromRows(rows)
+}
+
+// markCardReminderDelivered marks a reminder as delivered if it is
+// still due. It returns false if the reminder was delivered by another
+// node in the meantime, or was rescheduled.
+func (s *SQLStore) markCardReminderDelivered(db sq.BaseRunner, reminderID string, now int64) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_reminders").
+		Set("delivered_at", now).
+		Where(sq.Eq{"id": reminderID}).
+		Where(sq.Eq{"delivered_at": 0}).
+		Where(sq.LtOrEq{"remind_at": now})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("markCardReminderDelivered error", mlog.String("reminderID", reminderID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *SQLStore) deleteCardReminder(db sq.BaseRunner, reminderID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reminders").
+		Where(sq.Eq{"id": reminderID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("card reminder ID=" + reminderID)
+	}
+
+	return nil
+}
+
+func (s *SQLStore) deleteCardRemindersForCard(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reminders").
+		Where(sq.Eq{"card_id": cardID})
+
+	if _, err := query.Exec(); err != nil {
+		return fmt.Errorf("cannot delete reminders of card %s: %w", cardID, err)
+	}
+
+	return nil
+}
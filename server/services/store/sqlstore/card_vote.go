@@ -0,0 +1,327 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveCardVote adds the vote of a user for a card, unless the user
+// already voted for voteLimit other cards of the board. A zero
+// voteLimit means there is no limit. Saving a vote that already exists
+// leaves it unchanged.
+func (s *SQLStore) saveCardVote(db sq.BaseRunner, vote *model.CardVote, voteLimit int) error {
+	if err := vote.IsValid(); err != nil {
+		return err
+	}
+
+	voteLimitErr := model.NewErrBadRequest(fmt.Sprintf("vote limit of %d cards reached for this board", voteLimit))
+
+	if voteLimit > 0 {
+		// the user may have no votes yet whose rows could be locked, so
+		// the board row serializes the votes counted against the limit
+		if s.dbType != model.SqliteDBType {
+			if err := s.lockBoardForVotes(db, vote.BoardID); err != nil {
+				return err
+			}
+		}
+
+		count, err := s.countOtherCardVotes(db, vote)
+		if err != nil {
+			return err
+		}
+		if count >= voteLimit {
+			return voteLimitErr
+		}
+	}
+
+	if vote.CreateAt == 0 {
+		vote.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_votes").
+		Columns(
+			"card_id",
+			"user_id",
+			"board_id",
+			"create_at",
+		).
+		Values(
+			vote.CardID,
+			vote.UserID,
+			vote.BoardID,
+			vote.CreateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = card_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id, user_id) DO NOTHING")
+	}
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("saveCardVote error",
+			mlog.String("cardID", vote.CardID),
+			mlog.String("userID", vote.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	// SQLite saves the vote outside of a transaction, so the limit is
+	// checked again in case a concurrent vote got in first
+	if voteLimit > 0 && s.dbType == model.SqliteDBType {
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if inserted == 0 {
+			return nil
+		}
+
+		count, err := s.countOtherCardVotes(db, vote)
+		if err != nil {
+			return err
+		}
+		if count >= voteLimit {
+			if err := s.deleteCardVote(db, vote.CardID, vote.UserID); err != nil {
+				return err
+			}
+			return voteLimitErr
+		}
+	}
+
+	return nil
+}
+
+// lockBoardForVotes locks the row of the board until the end of the
+// transaction.
+func (s *SQLStore) lockBoardForVotes(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"id": boardID}).
+		Suffix("FOR UPDATE")
+
+	var id string
+	if err := query.QueryRow().Scan(&id); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Error("lockBoardForVotes error", mlog.String("boardID", boardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// countOtherCardVotes returns the number of cards of the board, other
+// than the card of the vote, the user voted for.
+func (s *SQLStore) countOtherCardVotes(db sq.BaseRunner, vote *model.CardVote) (int, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "card_votes").
+		Where(sq.Eq{"board_id": vote.BoardID}).
+		Where(sq.Eq{"user_id": vote.UserID}).
+		Where(sq.NotEq{"card_id": vote.CardID})
+
+	var count int
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("countOtherCardVotes error", mlog.String("boardID", vote.BoardID), mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *SQLStore) deleteCardVote(db sq.BaseRunner, cardID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_votes").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound(fmt.Sprintf("card vote CardID=%s UserID=%s", cardID, userID))
+	}
+
+	return nil
+}
+
+// saveCardReaction adds a reaction to a card. Saving a reaction that
+// already exists leaves it unchanged.
+func (s *SQLStore) saveCardReaction(db sq.BaseRunner, reaction *model.CardReaction) error {
+	if err := reaction.IsValid(); err != nil {
+		return err
+	}
+
+	if reaction.CreateAt == 0 {
+		reaction.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_reactions").
+		Columns(
+			"card_id",
+			"user_id",
+			"emoji",
+			"board_id",
+			"create_at",
+		).
+		Values(
+			reaction.CardID,
+			reaction.UserID,
+			reaction.Emoji,
+			reaction.BoardID,
+			reaction.CreateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = card_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id, user_id, emoji) DO NOTHING")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveCardReaction error",
+			mlog.String("cardID", reaction.CardID),
+			mlog.String("userID", reaction.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SQLStore) deleteCardReaction(db sq.BaseRunner, cardID, userID, emoji string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reactions").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"emoji": emoji})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		message := fmt.Sprintf("card reaction CardID=%s UserID=%s Emoji=%s", cardID, userID, emoji)
+		return model.NewErrNotFound(message)
+	}
+
+	return nil
+}
+
+// getCardVotes returns the votes and reactions of the cards as seen by
+// the user, keyed by card ID. Every card gets an entry, even if it has
+// no votes.
+func (s *SQLStore) getCardVotes(db sq.BaseRunner, cardIDs []string, userID string) (map[string]*model.CardVotes, error) {
+	votes := make(map[string]*model.CardVotes, len(cardIDs))
+	if len(cardIDs) == 0 {
+		return votes, nil
+	}
+
+	for _, cardID := range cardIDs {
+		votes[cardID] = model.NewCardVotes(cardID)
+	}
+
+	if err := s.countCardVotes(db, votes, cardIDs, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.countCardReactions(db, votes, cardIDs, userID); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func (s *SQLStore) countCardVotes(db sq.BaseRunner, votes map[string]*model.CardVotes, cardIDs []string, userID string) error {
+	query := s.getQueryBuilder(db).
+		Select("card_id", "user_id").
+		From(s.tablePrefix + "card_votes").
+		Where(sq.Eq{"card_id": cardIDs})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("countCardVotes error", mlog.Err(err))
+		return err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var cardID, voteUserID string
+		if err = rows.Scan(&cardID, &voteUserID); err != nil {
+			s.logger.Error("countCardVotes scan error", mlog.Err(err))
+			return err
+		}
+
+		votes[cardID].VoteCount++
+		if voteUserID == userID {
+			votes[cardID].Voted = true
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLStore) countCardReactions(db sq.BaseRunner, votes map[string]*model.CardVotes, cardIDs []string, userID string) error {
+	query := s.getQueryBuilder(db).
+		Select("card_id", "user_id", "emoji").
+		From(s.tablePrefix+"card_reactions").
+		Where(sq.Eq{"card_id": cardIDs}).
+		OrderBy("create_at", "user_id", "emoji")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("countCardReactions error", mlog.Err(err))
+		return err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var cardID, reactionUserID, emoji string
+		if err = rows.Scan(&cardID, &reactionUserID, &emoji); err != nil {
+			s.logger.Error("countCardReactions scan error", mlog.Err(err))
+			return err
+		}
+
+		votes[cardID].AddReaction(emoji, reactionUserID == userID)
+	}
+
+	return nil
+}
+
+func (s *SQLStore) deleteCardVotesForCard(db sq.BaseRunner, cardID string) error {
+	for _, table := range []string{"card_votes", "card_reactions"} {
+		query := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + table).
+			Where(sq.Eq{"card_id": cardID})
+
+		if _, err := query.Exec(); err != nil {
+			return fmt.Errorf("cannot delete %s of card %s: %w", table, cardID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func commentReactionFields() []string {
+	return []string{
+		"comment_id",
+		"board_id",
+		"user_id",
+		"emoji",
+		"create_at",
+	}
+}
+
+func (s *SQLStore) commentReactionsFromRows(rows *sql.Rows) ([]*model.CommentReaction, error) {
+	reactions := []*model.CommentReaction{}
+
+	for rows.Next() {
+		var reaction model.CommentReaction
+
+		err := rows.Scan(
+			&reaction.CommentID,
+			&reaction.BoardID,
+			&reaction.UserID,
+			&reaction.Emoji,
+			&reaction.CreateAt,
+		)
+		if err != nil {
+			s.logger.Error("commentReactionsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		reactions = append(reactions, &reaction)
+	}
+
+	return reactions, nil
+}
+
+// saveCommentReaction adds a reaction to a comment. Saving a reaction
+// that already exists leaves it unchanged.
+func (s *SQLStore) saveCommentReaction(db sq.BaseRunner, reaction *model.CommentReaction) (*model.CommentReaction, error) {
+	if err := reaction.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if reaction.CreateAt == 0 {
+		reaction.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"comment_reactions").
+		Columns(
+			"comment_id",
+			"user_id",
+			"emoji",
+			"board_id",
+			"create_at",
+		).
+		Values(
+			reaction.CommentID,
+			reaction.UserID,
+			reaction.Emoji,
+			reaction.BoardID,
+			reaction.CreateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE comment_id = comment_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (comment_id, user_id, emoji) DO NOTHING")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveCommentReaction error",
+			mlog.String("commentID", reaction.CommentID),
+			mlog.String("userID", reaction.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (s *SQLStore) getCommentReactions(db sq.BaseRunner, commentIDs []string) ([]*model.CommentReaction, error) {
+	if len(commentIDs) == 0 {
+		return []*model.CommentReaction{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(commentReactionFields()...).
+		From(s.tablePrefix+"comment_reactions").
+		Where(sq.Eq{"comment_id": commentIDs}).
+		OrderBy("create_at", "user_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCommentReactions error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.commentReactionsFromRows(rows)
+}
+
+func (s *SQLStore) deleteCommentReaction(db sq.BaseRunner, commentID, userID, emoji string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "comment_reactions").
+		Where(sq.Eq{"comment_id": commentID}).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"emoji": emoji})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		message := fmt.Sprintf("comment reaction CommentID=%s UserID=%s Emoji=%s", commentID, userID, emoji)
+		return model.NewErrNotFound(message)
+	}
+
+	return nil
+}
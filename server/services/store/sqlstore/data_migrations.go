@@ -27,6 +27,7 @@ const (
 	TeamLessBoardsMigrationKey                = "TeamLessBoardsMigrationComplete"
 	DeletedMembershipBoardsMigrationKey       = "DeletedMembershipBoardsMigrationComplete"
 	DeDuplicateCategoryBoardTableMigrationKey = "DeDuplicateCategoryBoardTableComplete"
+	BlockLinksMigrationKey                    = "BlockLinksMigrationComplete"
 )
 
 func (s *SQLStore) getBlocksWithSameID(db sq.BaseRunner) ([]*model.Block, error) {
@@ -888,3 +889,46 @@ func (s *SQLStore) runPostgresDeDuplicateCategoryBoardsMigration() error {
 
 	return nil
 }
+
+// RunBlockLinksMigration saves the links of the blocks that were created
+// before links were tracked, so they show up as backlinks.
+func (s *SQLStore) RunBlockLinksMigration() error {
+	setting, err := s.GetSystemSetting(BlockLinksMigrationKey)
+	if err != nil {
+		return fmt.Errorf("cannot get migration state: %w", err)
+	}
+
+	// If the migration is already completed, do not run it again.
+	if hasAlreadyRun, _ := strconv.ParseBool(setting); hasAlreadyRun {
+		return nil
+	}
+
+	s.logger.Debug("Running block links migration")
+
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+
+	count, err := s.rebuildBlockLinks(tx, CategoryInsertBatch)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("block links transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "rebuildBlockLinks"))
+		}
+		return fmt.Errorf("cannot save the links of blocks: %w", err)
+	}
+
+	if err := s.setSystemSetting(tx, BlockLinksMigrationKey, strconv.FormatBool(true)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("block links transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "setSystemSetting"))
+		}
+		return fmt.Errorf("cannot mark migration as completed: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("cannot commit block links transaction: %w", err)
+	}
+
+	s.logger.Debug("Block links migration finished successfully", mlog.Int64("blocks", count))
+	return nil
+}
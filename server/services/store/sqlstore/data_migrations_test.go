@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -233,6 +234,49 @@ func TestRunUniqueIDsMigration(t *testing.T) {
 	require.NotEqual(t, block5.ID, newBlock5.ParentID)
 }
 
+func TestRunBlockLinksMigration(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id", Type: model.BoardTypeOpen, Title: "board"}
+	_, err := sqlStore.InsertBoard(board, "user-id")
+	require.NoError(t, err)
+
+	target := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, Type: model.TypeCard}
+	source := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, Type: model.TypeCard}
+	text := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: source.ID,
+		Type:     model.TypeText,
+		Title:    utils.MakeCardLink("http://localhost", board.TeamID, board.ID, target.ID),
+	}
+	for _, block := range []*model.Block{target, source, text} {
+		require.NoError(t, sqlStore.InsertBlock(block, "user-id"))
+	}
+
+	// the links of blocks created before links were tracked are
+	// missing, and the migration is pending
+	require.NoError(t, sqlStore.deleteBlockLinks(sqlStore.db, []string{text.ID}))
+	require.NoError(t, sqlStore.SetSystemSetting(BlockLinksMigrationKey, "false"))
+
+	backlinks, err := sqlStore.GetBacklinks(board.ID, target.ID)
+	require.NoError(t, err)
+	require.Empty(t, backlinks)
+
+	require.NoError(t, sqlStore.RunBlockLinksMigration())
+
+	backlinks, err = sqlStore.GetBacklinks(board.ID, target.ID)
+	require.NoError(t, err)
+	require.Len(t, backlinks, 1)
+	require.Equal(t, source.ID, backlinks[0].CardID)
+
+	setting, err := sqlStore.GetSystemSetting(BlockLinksMigrationKey)
+	require.NoError(t, err)
+	require.Equal(t, "true", setting)
+}
+
 func TestCheckForMismatchedCollation(t *testing.T) {
 	store, tearDown := SetupTests(t)
 	sqlStore := store.(*SQLStore)
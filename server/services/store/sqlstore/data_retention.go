@@ -67,7 +67,13 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 			PrimaryKeys:   []string{"id"},
 			BoardIDColumn: "board_id",
 		},
+		{
+			Table:         "card_redirects",
+			PrimaryKeys:   []string{"source_board_id"},
+			BoardIDColumn: "source_board_id",
+		},
 	}
+	deleteTables = append(deleteTables, boardDataTables...)
 
 	subBuilder := s.getQueryBuilder(db).
 		Select("board_id, MAX(update_at) AS maxDate").
@@ -170,7 +176,8 @@ func (s *SQLStore) genericRetentionPoliciesDeletion(
 			return 0, errors.Wrap(err, "failed to get rows affected for "+info.Table)
 		}
 		totalRowsAffected += batchRowsAffected
-		if batchRowsAffected != batchSize {
+		// without a batch size everything is deleted at once
+		if batchSize <= 0 || batchRowsAffected != batchSize {
 			break
 		}
 	}
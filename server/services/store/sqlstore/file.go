@@ -49,6 +49,20 @@ func (s *SQLStore) saveFileInfo(db sq.BaseRunner, fileInfo *mmModel.FileInfo) er
 	return nil
 }
 
+func (s *SQLStore) updateFileInfoPath(db sq.BaseRunner, id, path string) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"file_info").
+		Set("path", path).
+		Where(sq.Eq{"id": id})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("failed to update fileinfo path", mlog.String("id", id), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
+
 func (s *SQLStore) getFileInfo(db sq.BaseRunner, id string) (*mmModel.FileInfo, error) {
 	query := s.getQueryBuilder(db).
 		Select(
@@ -0,0 +1,133 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// integrityCheck is a query of the rows of a table that fail a check.
+type integrityCheck struct {
+	name        string
+	description string
+	table       string
+	idColumn    string
+	condition   sq.Sqlizer
+}
+
+func (s *SQLStore) integrityChecks() []integrityCheck {
+	blocks := s.tablePrefix + "blocks"
+	boards := s.tablePrefix + "boards"
+	boardsHistory := s.tablePrefix + "boards_history"
+	boardMembers := s.tablePrefix + "board_members"
+	users := s.tablePrefix + "users"
+
+	return []integrityCheck{
+		{
+			name:        "blocks_without_board",
+			description: "blocks of boards that don't exist",
+			table:       blocks + " AS x",
+			idColumn:    "x.id",
+			condition:   sq.Expr("NOT EXISTS (SELECT 1 FROM " + boards + " WHERE id = x.board_id)"),
+		},
+		{
+			name:        "blocks_without_parent",
+			description: "blocks whose parent is neither their board nor a block",
+			table:       blocks + " AS x",
+			idColumn:    "x.id",
+			condition: sq.And{
+				sq.NotEq{"x.parent_id": ""},
+				sq.Expr("x.parent_id <> x.board_id"),
+				sq.Expr("NOT EXISTS (SELECT 1 FROM " + blocks + " WHERE id = x.parent_id)"),
+			},
+		},
+		{
+			name:        "members_without_board",
+			description: "board members of boards that never existed, by board ID",
+			table:       boardMembers + " AS x",
+			idColumn:    "x.board_id",
+			condition: sq.And{
+				sq.Expr("NOT EXISTS (SELECT 1 FROM " + boards + " WHERE id = x.board_id)"),
+				sq.Expr("NOT EXISTS (SELECT 1 FROM " + boardsHistory + " WHERE id = x.board_id)"),
+			},
+		},
+		{
+			name:        "boards_without_admin",
+			description: "boards without any admin member",
+			table:       boards + " AS x",
+			idColumn:    "x.id",
+			condition: sq.And{
+				sq.Eq{"x.is_template": false},
+				sq.Expr("NOT EXISTS (SELECT 1 FROM "+boardMembers+" WHERE board_id = x.id AND scheme_admin = ?)", true),
+			},
+		},
+		{
+			name:        "sessions_without_user",
+			description: "sessions of users that don't exist or are deactivated",
+			table:       s.tablePrefix + "sessions AS x",
+			idColumn:    "x.id",
+			condition:   sq.Expr("NOT EXISTS (SELECT 1 FROM "+users+" WHERE id = x.user_id AND delete_at = ?)", 0),
+		},
+	}
+}
+
+// checkIntegrity runs the data integrity checks, and returns their
+// results in the order they ran.
+func (s *SQLStore) checkIntegrity(db sq.BaseRunner) ([]*model.IntegrityCheck, error) {
+	results := []*model.IntegrityCheck{}
+	for _, check := range s.integrityChecks() {
+		result, err := s.runIntegrityCheck(db, check)
+		if err != nil {
+			s.logger.Error("checkIntegrity error", mlog.String("check", check.name), mlog.Err(err))
+			return nil, fmt.Errorf("cannot run integrity check %s: %w", check.name, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *SQLStore) runIntegrityCheck(db sq.BaseRunner, check integrityCheck) (*model.IntegrityCheck, error) {
+	result := &model.IntegrityCheck{
+		Name:        check.name,
+		Description: check.description,
+	}
+
+	countQuery := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(check.table).
+		Where(check.condition)
+
+	if err := countQuery.QueryRow().Scan(&result.Count); err != nil {
+		return nil, err
+	}
+	if result.Count == 0 {
+		return result, nil
+	}
+
+	sampleQuery := s.getQueryBuilder(db).
+		Select(check.idColumn).
+		From(check.table).
+		Where(check.condition).
+		OrderBy(check.idColumn).
+		Limit(model.IntegrityCheckSampleSize)
+
+	rows, err := sampleQuery.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result.SampleIDs = append(result.SampleIDs, id)
+	}
+
+	return result, rows.Err()
+}
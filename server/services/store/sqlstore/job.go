@@ -0,0 +1,445 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// jobClaimCandidates is the number of due jobs a worker tries to claim
+// in turn, in case other workers claim the first ones.
+const jobClaimCandidates = 10
+
+const errJobWorkerStopped = "the worker stopped responding"
+
+func jobFields() []string {
+	return []string{
+		"id",
+		"type",
+		"status",
+		"COALESCE(data, '{}')",
+		"progress",
+		"attempts",
+		"max_attempts",
+		"COALESCE(last_error, '')",
+		"COALESCE(worker_id, '')",
+		"scheduled_at",
+		"start_at",
+		"finish_at",
+		"create_at",
+		"update_at",
+	}
+}
+
+func (s *SQLStore) jobsFromRows(rows *sql.Rows) ([]*model.Job, error) {
+	jobs := []*model.Job{}
+
+	for rows.Next() {
+		var job model.Job
+		var dataJSON string
+
+		err := rows.Scan(
+			&job.ID,
+			&job.Type,
+			&job.Status,
+			&dataJSON,
+			&job.Progress,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.LastError,
+			&job.WorkerID,
+			&job.ScheduledAt,
+			&job.StartAt,
+			&job.FinishAt,
+			&job.CreateAt,
+			&job.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("jobsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(dataJSON), &job.Data); err != nil {
+			s.logger.Error("jobsFromRows data error", mlog.String("jobID", job.ID), mlog.Err(err))
+			return nil, err
+		}
+
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
+func (s *SQLStore) createJob(db sq.BaseRunner, job *model.Job) (*model.Job, error) {
+	now := utils.GetMillis()
+	if job.Status == "" {
+		job.Status = model.JobStatusPending
+	}
+	if job.MaxAttempts == 0 {
+		job.MaxAttempts = model.DefaultJobMaxAttempts
+	}
+	if job.ScheduledAt == 0 {
+		job.ScheduledAt = now
+	}
+	job.CreateAt = now
+	job.UpdateAt = now
+
+	if err := job.IsValid(); err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.Marshal(job.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"jobs").
+		Columns(
+			"id",
+			"type",
+			"status",
+			"data",
+			"progress",
+			"attempts",
+			"max_attempts",
+			"last_error",
+			"worker_id",
+			"scheduled_at",
+			"start_at",
+			"finish_at",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			job.ID,
+			job.Type,
+			job.Status,
+			dataJSON,
+			job.Progress,
+			job.Attempts,
+			job.MaxAttempts,
+			job.LastError,
+			job.WorkerID,
+			job.ScheduledAt,
+			job.StartAt,
+			job.FinishAt,
+			job.CreateAt,
+			job.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createJob error", mlog.String("jobID", job.ID), mlog.String("type", job.Type), mlog.Err(err))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *SQLStore) getJob(db sq.BaseRunner, jobID string) (*model.Job, error) {
+	query := s.getQueryBuilder(db).
+		Select(jobFields()...).
+		From(s.tablePrefix + "jobs").
+		Where(sq.Eq{"id": jobID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getJob error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	jobs, err := s.jobsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, model.NewErrNotFound("job ID=" + jobID)
+	}
+
+	return jobs[0], nil
+}
+
+func (s *SQLStore) getJobs(db sq.BaseRunner, opts model.QueryJobsOptions) ([]*model.Job, error) {
+	query := s.getQueryBuilder(db).
+		Select(jobFields()...).
+		From(s.tablePrefix+"jobs").
+		OrderBy("scheduled_at DESC", "id")
+
+	if opts.Type != "" {
+		query = query.Where(sq.Eq{"type": opts.Type})
+	}
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"status": opts.Status})
+	}
+
+	if opts.Page != 0 {
+		query = query.Offset(uint64(opts.Page * opts.PerPage))
+	}
+
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getJobs error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.jobsFromRows(rows)
+}
+
+// hasActiveJob returns true if a job of a type is pending or running.
+func (s *SQLStore) hasActiveJob(db sq.BaseRunner, jobType string) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "jobs").
+		Where(sq.Eq{"type": jobType}).
+		Where(sq.Eq{"status": []model.JobStatus{model.JobStatusPending, model.JobStatusRunning}})
+
+	var count int
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error("hasActiveJob error", mlog.String("type", jobType), mlog.Err(err))
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// claimNextJob marks the oldest due job of the given types as running
+// for a worker and returns it. The job is only claimed if it is still
+// pending, so that a job is run by a single worker even if several
+// servers share the database.
+func (s *SQLStore) claimNextJob(db sq.BaseRunner, jobTypes []string, workerID string, now int64) (*model.Job, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"jobs").
+		Where(sq.Eq{"status": model.JobStatusPending}).
+		Where(sq.Eq{"type": jobTypes}).
+		Where(sq.LtOrEq{"scheduled_at": now}).
+		OrderBy("scheduled_at", "id").
+		Limit(jobClaimCandidates)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("claimNextJob error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	var jobIDs []string
+	for rows.Next() {
+		var jobID string
+		if err := rows.Scan(&jobID); err != nil {
+			return nil, err
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	for _, jobID := range jobIDs {
+		result, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"jobs").
+			Set("status", model.JobStatusRunning).
+			Set("worker_id", workerID).
+			Set("attempts", sq.Expr("attempts + 1")).
+			Set("progress", 0).
+			Set("start_at", now).
+			Set("update_at", now).
+			Where(sq.Eq{"id": jobID}).
+			Where(sq.Eq{"status": model.JobStatusPending}).
+			Exec()
+		if err != nil {
+			s.logger.Error("claimNextJob error", mlog.String("jobID", jobID), mlog.Err(err))
+			return nil, err
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return s.getJob(db, jobID)
+		}
+	}
+
+	return nil, model.NewErrNotFound("due job")
+}
+
+// updateJobProgress saves the progress of a running job, which also
+// serves as its heartbeat. It returns false if the job no longer runs
+// for the worker, because it was cancelled or given to another worker.
+func (s *SQLStore) updateJobProgress(db sq.BaseRunner, jobID, workerID string, progress int, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("progress", progress).
+		Set("update_at", now).
+		Where(sq.Eq{"id": jobID}).
+		Where(sq.Eq{"worker_id": workerID}).
+		Where(sq.Eq{"status": model.JobStatusRunning}).
+		Exec()
+	if err != nil {
+		s.logger.Error("updateJobProgress error", mlog.String("jobID", jobID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// finishJob saves the outcome of an attempt of a job that runs for its
+// worker. It returns false if the job no longer runs for the worker.
+func (s *SQLStore) finishJob(db sq.BaseRunner, job *model.Job, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", job.Status).
+		Set("progress", job.Progress).
+		Set("last_error", job.LastError).
+		Set("scheduled_at", job.ScheduledAt).
+		Set("finish_at", job.FinishAt).
+		Set("update_at", now).
+		Where(sq.Eq{"id": job.ID}).
+		Where(sq.Eq{"worker_id": job.WorkerID}).
+		Where(sq.Eq{"status": model.JobStatusRunning}).
+		Exec()
+	if err != nil {
+		s.logger.Error("finishJob error", mlog.String("jobID", job.ID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// cancelJob cancels a pending or running job. It returns false if the
+// job is already finished.
+func (s *SQLStore) cancelJob(db sq.BaseRunner, jobID string, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", model.JobStatusCanceled).
+		Set("finish_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"id": jobID}).
+		Where(sq.Eq{"status": []model.JobStatus{model.JobStatusPending, model.JobStatusRunning}}).
+		Exec()
+	if err != nil {
+		s.logger.Error("cancelJob error", mlog.String("jobID", jobID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// retryJob schedules again a failed or cancelled job, with all its
+// attempts. It returns false if the job is not failed or cancelled.
+func (s *SQLStore) retryJob(db sq.BaseRunner, jobID string, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", model.JobStatusPending).
+		Set("progress", 0).
+		Set("attempts", 0).
+		Set("worker_id", "").
+		Set("scheduled_at", now).
+		Set("start_at", 0).
+		Set("finish_at", 0).
+		Set("update_at", now).
+		Where(sq.Eq{"id": jobID}).
+		Where(sq.Eq{"status": []model.JobStatus{model.JobStatusError, model.JobStatusCanceled}}).
+		Exec()
+	if err != nil {
+		s.logger.Error("retryJob error", mlog.String("jobID", jobID), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// resetStaleJobs gives back the running jobs whose worker hasn't sent a
+// heartbeat since staleBefore, which happens when its server crashed.
+// They are scheduled again, or marked as failed if they have no
+// attempts left. It returns the number of jobs reset.
+func (s *SQLStore) resetStaleJobs(db sq.BaseRunner, staleBefore, now int64) (int64, error) {
+	failed, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", model.JobStatusError).
+		Set("last_error", errJobWorkerStopped).
+		Set("finish_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"status": model.JobStatusRunning}).
+		Where(sq.Lt{"update_at": staleBefore}).
+		Where("attempts >= max_attempts").
+		Exec()
+	if err != nil {
+		s.logger.Error("resetStaleJobs error", mlog.Err(err))
+		return 0, err
+	}
+
+	rescheduled, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", model.JobStatusPending).
+		Set("last_error", errJobWorkerStopped).
+		Set("worker_id", "").
+		Set("scheduled_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"status": model.JobStatusRunning}).
+		Where(sq.Lt{"update_at": staleBefore}).
+		Exec()
+	if err != nil {
+		s.logger.Error("resetStaleJobs error", mlog.Err(err))
+		return 0, err
+	}
+
+	failedCount, err := failed.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	rescheduledCount, err := rescheduled.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return failedCount + rescheduledCount, nil
+}
+
+// deleteFinishedJobs deletes the jobs that finished before a time, and
+// returns the number of jobs deleted.
+func (s *SQLStore) deleteFinishedJobs(db sq.BaseRunner, finishedBefore int64) (int64, error) {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "jobs").
+		Where(sq.Eq{"status": []model.JobStatus{model.JobStatusSuccess, model.JobStatusError, model.JobStatusCanceled}}).
+		Where(sq.Lt{"finish_at": finishedBefore}).
+		Exec()
+	if err != nil {
+		s.logger.Error("deleteFinishedJobs error", mlog.Err(err))
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,89 @@
+package sqlstore
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// acquireLease takes or renews the lease with the given name until
+// expireAt. It returns false if another holder has a lease that hasn't
+// expired yet.
+func (s *SQLStore) acquireLease(db sq.BaseRunner, name, holderID string, expireAt, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"leases").
+		Set("holder_id", holderID).
+		Set("expire_at", expireAt).
+		Set("update_at", now).
+		Where(sq.Eq{"name": name}).
+		Where(sq.Or{
+			sq.Eq{"holder_id": holderID},
+			sq.Lt{"expire_at": now},
+		}).
+		Exec()
+	if err != nil {
+		s.logger.Error("acquireLease update error", mlog.String("name", name), mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	// the lease either doesn't exist yet or is held by someone else
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"leases").
+		Columns(
+			"name",
+			"holder_id",
+			"expire_at",
+			"update_at",
+		).
+		Values(
+			name,
+			holderID,
+			expireAt,
+			now,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE name = name")
+	} else {
+		query = query.Suffix("ON CONFLICT (name) DO NOTHING")
+	}
+
+	result, err = query.Exec()
+	if err != nil {
+		s.logger.Error("acquireLease insert error", mlog.String("name", name), mlog.Err(err))
+		return false, err
+	}
+
+	count, err = result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// releaseLease gives up the lease with the given name, if it is held by
+// holderID, so that another holder can take it right away.
+func (s *SQLStore) releaseLease(db sq.BaseRunner, name, holderID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "leases").
+		Where(sq.Eq{"name": name}).
+		Where(sq.Eq{"holder_id": holderID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("releaseLease error", mlog.String("name", name), mlog.Err(err))
+		return err
+	}
+
+	return nil
+}
@@ -36,6 +36,9 @@ func legacyBoardFields(prefix string) []string {
 		"create_at",
 		"update_at",
 		"delete_at",
+		"false", // substitute for frozen column.
+		"''",    // substitute for frozen_reason column.
+		"0",     // substitute for frozen_until column.
 	}
 
 	if prefix == "" {
@@ -47,7 +50,7 @@ func legacyBoardFields(prefix string) []string {
 		switch {
 		case strings.HasPrefix(field, "COALESCE("):
 			prefixedFields[i] = strings.Replace(field, "COALESCE(", "COALESCE("+prefix, 1)
-		case field == "''":
+		case field == "''", field == "false", field == "0":
 			prefixedFields[i] = field
 		default:
 			prefixedFields[i] = prefix + field
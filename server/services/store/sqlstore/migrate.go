@@ -266,6 +266,12 @@ func (s *SQLStore) runMigrationSequence(engine *morph.Morph, driver drivers.Driv
 		return err
 	}
 
+	// the links are saved with the final blocks schema, so this runs
+	// once all the schema migrations are applied
+	if mErr := s.RunBlockLinksMigration(); mErr != nil {
+		return fmt.Errorf("error running block links migration: %w", mErr)
+	}
+
 	// always run the collations & charset fix-ups
 	if mErr := s.RunFixCollationsAndCharsetsMigration(); mErr != nil {
 		return fmt.Errorf("error running fix collations and charsets migration: %w", mErr)
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// latestMigrationVersion returns the version of the last migration
+// embedded in the server.
+func latestMigrationVersion() (int, error) {
+	assetsList, err := Assets.ReadDir("migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, dirEntry := range assetsList {
+		name := dirEntry.Name()
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration name %s: %w", name, err)
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
+
+// migrationTableName returns the table of the applied migrations. The
+// migration engine runs without options on SQLite, so it uses the
+// default table of morph there.
+func (s *SQLStore) migrationTableName() string {
+	if s.dbType == model.SqliteDBType {
+		return "db_migrations"
+	}
+	return s.tablePrefix + "schema_migrations"
+}
+
+func (s *SQLStore) getMigrationStatus(db sq.BaseRunner) (*model.MigrationStatus, error) {
+	latest, err := latestMigrationVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(MAX(Version), 0)").
+		From(s.migrationTableName())
+
+	var applied int
+	if err := query.QueryRow().Scan(&applied); err != nil {
+		return nil, err
+	}
+
+	return &model.MigrationStatus{
+		AppliedVersion: applied,
+		LatestVersion:  latest,
+	}, nil
+}
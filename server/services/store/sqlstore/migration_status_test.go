@@ -0,0 +1,21 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMigrationStatus(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	defer tearDown()
+
+	latest, err := latestMigrationVersion()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, latest, 48)
+
+	status, err := store.GetMigrationStatus()
+	require.NoError(t, err)
+	require.Equal(t, latest, status.LatestVersion)
+	require.Equal(t, latest, status.AppliedVersion)
+}
@@ -0,0 +1,7 @@
+{{ addColumnIfNeeded "boards" "frozen" "boolean" "" }}
+{{ addColumnIfNeeded "boards" "frozen_reason" "varchar(1024)" "" }}
+{{ addColumnIfNeeded "boards" "frozen_until" "bigint" "" }}
+
+{{ addColumnIfNeeded "boards_history" "frozen" "boolean" "" }}
+{{ addColumnIfNeeded "boards_history" "frozen_reason" "varchar(1024)" "" }}
+{{ addColumnIfNeeded "boards_history" "frozen_until" "bigint" "" }}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_redirects
+(
+    source_board_id VARCHAR(36) NOT NULL,
+    card_id         VARCHAR(36) NOT NULL,
+    target_board_id VARCHAR(36) NOT NULL,
+    created_by      VARCHAR(36) NOT NULL,
+    create_at       BIGINT,
+    PRIMARY KEY (source_board_id, card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_redirects" "card_id" }}
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_mirrors
+(
+    board_id    VARCHAR(36) NOT NULL,
+    card_id     VARCHAR(36) NOT NULL,
+    {{if .mysql}}
+    properties  JSON,
+    {{end}}
+    {{if .postgres}}
+    properties  JSONB,
+    {{end}}
+    {{if .sqlite}}
+    properties  TEXT,
+    {{end}}
+    created_by  VARCHAR(36) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    create_at   BIGINT,
+    update_at   BIGINT,
+    delete_at   BIGINT,
+    PRIMARY KEY (board_id, card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_mirrors" "card_id" }}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}read_states
+(
+    user_id   VARCHAR(36) NOT NULL,
+    board_id  VARCHAR(36) NOT NULL,
+    card_id   VARCHAR(36) NOT NULL,
+    viewed_at BIGINT,
+    PRIMARY KEY (user_id, board_id, card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
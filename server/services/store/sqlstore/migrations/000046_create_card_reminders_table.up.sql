@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_reminders
+(
+    id           VARCHAR(36) NOT NULL,
+    user_id      VARCHAR(36) NOT NULL,
+    board_id     VARCHAR(36) NOT NULL,
+    card_id      VARCHAR(36) NOT NULL,
+    note         TEXT,
+    remind_at    BIGINT,
+    delivered_at BIGINT,
+    create_at    BIGINT,
+    update_at    BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_reminders" "user_id" }}
+{{ createIndexIfNeeded "card_reminders" "card_id" }}
+{{ createIndexIfNeeded "card_reminders" "remind_at" }}
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_votes
+(
+    card_id   VARCHAR(36) NOT NULL,
+    user_id   VARCHAR(36) NOT NULL,
+    board_id  VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (card_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_reactions
+(
+    card_id   VARCHAR(36) NOT NULL,
+    user_id   VARCHAR(36) NOT NULL,
+    emoji     VARCHAR(64) NOT NULL,
+    board_id  VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (card_id, user_id, emoji)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_votes" "board_id, user_id" }}
+{{ createIndexIfNeeded "card_reactions" "board_id" }}
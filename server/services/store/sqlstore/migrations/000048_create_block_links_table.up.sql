@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}block_links
+(
+    source_id       VARCHAR(36) NOT NULL,
+    target_board_id VARCHAR(36) NOT NULL,
+    target_card_id  VARCHAR(36) NOT NULL,
+    source_board_id VARCHAR(36) NOT NULL,
+    source_card_id  VARCHAR(36) NOT NULL,
+    create_at       BIGINT,
+    PRIMARY KEY (source_id, target_board_id, target_card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "block_links" "target_card_id" }}
+{{ createIndexIfNeeded "block_links" "target_board_id" }}
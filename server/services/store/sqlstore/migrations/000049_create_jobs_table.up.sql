@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}jobs
+(
+    id           VARCHAR(36) NOT NULL,
+    type         VARCHAR(64) NOT NULL,
+    status       VARCHAR(32) NOT NULL,
+    data         {{if .postgres}}JSON{{else}}TEXT{{end}},
+    progress     INT,
+    attempts     INT,
+    max_attempts INT,
+    last_error   TEXT,
+    worker_id    VARCHAR(36),
+    scheduled_at BIGINT,
+    start_at     BIGINT,
+    finish_at    BIGINT,
+    create_at    BIGINT,
+    update_at    BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "jobs" "status, scheduled_at" }}
+{{ createIndexIfNeeded "jobs" "type, status" }}
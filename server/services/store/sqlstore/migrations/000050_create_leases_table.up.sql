@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}leases
+(
+    name      VARCHAR(64) NOT NULL,
+    holder_id VARCHAR(36) NOT NULL,
+    expire_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (name)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
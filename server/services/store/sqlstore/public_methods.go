@@ -22,6 +22,11 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+func (s *SQLStore) AcquireLease(name string, holderID string, expireAt int64, now int64) (bool, error) {
+	return s.acquireLease(s.db, name, holderID, expireAt, now)
+
+}
+
 func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, boardIDs []string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.addUpdateCategoryBoard(s.db, userID, categoryID, boardIDs)
@@ -51,6 +56,21 @@ func (s *SQLStore) CanSeeUser(seerID string, seenID string) (bool, error) {
 
 }
 
+func (s *SQLStore) CancelJob(jobID string, now int64) (bool, error) {
+	return s.cancelJob(s.db, jobID, now)
+
+}
+
+func (s *SQLStore) CheckIntegrity() ([]*model.IntegrityCheck, error) {
+	return s.checkIntegrity(s.db)
+
+}
+
+func (s *SQLStore) ClaimNextJob(jobTypes []string, workerID string, now int64) (*model.Job, error) {
+	return s.claimNextJob(s.db, jobTypes, workerID, now)
+
+}
+
 func (s *SQLStore) CleanUpSessions(expireTime int64) error {
 	return s.cleanUpSessions(s.db, expireTime)
 
@@ -128,6 +148,11 @@ func (s *SQLStore) CreateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) CreateJob(job *model.Job) (*model.Job, error) {
+	return s.createJob(s.db, job)
+
+}
+
 func (s *SQLStore) CreateSession(session *model.Session) error {
 	return s.createSession(s.db, session)
 
@@ -143,6 +168,30 @@ func (s *SQLStore) CreateUser(user *model.User) (*model.User, error) {
 
 }
 
+func (s *SQLStore) DeactivateUser(userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deactivateUser(s.db, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deactivateUser(tx, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeactivateUser"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
@@ -225,11 +274,75 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
+func (s *SQLStore) DeleteCardContent(cardID string, blockID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteCardContent(s.db, cardID, blockID, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteCardContent(tx, cardID, blockID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteCardContent"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) DeleteCardMirror(boardID string, cardID string, userID string) error {
+	return s.deleteCardMirror(s.db, boardID, cardID, userID)
+
+}
+
+func (s *SQLStore) DeleteCardReaction(cardID string, userID string, emoji string) error {
+	return s.deleteCardReaction(s.db, cardID, userID, emoji)
+
+}
+
+func (s *SQLStore) DeleteCardReminder(reminderID string) error {
+	return s.deleteCardReminder(s.db, reminderID)
+
+}
+
+func (s *SQLStore) DeleteCardRemindersForCard(cardID string) error {
+	return s.deleteCardRemindersForCard(s.db, cardID)
+
+}
+
+func (s *SQLStore) DeleteCardVote(cardID string, userID string) error {
+	return s.deleteCardVote(s.db, cardID, userID)
+
+}
+
+func (s *SQLStore) DeleteCardVotesForCard(cardID string) error {
+	return s.deleteCardVotesForCard(s.db, cardID)
+
+}
+
 func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
 	return s.deleteCategory(s.db, categoryID, userID, teamID)
 
 }
 
+func (s *SQLStore) DeleteCommentReaction(commentID string, userID string, emoji string) error {
+	return s.deleteCommentReaction(s.db, commentID, userID, emoji)
+
+}
+
+func (s *SQLStore) DeleteFinishedJobs(finishedBefore int64) (int64, error) {
+	return s.deleteFinishedJobs(s.db, finishedBefore)
+
+}
+
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
 	return s.deleteMember(s.db, boardID, userID)
 
@@ -298,6 +411,11 @@ func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string,
 
 }
 
+func (s *SQLStore) FinishJob(job *model.Job, now int64) (bool, error) {
+	return s.finishJob(s.db, job, now)
+
+}
+
 func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
 	return s.getActiveUserCount(s.db, updatedSecondsAgo)
 
@@ -308,6 +426,16 @@ func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
 
 }
 
+func (s *SQLStore) GetAllUsers(includeDeleted bool) ([]*model.User, error) {
+	return s.getAllUsers(s.db, includeDeleted)
+
+}
+
+func (s *SQLStore) GetBacklinks(boardID string, cardID string) ([]*model.Backlink, error) {
+	return s.getBacklinks(s.db, boardID, cardID)
+
+}
+
 func (s *SQLStore) GetBlock(blockID string) (*model.Block, error) {
 	return s.getBlock(s.db, blockID)
 
@@ -398,6 +526,11 @@ func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit ui
 
 }
 
+func (s *SQLStore) GetBoardUnreadCounts(userID string, boardIDs []string) (map[string]int, error) {
+	return s.getBoardUnreadCounts(s.db, userID, boardIDs)
+
+}
+
 func (s *SQLStore) GetBoardsComplianceHistory(opts model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	return s.getBoardsComplianceHistory(s.db, opts)
 
@@ -418,11 +551,56 @@ func (s *SQLStore) GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*mo
 
 }
 
+func (s *SQLStore) GetBoardsWithExpiredFreeze(before int64) ([]*model.Board, error) {
+	return s.getBoardsWithExpiredFreeze(s.db, before)
+
+}
+
 func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
 	return s.getCardLimitTimestamp(s.db)
 
 }
 
+func (s *SQLStore) GetCardMirror(boardID string, cardID string) (*model.CardMirror, error) {
+	return s.getCardMirror(s.db, boardID, cardID)
+
+}
+
+func (s *SQLStore) GetCardMirrorsForBoard(boardID string) ([]*model.CardMirror, error) {
+	return s.getCardMirrorsForBoard(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetCardMirrorsForCard(cardID string) ([]*model.CardMirror, error) {
+	return s.getCardMirrorsForCard(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardRedirect(boardID string, cardID string) (*model.CardRedirect, error) {
+	return s.getCardRedirect(s.db, boardID, cardID)
+
+}
+
+func (s *SQLStore) GetCardReminder(reminderID string) (*model.CardReminder, error) {
+	return s.getCardReminder(s.db, reminderID)
+
+}
+
+func (s *SQLStore) GetCardRemindersForUser(userID string) ([]*model.CardReminder, error) {
+	return s.getCardRemindersForUser(s.db, userID)
+
+}
+
+func (s *SQLStore) GetCardUnreadCounts(userID string, boardID string) (map[string]int, error) {
+	return s.getCardUnreadCounts(s.db, userID, boardID)
+
+}
+
+func (s *SQLStore) GetCardVotes(cardIDs []string, userID string) (map[string]*model.CardVotes, error) {
+	return s.getCardVotes(s.db, cardIDs, userID)
+
+}
+
 func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
 	return s.getCategory(s.db, id)
 
@@ -438,11 +616,31 @@ func (s *SQLStore) GetCloudLimits() (*mmModel.ProductLimits, error) {
 
 }
 
+func (s *SQLStore) GetCommentReactions(commentIDs []string) ([]*model.CommentReaction, error) {
+	return s.getCommentReactions(s.db, commentIDs)
+
+}
+
+func (s *SQLStore) GetDueCardReminders(now int64) ([]*model.CardReminder, error) {
+	return s.getDueCardReminders(s.db, now)
+
+}
+
 func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
 	return s.getFileInfo(s.db, id)
 
 }
 
+func (s *SQLStore) GetJob(jobID string) (*model.Job, error) {
+	return s.getJob(s.db, jobID)
+
+}
+
+func (s *SQLStore) GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error) {
+	return s.getJobs(s.db, opts)
+
+}
+
 func (s *SQLStore) GetLicense() *mmModel.License {
 	return s.getLicense(s.db)
 
@@ -463,6 +661,11 @@ func (s *SQLStore) GetMembersForUser(userID string) ([]*model.BoardMember, error
 
 }
 
+func (s *SQLStore) GetMigrationStatus() (*model.MigrationStatus, error) {
+	return s.getMigrationStatus(s.db)
+
+}
+
 func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
 	return s.getNextNotificationHint(s.db, remove)
 
@@ -473,6 +676,11 @@ func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint,
 
 }
 
+func (s *SQLStore) GetReadState(userID string, boardID string, cardID string) (*model.ReadState, error) {
+	return s.getReadState(s.db, userID, boardID, cardID)
+
+}
+
 func (s *SQLStore) GetRegisteredUserCount() (int, error) {
 	return s.getRegisteredUserCount(s.db)
 
@@ -603,6 +811,11 @@ func (s *SQLStore) GetUsersList(userIDs []string, showEmail bool, showName bool)
 
 }
 
+func (s *SQLStore) HasActiveJob(jobType string) (bool, error) {
+	return s.hasActiveJob(s.db, jobType)
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
@@ -680,6 +893,107 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 
 }
 
+func (s *SQLStore) InsertCardContent(block *model.Block, position int, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.insertCardContent(s.db, block, position, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.insertCardContent(tx, block, position, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertCardContent"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) MarkCardReminderDelivered(reminderID string, now int64) (bool, error) {
+	return s.markCardReminderDelivered(s.db, reminderID, now)
+
+}
+
+func (s *SQLStore) MoveBoardToTeam(boardID string, toTeamID string, userID string, removedUserIDs []string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.moveBoardToTeam(s.db, boardID, toTeamID, userID, removedUserIDs)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.moveBoardToTeam(tx, boardID, toTeamID, userID, removedUserIDs)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBoardToTeam"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) MoveCardContent(cardID string, blockID string, position int, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.moveCardContent(s.db, cardID, blockID, position, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.moveCardContent(tx, cardID, blockID, position, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveCardContent"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) MoveCardToBoard(card *model.Block, toBoardID string, userID string) (*model.CardRedirect, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.moveCardToBoard(s.db, card, toBoardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.moveCardToBoard(tx, card, toBoardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveCardToBoard"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
@@ -786,11 +1100,21 @@ func (s *SQLStore) PostMessage(message string, postType string, channelID string
 
 }
 
+func (s *SQLStore) RebuildBlockLinks(batchSize int) (int64, error) {
+	return s.rebuildBlockLinks(s.db, batchSize)
+
+}
+
 func (s *SQLStore) RefreshSession(session *model.Session) error {
 	return s.refreshSession(s.db, session)
 
 }
 
+func (s *SQLStore) ReleaseLease(name string, holderID string) error {
+	return s.releaseLease(s.db, name, holderID)
+
+}
+
 func (s *SQLStore) RemoveDefaultTemplates(boards []*model.Board) error {
 	return s.removeDefaultTemplates(s.db, boards)
 
@@ -806,6 +1130,16 @@ func (s *SQLStore) ReorderCategoryBoards(categoryID string, newBoardsOrder []str
 
 }
 
+func (s *SQLStore) ResetStaleJobs(staleBefore int64, now int64) (int64, error) {
+	return s.resetStaleJobs(s.db, staleBefore, now)
+
+}
+
+func (s *SQLStore) RetryJob(jobID string, now int64) (bool, error) {
+	return s.retryJob(s.db, jobID, now)
+
+}
+
 func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
@@ -830,6 +1164,50 @@ func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64)
 
 }
 
+func (s *SQLStore) SaveCardMirror(mirror *model.CardMirror) (*model.CardMirror, error) {
+	return s.saveCardMirror(s.db, mirror)
+
+}
+
+func (s *SQLStore) SaveCardReaction(reaction *model.CardReaction) error {
+	return s.saveCardReaction(s.db, reaction)
+
+}
+
+func (s *SQLStore) SaveCardReminder(reminder *model.CardReminder) (*model.CardReminder, error) {
+	return s.saveCardReminder(s.db, reminder)
+
+}
+
+func (s *SQLStore) SaveCardVote(vote *model.CardVote, voteLimit int) error {
+	if s.dbType == model.SqliteDBType {
+		return s.saveCardVote(s.db, vote, voteLimit)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.saveCardVote(tx, vote, voteLimit)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveCardVote"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) SaveCommentReaction(reaction *model.CommentReaction) (*model.CommentReaction, error) {
+	return s.saveCommentReaction(s.db, reaction)
+
+}
+
 func (s *SQLStore) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 	return s.saveFileInfo(s.db, fileInfo)
 
@@ -840,6 +1218,11 @@ func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
 
 }
 
+func (s *SQLStore) SaveReadState(readState *model.ReadState) error {
+	return s.saveReadState(s.db, readState)
+
+}
+
 func (s *SQLStore) SearchBoardsForUser(term string, searchField model.BoardSearchField, userID string, includePublicBoards bool) ([]*model.Board, error) {
 	return s.searchBoardsForUser(s.db, term, searchField, userID, includePublicBoards)
 
@@ -850,6 +1233,11 @@ func (s *SQLStore) SearchBoardsForUserInTeam(teamID string, term string, userID
 
 }
 
+func (s *SQLStore) SearchCardsForBoard(boardID string, term string) ([]*model.Block, error) {
+	return s.searchCardsForBoard(s.db, boardID, term)
+
+}
+
 func (s *SQLStore) SearchUserChannels(teamID string, userID string, query string) ([]*mmModel.Channel, error) {
 	return s.searchUserChannels(s.db, teamID, userID, query)
 
@@ -875,6 +1263,30 @@ func (s *SQLStore) SetSystemSetting(key string, value string) error {
 
 }
 
+func (s *SQLStore) TransferBoardOwnership(boardID string, userID string) (*model.Board, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.transferBoardOwnership(s.db, boardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.transferBoardOwnership(tx, boardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "TransferBoardOwnership"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.undeleteBlock(s.db, blockID, modifiedBy)
@@ -933,6 +1345,16 @@ func (s *SQLStore) UpdateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) UpdateFileInfoPath(id string, path string) error {
+	return s.updateFileInfoPath(s.db, id, path)
+
+}
+
+func (s *SQLStore) UpdateJobProgress(jobID string, workerID string, progress int, now int64) (bool, error) {
+	return s.updateJobProgress(s.db, jobID, workerID, progress, now)
+
+}
+
 func (s *SQLStore) UpdateSession(session *model.Session) error {
 	return s.updateSession(s.db, session)
 
@@ -0,0 +1,203 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// historyCardIDExpr resolves the card a history entry belongs to: the
+// card itself, or the parent of its content blocks and comments.
+const historyCardIDExpr = "CASE WHEN bh.type = 'card' THEN bh.id ELSE bh.parent_id END"
+
+// saveReadState stores the last time a user viewed a board or a card,
+// replacing the previous one.
+func (s *SQLStore) saveReadState(db sq.BaseRunner, readState *model.ReadState) error {
+	if err := readState.IsValid(); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"read_states").
+		Columns(
+			"user_id",
+			"board_id",
+			"card_id",
+			"viewed_at",
+		).
+		Values(
+			readState.UserID,
+			readState.BoardID,
+			readState.CardID,
+			readState.ViewedAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE viewed_at = ?", readState.ViewedAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (user_id, board_id, card_id) DO UPDATE SET viewed_at = EXCLUDED.viewed_at")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveReadState error",
+			mlog.String("userID", readState.UserID),
+			mlog.String("boardID", readState.BoardID),
+			mlog.String("cardID", readState.CardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SQLStore) getReadState(db sq.BaseRunner, userID, boardID, cardID string) (*model.ReadState, error) {
+	query := s.getQueryBuilder(db).
+		Select("viewed_at").
+		From(s.tablePrefix + "read_states").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"card_id": cardID})
+
+	readState := &model.ReadState{
+		UserID:  userID,
+		BoardID: boardID,
+		CardID:  cardID,
+	}
+
+	err := query.QueryRow().Scan(&readState.ViewedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		message := fmt.Sprintf("read state UserID=%s BoardID=%s CardID=%s", userID, boardID, cardID)
+		return nil, model.NewErrNotFound(message)
+	}
+	if err != nil {
+		s.logger.Error("getReadState error", mlog.Err(err))
+		return nil, err
+	}
+
+	return readState, nil
+}
+
+// getBoardUnreadCounts returns, by board ID, the number of changes made
+// by other users to the blocks of the boards since the user last
+// viewed them. Changes to the private views of other users aren't
+// counted. Boards without unread changes are omitted.
+func (s *SQLStore) getBoardUnreadCounts(db sq.BaseRunner, userID string, boardIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	if len(boardIDs) == 0 {
+		return counts, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("bh.board_id", "COUNT(*)").
+		From(s.tablePrefix+"blocks_history AS bh").
+		LeftJoin(s.tablePrefix+"read_states AS rs ON rs.user_id = ? AND rs.board_id = bh.board_id AND rs.card_id = ''", userID).
+		Where(sq.Eq{"bh.board_id": boardIDs}).
+		Where(sq.NotEq{"bh.modified_by": userID}).
+		Where(s.notOthersPrivateViewCondition("bh", userID)).
+		Where("bh.update_at > COALESCE(rs.viewed_at, 0)").
+		GroupBy("bh.board_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBoardUnreadCounts error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.unreadCountsFromRows(rows)
+}
+
+// getCardUnreadCounts returns, by card ID, the number of changes made by
+// other users to the cards of a board, their content and their comments
+// since the user last viewed them. Cards the user never viewed count
+// the changes since the user last viewed the board. Cards without unread
+// changes are omitted.
+func (s *SQLStore) getCardUnreadCounts(db sq.BaseRunner, userID, boardID string) (map[string]int, error) {
+	var boardViewedAt int64
+	boardReadState, err := s.getReadState(db, userID, boardID, "")
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if boardReadState != nil {
+		boardViewedAt = boardReadState.ViewedAt
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(historyCardIDExpr, "COUNT(*)").
+		From(s.tablePrefix+"blocks_history AS bh").
+		LeftJoin(s.tablePrefix+"read_states AS rs ON rs.user_id = ? AND rs.board_id = bh.board_id AND rs.card_id = "+historyCardIDExpr, userID).
+		Where(sq.Eq{"bh.board_id": boardID}).
+		Where(sq.NotEq{"bh.modified_by": userID}).
+		Where(s.notOthersPrivateViewCondition("bh", userID)).
+		Where("bh.update_at > COALESCE(rs.viewed_at, ?)", boardViewedAt).
+		GroupBy(historyCardIDExpr)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardUnreadCounts error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	counts, err := s.unreadCountsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// blocks that are direct children of the board, like views, don't
+	// belong to any card
+	delete(counts, boardID)
+	delete(counts, "")
+
+	return counts, nil
+}
+
+// notOthersPrivateViewCondition returns the condition that filters out
+// the private views of other users than userID, so their changes don't
+// reveal that the views exist.
+func (s *SQLStore) notOthersPrivateViewCondition(tableAlias, userID string) sq.Sqlizer {
+	if tableAlias != "" && !strings.HasSuffix(tableAlias, ".") {
+		tableAlias += "."
+	}
+
+	var ownerIDExpr string
+	switch s.dbType {
+	case model.PostgresDBType:
+		ownerIDExpr = tableAlias + "fields->>'" + model.ViewFieldOwnerID + "'"
+	case model.MysqlDBType:
+		ownerIDExpr = "JSON_UNQUOTE(JSON_EXTRACT(" + tableAlias + "fields, '$." + model.ViewFieldOwnerID + "'))"
+	default:
+		ownerIDExpr = "JSON_EXTRACT(" + tableAlias + "fields, '$." + model.ViewFieldOwnerID + "')"
+	}
+
+	return sq.Or{
+		sq.NotEq{tableAlias + "type": model.TypeView},
+		sq.Expr("COALESCE("+ownerIDExpr+", '') IN ('', ?)", userID),
+	}
+}
+
+func (s *SQLStore) unreadCountsFromRows(rows *sql.Rows) (map[string]int, error) {
+	counts := map[string]int{}
+
+	for rows.Next() {
+		var id string
+		var count int
+
+		if err := rows.Scan(&id, &count); err != nil {
+			s.logger.Error("unreadCountsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+
+		counts[id] = count
+	}
+
+	return counts, nil
+}
@@ -30,6 +30,16 @@ func TestSQLStore(t *testing.T) {
 	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
 	t.Run("BoardsInsightsStore", func(t *testing.T) { storetests.StoreTestBoardsInsightsStore(t, SetupTests) })
 	t.Run("ComplianceHistoryStore", func(t *testing.T) { storetests.StoreTestComplianceHistoryStore(t, SetupTests) })
+	t.Run("CardMirrorsStore", func(t *testing.T) { storetests.StoreTestCardMirrorsStore(t, SetupTests) })
+	t.Run("CommentReactionsStore", func(t *testing.T) { storetests.StoreTestCommentReactionsStore(t, SetupTests) })
+	t.Run("CardContentStore", func(t *testing.T) { storetests.StoreTestCardContentStore(t, SetupTests) })
+	t.Run("ReadStateStore", func(t *testing.T) { storetests.StoreTestReadStateStore(t, SetupTests) })
+	t.Run("CardReminderStore", func(t *testing.T) { storetests.StoreTestCardReminderStore(t, SetupTests) })
+	t.Run("CardVotesStore", func(t *testing.T) { storetests.StoreTestCardVotesStore(t, SetupTests) })
+	t.Run("BlockLinksStore", func(t *testing.T) { storetests.StoreTestBlockLinksStore(t, SetupTests) })
+	t.Run("Integrity", func(t *testing.T) { storetests.StoreTestIntegrity(t, SetupTests) })
+	t.Run("JobStore", func(t *testing.T) { storetests.StoreTestJobStore(t, SetupTests) })
+	t.Run("LeaseStore", func(t *testing.T) { storetests.StoreTestLeaseStore(t, SetupTests) })
 }
 
 //  tests for  utility functions inside sqlstore.go
@@ -57,8 +57,8 @@ func (s *SQLStore) getUserByCondition(db sq.BaseRunner, condition sq.Eq) (*model
 	return users[0], nil
 }
 
-func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{}, limit uint64) ([]*model.User, error) {
-	query := s.getQueryBuilder(db).
+func (s *SQLStore) usersQuery(db sq.BaseRunner) sq.SelectBuilder {
+	return s.getQueryBuilder(db).
 		Select(
 			"id",
 			"username",
@@ -71,7 +71,11 @@ func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{},
 			"update_at",
 			"delete_at",
 		).
-		From(s.tablePrefix + "users").
+		From(s.tablePrefix + "users")
+}
+
+func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{}, limit uint64) ([]*model.User, error) {
+	query := s.usersQuery(db).
 		Where(sq.Eq{"delete_at": 0}).
 		Where(condition)
 
@@ -223,6 +227,59 @@ func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string, _ string, _, _ boo
 	return users, err
 }
 
+// getAllUsers returns the users sorted by username, with the
+// deactivated ones if includeDeleted is true.
+func (s *SQLStore) getAllUsers(db sq.BaseRunner, includeDeleted bool) ([]*model.User, error) {
+	query := s.usersQuery(db).OrderBy("username")
+	if !includeDeleted {
+		query = query.Where(sq.Eq{"delete_at": 0})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getAllUsers ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.usersFromRows(rows)
+}
+
+// deactivateUser marks a user as deleted, so that they can no longer
+// log in, and deletes their sessions.
+func (s *SQLStore) deactivateUser(db sq.BaseRunner, userID string) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("update_at", now).
+		Set("delete_at", now).
+		Where(sq.Eq{"id": userID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return model.NewErrNotFound("user ID=" + userID)
+	}
+
+	deleteQuery := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+		Where(sq.Eq{"user_id": userID})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		return fmt.Errorf("cannot delete the sessions of user %s: %w", userID, err)
+	}
+
+	return nil
+}
+
 func (s *SQLStore) searchUsersByTeam(db sq.BaseRunner, _ string, searchQuery string, _ string, _, _, _ bool) ([]*model.User, error) {
 	users, err := s.getUsersByCondition(db, &sq.Like{"username": "%" + searchQuery + "%"}, 10)
 	if model.IsErrNotFound(err) {
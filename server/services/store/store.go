@@ -21,6 +21,7 @@ type Store interface {
 	GetBlocksWithType(boardID, blockType string) ([]*model.Block, error)
 	GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]*model.Block, error)
 	GetBlocksForBoard(boardID string) ([]*model.Block, error)
+	SearchCardsForBoard(boardID, term string) ([]*model.Block, error)
 	// @withTransaction
 	InsertBlock(block *model.Block, userID string) error
 	// @withTransaction
@@ -47,6 +48,49 @@ type Store interface {
 	// @withTransaction
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
 	// @withTransaction
+	MoveCardToBoard(card *model.Block, toBoardID, userID string) (*model.CardRedirect, error)
+	// @withTransaction
+	InsertCardContent(block *model.Block, position int, userID string) error
+	// @withTransaction
+	MoveCardContent(cardID, blockID string, position int, userID string) error
+	// @withTransaction
+	DeleteCardContent(cardID, blockID, userID string) error
+	GetCardRedirect(boardID, cardID string) (*model.CardRedirect, error)
+
+	SaveCardMirror(mirror *model.CardMirror) (*model.CardMirror, error)
+	GetCardMirror(boardID, cardID string) (*model.CardMirror, error)
+	GetCardMirrorsForBoard(boardID string) ([]*model.CardMirror, error)
+	GetCardMirrorsForCard(cardID string) ([]*model.CardMirror, error)
+	DeleteCardMirror(boardID, cardID, userID string) error
+
+	SaveCommentReaction(reaction *model.CommentReaction) (*model.CommentReaction, error)
+	GetCommentReactions(commentIDs []string) ([]*model.CommentReaction, error)
+	DeleteCommentReaction(commentID, userID, emoji string) error
+
+	SaveReadState(readState *model.ReadState) error
+	GetReadState(userID, boardID, cardID string) (*model.ReadState, error)
+	GetBoardUnreadCounts(userID string, boardIDs []string) (map[string]int, error)
+	GetCardUnreadCounts(userID, boardID string) (map[string]int, error)
+
+	SaveCardReminder(reminder *model.CardReminder) (*model.CardReminder, error)
+	GetCardReminder(reminderID string) (*model.CardReminder, error)
+	GetCardRemindersForUser(userID string) ([]*model.CardReminder, error)
+	GetDueCardReminders(now int64) ([]*model.CardReminder, error)
+	MarkCardReminderDelivered(reminderID string, now int64) (bool, error)
+	DeleteCardReminder(reminderID string) error
+	DeleteCardRemindersForCard(cardID string) error
+
+	// @withTransaction
+	SaveCardVote(vote *model.CardVote, voteLimit int) error
+	DeleteCardVote(cardID, userID string) error
+	SaveCardReaction(reaction *model.CardReaction) error
+	DeleteCardReaction(cardID, userID, emoji string) error
+	GetCardVotes(cardIDs []string, userID string) (map[string]*model.CardVotes, error)
+	DeleteCardVotesForCard(cardID string) error
+
+	GetBacklinks(boardID, cardID string) ([]*model.Backlink, error)
+	RebuildBlockLinks(batchSize int) (int64, error)
+	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
 
 	Shutdown() error
@@ -65,6 +109,9 @@ type Store interface {
 	UpdateUserPassword(username, password string) error
 	UpdateUserPasswordByID(userID, password string) error
 	GetUsersByTeam(teamID string, asGuestID string, showEmail, showName bool) ([]*model.User, error)
+	GetAllUsers(includeDeleted bool) ([]*model.User, error)
+	// @withTransaction
+	DeactivateUser(userID string) error
 	SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool, showEmail, showName bool) ([]*model.User, error)
 	PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error)
 	GetUserPreferences(userID string) (mmModel.Preferences, error)
@@ -95,6 +142,11 @@ type Store interface {
 	GetBoard(id string) (*model.Board, error)
 	GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
 	GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error)
+	GetBoardsWithExpiredFreeze(before int64) ([]*model.Board, error)
+	// @withTransaction
+	MoveBoardToTeam(boardID, toTeamID, userID string, removedUserIDs []string) (*model.Board, error)
+	// @withTransaction
+	TransferBoardOwnership(boardID, userID string) (*model.Board, error)
 	// @withTransaction
 	DeleteBoard(boardID, userID string) error
 
@@ -130,6 +182,7 @@ type Store interface {
 
 	GetFileInfo(id string) (*mmModel.FileInfo, error)
 	SaveFileInfo(fileInfo *mmModel.FileInfo) error
+	UpdateFileInfoPath(id, path string) error
 
 	// @withTransaction
 	AddUpdateCategoryBoard(userID, categoryID string, boardIDs []string) error
@@ -155,6 +208,24 @@ type Store interface {
 	// @withTransaction
 	RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error)
 
+	CheckIntegrity() ([]*model.IntegrityCheck, error)
+	GetMigrationStatus() (*model.MigrationStatus, error)
+
+	CreateJob(job *model.Job) (*model.Job, error)
+	GetJob(jobID string) (*model.Job, error)
+	GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error)
+	HasActiveJob(jobType string) (bool, error)
+	ClaimNextJob(jobTypes []string, workerID string, now int64) (*model.Job, error)
+	UpdateJobProgress(jobID, workerID string, progress int, now int64) (bool, error)
+	FinishJob(job *model.Job, now int64) (bool, error)
+	CancelJob(jobID string, now int64) (bool, error)
+	RetryJob(jobID string, now int64) (bool, error)
+	ResetStaleJobs(staleBefore, now int64) (int64, error)
+	DeleteFinishedJobs(finishedBefore int64) (int64, error)
+
+	AcquireLease(name, holderID string, expireAt, now int64) (bool, error)
+	ReleaseLease(name, holderID string) error
+
 	GetUsedCardsCount() (int, error)
 	GetCardLimitTimestamp() (int64, error)
 	UpdateCardLimitTimestamp(cardLimit int) (int64, error)
@@ -0,0 +1,183 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBlockLinksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveBlockLinks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveBlockLinks(t, store)
+	})
+	t.Run("DeleteAndUndeleteBlockLinks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteAndUndeleteBlockLinks(t, store)
+	})
+	t.Run("MoveCardWithBlockLinks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveCardWithBlockLinks(t, store)
+	})
+	t.Run("RebuildBlockLinks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRebuildBlockLinks(t, store)
+	})
+}
+
+func newTestLinkBlock(t *testing.T, store store.Store, card *model.Block, blockType model.BlockType, title string) *model.Block {
+	block := &model.Block{
+		ID:        utils.NewID(utils.IDTypeBlock),
+		BoardID:   card.BoardID,
+		ParentID:  card.ID,
+		Type:      blockType,
+		Title:     title,
+		CreatedBy: testUserID,
+	}
+	require.NoError(t, store.InsertBlock(block, testUserID))
+	return block
+}
+
+func requireBacklinkCardIDs(t *testing.T, store store.Store, boardID, cardID string, expected ...string) {
+	backlinks, err := store.GetBacklinks(boardID, cardID)
+	require.NoError(t, err)
+
+	cardIDs := make([]string, 0, len(backlinks))
+	for _, backlink := range backlinks {
+		cardIDs = append(cardIDs, backlink.CardID)
+	}
+	require.ElementsMatch(t, expected, cardIDs)
+}
+
+func testSaveBlockLinks(t *testing.T, store store.Store) {
+	boards := createTestBoards(t, store, testTeamID, testUserID, 2)
+	target := createTestCards(t, store, testUserID, boards[0].ID, 1)[0]
+	sources := createTestCards(t, store, testUserID, boards[1].ID, 2)
+
+	cardLink := utils.MakeCardLink("http://localhost", testTeamID, target.BoardID, target.ID)
+	boardLink := utils.MakeBoardLink("http://localhost", testTeamID, target.BoardID)
+
+	t.Run("no backlinks", func(t *testing.T) {
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+		requireBacklinkCardIDs(t, store, target.BoardID, "")
+	})
+
+	text := newTestLinkBlock(t, store, sources[0], model.TypeText, "see "+cardLink+" and "+cardLink)
+	newTestLinkBlock(t, store, sources[1], model.TypeComment, "about "+boardLink)
+
+	t.Run("links of text and comment blocks", func(t *testing.T) {
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, sources[0].ID)
+		requireBacklinkCardIDs(t, store, target.BoardID, "", sources[1].ID)
+
+		backlinks, err := store.GetBacklinks(target.BoardID, target.ID)
+		require.NoError(t, err)
+		require.Equal(t, boards[1].ID, backlinks[0].BoardID)
+		require.Equal(t, boards[1].Title, backlinks[0].BoardTitle)
+		require.Equal(t, sources[0].Title, backlinks[0].CardTitle)
+		require.NotZero(t, backlinks[0].UpdateAt)
+	})
+
+	t.Run("links of other block types are ignored", func(t *testing.T) {
+		newTestLinkBlock(t, store, sources[1], model.TypeCheckbox, cardLink)
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, sources[0].ID)
+	})
+
+	t.Run("patching the text updates the links", func(t *testing.T) {
+		title := "now about " + boardLink
+		require.NoError(t, store.PatchBlock(text.ID, &model.BlockPatch{Title: &title}, testUserID))
+
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+		requireBacklinkCardIDs(t, store, target.BoardID, "", sources[0].ID, sources[1].ID)
+	})
+}
+
+func testDeleteAndUndeleteBlockLinks(t *testing.T, store store.Store) {
+	boards := createTestBoards(t, store, testTeamID, testUserID, 2)
+	target := createTestCards(t, store, testUserID, boards[0].ID, 1)[0]
+	source := createTestCards(t, store, testUserID, boards[1].ID, 1)[0]
+
+	cardLink := utils.MakeCardLink("http://localhost", testTeamID, target.BoardID, target.ID)
+	text := newTestLinkBlock(t, store, source, model.TypeText, cardLink)
+	requireBacklinkCardIDs(t, store, target.BoardID, target.ID, source.ID)
+
+	t.Run("delete and undelete the block", func(t *testing.T) {
+		require.NoError(t, store.DeleteBlock(text.ID, testUserID))
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+
+		require.NoError(t, store.UndeleteBlock(text.ID, testUserID))
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, source.ID)
+	})
+
+	t.Run("delete and undelete the card", func(t *testing.T) {
+		require.NoError(t, store.DeleteBlock(source.ID, testUserID))
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+
+		require.NoError(t, store.UndeleteBlock(source.ID, testUserID))
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, source.ID)
+	})
+
+	t.Run("delete the board", func(t *testing.T) {
+		deleteTestBoard(t, store, boards[1].ID, testUserID)
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+	})
+}
+
+func testMoveCardWithBlockLinks(t *testing.T, store store.Store) {
+	boards := createTestBoards(t, store, testTeamID, testUserID, 3)
+	target := createTestCards(t, store, testUserID, boards[0].ID, 1)[0]
+	source := createTestCards(t, store, testUserID, boards[1].ID, 1)[0]
+
+	cardLink := utils.MakeCardLink("http://localhost", testTeamID, target.BoardID, target.ID)
+	newTestLinkBlock(t, store, source, model.TypeText, cardLink)
+
+	t.Run("move the source card", func(t *testing.T) {
+		_, err := store.MoveCardToBoard(source, boards[2].ID, testUserID)
+		require.NoError(t, err)
+
+		backlinks, err := store.GetBacklinks(target.BoardID, target.ID)
+		require.NoError(t, err)
+		require.Len(t, backlinks, 1)
+		require.Equal(t, source.ID, backlinks[0].CardID)
+		require.Equal(t, boards[2].ID, backlinks[0].BoardID)
+		require.Equal(t, boards[2].Title, backlinks[0].BoardTitle)
+	})
+
+	t.Run("delete the new board of the source card", func(t *testing.T) {
+		deleteTestBoard(t, store, boards[2].ID, testUserID)
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID)
+	})
+}
+
+func testRebuildBlockLinks(t *testing.T, store store.Store) {
+	boards := createTestBoards(t, store, testTeamID, testUserID, 2)
+	target := createTestCards(t, store, testUserID, boards[0].ID, 1)[0]
+	sources := createTestCards(t, store, testUserID, boards[1].ID, 3)
+
+	cardLink := utils.MakeCardLink("http://localhost", testTeamID, target.BoardID, target.ID)
+	for _, source := range sources {
+		newTestLinkBlock(t, store, source, model.TypeText, "see "+cardLink)
+	}
+	newTestLinkBlock(t, store, sources[0], model.TypeComment, "no link")
+	newTestLinkBlock(t, store, sources[0], model.TypeCheckbox, cardLink)
+
+	t.Run("in batches", func(t *testing.T) {
+		count, err := store.RebuildBlockLinks(2)
+		require.NoError(t, err)
+		require.EqualValues(t, 4, count)
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, sources[0].ID, sources[1].ID, sources[2].ID)
+	})
+
+	t.Run("again", func(t *testing.T) {
+		count, err := store.RebuildBlockLinks(100)
+		require.NoError(t, err)
+		require.EqualValues(t, 4, count)
+		requireBacklinkCardIDs(t, store, target.BoardID, target.ID, sources[0].ID, sources[1].ID, sources[2].ID)
+	})
+}
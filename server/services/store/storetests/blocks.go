@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -72,6 +73,16 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testDuplicateBlock(t, store)
 	})
+	t.Run("MoveCardToBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveCardToBoard(t, store)
+	})
+	t.Run("ArchivedCards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testArchivedCards(t, store)
+	})
 	t.Run("GetBlockMetadata", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -920,6 +931,140 @@ func testGetBlock(t *testing.T, store store.Store) {
 	})
 }
 
+func testMoveCardToBoard(t *testing.T, store store.Store) {
+	const boardA = "board-a"
+	const boardB = "board-b"
+	const boardC = "board-c"
+
+	card := &model.Block{
+		ID:         "card1",
+		BoardID:    boardA,
+		ParentID:   boardA,
+		ModifiedBy: testUserID,
+		Type:       model.TypeCard,
+		Fields:     map[string]interface{}{"properties": map[string]interface{}{"prop-a": "value"}},
+	}
+	blocksToInsert := []*model.Block{
+		card,
+		{
+			ID:         "card1-text",
+			BoardID:    boardA,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeText,
+		},
+		{
+			ID:         "card1-comment",
+			BoardID:    boardA,
+			ParentID:   "card1",
+			ModifiedBy: testUserID,
+			Type:       model.TypeComment,
+		},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	time.Sleep(1 * time.Millisecond)
+
+	require.NoError(t, store.SaveReadState(&model.ReadState{UserID: testUserID, BoardID: boardA, CardID: "card1", ViewedAt: 1000}))
+	require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card1", BoardID: boardA, UserID: testUserID}, 0))
+	_, err := store.SaveCardReminder(&model.CardReminder{ID: "reminder1", UserID: testUserID, BoardID: boardA, CardID: "card1", RemindAt: 1000})
+	require.NoError(t, err)
+	_, err = store.SaveCommentReaction(&model.CommentReaction{CommentID: "card1-comment", BoardID: boardA, UserID: testUserID, Emoji: "+1"})
+	require.NoError(t, err)
+	_, err = store.SaveCardMirror(&model.CardMirror{BoardID: boardB, CardID: "card1", CreatedBy: testUserID})
+	require.NoError(t, err)
+	_, err = store.SaveCardMirror(&model.CardMirror{BoardID: boardC, CardID: "card1", CreatedBy: testUserID})
+	require.NoError(t, err)
+
+	t.Run("move card and children", func(t *testing.T) {
+		card.Fields = map[string]interface{}{"properties": map[string]interface{}{"prop-b": "value"}}
+		redirect, err := store.MoveCardToBoard(card, boardB, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, boardA, redirect.SourceBoardID)
+		require.Equal(t, boardB, redirect.TargetBoardID)
+
+		blocks, err := store.GetBlocksForBoard(boardA)
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+
+		blocks, err = store.GetBlocksForBoard(boardB)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+
+		movedCard, err := store.GetBlock("card1")
+		require.NoError(t, err)
+		require.Equal(t, boardB, movedCard.BoardID)
+		require.Equal(t, map[string]interface{}{"prop-b": "value"}, movedCard.Fields["properties"])
+
+		history, err := store.GetBlockHistory("card1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		redirect, err = store.GetCardRedirect(boardA, "card1")
+		require.NoError(t, err)
+		require.Equal(t, boardB, redirect.TargetBoardID)
+	})
+
+	t.Run("card data follows the card", func(t *testing.T) {
+		readState, err := store.GetReadState(testUserID, boardB, "card1")
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), readState.ViewedAt)
+
+		reminder, err := store.GetCardReminder("reminder1")
+		require.NoError(t, err)
+		require.Equal(t, boardB, reminder.BoardID)
+
+		reactions, err := store.GetCommentReactions([]string{"card1-comment"})
+		require.NoError(t, err)
+		require.Len(t, reactions, 1)
+		require.Equal(t, boardB, reactions[0].BoardID)
+
+		// the vote counts against the vote limit of the new board
+		err = store.SaveCardVote(&model.CardVote{CardID: "card2", BoardID: boardB, UserID: testUserID}, 1)
+		require.True(t, model.IsErrBadRequest(err), err)
+	})
+
+	t.Run("the mirror on the new board is deleted", func(t *testing.T) {
+		_, err := store.GetCardMirror(boardB, "card1")
+		require.True(t, model.IsErrNotFound(err), err)
+
+		mirrors, err := store.GetCardMirrorsForCard("card1")
+		require.NoError(t, err)
+		require.Len(t, mirrors, 1)
+		require.Equal(t, boardC, mirrors[0].BoardID)
+	})
+
+	t.Run("redirects follow the card", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		card.BoardID = boardB
+		_, err := store.MoveCardToBoard(card, boardC, testUserID)
+		require.NoError(t, err)
+
+		redirect, err := store.GetCardRedirect(boardA, "card1")
+		require.NoError(t, err)
+		require.Equal(t, boardC, redirect.TargetBoardID)
+
+		time.Sleep(1 * time.Millisecond)
+		card.BoardID = boardC
+		_, err = store.MoveCardToBoard(card, boardA, testUserID)
+		require.NoError(t, err)
+
+		redirect, err = store.GetCardRedirect(boardA, "card1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, redirect)
+
+		redirect, err = store.GetCardRedirect(boardB, "card1")
+		require.NoError(t, err)
+		require.Equal(t, boardA, redirect.TargetBoardID)
+	})
+
+	t.Run("move not existing card", func(t *testing.T) {
+		notExisting := &model.Block{ID: "not-existing-id", BoardID: boardA, Type: model.TypeCard}
+		redirect, err := store.MoveCardToBoard(notExisting, boardB, testUserID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, redirect)
+	})
+}
+
 func testDuplicateBlock(t *testing.T, store store.Store) {
 	blocksToInsert := subtreeSampleBlocks
 	blocksToInsert = append(blocksToInsert,
@@ -1347,3 +1492,76 @@ func testGetBlockHistoryNewestChildren(t *testing.T, store store.Store) {
 		}
 	})
 }
+
+func testArchivedCards(t *testing.T, store store.Store) {
+	boardID := testBoardID
+	cards := make([]*model.Block, 0, 3)
+	for i := 0; i < 3; i++ {
+		card := &model.Block{
+			ID:        utils.NewID(utils.IDTypeCard),
+			BoardID:   boardID,
+			ParentID:  boardID,
+			Type:      model.TypeCard,
+			CreatedBy: testUserID,
+			Title:     fmt.Sprintf("card %d", i),
+			Fields:    map[string]interface{}{},
+		}
+		require.NoError(t, store.InsertBlock(card, testUserID))
+		cards = append(cards, card)
+	}
+
+	archivePatch := model.NewArchiveCardPatch(testUserID, utils.GetMillis())
+	err := store.PatchBlock(cards[0].ID, &archivePatch, testUserID)
+	require.NoError(t, err)
+
+	t.Run("archived cards are excluded on demand", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{
+			BoardID:         boardID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
+		}
+		blocks, err := store.GetBlocks(opts)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{cards[1].ID, cards[2].ID}, extractIDs(t, blocks))
+
+		opts.ExcludeArchived = false
+		blocks, err = store.GetBlocks(opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("archived cards are searchable", func(t *testing.T) {
+		blocks, err := store.SearchCardsForBoard(boardID, "CARD 0")
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, cards[0].ID, blocks[0].ID)
+		require.True(t, model.IsArchivedCard(blocks[0]))
+
+		blocks, err = store.SearchCardsForBoard(boardID, "card")
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+
+		blocks, err = store.SearchCardsForBoard(boardID, "nonexistent")
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("unarchive card", func(t *testing.T) {
+		unarchivePatch := model.NewUnarchiveCardPatch()
+		err := store.PatchBlock(cards[0].ID, &unarchivePatch, testUserID)
+		require.NoError(t, err)
+
+		blocks, err := store.GetBlocks(model.QueryBlocksOptions{
+			BoardID:         boardID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+
+		// archiving and unarchiving are recorded in the card history
+		history, err := store.GetBlockHistory(cards[0].ID, model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+	})
+}
@@ -97,6 +97,22 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardCount(t, store)
 	})
+	t.Run("GetBoardsWithExpiredFreeze", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsWithExpiredFreeze(t, store)
+	})
+	t.Run("MoveBoardToTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveBoardToTeam(t, store)
+	})
+
+	t.Run("TransferBoardOwnership", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testTransferBoardOwnership(t, store)
+	})
 }
 
 func testGetBoard(t *testing.T, store store.Store) {
@@ -1199,3 +1215,196 @@ func testGetBoardCount(t *testing.T, store store.Store) {
 		require.Equal(t, originalCount+1, newCount)
 	})
 }
+
+func testGetBoardsWithExpiredFreeze(t *testing.T, store store.Store) {
+	userID := testUserID
+	now := utils.GetMillis()
+
+	boards := []*model.Board{
+		{ID: "not-frozen", TeamID: testTeamID, Type: model.BoardTypeOpen},
+		{ID: "frozen-indefinitely", TeamID: testTeamID, Type: model.BoardTypeOpen, Frozen: true, FrozenReason: "reason"},
+		{ID: "frozen-expired", TeamID: testTeamID, Type: model.BoardTypeOpen, Frozen: true, FrozenUntil: now - 1000},
+		{ID: "frozen-not-expired", TeamID: testTeamID, Type: model.BoardTypeOpen, Frozen: true, FrozenUntil: now + 60000},
+	}
+	for _, board := range boards {
+		_, err := store.InsertBoard(board, userID)
+		require.NoError(t, err)
+	}
+
+	t.Run("freeze fields should be persisted", func(t *testing.T) {
+		rBoard, err := store.GetBoard("frozen-indefinitely")
+		require.NoError(t, err)
+		require.True(t, rBoard.Frozen)
+		require.Equal(t, "reason", rBoard.FrozenReason)
+		require.Zero(t, rBoard.FrozenUntil)
+		require.True(t, rBoard.IsFrozen())
+
+		rBoard, err = store.GetBoard("frozen-expired")
+		require.NoError(t, err)
+		require.True(t, rBoard.Frozen)
+		require.False(t, rBoard.IsFrozen())
+	})
+
+	t.Run("should only return boards with an expired freeze", func(t *testing.T) {
+		rBoards, err := store.GetBoardsWithExpiredFreeze(now)
+		require.NoError(t, err)
+		require.Len(t, rBoards, 1)
+		require.Equal(t, "frozen-expired", rBoards[0].ID)
+	})
+
+	t.Run("unfrozen boards should not be returned", func(t *testing.T) {
+		frozen := false
+		_, err := store.PatchBoard("frozen-expired", &model.BoardPatch{Frozen: &frozen}, userID)
+		require.NoError(t, err)
+
+		rBoards, err := store.GetBoardsWithExpiredFreeze(now)
+		require.NoError(t, err)
+		require.Empty(t, rBoards)
+
+		history, err := store.GetBoardHistory("frozen-expired", model.QueryBoardHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.False(t, history[0].Frozen)
+		require.True(t, history[1].Frozen)
+	})
+}
+
+func testMoveBoardToTeam(t *testing.T, store store.Store) {
+	userID := testUserID
+	toTeamID := "other-team-id"
+
+	t.Run("nonexisting board", func(t *testing.T) {
+		rBoard, err := store.MoveBoardToTeam("nonexistent-id", toTeamID, userID, nil)
+		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
+		require.Nil(t, rBoard)
+	})
+
+	t.Run("should change the team and detach the channel", func(t *testing.T) {
+		board := &model.Board{
+			ID:        "id-test-move",
+			TeamID:    testTeamID,
+			ChannelID: "channel-id",
+			Type:      model.BoardTypeOpen,
+			Title:     "board to move",
+		}
+		_, err := store.InsertBoard(board, userID)
+		require.NoError(t, err)
+
+		block := &model.Block{
+			ID:       "block-id",
+			BoardID:  board.ID,
+			ParentID: board.ID,
+			Type:     model.TypeCard,
+		}
+		require.NoError(t, store.InsertBlock(block, userID))
+
+		rBoard, err := store.MoveBoardToTeam(board.ID, toTeamID, userID, nil)
+		require.NoError(t, err)
+		require.Equal(t, toTeamID, rBoard.TeamID)
+		require.Empty(t, rBoard.ChannelID)
+
+		dbBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, toTeamID, dbBoard.TeamID)
+		require.Empty(t, dbBoard.ChannelID)
+		require.Equal(t, board.Title, dbBoard.Title)
+
+		blocks, err := store.GetBlocksForBoard(board.ID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+
+		history, err := store.GetBoardHistory(board.ID, model.QueryBoardHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, toTeamID, history[0].TeamID)
+		require.Equal(t, testTeamID, history[1].TeamID)
+	})
+	t.Run("should remove the members that lose access", func(t *testing.T) {
+		board := &model.Board{
+			ID:     "id-test-move-members",
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}
+		_, err := store.InsertBoard(board, userID)
+		require.NoError(t, err)
+
+		for _, memberID := range []string{"kept-user-id", "removed-user-id"} {
+			_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: memberID, SchemeEditor: true})
+			require.NoError(t, err)
+
+			categoryID := utils.NewID(utils.IDTypeNone)
+			require.NoError(t, store.CreateCategory(model.Category{
+				ID:     categoryID,
+				Name:   "Boards",
+				UserID: memberID,
+				TeamID: testTeamID,
+				Type:   model.CategoryTypeCustom,
+			}))
+			require.NoError(t, store.AddUpdateCategoryBoard(memberID, categoryID, []string{board.ID}))
+		}
+
+		_, err = store.MoveBoardToTeam(board.ID, toTeamID, userID, []string{"removed-user-id"})
+		require.NoError(t, err)
+
+		members, err := store.GetMembersForBoard(board.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		require.Equal(t, "kept-user-id", members[0].UserID)
+
+		categories, err := store.GetUserCategoryBoards("removed-user-id", testTeamID)
+		require.NoError(t, err)
+		require.Len(t, categories, 1)
+		require.Empty(t, categories[0].BoardMetadata)
+
+		categories, err = store.GetUserCategoryBoards("kept-user-id", testTeamID)
+		require.NoError(t, err)
+		require.Len(t, categories, 1)
+		require.Len(t, categories[0].BoardMetadata, 1)
+	})
+}
+
+func testTransferBoardOwnership(t *testing.T, store store.Store) {
+	ownerID := testUserID
+	newOwnerID := "new-owner-id"
+
+	t.Run("nonexisting board", func(t *testing.T) {
+		rBoard, err := store.TransferBoardOwnership("nonexistent-id", newOwnerID)
+		require.True(t, model.IsErrNotFound(err), "Should be ErrNotFound compatible error")
+		require.Nil(t, rBoard)
+	})
+
+	t.Run("should change the creator and the admin", func(t *testing.T) {
+		board := &model.Board{
+			ID:        "id-test-transfer",
+			TeamID:    testTeamID,
+			Type:      model.BoardTypeOpen,
+			CreatedBy: ownerID,
+		}
+		_, _, err := store.InsertBoardWithAdmin(board, ownerID)
+		require.NoError(t, err)
+
+		// wait to avoid hitting pk uniqueness constraint in history
+		time.Sleep(10 * time.Millisecond)
+
+		rBoard, err := store.TransferBoardOwnership(board.ID, newOwnerID)
+		require.NoError(t, err)
+		require.Equal(t, newOwnerID, rBoard.CreatedBy)
+
+		dbBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, newOwnerID, dbBoard.CreatedBy)
+
+		previousOwner, err := store.GetMemberForBoard(board.ID, ownerID)
+		require.NoError(t, err)
+		require.Equal(t, model.BoardRoleEditor, previousOwner.Role())
+
+		newOwner, err := store.GetMemberForBoard(board.ID, newOwnerID)
+		require.NoError(t, err)
+		require.Equal(t, model.BoardRoleAdmin, newOwner.Role())
+
+		history, err := store.GetBoardHistory(board.ID, model.QueryBoardHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, newOwnerID, history[0].CreatedBy)
+	})
+}
@@ -688,6 +688,24 @@ func testDuplicateBoard(t *testing.T, store store.Store) {
 		require.Equal(t, "", bab.Boards[0].ChannelID)
 	})
 
+	t.Run("duplicate board without the private views of others", func(t *testing.T) {
+		views := []*model.Block{
+			{ID: "view-id-1", BoardID: "board-id-3", Type: model.TypeView, Fields: map[string]interface{}{model.ViewFieldOwnerID: userID}},
+			{ID: "view-id-2", BoardID: "board-id-3", Type: model.TypeView, Fields: map[string]interface{}{model.ViewFieldOwnerID: "other-user-id"}},
+			{ID: "view-id-3", BoardID: "board-id-3", Type: model.TypeView, Fields: map[string]interface{}{}},
+		}
+		for _, view := range views {
+			require.NoError(t, store.InsertBlock(view, userID))
+		}
+
+		bab, _, err := store.DuplicateBoard("board-id-3", userID, teamID, false)
+		require.NoError(t, err)
+		require.Len(t, bab.Blocks, 2)
+		for _, block := range bab.Blocks {
+			require.True(t, model.IsBlockVisibleTo(block, userID))
+		}
+	})
+
 	t.Run("duplicate not existing board", func(t *testing.T) {
 		bab, members, err := store.DuplicateBoard("not-existing-id", userID, teamID, false)
 		require.Error(t, err)
@@ -0,0 +1,134 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardContentStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertCardContent", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertCardContent(t, store)
+	})
+	t.Run("MoveCardContent", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveCardContent(t, store)
+	})
+	t.Run("DeleteCardContent", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardContent(t, store)
+	})
+}
+
+func createTestContentCard(t *testing.T, store store.Store, contentIDs ...string) {
+	now := utils.GetMillis()
+	card := &model.Block{
+		ID:         "card-id",
+		BoardID:    "board-id",
+		ParentID:   "board-id",
+		Type:       model.TypeCard,
+		Fields:     map[string]interface{}{model.CardFieldContentOrder: []interface{}{}},
+		CreatedBy:  testUserID,
+		ModifiedBy: testUserID,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+	require.NoError(t, store.InsertBlock(card, testUserID))
+
+	for i, id := range contentIDs {
+		block := newTestContentBlock(id, now+int64(i))
+		require.NoError(t, store.InsertCardContent(block, -1, testUserID))
+	}
+}
+
+func newTestContentBlock(id string, createAt int64) *model.Block {
+	return &model.Block{
+		ID:         id,
+		BoardID:    "board-id",
+		ParentID:   "card-id",
+		Type:       model.TypeText,
+		Title:      "text " + id,
+		CreatedBy:  testUserID,
+		ModifiedBy: testUserID,
+		CreateAt:   createAt,
+		UpdateAt:   createAt,
+	}
+}
+
+func requireContentOrder(t *testing.T, store store.Store, expected ...interface{}) {
+	card, err := store.GetBlock("card-id")
+	require.NoError(t, err)
+	require.Equal(t, expected, model.GetContentOrder(card))
+}
+
+func testInsertCardContent(t *testing.T, store store.Store) {
+	createTestContentCard(t, store, "block-1", "block-2")
+	requireContentOrder(t, store, "block-1", "block-2")
+
+	t.Run("parent is not a card", func(t *testing.T) {
+		block := newTestContentBlock("block-3", utils.GetMillis())
+		block.ParentID = "block-1"
+
+		err := store.InsertCardContent(block, 0, testUserID)
+		require.True(t, model.IsErrBadRequest(err))
+
+		_, err = store.GetBlock("block-3")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("insert at a position", func(t *testing.T) {
+		err := store.InsertCardContent(newTestContentBlock("block-3", utils.GetMillis()), 1, testUserID)
+		require.NoError(t, err)
+		requireContentOrder(t, store, "block-1", "block-3", "block-2")
+
+		block, err := store.GetBlock("block-3")
+		require.NoError(t, err)
+		require.Equal(t, "text block-3", block.Title)
+	})
+
+	t.Run("out of range position", func(t *testing.T) {
+		err := store.InsertCardContent(newTestContentBlock("block-4", utils.GetMillis()), 10, testUserID)
+		require.NoError(t, err)
+		requireContentOrder(t, store, "block-1", "block-3", "block-2", "block-4")
+	})
+}
+
+func testMoveCardContent(t *testing.T, store store.Store) {
+	createTestContentCard(t, store, "block-1", "block-2", "block-3")
+
+	t.Run("block of another card", func(t *testing.T) {
+		err := store.MoveCardContent("card-id", "nonexistent-block", 0, testUserID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("move a block", func(t *testing.T) {
+		err := store.MoveCardContent("card-id", "block-3", 0, testUserID)
+		require.NoError(t, err)
+		requireContentOrder(t, store, "block-3", "block-1", "block-2")
+
+		err = store.MoveCardContent("card-id", "block-3", -1, testUserID)
+		require.NoError(t, err)
+		requireContentOrder(t, store, "block-1", "block-2", "block-3")
+	})
+}
+
+func testDeleteCardContent(t *testing.T, store store.Store) {
+	createTestContentCard(t, store, "block-1", "block-2")
+
+	err := store.DeleteCardContent("card-id", "block-1", testUserID)
+	require.NoError(t, err)
+	requireContentOrder(t, store, "block-2")
+
+	_, err = store.GetBlock("block-1")
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteCardContent("card-id", "block-1", testUserID)
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -0,0 +1,180 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardMirrorsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveCardMirrorAndGetCardMirror", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveCardMirrorAndGetCardMirror(t, store)
+	})
+	t.Run("GetCardMirrors", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardMirrors(t, store)
+	})
+	t.Run("DeleteCardMirror", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardMirror(t, store)
+	})
+}
+
+func testSaveCardMirrorAndGetCardMirror(t *testing.T, store store.Store) {
+	t.Run("invalid mirror", func(t *testing.T) {
+		mirror, err := store.SaveCardMirror(&model.CardMirror{BoardID: "board-id"})
+		require.Error(t, err)
+		require.Nil(t, mirror)
+	})
+
+	t.Run("nonexistent mirror", func(t *testing.T) {
+		mirror, err := store.GetCardMirror("board-id", "nonexistent-card-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, mirror)
+	})
+
+	t.Run("save and update a mirror", func(t *testing.T) {
+		mirror := &model.CardMirror{
+			BoardID:    "board-id",
+			CardID:     "card-id",
+			Properties: map[string]interface{}{"prop-id": "value"},
+			CreatedBy:  testUserID,
+		}
+
+		newMirror, err := store.SaveCardMirror(mirror)
+		require.NoError(t, err)
+		require.NotZero(t, newMirror.CreateAt)
+		require.Equal(t, testUserID, newMirror.ModifiedBy)
+
+		storedMirror, err := store.GetCardMirror("board-id", "card-id")
+		require.NoError(t, err)
+		require.Equal(t, "value", storedMirror.Properties["prop-id"])
+		require.Equal(t, testUserID, storedMirror.CreatedBy)
+
+		storedMirror.Properties["prop-id"] = "new-value"
+		storedMirror.ModifiedBy = "user-id-2"
+		_, err = store.SaveCardMirror(storedMirror)
+		require.NoError(t, err)
+
+		updatedMirror, err := store.GetCardMirror("board-id", "card-id")
+		require.NoError(t, err)
+		require.Equal(t, "new-value", updatedMirror.Properties["prop-id"])
+		require.Equal(t, testUserID, updatedMirror.CreatedBy)
+		require.Equal(t, "user-id-2", updatedMirror.ModifiedBy)
+		require.Equal(t, storedMirror.CreateAt, updatedMirror.CreateAt)
+	})
+}
+
+func testGetCardMirrors(t *testing.T, store store.Store) {
+	mirrors := []*model.CardMirror{
+		{BoardID: "board-id-1", CardID: "card-id-1", CreatedBy: testUserID},
+		{BoardID: "board-id-1", CardID: "card-id-2", CreatedBy: testUserID},
+		{BoardID: "board-id-2", CardID: "card-id-1", CreatedBy: testUserID},
+	}
+	for _, mirror := range mirrors {
+		_, err := store.SaveCardMirror(mirror)
+		require.NoError(t, err)
+	}
+
+	t.Run("get mirrors for board", func(t *testing.T) {
+		boardMirrors, err := store.GetCardMirrorsForBoard("board-id-1")
+		require.NoError(t, err)
+		require.Len(t, boardMirrors, 2)
+		for _, mirror := range boardMirrors {
+			require.Equal(t, "board-id-1", mirror.BoardID)
+			require.NotNil(t, mirror.Properties)
+		}
+
+		boardMirrors, err = store.GetCardMirrorsForBoard("nonexistent-board-id")
+		require.NoError(t, err)
+		require.Empty(t, boardMirrors)
+	})
+
+	t.Run("get mirrors for card", func(t *testing.T) {
+		cardMirrors, err := store.GetCardMirrorsForCard("card-id-1")
+		require.NoError(t, err)
+		require.Len(t, cardMirrors, 2)
+		for _, mirror := range cardMirrors {
+			require.Equal(t, "card-id-1", mirror.CardID)
+		}
+	})
+}
+
+func testDeleteCardMirror(t *testing.T, store store.Store) {
+	_, err := store.SaveCardMirror(&model.CardMirror{BoardID: "board-id", CardID: "card-id", CreatedBy: testUserID})
+	require.NoError(t, err)
+
+	t.Run("nonexistent mirror", func(t *testing.T) {
+		err := store.DeleteCardMirror("board-id", "nonexistent-card-id", testUserID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete and restore a mirror", func(t *testing.T) {
+		err := store.DeleteCardMirror("board-id", "card-id", testUserID)
+		require.NoError(t, err)
+
+		mirror, err := store.GetCardMirror("board-id", "card-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, mirror)
+
+		mirrors, err := store.GetCardMirrorsForCard("card-id")
+		require.NoError(t, err)
+		require.Empty(t, mirrors)
+
+		// deleting twice fails, as the mirror is already gone
+		err = store.DeleteCardMirror("board-id", "card-id", testUserID)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.SaveCardMirror(&model.CardMirror{BoardID: "board-id", CardID: "card-id", CreatedBy: testUserID})
+		require.NoError(t, err)
+
+		mirror, err = store.GetCardMirror("board-id", "card-id")
+		require.NoError(t, err)
+		require.Zero(t, mirror.DeleteAt)
+	})
+
+	t.Run("deleting the board of a card deletes its mirrors", func(t *testing.T) {
+		_, err := store.InsertBoard(&model.Board{ID: "source-board-id", TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+		require.NoError(t, err)
+		err = store.InsertBlock(&model.Block{ID: "source-card-id", BoardID: "source-board-id", Type: model.TypeCard}, testUserID)
+		require.NoError(t, err)
+		_, err = store.SaveCardMirror(&model.CardMirror{BoardID: "board-id", CardID: "source-card-id", CreatedBy: testUserID})
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.DeleteBoard("source-board-id", testUserID))
+
+		mirrors, err := store.GetCardMirrorsForCard("source-card-id")
+		require.NoError(t, err)
+		require.Empty(t, mirrors)
+
+		_, err = store.GetCardMirror("board-id", "card-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("deleting a card deletes its mirrors", func(t *testing.T) {
+		err := store.InsertBlock(&model.Block{ID: "deleted-card-id", BoardID: "source-board-id-2", Type: model.TypeCard}, testUserID)
+		require.NoError(t, err)
+		_, err = store.SaveCardMirror(&model.CardMirror{BoardID: "board-id", CardID: "deleted-card-id", CreatedBy: testUserID})
+		require.NoError(t, err)
+		_, err = store.SaveCardMirror(&model.CardMirror{BoardID: "board-id-2", CardID: "deleted-card-id", CreatedBy: testUserID})
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.DeleteBlock("deleted-card-id", testUserID))
+
+		mirrors, err := store.GetCardMirrorsForCard("deleted-card-id")
+		require.NoError(t, err)
+		require.Empty(t, mirrors)
+
+		_, err = store.GetCardMirror("board-id", "card-id")
+		require.NoError(t, err)
+	})
+}
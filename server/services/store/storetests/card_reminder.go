@@ -0,0 +1,164 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardReminderStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndGetCardReminders", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndGetCardReminders(t, store)
+	})
+	t.Run("GetDueCardRemindersAndMarkDelivered", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueCardRemindersAndMarkDelivered(t, store)
+	})
+	t.Run("DeleteCardReminders", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardReminders(t, store)
+	})
+}
+
+func newTestCardReminder(userID, boardID, cardID string, remindAt int64) *model.CardReminder {
+	return &model.CardReminder{
+		ID:       utils.NewID(utils.IDTypeNone),
+		UserID:   userID,
+		BoardID:  boardID,
+		CardID:   cardID,
+		RemindAt: remindAt,
+	}
+}
+
+func testSaveAndGetCardReminders(t *testing.T, store store.Store) {
+	t.Run("invalid reminder", func(t *testing.T) {
+		reminder := newTestCardReminder(testUserID, "board-id", "card-id", 0)
+		_, err := store.SaveCardReminder(reminder)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("nonexistent reminder", func(t *testing.T) {
+		reminder, err := store.GetCardReminder("nonexistent-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, reminder)
+	})
+
+	t.Run("save and update reminders", func(t *testing.T) {
+		later := newTestCardReminder(testUserID, "board-id", "card-id", 2000)
+		later.Note = "note"
+		_, err := store.SaveCardReminder(later)
+		require.NoError(t, err)
+		require.NotZero(t, later.CreateAt)
+
+		earlier := newTestCardReminder(testUserID, "board-id", "card-id-2", 1000)
+		_, err = store.SaveCardReminder(earlier)
+		require.NoError(t, err)
+
+		_, err = store.SaveCardReminder(newTestCardReminder("other-user-id", "board-id", "card-id", 500))
+		require.NoError(t, err)
+
+		reminder, err := store.GetCardReminder(later.ID)
+		require.NoError(t, err)
+		require.Equal(t, later, reminder)
+
+		reminders, err := store.GetCardRemindersForUser(testUserID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 2)
+		require.Equal(t, earlier.ID, reminders[0].ID)
+		require.Equal(t, later.ID, reminders[1].ID)
+
+		later.RemindAt = 3000
+		later.Note = ""
+		_, err = store.SaveCardReminder(later)
+		require.NoError(t, err)
+
+		reminder, err = store.GetCardReminder(later.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(3000), reminder.RemindAt)
+		require.Empty(t, reminder.Note)
+	})
+}
+
+func testGetDueCardRemindersAndMarkDelivered(t *testing.T, store store.Store) {
+	due := newTestCardReminder(testUserID, "board-id", "card-id", 1000)
+	_, err := store.SaveCardReminder(due)
+	require.NoError(t, err)
+
+	notDue := newTestCardReminder(testUserID, "board-id", "card-id", 5000)
+	_, err = store.SaveCardReminder(notDue)
+	require.NoError(t, err)
+
+	reminders, err := store.GetDueCardReminders(2000)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	require.Equal(t, due.ID, reminders[0].ID)
+
+	t.Run("a reminder that is not due is not marked", func(t *testing.T) {
+		delivered, err := store.MarkCardReminderDelivered(notDue.ID, 2000)
+		require.NoError(t, err)
+		require.False(t, delivered)
+	})
+
+	t.Run("a reminder is marked only once", func(t *testing.T) {
+		delivered, err := store.MarkCardReminderDelivered(due.ID, 2000)
+		require.NoError(t, err)
+		require.True(t, delivered)
+
+		delivered, err = store.MarkCardReminderDelivered(due.ID, 2001)
+		require.NoError(t, err)
+		require.False(t, delivered)
+
+		reminder, err := store.GetCardReminder(due.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), reminder.DeliveredAt)
+
+		reminders, err := store.GetDueCardReminders(2000)
+		require.NoError(t, err)
+		require.Empty(t, reminders)
+	})
+}
+
+func testDeleteCardReminders(t *testing.T, store store.Store) {
+	reminder1 := newTestCardReminder(testUserID, "board-id", "card-id", 1000)
+	reminder2 := newTestCardReminder(testUserID, "board-id", "card-id-2", 1000)
+	reminder3 := newTestCardReminder(testUserID, "board-id-2", "card-id-3", 1000)
+	reminder4 := newTestCardReminder(testUserID, "board-id-2", "card-id-4", 1000)
+	for _, reminder := range []*model.CardReminder{reminder1, reminder2, reminder3, reminder4} {
+		_, err := store.SaveCardReminder(reminder)
+		require.NoError(t, err)
+	}
+
+	t.Run("delete a reminder", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardReminder(reminder4.ID))
+
+		err := store.DeleteCardReminder(reminder4.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete the reminders of a card", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardRemindersForCard("card-id"))
+
+		_, err := store.GetCardReminder(reminder1.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete the reminders of a board", func(t *testing.T) {
+		_, err := store.InsertBoard(&model.Board{ID: "board-id-2", TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+		require.NoError(t, err)
+		time.Sleep(1 * time.Millisecond)
+		require.NoError(t, store.DeleteBoard("board-id-2", testUserID))
+
+		reminders, err := store.GetCardRemindersForUser(testUserID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		require.Equal(t, reminder2.ID, reminders[0].ID)
+	})
+}
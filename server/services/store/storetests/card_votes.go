@@ -0,0 +1,200 @@
+package storetests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCardVotesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndDeleteCardVotes", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndDeleteCardVotes(t, store)
+	})
+	t.Run("CardVoteLimit", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCardVoteLimit(t, store)
+	})
+	t.Run("SaveAndDeleteCardReactions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndDeleteCardReactions(t, store)
+	})
+	t.Run("DeleteCardVotesForCardAndBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardVotesForCardAndBoard(t, store)
+	})
+}
+
+func testSaveAndDeleteCardVotes(t *testing.T, store store.Store) {
+	t.Run("invalid vote", func(t *testing.T) {
+		err := store.SaveCardVote(&model.CardVote{CardID: "card-id", UserID: testUserID}, 0)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("votes of a card", func(t *testing.T) {
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id", BoardID: "board-id", UserID: testUserID}, 0))
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id", BoardID: "board-id", UserID: "other-user-id"}, 0))
+
+		// voting twice keeps a single vote
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id", BoardID: "board-id", UserID: testUserID}, 0))
+
+		votes, err := store.GetCardVotes([]string{"card-id", "card-id-2"}, testUserID)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+		require.Equal(t, 2, votes["card-id"].VoteCount)
+		require.True(t, votes["card-id"].Voted)
+		require.Zero(t, votes["card-id-2"].VoteCount)
+		require.False(t, votes["card-id-2"].Voted)
+
+		votes, err = store.GetCardVotes([]string{"card-id"}, "third-user-id")
+		require.NoError(t, err)
+		require.Equal(t, 2, votes["card-id"].VoteCount)
+		require.False(t, votes["card-id"].Voted)
+	})
+
+	t.Run("remove a vote", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardVote("card-id", testUserID))
+
+		err := store.DeleteCardVote("card-id", testUserID)
+		require.True(t, model.IsErrNotFound(err))
+
+		votes, err := store.GetCardVotes([]string{"card-id"}, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, 1, votes["card-id"].VoteCount)
+		require.False(t, votes["card-id"].Voted)
+	})
+}
+
+func testCardVoteLimit(t *testing.T, store store.Store) {
+	require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-1", BoardID: "board-id", UserID: testUserID}, 2))
+	require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-2", BoardID: "board-id", UserID: testUserID}, 2))
+
+	t.Run("the limit is per user and board", func(t *testing.T) {
+		err := store.SaveCardVote(&model.CardVote{CardID: "card-id-3", BoardID: "board-id", UserID: testUserID}, 2)
+		require.True(t, model.IsErrBadRequest(err))
+
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-3", BoardID: "board-id", UserID: "other-user-id"}, 2))
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-4", BoardID: "board-id-2", UserID: testUserID}, 2))
+	})
+
+	t.Run("voting again for a card within the limit", func(t *testing.T) {
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-2", BoardID: "board-id", UserID: testUserID}, 2))
+	})
+
+	t.Run("removing a vote frees it", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardVote("card-id-1", testUserID))
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-3", BoardID: "board-id", UserID: testUserID}, 2))
+	})
+
+	t.Run("concurrent votes don't exceed the limit", func(t *testing.T) {
+		board, err := store.InsertBoard(&model.Board{ID: "board-id-3", TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+		require.NoError(t, err)
+
+		const voteLimit = 3
+		cardIDs := make([]string, 10)
+		errs := make([]error, len(cardIDs))
+		var wg sync.WaitGroup
+		for i := range cardIDs {
+			cardIDs[i] = fmt.Sprintf("concurrent-card-id-%d", i)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = store.SaveCardVote(&model.CardVote{CardID: cardIDs[i], BoardID: board.ID, UserID: testUserID}, voteLimit)
+			}(i)
+		}
+		wg.Wait()
+
+		saved := 0
+		for _, err := range errs {
+			if err == nil {
+				saved++
+				continue
+			}
+			require.True(t, model.IsErrBadRequest(err), err)
+		}
+
+		votes, err := store.GetCardVotes(cardIDs, testUserID)
+		require.NoError(t, err)
+		voted := 0
+		for _, vote := range votes {
+			if vote.Voted {
+				voted++
+			}
+		}
+		require.LessOrEqual(t, voted, voteLimit)
+		require.Equal(t, saved, voted)
+	})
+}
+
+func testSaveAndDeleteCardReactions(t *testing.T, store store.Store) {
+	t.Run("invalid reaction", func(t *testing.T) {
+		err := store.SaveCardReaction(&model.CardReaction{CardID: "card-id", BoardID: "board-id", UserID: testUserID, Emoji: "two words"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("reactions of a card", func(t *testing.T) {
+		require.NoError(t, store.SaveCardReaction(&model.CardReaction{CardID: "card-id", BoardID: "board-id", UserID: testUserID, Emoji: "+1", CreateAt: 100}))
+		require.NoError(t, store.SaveCardReaction(&model.CardReaction{CardID: "card-id", BoardID: "board-id", UserID: "other-user-id", Emoji: "tada", CreateAt: 200}))
+		require.NoError(t, store.SaveCardReaction(&model.CardReaction{CardID: "card-id", BoardID: "board-id", UserID: "other-user-id", Emoji: "+1", CreateAt: 300}))
+		require.NoError(t, store.SaveCardReaction(&model.CardReaction{CardID: "card-id", BoardID: "board-id", UserID: testUserID, Emoji: "+1", CreateAt: 400}))
+
+		votes, err := store.GetCardVotes([]string{"card-id"}, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.CardReactionCount{
+			{Emoji: "+1", Count: 2, Reacted: true},
+			{Emoji: "tada", Count: 1},
+		}, votes["card-id"].Reactions)
+	})
+
+	t.Run("remove a reaction", func(t *testing.T) {
+		require.NoError(t, store.DeleteCardReaction("card-id", testUserID, "+1"))
+
+		err := store.DeleteCardReaction("card-id", testUserID, "+1")
+		require.True(t, model.IsErrNotFound(err))
+
+		votes, err := store.GetCardVotes([]string{"card-id"}, testUserID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.CardReactionCount{
+			{Emoji: "tada", Count: 1},
+			{Emoji: "+1", Count: 1},
+		}, votes["card-id"].Reactions)
+	})
+}
+
+func testDeleteCardVotesForCardAndBoard(t *testing.T, store store.Store) {
+	for _, cardID := range []string{"card-id-1", "card-id-2"} {
+		require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: cardID, BoardID: "board-id", UserID: testUserID}, 0))
+		require.NoError(t, store.SaveCardReaction(&model.CardReaction{CardID: cardID, BoardID: "board-id", UserID: testUserID, Emoji: "+1"}))
+	}
+	require.NoError(t, store.SaveCardVote(&model.CardVote{CardID: "card-id-3", BoardID: "board-id-2", UserID: testUserID}, 0))
+
+	require.NoError(t, store.DeleteCardVotesForCard("card-id-1"))
+
+	votes, err := store.GetCardVotes([]string{"card-id-1", "card-id-2", "card-id-3"}, testUserID)
+	require.NoError(t, err)
+	require.Zero(t, votes["card-id-1"].VoteCount)
+	require.Empty(t, votes["card-id-1"].Reactions)
+	require.Equal(t, 1, votes["card-id-2"].VoteCount)
+	require.Len(t, votes["card-id-2"].Reactions, 1)
+
+	// deleting the board deletes the votes and reactions of its cards
+	_, err = store.InsertBoard(&model.Board{ID: "board-id", TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+	require.NoError(t, err)
+	time.Sleep(1 * time.Millisecond)
+	require.NoError(t, store.DeleteBoard("board-id", testUserID))
+
+	votes, err = store.GetCardVotes([]string{"card-id-2", "card-id-3"}, testUserID)
+	require.NoError(t, err)
+	require.Zero(t, votes["card-id-2"].VoteCount)
+	require.Empty(t, votes["card-id-2"].Reactions)
+	require.Equal(t, 1, votes["card-id-3"].VoteCount)
+}
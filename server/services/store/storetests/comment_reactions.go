@@ -0,0 +1,100 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestCommentReactionsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveCommentReactionAndGetCommentReactions", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveCommentReactionAndGetCommentReactions(t, store)
+	})
+	t.Run("DeleteCommentReaction", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCommentReaction(t, store)
+	})
+}
+
+func testSaveCommentReactionAndGetCommentReactions(t *testing.T, store store.Store) {
+	t.Run("invalid reaction", func(t *testing.T) {
+		reaction, err := store.SaveCommentReaction(&model.CommentReaction{CommentID: "comment-id", UserID: testUserID})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, reaction)
+	})
+
+	t.Run("no comments", func(t *testing.T) {
+		reactions, err := store.GetCommentReactions([]string{})
+		require.NoError(t, err)
+		require.Empty(t, reactions)
+	})
+
+	t.Run("save reactions", func(t *testing.T) {
+		newReaction := func(commentID, userID, emoji string, createAt int64) *model.CommentReaction {
+			return &model.CommentReaction{
+				CommentID: commentID,
+				BoardID:   "board-id",
+				UserID:    userID,
+				Emoji:     emoji,
+				CreateAt:  createAt,
+			}
+		}
+
+		for _, reaction := range []*model.CommentReaction{
+			newReaction("comment-id-1", testUserID, "+1", 100),
+			newReaction("comment-id-1", "user-id-2", "+1", 200),
+			newReaction("comment-id-1", testUserID, "tada", 300),
+			newReaction("comment-id-2", testUserID, "+1", 400),
+			newReaction("comment-id-3", testUserID, "+1", 500),
+		} {
+			_, err := store.SaveCommentReaction(reaction)
+			require.NoError(t, err)
+		}
+
+		// saving the same reaction again is a no-op
+		_, err := store.SaveCommentReaction(newReaction("comment-id-1", testUserID, "+1", 600))
+		require.NoError(t, err)
+
+		reactions, err := store.GetCommentReactions([]string{"comment-id-1", "comment-id-2"})
+		require.NoError(t, err)
+		require.Len(t, reactions, 4)
+		require.Equal(t, "comment-id-1", reactions[0].CommentID)
+		require.Equal(t, int64(100), reactions[0].CreateAt)
+		require.Equal(t, "board-id", reactions[0].BoardID)
+		require.Equal(t, "tada", reactions[2].Emoji)
+		require.Equal(t, "comment-id-2", reactions[3].CommentID)
+	})
+}
+
+func testDeleteCommentReaction(t *testing.T, store store.Store) {
+	reaction := &model.CommentReaction{
+		CommentID: "comment-id",
+		BoardID:   "board-id",
+		UserID:    testUserID,
+		Emoji:     "+1",
+	}
+	_, err := store.SaveCommentReaction(reaction)
+	require.NoError(t, err)
+
+	t.Run("nonexistent reaction", func(t *testing.T) {
+		err := store.DeleteCommentReaction("comment-id", testUserID, "tada")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("delete a reaction", func(t *testing.T) {
+		err := store.DeleteCommentReaction("comment-id", testUserID, "+1")
+		require.NoError(t, err)
+
+		reactions, err := store.GetCommentReactions([]string{"comment-id"})
+		require.NoError(t, err)
+		require.Empty(t, reactions)
+
+		err = store.DeleteCommentReaction("comment-id", testUserID, "+1")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -94,6 +94,15 @@ func LoadData(t *testing.T, store store.Store) {
 
 	err = store.AddUpdateCategoryBoard(testUserID, categoryID, []string{boardID})
 	require.NoError(t, err)
+
+	err = store.SaveReadState(&model.ReadState{UserID: testUserID, BoardID: boardID, ViewedAt: utils.GetMillis()})
+	require.NoError(t, err)
+
+	_, err = store.SaveCommentReaction(&model.CommentReaction{CommentID: "id-test", BoardID: boardID, UserID: testUserID, Emoji: "+1"})
+	require.NoError(t, err)
+
+	_, err = store.SaveCardMirror(&model.CardMirror{BoardID: boardID, CardID: "id-test2", CreatedBy: testUserID, ModifiedBy: testUserID})
+	require.NoError(t, err)
 }
 
 func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
@@ -135,5 +144,17 @@ func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
 		category, err := store.GetUserCategoryBoards(boardID, testTeamID)
 		require.NoError(t, err)
 		require.Empty(t, category)
+
+		readState, err := store.GetReadState(testUserID, boardID, "")
+		require.True(t, model.IsErrNotFound(err), err)
+		require.Nil(t, readState)
+
+		reactions, err := store.GetCommentReactions([]string{"id-test"})
+		require.NoError(t, err)
+		require.Empty(t, reactions)
+
+		mirrors, err := store.GetCardMirrorsForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, mirrors)
 	})
 }
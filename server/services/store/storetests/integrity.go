@@ -0,0 +1,86 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestIntegrity(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CheckIntegrity", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCheckIntegrity(t, store)
+	})
+}
+
+func requireIntegrityCounts(t *testing.T, store store.Store, expected map[string]int64) map[string]*model.IntegrityCheck {
+	checks, err := store.CheckIntegrity()
+	require.NoError(t, err)
+
+	byName := map[string]*model.IntegrityCheck{}
+	for _, check := range checks {
+		byName[check.Name] = check
+		require.Equal(t, expected[check.Name], check.Count, check.Name)
+		require.Len(t, check.SampleIDs, int(check.Count), check.Name)
+	}
+	return byName
+}
+
+func testCheckIntegrity(t *testing.T, store store.Store) {
+	t.Run("no problems", func(t *testing.T) {
+		board, _, err := store.InsertBoardWithAdmin(&model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+		createTestCards(t, store, testUserID, board.ID, 2)
+
+		requireIntegrityCounts(t, store, nil)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		orphans := createTestBlocks(t, store, testUserID, 2)
+
+		board := createTestBoards(t, store, testTeamID, testUserID, 1)[0]
+		orphanChild := &model.Block{
+			ID:        utils.NewID(utils.IDTypeBlock),
+			BoardID:   board.ID,
+			ParentID:  utils.NewID(utils.IDTypeCard),
+			Type:      model.TypeText,
+			CreatedBy: testUserID,
+		}
+		require.NoError(t, store.InsertBlock(orphanChild, testUserID))
+
+		member := &model.BoardMember{BoardID: utils.NewID(utils.IDTypeBoard), UserID: testUserID}
+		_ = member.SetRole(model.BoardRoleEditor)
+		_, err := store.SaveMember(member)
+		require.NoError(t, err)
+
+		session := &model.Session{
+			ID:     utils.NewID(utils.IDTypeNone),
+			Token:  utils.NewID(utils.IDTypeToken),
+			UserID: utils.NewID(utils.IDTypeUser),
+			Props:  map[string]interface{}{},
+		}
+		require.NoError(t, store.CreateSession(session))
+
+		checks := requireIntegrityCounts(t, store, map[string]int64{
+			"blocks_without_board":  2,
+			"blocks_without_parent": 1,
+			"members_without_board": 1,
+			"boards_without_admin":  1,
+			"sessions_without_user": 1,
+		})
+		require.ElementsMatch(t, extractIDs(t, orphans), checks["blocks_without_board"].SampleIDs)
+		require.Equal(t, []string{orphanChild.ID}, checks["blocks_without_parent"].SampleIDs)
+		require.Equal(t, []string{member.BoardID}, checks["members_without_board"].SampleIDs)
+		require.Equal(t, []string{board.ID}, checks["boards_without_admin"].SampleIDs)
+		require.Equal(t, []string{session.ID}, checks["sessions_without_user"].SampleIDs)
+	})
+}
@@ -0,0 +1,246 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestJobStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetJobs(t, store)
+	})
+	t.Run("ClaimAndFinishJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimAndFinishJobs(t, store)
+	})
+	t.Run("CancelAndRetryJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCancelAndRetryJobs(t, store)
+	})
+	t.Run("ResetStaleAndDeleteFinishedJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testResetStaleAndDeleteFinishedJobs(t, store)
+	})
+}
+
+func newTestJob(jobType string, scheduledAt int64) *model.Job {
+	return &model.Job{
+		ID:          utils.NewID(utils.IDTypeNone),
+		Type:        jobType,
+		ScheduledAt: scheduledAt,
+	}
+}
+
+func testCreateAndGetJobs(t *testing.T, store store.Store) {
+	t.Run("invalid job", func(t *testing.T) {
+		_, err := store.CreateJob(newTestJob("", 1000))
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("nonexistent job", func(t *testing.T) {
+		job, err := store.GetJob("nonexistent-id")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, job)
+	})
+
+	first := newTestJob("export", 1000)
+	first.Data = map[string]interface{}{"boardId": "board-id"}
+	_, err := store.CreateJob(first)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusPending, first.Status)
+	require.Equal(t, model.DefaultJobMaxAttempts, first.MaxAttempts)
+	require.NotZero(t, first.CreateAt)
+
+	second, err := store.CreateJob(newTestJob("import", 2000))
+	require.NoError(t, err)
+
+	t.Run("get a job", func(t *testing.T) {
+		job, err := store.GetJob(first.ID)
+		require.NoError(t, err)
+		require.Equal(t, "export", job.Type)
+		require.Equal(t, "board-id", job.Data["boardId"])
+		require.Equal(t, int64(1000), job.ScheduledAt)
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		jobs, err := store.GetJobs(model.QueryJobsOptions{})
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		require.Equal(t, second.ID, jobs[0].ID)
+
+		jobs, err = store.GetJobs(model.QueryJobsOptions{Type: "export"})
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, first.ID, jobs[0].ID)
+
+		jobs, err = store.GetJobs(model.QueryJobsOptions{Status: model.JobStatusRunning})
+		require.NoError(t, err)
+		require.Empty(t, jobs)
+
+		jobs, err = store.GetJobs(model.QueryJobsOptions{Page: 1, PerPage: 1})
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, first.ID, jobs[0].ID)
+	})
+
+	t.Run("active jobs", func(t *testing.T) {
+		active, err := store.HasActiveJob("export")
+		require.NoError(t, err)
+		require.True(t, active)
+
+		active, err = store.HasActiveJob("nonexistent")
+		require.NoError(t, err)
+		require.False(t, active)
+	})
+}
+
+func testClaimAndFinishJobs(t *testing.T, store store.Store) {
+	job, err := store.CreateJob(newTestJob("export", 1000))
+	require.NoError(t, err)
+
+	t.Run("jobs are claimed once due", func(t *testing.T) {
+		_, err := store.ClaimNextJob([]string{"export"}, "worker-1", 999)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.ClaimNextJob([]string{"import"}, "worker-1", 1000)
+		require.True(t, model.IsErrNotFound(err))
+
+		claimed, err := store.ClaimNextJob([]string{"import", "export"}, "worker-1", 1000)
+		require.NoError(t, err)
+		require.Equal(t, job.ID, claimed.ID)
+		require.Equal(t, model.JobStatusRunning, claimed.Status)
+		require.Equal(t, "worker-1", claimed.WorkerID)
+		require.Equal(t, 1, claimed.Attempts)
+		require.Equal(t, int64(1000), claimed.StartAt)
+
+		_, err = store.ClaimNextJob([]string{"export"}, "worker-2", 1000)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("only the worker of a job updates it", func(t *testing.T) {
+		ok, err := store.UpdateJobProgress(job.ID, "worker-2", 50, 1100)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = store.UpdateJobProgress(job.ID, "worker-1", 50, 1100)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		job, err = store.GetJob(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, 50, job.Progress)
+		require.Equal(t, int64(1100), job.UpdateAt)
+	})
+
+	t.Run("finish a job", func(t *testing.T) {
+		job.Status = model.JobStatusSuccess
+		job.Progress = 100
+		job.FinishAt = 1200
+		ok, err := store.FinishJob(job, 1200)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = store.FinishJob(job, 1300)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		job, err = store.GetJob(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, model.JobStatusSuccess, job.Status)
+		require.Equal(t, int64(1200), job.FinishAt)
+
+		active, err := store.HasActiveJob("export")
+		require.NoError(t, err)
+		require.False(t, active)
+	})
+}
+
+func testCancelAndRetryJobs(t *testing.T, store store.Store) {
+	job, err := store.CreateJob(newTestJob("export", 1000))
+	require.NoError(t, err)
+
+	ok, err := store.RetryJob(job.ID, 1100)
+	require.NoError(t, err)
+	require.False(t, ok, "pending jobs can't be retried")
+
+	_, err = store.ClaimNextJob([]string{"export"}, "worker-1", 1000)
+	require.NoError(t, err)
+
+	ok, err = store.CancelJob(job.ID, 1100)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = store.CancelJob(job.ID, 1200)
+	require.NoError(t, err)
+	require.False(t, ok, "finished jobs can't be cancelled")
+
+	ok, err = store.UpdateJobProgress(job.ID, "worker-1", 50, 1200)
+	require.NoError(t, err)
+	require.False(t, ok, "the worker should see the job is cancelled")
+
+	ok, err = store.RetryJob(job.ID, 1300)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	job, err = store.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusPending, job.Status)
+	require.Zero(t, job.Attempts)
+	require.Zero(t, job.FinishAt)
+	require.Equal(t, int64(1300), job.ScheduledAt)
+}
+
+func testResetStaleAndDeleteFinishedJobs(t *testing.T, store store.Store) {
+	retried, err := store.CreateJob(newTestJob("export", 1000))
+	require.NoError(t, err)
+	failed := newTestJob("import", 1000)
+	failed.MaxAttempts = 1
+	_, err = store.CreateJob(failed)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = store.ClaimNextJob([]string{"export", "import"}, "worker-1", 1000)
+		require.NoError(t, err)
+	}
+
+	count, err := store.ResetStaleJobs(1000, 2000)
+	require.NoError(t, err)
+	require.Zero(t, count, "jobs with a recent heartbeat are not stale")
+
+	count, err = store.ResetStaleJobs(1500, 2000)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	retried, err = store.GetJob(retried.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusPending, retried.Status)
+	require.Empty(t, retried.WorkerID)
+	require.NotEmpty(t, retried.LastError)
+
+	failed, err = store.GetJob(failed.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusError, failed.Status)
+	require.Equal(t, int64(2000), failed.FinishAt)
+
+	count, err = store.DeleteFinishedJobs(2000)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = store.DeleteFinishedJobs(2001)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = store.GetJob(failed.ID)
+	require.True(t, model.IsErrNotFound(err))
+	_, err = store.GetJob(retried.ID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestLeaseStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("AcquireLease", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testAcquireLease(t, store)
+	})
+	t.Run("ReleaseLease", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReleaseLease(t, store)
+	})
+}
+
+func testAcquireLease(t *testing.T, store store.Store) {
+	t.Run("new lease", func(t *testing.T) {
+		ok, err := store.AcquireLease("scheduler", "node-1", 2000, 1000)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("held by another node", func(t *testing.T) {
+		ok, err := store.AcquireLease("scheduler", "node-2", 2500, 1500)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("renewed by its holder", func(t *testing.T) {
+		ok, err := store.AcquireLease("scheduler", "node-1", 3000, 1800)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = store.AcquireLease("scheduler", "node-2", 3000, 2500)
+		require.NoError(t, err)
+		require.False(t, ok, "the renewal should extend the lease")
+	})
+
+	t.Run("expired lease", func(t *testing.T) {
+		ok, err := store.AcquireLease("scheduler", "node-2", 4500, 3500)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = store.AcquireLease("scheduler", "node-1", 4600, 3600)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("other lease", func(t *testing.T) {
+		ok, err := store.AcquireLease("notifier", "node-1", 4600, 3600)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
+
+func testReleaseLease(t *testing.T, store store.Store) {
+	ok, err := store.AcquireLease("scheduler", "node-1", 2000, 1000)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	t.Run("not the holder", func(t *testing.T) {
+		require.NoError(t, store.ReleaseLease("scheduler", "node-2"))
+
+		ok, err := store.AcquireLease("scheduler", "node-2", 2500, 1500)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("holder", func(t *testing.T) {
+		require.NoError(t, store.ReleaseLease("scheduler", "node-1"))
+
+		ok, err := store.AcquireLease("scheduler", "node-2", 2500, 1500)
+		require.NoError(t, err)
+		require.True(t, ok, "a released lease should be available before it expires")
+	})
+
+	t.Run("nonexistent lease", func(t *testing.T) {
+		require.NoError(t, store.ReleaseLease("nonexistent", "node-1"))
+	})
+}
@@ -0,0 +1,182 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestReadStateStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveReadStateAndGetReadState", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveReadStateAndGetReadState(t, store)
+	})
+	t.Run("GetUnreadCounts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUnreadCounts(t, store)
+	})
+}
+
+func testSaveReadStateAndGetReadState(t *testing.T, store store.Store) {
+	t.Run("invalid read state", func(t *testing.T) {
+		err := store.SaveReadState(&model.ReadState{UserID: testUserID, ViewedAt: 100})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("nonexistent read state", func(t *testing.T) {
+		readState, err := store.GetReadState(testUserID, "board-id", "")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, readState)
+	})
+
+	t.Run("save and replace read states", func(t *testing.T) {
+		boardReadState := &model.ReadState{UserID: testUserID, BoardID: "board-id", ViewedAt: 100}
+		require.NoError(t, store.SaveReadState(boardReadState))
+
+		cardReadState := &model.ReadState{UserID: testUserID, BoardID: "board-id", CardID: "card-id", ViewedAt: 200}
+		require.NoError(t, store.SaveReadState(cardReadState))
+
+		readState, err := store.GetReadState(testUserID, "board-id", "")
+		require.NoError(t, err)
+		require.Equal(t, boardReadState, readState)
+
+		readState, err = store.GetReadState(testUserID, "board-id", "card-id")
+		require.NoError(t, err)
+		require.Equal(t, cardReadState, readState)
+
+		boardReadState.ViewedAt = 300
+		require.NoError(t, store.SaveReadState(boardReadState))
+
+		readState, err = store.GetReadState(testUserID, "board-id", "")
+		require.NoError(t, err)
+		require.Equal(t, int64(300), readState.ViewedAt)
+	})
+}
+
+func testGetUnreadCounts(t *testing.T, store store.Store) {
+	otherUserID := "other-user-id"
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	card1 := createTestCards(t, store, otherUserID, boardID, 1)[0]
+	card2 := createTestCards(t, store, otherUserID, boardID, 1)[0]
+	createTestCards(t, store, testUserID, otherBoardID, 1)
+
+	comment := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  boardID,
+		ParentID: card1.ID,
+		Type:     model.TypeComment,
+	}
+	require.NoError(t, store.InsertBlock(comment, otherUserID))
+
+	view := &model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeView,
+	}
+	require.NoError(t, store.InsertBlock(view, otherUserID))
+
+	// the private views of other users aren't counted
+	privateView := &model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeView,
+		Fields:   map[string]interface{}{model.ViewFieldOwnerID: otherUserID},
+	}
+	require.NoError(t, store.InsertBlock(privateView, otherUserID))
+
+	t.Run("no boards", func(t *testing.T) {
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{})
+		require.NoError(t, err)
+		require.Empty(t, counts)
+	})
+
+	t.Run("never viewed", func(t *testing.T) {
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{boardID, otherBoardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 4}, counts)
+
+		cardCounts, err := store.GetCardUnreadCounts(testUserID, boardID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{card1.ID: 2, card2.ID: 1}, cardCounts)
+	})
+
+	t.Run("viewed card", func(t *testing.T) {
+		readState := &model.ReadState{UserID: testUserID, BoardID: boardID, CardID: card1.ID, ViewedAt: comment.UpdateAt}
+		require.NoError(t, store.SaveReadState(readState))
+
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 4}, counts)
+
+		cardCounts, err := store.GetCardUnreadCounts(testUserID, boardID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{card2.ID: 1}, cardCounts)
+	})
+
+	t.Run("viewed board", func(t *testing.T) {
+		readState := &model.ReadState{UserID: testUserID, BoardID: boardID, ViewedAt: view.UpdateAt}
+		require.NoError(t, store.SaveReadState(readState))
+
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Empty(t, counts)
+
+		cardCounts, err := store.GetCardUnreadCounts(testUserID, boardID)
+		require.NoError(t, err)
+		require.Empty(t, cardCounts)
+	})
+
+	t.Run("changes after the last view", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+
+		title := "changed"
+		require.NoError(t, store.PatchBlock(card2.ID, &model.BlockPatch{Title: &title}, otherUserID))
+		require.NoError(t, store.PatchBlock(card1.ID, &model.BlockPatch{Title: &title}, testUserID))
+
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 1}, counts)
+
+		cardCounts, err := store.GetCardUnreadCounts(testUserID, boardID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{card2.ID: 1}, cardCounts)
+
+		counts, err = store.GetBoardUnreadCounts(otherUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 1}, counts)
+	})
+
+	t.Run("private views count for their owner", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+
+		title := "renamed"
+		require.NoError(t, store.PatchBlock(privateView.ID, &model.BlockPatch{Title: &title}, otherUserID))
+
+		counts, err := store.GetBoardUnreadCounts(testUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 1}, counts)
+
+		ownView := &model.Block{
+			ID:       utils.NewID(utils.IDTypeView),
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeView,
+			Fields:   map[string]interface{}{model.ViewFieldOwnerID: testUserID},
+		}
+		require.NoError(t, store.InsertBlock(ownView, otherUserID))
+
+		counts, err = store.GetBoardUnreadCounts(testUserID, []string{boardID})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{boardID: 2}, counts)
+	})
+}
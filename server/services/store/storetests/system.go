@@ -13,6 +13,7 @@ var dataMigrationSystemSettings = map[string]string{
 	"UniqueIDsMigrationComplete":            "true",
 	"CategoryUuidIdMigrationComplete":       "true",
 	"DeDuplicateCategoryBoardTableComplete": "true",
+	"BlockLinksMigrationComplete":           "true",
 }
 
 func addBaseSettings(m map[string]string) map[string]string {
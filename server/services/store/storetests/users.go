@@ -52,6 +52,12 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testPatchUserProps(t, store)
 	})
+
+	t.Run("GetAllUsersAndDeactivateUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetAllUsersAndDeactivateUser(t, store)
+	})
 }
 
 func testGetUsersByTeam(t *testing.T, store store.Store) {
@@ -343,3 +349,50 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 		}
 	}
 }
+
+func testGetAllUsersAndDeactivateUser(t *testing.T, store store.Store) {
+	users := createTestUsers(t, store, 3)
+
+	session := &model.Session{
+		ID:     utils.NewID(utils.IDTypeNone),
+		Token:  utils.NewID(utils.IDTypeToken),
+		UserID: users[1].ID,
+		Props:  map[string]interface{}{},
+	}
+	require.NoError(t, store.CreateSession(session))
+
+	t.Run("all the users, ordered by username", func(t *testing.T) {
+		all, err := store.GetAllUsers(false)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		for i, user := range all {
+			require.Equal(t, users[i].ID, user.ID)
+		}
+	})
+
+	t.Run("deactivate a user", func(t *testing.T) {
+		require.NoError(t, store.DeactivateUser(users[1].ID))
+
+		active, err := store.GetAllUsers(false)
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		require.Equal(t, users[0].ID, active[0].ID)
+		require.Equal(t, users[2].ID, active[1].ID)
+
+		all, err := store.GetAllUsers(true)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		require.NotZero(t, all[1].DeleteAt)
+
+		_, err = store.GetSession(session.Token, 60*60)
+		require.Error(t, err)
+	})
+
+	t.Run("deactivate a deactivated or nonexistent user", func(t *testing.T) {
+		err := store.DeactivateUser(users[1].ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		err = store.DeactivateUser(utils.NewID(utils.IDTypeUser))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sync"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
@@ -14,7 +15,8 @@ import (
 
 // NotifyUpdate calls webhooks.
 func (wh *Client) NotifyUpdate(block *model.Block) {
-	if len(wh.config.WebhookUpdate) < 1 {
+	urls := wh.getConfig().WebhookUpdate
+	if len(urls) < 1 {
 		return
 	}
 
@@ -22,7 +24,7 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 	if err != nil {
 		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
 	}
-	for _, url := range wh.config.WebhookUpdate {
+	for _, url := range urls {
 		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
 		_, _ = io.ReadAll(resp.Body)
 		resp.Body.Close()
@@ -33,8 +35,9 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger mlog.LoggerIFace
+	configMux sync.RWMutex
+	config    *config.Configuration
+	logger    mlog.LoggerIFace
 }
 
 // NewClient creates a new Client.
@@ -44,3 +47,16 @@ func NewClient(config *config.Configuration, logger mlog.LoggerIFace) *Client {
 		logger: logger,
 	}
 }
+
+// SetConfig replaces the configuration of the client.
+func (wh *Client) SetConfig(config *config.Configuration) {
+	wh.configMux.Lock()
+	defer wh.configMux.Unlock()
+	wh.config = config
+}
+
+func (wh *Client) getConfig() *config.Configuration {
+	wh.configMux.RLock()
+	defer wh.configMux.RUnlock()
+	return wh.config
+}
@@ -83,6 +83,11 @@ func (cn *CallbackQueue) Shutdown(context context.Context) bool {
 	}
 }
 
+// Len returns the number of callbacks waiting in the queue.
+func (cn *CallbackQueue) Len() int {
+	return len(cn.queue)
+}
+
 // Enqueue adds a callback to the queue.
 func (cn *CallbackQueue) Enqueue(f CallbackFunc) {
 	if atomic.LoadUint32(&cn.idone) != 0 {
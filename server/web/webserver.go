@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -115,9 +116,10 @@ func (ws *Server) Start() {
 	if isSSL {
 		ws.logger.Info("https server started", mlog.Int("port", ws.port))
 		go func() {
-			if err := ws.ListenAndServeTLS("./cert/cert.pem", "./cert/key.pem"); err != nil {
+			if err := ws.ListenAndServeTLS("./cert/cert.pem", "./cert/key.pem"); !errors.Is(err, http.ErrServerClosed) {
 				ws.logger.Fatal("ListenAndServeTLS", mlog.Err(err))
 			}
+			ws.logger.Info("https server stopped")
 		}()
 
 		return
@@ -132,8 +134,15 @@ func (ws *Server) Start() {
 	}()
 }
 
-func (ws *Server) Shutdown() error {
-	return ws.Close()
+// Shutdown stops accepting connections and waits for the requests in
+// flight until the context is done, then closes the remaining
+// connections.
+func (ws *Server) Shutdown(ctx context.Context) error {
+	if err := ws.Server.Shutdown(ctx); err != nil {
+		_ = ws.Close()
+		return err
+	}
+	return nil
 }
 
 // fileExists returns true if a file exists at the path.
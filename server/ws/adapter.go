@@ -5,23 +5,29 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 )
 
+// Websocket actions, used by the clients of the websocket server as
+// well.
 const (
-	websocketActionAuth                     = "AUTH"
-	websocketActionSubscribeTeam            = "SUBSCRIBE_TEAM"
-	websocketActionUnsubscribeTeam          = "UNSUBSCRIBE_TEAM"
-	websocketActionSubscribeBlocks          = "SUBSCRIBE_BLOCKS"
-	websocketActionUnsubscribeBlocks        = "UNSUBSCRIBE_BLOCKS"
-	websocketActionUpdateBoard              = "UPDATE_BOARD"
-	websocketActionUpdateMember             = "UPDATE_MEMBER"
-	websocketActionDeleteMember             = "DELETE_MEMBER"
-	websocketActionUpdateBlock              = "UPDATE_BLOCK"
-	websocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
-	websocketActionUpdateCategory           = "UPDATE_CATEGORY"
-	websocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
-	websocketActionUpdateSubscription       = "UPDATE_SUBSCRIPTION"
-	websocketActionUpdateCardLimitTimestamp = "UPDATE_CARD_LIMIT_TIMESTAMP"
-	websocketActionReorderCategories        = "REORDER_CATEGORIES"
-	websocketActionReorderCategoryBoards    = "REORDER_CATEGORY_BOARDS"
+	WebsocketActionAuth                     = "AUTH"
+	WebsocketActionSubscribeTeam            = "SUBSCRIBE_TEAM"
+	WebsocketActionUnsubscribeTeam          = "UNSUBSCRIBE_TEAM"
+	WebsocketActionSubscribeBlocks          = "SUBSCRIBE_BLOCKS"
+	WebsocketActionUnsubscribeBlocks        = "UNSUBSCRIBE_BLOCKS"
+	WebsocketActionUpdateBoard              = "UPDATE_BOARD"
+	WebsocketActionUpdateMember             = "UPDATE_MEMBER"
+	WebsocketActionDeleteMember             = "DELETE_MEMBER"
+	WebsocketActionUpdateBlock              = "UPDATE_BLOCK"
+	WebsocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
+	WebsocketActionUpdateCategory           = "UPDATE_CATEGORY"
+	WebsocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
+	WebsocketActionUpdateSubscription       = "UPDATE_SUBSCRIPTION"
+	WebsocketActionUpdateCardLimitTimestamp = "UPDATE_CARD_LIMIT_TIMESTAMP"
+	WebsocketActionReorderCategories        = "REORDER_CATEGORIES"
+	WebsocketActionReorderCategoryBoards    = "REORDER_CATEGORY_BOARDS"
+	WebsocketActionUpdateCardMirror         = "UPDATE_CARD_MIRROR"
+	WebsocketActionUpdateCommentReactions   = "UPDATE_COMMENT_REACTIONS"
+	WebsocketActionUpdateUnreadCounts       = "UPDATE_UNREAD_COUNTS"
+	WebsocketActionUpdateCardVotes          = "UPDATE_CARD_VOTES"
 )
 
 type Store interface {
@@ -29,6 +35,12 @@ type Store interface {
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
 }
 
+// ConnectionCloser is implemented by the adapters that own their
+// websocket connections, which are closed when the server shuts down.
+type ConnectionCloser interface {
+	CloseConnections() int
+}
+
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block *model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
@@ -43,4 +55,8 @@ type Adapter interface {
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 	BroadcastCategoryReorder(teamID, userID string, categoryOrder []string)
 	BroadcastCategoryBoardsReorder(teamID, userID, categoryID string, boardsOrder []string)
+	BroadcastCardMirrorChange(teamID string, mirror *model.CardMirror, block *model.Block)
+	BroadcastCommentReactionsChange(teamID, boardID, commentID string, reactions []*model.CommentReactionSummary)
+	BroadcastUnreadCountsChange(teamID, userID string, unreadCounts *model.BoardUnreadCounts)
+	BroadcastCardVotesChange(teamID, boardID, userID string, votes *model.CardVotes)
 }
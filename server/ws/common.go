@@ -26,6 +26,44 @@ type UpdateBoardMsg struct {
 	Board  *model.Board `json:"board"`
 }
 
+// UpdateCardMirrorMsg is sent to the boards that mirror a card when
+// the mirror changes or when the card or its content are updated.
+type UpdateCardMirrorMsg struct {
+	Action string            `json:"action"`
+	TeamID string            `json:"teamId"`
+	Mirror *model.CardMirror `json:"mirror"`
+	Block  *model.Block      `json:"block,omitempty"`
+}
+
+// UpdateCommentReactionsMsg is sent when the reactions to a comment
+// change.
+type UpdateCommentReactionsMsg struct {
+	Action    string                          `json:"action"`
+	TeamID    string                          `json:"teamId"`
+	BoardID   string                          `json:"boardId"`
+	CommentID string                          `json:"commentId"`
+	Reactions []*model.CommentReactionSummary `json:"reactions"`
+}
+
+// UpdateUnreadCountsMsg is sent to a user when the unread counts of a
+// board or of its cards change.
+type UpdateUnreadCountsMsg struct {
+	Action       string                   `json:"action"`
+	TeamID       string                   `json:"teamId"`
+	UnreadCounts *model.BoardUnreadCounts `json:"unreadCounts"`
+}
+
+// UpdateCardVotesMsg is sent when the votes or the reactions of a card
+// change. The votes carry no per-user state; UserID is the user that
+// changed them.
+type UpdateCardVotesMsg struct {
+	Action  string           `json:"action"`
+	TeamID  string           `json:"teamId"`
+	BoardID string           `json:"boardId"`
+	UserID  string           `json:"userId"`
+	Votes   *model.CardVotes `json:"votes"`
+}
+
 // UpdateMemberMsg is sent on membership updates.
 type UpdateMemberMsg struct {
 	Action string             `json:"action"`
@@ -52,10 +52,10 @@ func (th *TestHelper) SubscribeWebConnToTeam(webConnID, userID, teamID string) {
 		Return(true)
 
 	msgData := map[string]interface{}{"teamId": teamID}
-	th.ReceiveWebSocketMessage(webConnID, userID, websocketActionSubscribeTeam, msgData)
+	th.ReceiveWebSocketMessage(webConnID, userID, WebsocketActionSubscribeTeam, msgData)
 }
 
 func (th *TestHelper) UnsubscribeWebConnFromTeam(webConnID, userID, teamID string) {
 	msgData := map[string]interface{}{"teamId": teamID}
-	th.ReceiveWebSocketMessage(webConnID, userID, websocketActionUnsubscribeTeam, msgData)
+	th.ReceiveWebSocketMessage(webConnID, userID, WebsocketActionUnsubscribeTeam, msgData)
 }
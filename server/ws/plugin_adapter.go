@@ -356,7 +356,7 @@ func (pa *PluginAdapter) WebSocketMessageHasBeenPosted(webConnID, userID string,
 	// The block-related commands are not implemented in the adapter
 	// as there is no such thing as unauthenticated websocket
 	// connections in plugin mode. Only a debug line is logged
-	case websocketActionSubscribeBlocks, websocketActionUnsubscribeBlocks:
+	case WebsocketActionSubscribeBlocks, WebsocketActionUnsubscribeBlocks:
 		pa.logger.Debug(`Command not implemented in plugin mode`,
 			mlog.String("command", command.Action),
 			mlog.String("webConnID", webConnID),
@@ -364,7 +364,7 @@ func (pa *PluginAdapter) WebSocketMessageHasBeenPosted(webConnID, userID string,
 			mlog.String("teamID", command.TeamID),
 		)
 
-	case websocketActionSubscribeTeam:
+	case WebsocketActionSubscribeTeam:
 		pa.logger.Debug(`Command not implemented in plugin mode`,
 			mlog.String("command", command.Action),
 			mlog.String("webConnID", webConnID),
@@ -377,7 +377,7 @@ func (pa *PluginAdapter) WebSocketMessageHasBeenPosted(webConnID, userID string,
 		}
 
 		pa.subscribeListenerToTeam(pac, command.TeamID)
-	case websocketActionUnsubscribeTeam:
+	case WebsocketActionUnsubscribeTeam:
 		pa.logger.Debug(`Command: UNSUBSCRIBE_WORKSPACE`,
 			mlog.String("webConnID", webConnID),
 			mlog.String("userID", userID),
@@ -395,7 +395,7 @@ func (pa *PluginAdapter) sendMessageToAll(event string, payload map[string]inter
 }
 
 func (pa *PluginAdapter) BroadcastConfigChange(pluginConfig model.ClientConfig) {
-	pa.sendMessageToAll(websocketActionUpdateConfig, utils.StructToMap(pluginConfig))
+	pa.sendMessageToAll(WebsocketActionUpdateConfig, utils.StructToMap(pluginConfig))
 }
 
 // sendUserMessageSkipCluster sends the message to specific users.
@@ -432,7 +432,7 @@ func (pa *PluginAdapter) sendTeamMessage(event, teamID string, payload map[strin
 // subscribed to a given team that belong to one of its boards.
 func (pa *PluginAdapter) sendBoardMessageSkipCluster(teamID, boardID string, payload map[string]interface{}, ensureUserIDs ...string) {
 	userIDs := pa.getUserIDsForTeamAndBoard(teamID, boardID, ensureUserIDs...)
-	pa.sendUserMessageSkipCluster(websocketActionUpdateBoard, payload, userIDs...)
+	pa.sendUserMessageSkipCluster(WebsocketActionUpdateBoard, payload, userIDs...)
 }
 
 // sendBoardMessage sends and propagates a message that is aimed for
@@ -461,11 +461,28 @@ func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block *model.Block)
 	)
 
 	message := UpdateBlockMsg{
-		Action: websocketActionUpdateBlock,
+		Action: WebsocketActionUpdateBlock,
 		TeamID: teamID,
 		Block:  block,
 	}
 
+	// private views are only sent to their owner
+	if ownerID := model.GetViewOwnerID(block); ownerID != "" {
+		payload := utils.StructToMap(message)
+
+		go func() {
+			clusterMessage := &ClusterMessage{
+				Payload: payload,
+				UserID:  ownerID,
+			}
+
+			pa.sendMessageToCluster(clusterMessage)
+		}()
+
+		pa.sendUserMessageSkipCluster(WebsocketActionUpdateBlock, payload, ownerID)
+		return
+	}
+
 	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
 }
 
@@ -477,7 +494,7 @@ func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 	)
 
 	message := UpdateCategoryMessage{
-		Action:   websocketActionUpdateCategory,
+		Action:   WebsocketActionUpdateCategory,
 		TeamID:   category.TeamID,
 		Category: &category,
 	}
@@ -493,7 +510,7 @@ func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 		pa.sendMessageToCluster(clusterMessage)
 	}()
 
-	pa.sendUserMessageSkipCluster(websocketActionUpdateCategory, payload, category.UserID)
+	pa.sendUserMessageSkipCluster(WebsocketActionUpdateCategory, payload, category.UserID)
 }
 
 func (pa *PluginAdapter) BroadcastCategoryReorder(teamID, userID string, categoryOrder []string) {
@@ -503,7 +520,7 @@ func (pa *PluginAdapter) BroadcastCategoryReorder(teamID, userID string, categor
 	)
 
 	message := CategoryReorderMessage{
-		Action:        websocketActionReorderCategories,
+		Action:        WebsocketActionReorderCategories,
 		CategoryOrder: categoryOrder,
 		TeamID:        teamID,
 	}
@@ -528,7 +545,7 @@ func (pa *PluginAdapter) BroadcastCategoryBoardsReorder(teamID, userID, category
 	)
 
 	message := CategoryBoardReorderMessage{
-		Action:     websocketActionReorderCategoryBoards,
+		Action:     WebsocketActionReorderCategoryBoards,
 		CategoryID: categoryID,
 		BoardOrder: boardsOrder,
 		TeamID:     teamID,
@@ -555,7 +572,7 @@ func (pa *PluginAdapter) BroadcastCategoryBoardChange(teamID, userID string, boa
 	)
 
 	message := UpdateCategoryMessage{
-		Action:          websocketActionUpdateCategoryBoard,
+		Action:          WebsocketActionUpdateCategoryBoard,
 		TeamID:          teamID,
 		BoardCategories: boardCategories,
 	}
@@ -571,7 +588,7 @@ func (pa *PluginAdapter) BroadcastCategoryBoardChange(teamID, userID string, boa
 		pa.sendMessageToCluster(clusterMessage)
 	}()
 
-	pa.sendUserMessageSkipCluster(websocketActionUpdateCategoryBoard, utils.StructToMap(message), userID)
+	pa.sendUserMessageSkipCluster(WebsocketActionUpdateCategoryBoard, utils.StructToMap(message), userID)
 }
 
 func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
@@ -592,7 +609,7 @@ func (pa *PluginAdapter) BroadcastBoardChange(teamID string, board *model.Board)
 	)
 
 	message := UpdateBoardMsg{
-		Action: websocketActionUpdateBoard,
+		Action: WebsocketActionUpdateBoard,
 		TeamID: teamID,
 		Board:  board,
 	}
@@ -600,6 +617,84 @@ func (pa *PluginAdapter) BroadcastBoardChange(teamID string, board *model.Board)
 	pa.sendBoardMessage(teamID, board.ID, utils.StructToMap(message))
 }
 
+func (pa *PluginAdapter) BroadcastCardMirrorChange(teamID string, mirror *model.CardMirror, block *model.Block) {
+	pa.logger.Debug("BroadcastingCardMirrorChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", mirror.BoardID),
+		mlog.String("cardID", mirror.CardID),
+	)
+
+	message := UpdateCardMirrorMsg{
+		Action: WebsocketActionUpdateCardMirror,
+		TeamID: teamID,
+		Mirror: mirror,
+		Block:  block,
+	}
+
+	pa.sendBoardMessage(teamID, mirror.BoardID, utils.StructToMap(message))
+}
+
+func (pa *PluginAdapter) BroadcastCommentReactionsChange(teamID, boardID, commentID string, reactions []*model.CommentReactionSummary) {
+	pa.logger.Debug("BroadcastingCommentReactionsChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.String("commentID", commentID),
+	)
+
+	message := UpdateCommentReactionsMsg{
+		Action:    WebsocketActionUpdateCommentReactions,
+		TeamID:    teamID,
+		BoardID:   boardID,
+		CommentID: commentID,
+		Reactions: reactions,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
+func (pa *PluginAdapter) BroadcastCardVotesChange(teamID, boardID, userID string, votes *model.CardVotes) {
+	pa.logger.Debug("BroadcastingCardVotesChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.String("cardID", votes.CardID),
+	)
+
+	message := UpdateCardVotesMsg{
+		Action:  WebsocketActionUpdateCardVotes,
+		TeamID:  teamID,
+		BoardID: boardID,
+		UserID:  userID,
+		Votes:   votes,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
+func (pa *PluginAdapter) BroadcastUnreadCountsChange(teamID, userID string, unreadCounts *model.BoardUnreadCounts) {
+	pa.logger.Debug("BroadcastUnreadCountsChange",
+		mlog.String("userID", userID),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", unreadCounts.BoardID),
+	)
+
+	message := UpdateUnreadCountsMsg{
+		Action:       WebsocketActionUpdateUnreadCounts,
+		TeamID:       teamID,
+		UnreadCounts: unreadCounts,
+	}
+	payload := utils.StructToMap(message)
+	go func() {
+		clusterMessage := &ClusterMessage{
+			Payload: payload,
+			UserID:  userID,
+		}
+
+		pa.sendMessageToCluster(clusterMessage)
+	}()
+
+	pa.sendUserMessageSkipCluster(message.Action, payload, userID)
+}
+
 func (pa *PluginAdapter) BroadcastBoardDelete(teamID, boardID string) {
 	now := utils.GetMillis()
 	board := &model.Board{}
@@ -619,7 +714,7 @@ func (pa *PluginAdapter) BroadcastMemberChange(teamID, boardID string, member *m
 	)
 
 	message := UpdateMemberMsg{
-		Action: websocketActionUpdateMember,
+		Action: WebsocketActionUpdateMember,
 		TeamID: teamID,
 		Member: member,
 	}
@@ -635,7 +730,7 @@ func (pa *PluginAdapter) BroadcastMemberDelete(teamID, boardID, userID string) {
 	)
 
 	message := UpdateMemberMsg{
-		Action: websocketActionDeleteMember,
+		Action: WebsocketActionDeleteMember,
 		TeamID: teamID,
 		Member: &model.BoardMember{UserID: userID, BoardID: boardID},
 	}
@@ -654,11 +749,11 @@ func (pa *PluginAdapter) BroadcastSubscriptionChange(teamID string, subscription
 	)
 
 	message := UpdateSubscription{
-		Action:       websocketActionUpdateSubscription,
+		Action:       WebsocketActionUpdateSubscription,
 		Subscription: subscription,
 	}
 
-	pa.sendTeamMessage(websocketActionUpdateSubscription, teamID, utils.StructToMap(message))
+	pa.sendTeamMessage(WebsocketActionUpdateSubscription, teamID, utils.StructToMap(message))
 }
 
 func (pa *PluginAdapter) BroadcastCardLimitTimestampChange(cardLimitTimestamp int64) {
@@ -667,9 +762,9 @@ func (pa *PluginAdapter) BroadcastCardLimitTimestampChange(cardLimitTimestamp in
 	)
 
 	message := UpdateCardLimitTimestamp{
-		Action:    websocketActionUpdateCardLimitTimestamp,
+		Action:    WebsocketActionUpdateCardLimitTimestamp,
 		Timestamp: cardLimitTimestamp,
 	}
 
-	pa.sendMessageToAll(websocketActionUpdateCardLimitTimestamp, utils.StructToMap(message))
+	pa.sendMessageToAll(WebsocketActionUpdateCardLimitTimestamp, utils.StructToMap(message))
 }
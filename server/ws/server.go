@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -41,6 +42,10 @@ func (wss *websocketSession) isSubscribedToBlock(blockID string) bool {
 	return false
 }
 
+// closeMessageTimeout is the time given to send the close message to a
+// client when the server shuts down.
+const closeMessageTimeout = time.Second
+
 // Server is a WebSocket server.
 type Server struct {
 	upgrader         websocket.Upgrader
@@ -53,6 +58,7 @@ type Server struct {
 	isMattermostAuth bool
 	logger           mlog.LoggerIFace
 	store            Store
+	closing          bool
 }
 
 type websocketSession struct {
@@ -127,10 +133,16 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	for {
 		_, p, err := wsSession.conn.ReadMessage()
 		if err != nil {
-			ws.logger.Error("ERROR WebSocket",
-				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
-				mlog.Err(err),
-			)
+			if ws.isClosing() {
+				ws.logger.Debug("WebSocket closed on shutdown",
+					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+				)
+			} else {
+				ws.logger.Error("ERROR WebSocket",
+					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+					mlog.Err(err),
+				)
+			}
 			ws.removeListener(wsSession)
 			break
 		}
@@ -145,7 +157,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		if command.Action == websocketActionAuth {
+		if command.Action == WebsocketActionAuth {
 			ws.logger.Debug(`Command: AUTH`, mlog.Stringer("client", wsSession.conn.RemoteAddr()))
 			ws.authenticateListener(wsSession, command.Token)
 
@@ -155,7 +167,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		// if the client wants to subscribe to a set of blocks and it
 		// is sending a read token, we don't need to check for
 		// authentication
-		if command.Action == websocketActionSubscribeBlocks {
+		if command.Action == WebsocketActionSubscribeBlocks {
 			ws.logger.Debug(`Command: SUBSCRIBE_BLOCKS`,
 				mlog.String("teamID", command.TeamID),
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
@@ -175,7 +187,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		if command.Action == websocketActionUnsubscribeBlocks {
+		if command.Action == WebsocketActionUnsubscribeBlocks {
 			ws.logger.Debug(`Command: UNSUBSCRIBE_BLOCKS`,
 				mlog.String("teamID", command.TeamID),
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
@@ -207,7 +219,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 
 		switch command.Action {
-		case websocketActionSubscribeTeam:
+		case WebsocketActionSubscribeTeam:
 			ws.logger.Debug(`Command: SUBSCRIBE_TEAM`,
 				mlog.String("teamID", command.TeamID),
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
@@ -231,7 +243,7 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			}
 
 			ws.subscribeListenerToTeam(wsSession, command.TeamID)
-		case websocketActionUnsubscribeTeam:
+		case WebsocketActionUnsubscribeTeam:
 			ws.logger.Debug(`Command: UNSUBSCRIBE_TEAM`,
 				mlog.String("teamID", command.TeamID),
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
@@ -282,6 +294,41 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 	return isValid
 }
 
+// CloseConnections sends a going away close message to all the clients,
+// so that they reconnect to another server, and closes their
+// connections. It returns the number of connections closed.
+func (ws *Server) CloseConnections() int {
+	ws.mu.Lock()
+	ws.closing = true
+	listeners := make([]*websocketSession, 0, len(ws.listeners))
+	for listener := range ws.listeners {
+		listeners = append(listeners, listener)
+	}
+	ws.mu.Unlock()
+
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	deadline := time.Now().Add(closeMessageTimeout)
+	for _, listener := range listeners {
+		listener.mu.Lock()
+		if err := listener.conn.WriteControl(websocket.CloseMessage, message, deadline); err != nil {
+			ws.logger.Debug("Cannot send the close message",
+				mlog.Stringer("client", listener.conn.RemoteAddr()),
+				mlog.Err(err),
+			)
+		}
+		listener.mu.Unlock()
+		listener.conn.Close()
+	}
+
+	return len(listeners)
+}
+
+func (ws *Server) isClosing() bool {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return ws.closing
+}
+
 // addListener adds a listener to the websocket server. The listener
 // should not receive any update from the server until it subscribes
 // itself to some entity changes. Adding a listener to the server
@@ -530,11 +577,30 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 	blockIDsToNotify := []string{block.ID, block.ParentID}
 
 	message := UpdateBlockMsg{
-		Action: websocketActionUpdateBlock,
+		Action: WebsocketActionUpdateBlock,
 		TeamID: teamID,
 		Block:  block,
 	}
 
+	// private views are only sent to their owner
+	if ownerID := model.GetViewOwnerID(block); ownerID != "" {
+		listener := ws.getListenerForUser(teamID, ownerID)
+		if listener != nil {
+			ws.logger.Debug("Broadcast private view change",
+				mlog.String("userID", ownerID),
+				mlog.String("teamID", teamID),
+				mlog.String("blockID", block.ID),
+				mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+			)
+
+			if err := listener.WriteJSON(message); err != nil {
+				ws.logger.Error("broadcast private view change error", mlog.Err(err))
+				listener.conn.Close()
+			}
+		}
+		return
+	}
+
 	listeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
 	ws.logger.Trace("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
@@ -567,7 +633,7 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 
 func (ws *Server) BroadcastCategoryChange(category model.Category) {
 	message := UpdateCategoryMessage{
-		Action:   websocketActionUpdateCategory,
+		Action:   WebsocketActionUpdateCategory,
 		TeamID:   category.TeamID,
 		Category: &category,
 	}
@@ -590,7 +656,7 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 
 func (ws *Server) BroadcastCategoryReorder(teamID, userID string, categoryOrder []string) {
 	message := CategoryReorderMessage{
-		Action:        websocketActionReorderCategories,
+		Action:        WebsocketActionReorderCategories,
 		CategoryOrder: categoryOrder,
 		TeamID:        teamID,
 	}
@@ -612,7 +678,7 @@ func (ws *Server) BroadcastCategoryReorder(teamID, userID string, categoryOrder
 
 func (ws *Server) BroadcastCategoryBoardsReorder(teamID, userID, categoryID string, boardOrder []string) {
 	message := CategoryBoardReorderMessage{
-		Action:     websocketActionReorderCategoryBoards,
+		Action:     WebsocketActionReorderCategoryBoards,
 		CategoryID: categoryID,
 		BoardOrder: boardOrder,
 		TeamID:     teamID,
@@ -636,7 +702,7 @@ func (ws *Server) BroadcastCategoryBoardsReorder(teamID, userID, categoryID stri
 
 func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCategories []*model.BoardCategoryWebsocketData) {
 	message := UpdateCategoryMessage{
-		Action:          websocketActionUpdateCategoryBoard,
+		Action:          WebsocketActionUpdateCategoryBoard,
 		TeamID:          teamID,
 		BoardCategories: boardCategories,
 	}
@@ -660,16 +726,22 @@ func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCateg
 // BroadcastConfigChange broadcasts update messages to clients.
 func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 	message := UpdateClientConfig{
-		Action:       websocketActionUpdateConfig,
+		Action:       WebsocketActionUpdateConfig,
 		ClientConfig: clientConfig,
 	}
 
-	listeners := ws.listeners
+	ws.mu.RLock()
+	listeners := make([]*websocketSession, 0, len(ws.listeners))
+	for listener := range ws.listeners {
+		listeners = append(listeners, listener)
+	}
+	ws.mu.RUnlock()
+
 	ws.logger.Debug("broadcasting config change to listener(s)",
 		mlog.Int("listener_count", len(listeners)),
 	)
 
-	for listener := range listeners {
+	for _, listener := range listeners {
 		ws.logger.Debug("Broadcast Config change",
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
@@ -683,7 +755,7 @@ func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 
 func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
 	message := UpdateBoardMsg{
-		Action: websocketActionUpdateBoard,
+		Action: WebsocketActionUpdateBoard,
 		TeamID: teamID,
 		Board:  board,
 	}
@@ -710,6 +782,124 @@ func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
 	}
 }
 
+func (ws *Server) BroadcastCardMirrorChange(teamID string, mirror *model.CardMirror, block *model.Block) {
+	message := UpdateCardMirrorMsg{
+		Action: WebsocketActionUpdateCardMirror,
+		TeamID: teamID,
+		Mirror: mirror,
+		Block:  block,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, mirror.BoardID)
+	ws.logger.Trace("listener(s) for teamID and boardID",
+		mlog.Int("listener_count", len(listeners)),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", mirror.BoardID),
+	)
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast card mirror change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", mirror.BoardID),
+			mlog.String("cardID", mirror.CardID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
+func (ws *Server) BroadcastCommentReactionsChange(teamID, boardID, commentID string, reactions []*model.CommentReactionSummary) {
+	message := UpdateCommentReactionsMsg{
+		Action:    WebsocketActionUpdateCommentReactions,
+		TeamID:    teamID,
+		BoardID:   boardID,
+		CommentID: commentID,
+		Reactions: reactions,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	ws.logger.Trace("listener(s) for teamID and boardID",
+		mlog.Int("listener_count", len(listeners)),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+	)
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast comment reactions change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.String("commentID", commentID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
+func (ws *Server) BroadcastCardVotesChange(teamID, boardID, userID string, votes *model.CardVotes) {
+	message := UpdateCardVotesMsg{
+		Action:  WebsocketActionUpdateCardVotes,
+		TeamID:  teamID,
+		BoardID: boardID,
+		UserID:  userID,
+		Votes:   votes,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	ws.logger.Trace("listener(s) for teamID and boardID",
+		mlog.Int("listener_count", len(listeners)),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+	)
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast card votes change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.String("cardID", votes.CardID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
+func (ws *Server) BroadcastUnreadCountsChange(teamID, userID string, unreadCounts *model.BoardUnreadCounts) {
+	message := UpdateUnreadCountsMsg{
+		Action:       WebsocketActionUpdateUnreadCounts,
+		TeamID:       teamID,
+		UnreadCounts: unreadCounts,
+	}
+
+	listener := ws.getListenerForUser(teamID, userID)
+	if listener != nil {
+		ws.logger.Debug("Broadcast unread counts change",
+			mlog.String("userID", userID),
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", unreadCounts.BoardID),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast unread counts change error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
 	now := utils.GetMillis()
 	board := &model.Board{}
@@ -723,7 +913,7 @@ func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
 
 func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
 	message := UpdateMemberMsg{
-		Action: websocketActionUpdateMember,
+		Action: WebsocketActionUpdateMember,
 		TeamID: teamID,
 		Member: member,
 	}
@@ -752,7 +942,7 @@ func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.Bo
 
 func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
 	message := UpdateMemberMsg{
-		Action: websocketActionDeleteMember,
+		Action: WebsocketActionDeleteMember,
 		TeamID: teamID,
 		Member: &model.BoardMember{UserID: userID, BoardID: boardID},
 	}
@@ -1,8 +1,12 @@
 package ws
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
@@ -281,3 +285,24 @@ func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 		require.Equal(t, model.SingleUser, server.getUserIDForToken(singleUserToken))
 	})
 }
+
+func TestCloseConnections(t *testing.T) {
+	server := NewServer(&auth.Auth{}, "token", false, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug), nil)
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer httpServer.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.Eventually(t, func() bool {
+		server.mu.RLock()
+		defer server.mu.RUnlock()
+		return len(server.listeners) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, 1, server.CloseConnections())
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+}